@@ -0,0 +1,19 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"soccer_manager_service/internal/bootstrap"
+)
+
+func main() {
+	fix := flag.Bool("fix", false, "repair all inconsistencies in a single transaction")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	os.Exit(bootstrap.RunReconcile(bootstrap.ReconcileOptions{
+		Fix:  *fix,
+		JSON: *asJSON,
+		Out:  os.Stdout,
+	}))
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"net/http"
+	"soccer_manager_service/internal/api/rest/middleware"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/usecase/adapters"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AccountHandler struct {
+	accountService adapters.AccountService
+	logger         *zap.Logger
+}
+
+func NewAccountHandler(accountService adapters.AccountService, logger *zap.Logger) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+		logger:         logger.With(zap.String("handler", "AccountHandler")),
+	}
+}
+
+func (h *AccountHandler) log(c *gin.Context) *zap.Logger {
+	return logger.FromContext(c.Request.Context(), h.logger, zap.String("handler", "AccountHandler"))
+}
+
+// ChangePassword
+// @Summary Change password
+// @Description Change the password of the current user. All existing sessions are revoked and a new token pair is returned.
+// @ID change-password
+// @Tags account
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/password [put]
+func (h *AccountHandler) ChangePassword(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid change password request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accessToken, refreshToken, err := h.accountService.ChangePassword(c.Request.Context(), userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
+	})
+}
+
+// ChangeEmail
+// @Summary Change email
+// @Description Change the email of the current user. The new address must be verified again; all existing sessions are revoked and a new token pair is returned.
+// @ID change-email
+// @Tags account
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.ChangeEmailRequest true "New email and current password"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/email [put]
+func (h *AccountHandler) ChangeEmail(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	var req dto.ChangeEmailRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid change email request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accessToken, refreshToken, err := h.accountService.ChangeEmail(c.Request.Context(), userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
+	})
+}
+
+// DeleteAccount
+// @Summary Delete account
+// @Description Delete the current user with their team and players. Active transfer listings are cancelled; completed transfers stay in the history of the other teams.
+// @ID delete-account
+// @Tags account
+// @Security BearerAuth
+// @Accept json
+// @Param request body dto.DeleteAccountRequest true "Current password"
+// @Success 204
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account [delete]
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	var req dto.DeleteAccountRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid delete account request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	if err := h.accountService.DeleteAccount(c.Request.Context(), userID, &req); err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateScopedToken
+// @Summary Create scoped access token
+// @Description Issue an access token limited to the given scopes, e.g. a read-only token for a dashboard. It cannot be refreshed and stops working when all sessions are revoked.
+// @ID create-scoped-token
+// @Tags account
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateScopedTokenRequest true "Scopes and lifetime in seconds"
+// @Success 201 {object} dto.ScopedTokenResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/tokens [post]
+func (h *AccountHandler) CreateScopedToken(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	var req dto.CreateScopedTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid create scoped token request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	resp, err := h.accountService.CreateScopedToken(c.Request.Context(), userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
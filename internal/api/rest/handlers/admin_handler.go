@@ -0,0 +1,498 @@
+package handlers
+
+import (
+	"net/http"
+	"soccer_manager_service/internal/api/rest/middleware"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/usecase/adapters"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AdminHandler struct {
+	adminService adapters.AdminService
+	logger       *zap.Logger
+}
+
+func NewAdminHandler(adminService adapters.AdminService, logger *zap.Logger) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+		logger:       logger.With(zap.String("handler", "AdminHandler")),
+	}
+}
+
+func (h *AdminHandler) log(c *gin.Context) *zap.Logger {
+	return logger.FromContext(c.Request.Context(), h.logger, zap.String("handler", "AdminHandler"))
+}
+
+// ListUsers
+// @Summary List users
+// @Description List users, optionally filtered by email. Requires the moderator or admin role.
+// @ID admin-list-users
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param search query string false "Case-insensitive email substring"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param offset query int false "Number of users to skip"
+// @Success 200 {object} dto.AdminUsersResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/admin/users [get]
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	var req dto.AdminListRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log(c).Warn("invalid list users request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	users, err := h.adminService.ListUsers(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// ListTeams
+// @Summary List teams
+// @Description List teams, optionally filtered by name or country. Requires the moderator or admin role.
+// @ID admin-list-teams
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param search query string false "Case-insensitive name or country substring"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param offset query int false "Number of teams to skip"
+// @Success 200 {object} dto.AdminTeamsResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/admin/teams [get]
+func (h *AdminHandler) ListTeams(c *gin.Context) {
+	var req dto.AdminListRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log(c).Warn("invalid list teams request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	teams, err := h.adminService.ListTeams(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, teams)
+}
+
+// UpdateUserRole
+// @Summary Change user role
+// @Description Change the role of a user. Requires the admin role.
+// @ID admin-update-user-role
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body dto.UpdateUserRoleRequest true "New role"
+// @Success 200 {object} entity.User
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/admin/users/{id}/role [put]
+func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
+	actorID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperr.ErrInvalidUserID)
+
+		return
+	}
+
+	var req dto.UpdateUserRoleRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid update user role request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	user, err := h.adminService.UpdateUserRole(c.Request.Context(), actorID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// BanUser
+// @Summary Ban user
+// @Description Ban a user so they can no longer log in. Requires the admin role.
+// @ID admin-ban-user
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body dto.BanUserRequest true "Ban reason"
+// @Success 200 {object} entity.User
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/admin/users/{id}/ban [post]
+func (h *AdminHandler) BanUser(c *gin.Context) {
+	actorID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperr.ErrInvalidUserID)
+
+		return
+	}
+
+	var req dto.BanUserRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid ban user request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	user, err := h.adminService.BanUser(c.Request.Context(), actorID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UnbanUser
+// @Summary Unban user
+// @Description Lift a user ban. Requires the admin role.
+// @ID admin-unban-user
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} entity.User
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/admin/users/{id}/ban [delete]
+func (h *AdminHandler) UnbanUser(c *gin.Context) {
+	actorID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperr.ErrInvalidUserID)
+
+		return
+	}
+
+	user, err := h.adminService.UnbanUser(c.Request.Context(), actorID, userID)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UnlockUserLogin
+// @Summary Unlock user login
+// @Description Lift the login lockouts of a user's email from every IP. Requires the admin role.
+// @ID admin-unlock-user-login
+// @Tags admin
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/admin/users/{id}/login-lockout [delete]
+func (h *AdminHandler) UnlockUserLogin(c *gin.Context) {
+	actorID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperr.ErrInvalidUserID)
+
+		return
+	}
+
+	if err := h.adminService.UnlockUserLogin(c.Request.Context(), actorID, userID); err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResetTwoFactor
+// @Summary Reset user two-factor authentication
+// @Description Turn two-factor authentication off for a user who lost their authenticator and recovery codes. Requires the admin role.
+// @ID admin-reset-two-factor
+// @Tags admin
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/admin/users/{id}/two-factor [delete]
+func (h *AdminHandler) ResetTwoFactor(c *gin.Context) {
+	actorID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperr.ErrInvalidUserID)
+
+		return
+	}
+
+	if err := h.adminService.ResetTwoFactor(c.Request.Context(), actorID, userID); err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnlockIPLogin
+// @Summary Unlock IP login
+// @Description Lift the per-IP login lockout of an address. Requires the admin role.
+// @ID admin-unlock-ip-login
+// @Tags admin
+// @Security BearerAuth
+// @Param ip query string true "Client IP address"
+// @Success 204
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/admin/login-lockouts [delete]
+func (h *AdminHandler) UnlockIPLogin(c *gin.Context) {
+	actorID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	var req dto.UnlockIPRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log(c).Warn("invalid unlock ip request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	if err := h.adminService.UnlockIPLogin(c.Request.Context(), actorID, &req); err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AdjustTeamBudget
+// @Summary Adjust team budget
+// @Description Add a positive or negative amount to a team budget. Requires the admin role.
+// @ID admin-adjust-team-budget
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID"
+// @Param request body dto.AdjustBudgetRequest true "Budget adjustment"
+// @Success 200 {object} entity.Team
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/admin/teams/{id}/budget [post]
+func (h *AdminHandler) AdjustTeamBudget(c *gin.Context) {
+	actorID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	teamID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperr.ErrInvalidTeamID)
+
+		return
+	}
+
+	var req dto.AdjustBudgetRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid adjust budget request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	team, err := h.adminService.AdjustTeamBudget(c.Request.Context(), actorID, teamID, &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+// CancelTransfer
+// @Summary Force-cancel transfer
+// @Description Cancel an active transfer listing. Requires the moderator or admin role.
+// @ID admin-cancel-transfer
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Param id path string true "Transfer ID"
+// @Param request body dto.CancelTransferRequest true "Cancellation reason"
+// @Success 204
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/admin/transfers/{id}/cancel [post]
+func (h *AdminHandler) CancelTransfer(c *gin.Context) {
+	actorID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	transferID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperr.ErrInvalidTransferID)
+
+		return
+	}
+
+	var req dto.CancelTransferRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid cancel transfer request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	if err := h.adminService.CancelTransfer(c.Request.Context(), actorID, transferID, &req); err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListAuditLog
+// @Summary Query audit log
+// @Description List audit log entries, newest first, filtered by entity and/or actor. Requires the admin role.
+// @ID admin-list-audit-log
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param entity_type query string false "Entity type" Enums(user, team, player, transfer)
+// @Param entity_id query string false "Entity ID"
+// @Param actor_id query string false "ID of the user who made the change"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param offset query int false "Number of entries to skip"
+// @Success 200 {object} dto.AuditLogResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/admin/audit-log [get]
+func (h *AdminHandler) ListAuditLog(c *gin.Context) {
+	var req dto.AuditLogRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log(c).Warn("invalid audit log request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	entries, err := h.adminService.ListAuditLog(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+	"soccer_manager_service/internal/api/rest/middleware"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/usecase/adapters"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type APIKeyHandler struct {
+	apiKeyService adapters.APIKeyService
+	logger        *zap.Logger
+}
+
+func NewAPIKeyHandler(apiKeyService adapters.APIKeyService, logger *zap.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		logger:        logger.With(zap.String("handler", "APIKeyHandler")),
+	}
+}
+
+func (h *APIKeyHandler) log(c *gin.Context) *zap.Logger {
+	return logger.FromContext(c.Request.Context(), h.logger, zap.String("handler", "APIKeyHandler"))
+}
+
+// CreateAPIKey
+// @Summary Create API key
+// @Description Create a personal API key for scripts. Send it as "Authorization: Bearer smk_..."; it acts for the current user on the routes its scopes allow (read:team, write:team, read:market, write:transfers). The key is shown only once.
+// @ID create-api-key
+// @Tags account
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateAPIKeyRequest true "Key name and scopes"
+// @Success 201 {object} dto.CreateAPIKeyResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	var req dto.CreateAPIKeyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid create api key request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	resp, err := h.apiKeyService.Create(c.Request.Context(), userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListAPIKeys
+// @Summary List API keys
+// @Description List the current user's API keys that have not been revoked, newest first
+// @ID list-api-keys
+// @Tags account
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.APIKeyListResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	resp, err := h.apiKeyService.List(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RevokeAPIKey
+// @Summary Revoke API key
+// @Description Revoke one of the current user's API keys. Requests with it fail from then on.
+// @ID revoke-api-key
+// @Tags account
+// @Security BearerAuth
+// @Param id path string true "API key ID"
+// @Success 204
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	keyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperr.ErrInvalidAPIKeyID)
+
+		return
+	}
+
+	if err := h.apiKeyService.Revoke(c.Request.Context(), userID, keyID); err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -1,15 +1,16 @@
 package handlers
 
 import (
-	"errors"
+	"math"
 	"net/http"
 	"soccer_manager_service/internal/api/rest/middleware"
 	"soccer_manager_service/internal/dto"
 	"soccer_manager_service/internal/usecase/adapters"
 	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"go.uber.org/zap"
 )
 
@@ -25,6 +26,10 @@ func NewAuthHandler(authService adapters.AuthService, logger *zap.Logger) *AuthH
 	}
 }
 
+func (h *AuthHandler) log(c *gin.Context) *zap.Logger {
+	return logger.FromContext(c.Request.Context(), h.logger, zap.String("handler", "AuthHandler"))
+}
+
 // Register
 // @Summary Register new user
 // @Description Register new user and create team
@@ -34,37 +39,24 @@ func NewAuthHandler(authService adapters.AuthService, logger *zap.Logger) *AuthH
 // @Produce json
 // @Param request body dto.RegisterRequest true "Registration data"
 // @Success 201 {object} dto.TokenResponse
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 409 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
 // @Router /api/v1/auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
-	localizer := c.MustGet(middleware.LocalizerKey).(*i18n.Localizer)
-
 	var req dto.RegisterRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn("invalid register request", zap.Error(err))
-
-		msg, _ := localizer.Localize(&i18n.LocalizeConfig{MessageID: "errors.invalid_request"})
-		c.JSON(http.StatusBadRequest, gin.H{"error": msg, "details": err.Error()})
+		h.log(c).Warn("invalid register request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
 
 		return
 	}
 
 	accessToken, refreshToken, err := h.authService.Register(c.Request.Context(), &req)
 	if err != nil {
-		h.logger.Error("registration failed", zap.Error(err))
-
-		if errors.Is(err, apperr.ErrUserAlreadyExists) {
-			msg := apperr.LocalizeError(err, localizer)
-			c.JSON(http.StatusConflict, gin.H{"error": msg})
-
-			return
-		}
-
-		msg := apperr.LocalizeError(apperr.ErrInternal, localizer)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+		_ = c.Error(err)
 
 		return
 	}
@@ -77,52 +69,73 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 // Login
 // @Summary Login user
-// @Description Login user with credentials
+// @Description Login user with credentials. Repeated failures lock out the client IP or the (IP, email) pair with exponential backoff; locked out requests get 429 with a Retry-After header. Accounts with two-factor authentication get a challenge token instead of the token pair, to be exchanged at /auth/login/2fa.
 // @ID login
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body dto.LoginRequest true "Login credentials"
-// @Success 200 {object} dto.TokenResponse
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 429 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Header 429 {integer} Retry-After "Seconds until the lockout ends"
+// @Failure 500 {object} dto.ProblemResponse
 // @Router /api/v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
-	localizer := c.MustGet(middleware.LocalizerKey).(*i18n.Localizer)
-
 	var req dto.LoginRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn("invalid login request", zap.Error(err))
-
-		msg, _ := localizer.Localize(&i18n.LocalizeConfig{MessageID: "errors.invalid_request"})
-		c.JSON(http.StatusBadRequest, gin.H{"error": msg, "details": err.Error()})
+		h.log(c).Warn("invalid login request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
 
 		return
 	}
 
-	accessToken, refreshToken, err := h.authService.Login(c.Request.Context(), &req)
+	req.ClientIP = c.ClientIP()
+
+	resp, err := h.authService.Login(c.Request.Context(), &req)
 	if err != nil {
-		h.logger.Error("login failed", zap.Error(err))
+		setRetryAfter(c, err)
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
 
-		if errors.Is(err, apperr.ErrInvalidCredentials) {
-			msg := apperr.LocalizeError(err, localizer)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
+// CompleteTwoFactorLogin
+// @Summary Complete two-factor login
+// @Description Exchange the challenge token from /auth/login and a TOTP or recovery code for a token pair. The challenge is single-use: after a wrong code the user has to log in again. Wrong codes count towards the login lockout.
+// @ID login-two-factor
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.TwoFactorLoginRequest true "Challenge token and code"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Header 429 {integer} Retry-After "Seconds until the lockout ends"
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/auth/login/2fa [post]
+func (h *AuthHandler) CompleteTwoFactorLogin(c *gin.Context) {
+	var req dto.TwoFactorLoginRequest
 
-			return
-		}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid two-factor login request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
 
-		if errors.Is(err, apperr.ErrTooManyAttempts) {
-			msg := apperr.LocalizeError(err, localizer)
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": msg})
+		return
+	}
 
-			return
-		}
+	req.ClientIP = c.ClientIP()
 
-		msg := apperr.LocalizeError(apperr.ErrInternal, localizer)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+	accessToken, refreshToken, err := h.authService.CompleteTwoFactorLogin(c.Request.Context(), &req)
+	if err != nil {
+		setRetryAfter(c, err)
+		_ = c.Error(err)
 
 		return
 	}
@@ -132,3 +145,220 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		"refresh_token": refreshToken,
 	})
 }
+
+// ListOIDCProviders
+// @Summary List OpenID Connect providers
+// @Description List the names of the configured OpenID Connect providers that can be used to sign in
+// @ID list-oidc-providers
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.OIDCProvidersResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/auth/oidc/providers [get]
+func (h *AuthHandler) ListOIDCProviders(c *gin.Context) {
+	resp, err := h.authService.ListOIDCProviders(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// StartOIDCLogin
+// @Summary Start OpenID Connect login
+// @Description Return the provider URL to send the user to. team_name and country are used for the team if the login creates a new account.
+// @ID start-oidc-login
+// @Tags auth
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Param team_name query string false "Team name for a new account"
+// @Param country query string false "Team country for a new account"
+// @Success 200 {object} dto.OIDCAuthorizeResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/auth/oidc/{provider}/authorize [get]
+func (h *AuthHandler) StartOIDCLogin(c *gin.Context) {
+	var req dto.OIDCAuthorizeRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log(c).Warn("invalid oidc authorize request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	resp, err := h.authService.StartOIDCLogin(c.Request.Context(), c.Param("provider"), &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CompleteOIDCLogin
+// @Summary Complete OpenID Connect login
+// @Description Redeem the authorization code the provider redirected back with. Signs in the user linked to the provider account, links an existing account with the same verified email, or creates a new account with a team. Users with two-factor authentication get a challenge token instead of a token pair.
+// @ID complete-oidc-login
+// @Tags auth
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Param code query string false "Authorization code"
+// @Param state query string true "State from the authorization request"
+// @Param error query string false "Error reported by the provider"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/auth/oidc/{provider}/callback [get]
+func (h *AuthHandler) CompleteOIDCLogin(c *gin.Context) {
+	var req dto.OIDCCallbackRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log(c).Warn("invalid oidc callback request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	resp, err := h.authService.CompleteOIDCLogin(c.Request.Context(), c.Param("provider"), &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// setRetryAfter sets the Retry-After header for errors that carry one.
+func setRetryAfter(c *gin.Context, err error) {
+	if retryAfter, ok := apperr.RetryAfter(err); ok {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+}
+
+// VerifyEmail
+// @Summary Verify email address
+// @Description Confirm the email address of an account with the token from the verification email
+// @ID verify-email
+// @Tags auth
+// @Accept json
+// @Param request body dto.VerifyEmailRequest true "Verification token"
+// @Success 204
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid verify email request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	if err := h.authService.VerifyEmail(c.Request.Context(), &req); err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResendVerificationEmail
+// @Summary Resend verification email
+// @Description Send a new verification email to the current user. Earlier links stop working.
+// @ID resend-verification-email
+// @Tags auth
+// @Security BearerAuth
+// @Success 202
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/auth/verify-email/resend [post]
+func (h *AuthHandler) ResendVerificationEmail(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	if err := h.authService.ResendVerificationEmail(c.Request.Context(), userID); err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// RequestPasswordReset
+// @Summary Request password reset
+// @Description Email a password reset link. Responds the same whether or not the email is registered.
+// @ID request-password-reset
+// @Tags auth
+// @Accept json
+// @Param request body dto.PasswordResetRequest true "Account email"
+// @Success 202
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/auth/password-reset [post]
+func (h *AuthHandler) RequestPasswordReset(c *gin.Context) {
+	var req dto.PasswordResetRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid password reset request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	if err := h.authService.RequestPasswordReset(c.Request.Context(), &req); err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ResetPassword
+// @Summary Reset password
+// @Description Set a new password with the token from the password reset email
+// @ID reset-password
+// @Tags auth
+// @Accept json
+// @Param request body dto.ConfirmPasswordResetRequest true "Reset token and new password"
+// @Success 204
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/auth/password-reset/confirm [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req dto.ConfirmPasswordResetRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid reset password request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	if err := h.authService.ResetPassword(c.Request.Context(), &req); err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+	"soccer_manager_service/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jwksMaxAge is how long clients may cache the key set. Rotated keys are
+// published as verification keys before they sign, so caches pick them up in
+// time.
+const jwksMaxAge = "public, max-age=300"
+
+type JWKSHandler struct {
+	jwtManager *jwt.Manager
+}
+
+func NewJWKSHandler(jwtManager *jwt.Manager) *JWKSHandler {
+	return &JWKSHandler{jwtManager: jwtManager}
+}
+
+// GetKeySet
+// @Summary Get token verification keys
+// @Description Public keys that access tokens are signed with, as a JSON Web Key Set (RFC 7517). Tokens name their key in the kid header. Empty when tokens are signed with a shared secret.
+// @ID get-jwks
+// @Tags auth
+// @Produce json
+// @Success 200 {object} jwt.JSONWebKeySet
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetKeySet(c *gin.Context) {
+	c.Header("Cache-Control", jwksMaxAge)
+	c.JSON(http.StatusOK, h.jwtManager.JWKS())
+}
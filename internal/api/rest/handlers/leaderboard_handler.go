@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"soccer_manager_service/internal/api/rest/middleware"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/usecase/adapters"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type LeaderboardHandler struct {
+	leaderboardService adapters.LeaderboardService
+	logger             *zap.Logger
+}
+
+func NewLeaderboardHandler(leaderboardService adapters.LeaderboardService, logger *zap.Logger) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		leaderboardService: leaderboardService,
+		logger:             logger.With(zap.String("handler", "LeaderboardHandler")),
+	}
+}
+
+func (h *LeaderboardHandler) log(c *gin.Context) *zap.Logger {
+	return logger.FromContext(c.Request.Context(), h.logger, zap.String("handler", "LeaderboardHandler"))
+}
+
+// GetLeaderboard
+// @Summary Get leaderboard
+// @Description Get a page of a leaderboard, highest score first, with the ranks of the caller's teams.
+// @Description total_value, budget and transfer_profit rank teams; transfers ranks the most expensive completed transfers.
+// @ID get-leaderboard
+// @Tags leaderboards
+// @Security BearerAuth
+// @Produce json
+// @Param kind path string true "Leaderboard" Enums(total_value, budget, transfer_profit, transfers)
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param offset query int false "Number of entries to skip"
+// @Success 200 {object} dto.LeaderboardResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/leaderboards/{kind} [get]
+func (h *LeaderboardHandler) GetLeaderboard(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	var req dto.LeaderboardRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log(c).Warn("invalid leaderboard request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	leaderboard, err := h.leaderboardService.Get(c.Request.Context(), userID, entity.LeaderboardKind(c.Param("kind")), &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, leaderboard)
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"soccer_manager_service/internal/api/rest/middleware"
+	"soccer_manager_service/internal/usecase/adapters"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type MarketHandler struct {
+	marketService adapters.MarketService
+	heartbeat     time.Duration
+	logger        *zap.Logger
+}
+
+func NewMarketHandler(marketService adapters.MarketService, heartbeat time.Duration, logger *zap.Logger) *MarketHandler {
+	return &MarketHandler{
+		marketService: marketService,
+		heartbeat:     heartbeat,
+		logger:        logger.With(zap.String("handler", "MarketHandler")),
+	}
+}
+
+func (h *MarketHandler) log(c *gin.Context) *zap.Logger {
+	return logger.FromContext(c.Request.Context(), h.logger, zap.String("handler", "MarketHandler"))
+}
+
+// StreamEvents
+// @Summary Stream market events
+// @Description Server-Sent Events stream of the transfer market. Every client gets listing_created, listing_cancelled
+// @Description and listing_sold; player_sold and player_bought go only to the seller and the buyer. Each event is named
+// @Description after its type and carries an entity.MarketEvent as JSON. Comment lines are sent as heartbeats.
+// @Description Events published while a client is disconnected are not replayed, so reload GET /api/v1/transfers after reconnecting.
+// @ID stream-market-events
+// @Tags transfers
+// @Security BearerAuth
+// @Produce text/event-stream
+// @Success 200 {object} entity.MarketEvent
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Router /api/v1/market/events [get]
+func (h *MarketHandler) StreamEvents(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	events, unsubscribe := h.marketService.Subscribe(ctx, userID)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	h.log(c).Debug("market stream opened", zap.String("user_id", userID.String()))
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+
+			c.SSEvent(string(event.Type), event)
+
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+
+			return err == nil
+		}
+	})
+
+	h.log(c).Debug("market stream closed", zap.String("user_id", userID.String()))
+}
@@ -1,16 +1,15 @@
 package handlers
 
 import (
-	"errors"
 	"net/http"
 	"soccer_manager_service/internal/api/rest/middleware"
 	"soccer_manager_service/internal/dto"
 	"soccer_manager_service/internal/usecase/adapters"
 	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"go.uber.org/zap"
 )
 
@@ -26,6 +25,40 @@ func NewPlayerHandler(playerService adapters.PlayerService, logger *zap.Logger)
 	}
 }
 
+func (h *PlayerHandler) log(c *gin.Context) *zap.Logger {
+	return logger.FromContext(c.Request.Context(), h.logger, zap.String("handler", "PlayerHandler"))
+}
+
+// GetPlayer
+// @Summary Get player profile
+// @Description Get the public profile of any player with the public profile of its team
+// @ID get-public-player
+// @Tags players
+// @Produce json
+// @Param id path string true "Player ID"
+// @Success 200 {object} dto.PublicPlayerResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/players/{id} [get]
+func (h *PlayerHandler) GetPlayer(c *gin.Context) {
+	playerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperr.ErrInvalidPlayerID)
+
+		return
+	}
+
+	player, err := h.playerService.GetPublicPlayer(c.Request.Context(), playerID)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, player)
+}
+
 // UpdatePlayer
 // @Summary Update player
 // @Description Update player information
@@ -35,20 +68,19 @@ func NewPlayerHandler(playerService adapters.PlayerService, logger *zap.Logger)
 // @Accept json
 // @Produce json
 // @Param id path string true "Player ID"
+// @Param X-Team-ID header string false "Team to act on, required for users with several teams"
 // @Param request body dto.UpdatePlayerRequest true "Player update data"
 // @Success 200 {object} entity.Player
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
 // @Router /api/v1/players/{id} [patch]
 func (h *PlayerHandler) UpdatePlayer(c *gin.Context) {
-	localizer := c.MustGet(middleware.LocalizerKey).(*i18n.Localizer)
-
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		msg := apperr.LocalizeError(apperr.ErrUnauthorized, localizer)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
+		_ = c.Error(apperr.ErrUnauthorized)
 
 		return
 	}
@@ -57,8 +89,7 @@ func (h *PlayerHandler) UpdatePlayer(c *gin.Context) {
 
 	playerID, err := uuid.Parse(playerIDStr)
 	if err != nil {
-		msg, _ := localizer.Localize(&i18n.LocalizeConfig{MessageID: "errors.invalid_player_id"})
-		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		_ = c.Error(apperr.ErrInvalidPlayerID)
 
 		return
 	}
@@ -66,27 +97,15 @@ func (h *PlayerHandler) UpdatePlayer(c *gin.Context) {
 	var req dto.UpdatePlayerRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn("invalid update player request", zap.Error(err))
-
-		msg, _ := localizer.Localize(&i18n.LocalizeConfig{MessageID: "errors.invalid_request"})
-		c.JSON(http.StatusBadRequest, gin.H{"error": msg, "details": err.Error()})
+		h.log(c).Warn("invalid update player request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
 
 		return
 	}
 
-	player, err := h.playerService.UpdatePlayer(c.Request.Context(), userID, playerID, &req)
+	player, err := h.playerService.UpdatePlayer(c.Request.Context(), userID, middleware.GetTeamID(c), playerID, &req)
 	if err != nil {
-		h.logger.Error("failed to update player", zap.Error(err))
-
-		if errors.Is(err, apperr.ErrPlayerNotFound) {
-			msg := apperr.LocalizeError(err, localizer)
-			c.JSON(http.StatusNotFound, gin.H{"error": msg})
-
-			return
-		}
-
-		msg := apperr.LocalizeError(apperr.ErrInternal, localizer)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+		_ = c.Error(err)
 
 		return
 	}
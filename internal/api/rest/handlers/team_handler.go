@@ -1,15 +1,15 @@
 package handlers
 
 import (
-	"errors"
 	"net/http"
 	"soccer_manager_service/internal/api/rest/middleware"
 	"soccer_manager_service/internal/dto"
 	"soccer_manager_service/internal/usecase/adapters"
 	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
 
 	"github.com/gin-gonic/gin"
-	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -25,42 +25,34 @@ func NewTeamHandler(teamService adapters.TeamService, logger *zap.Logger) *TeamH
 	}
 }
 
+func (h *TeamHandler) log(c *gin.Context) *zap.Logger {
+	return logger.FromContext(c.Request.Context(), h.logger, zap.String("handler", "TeamHandler"))
+}
+
 // GetMyTeam
 // @Summary Get my team
-// @Description Get current user's team with players
+// @Description Get the selected team of the current user with players
 // @ID get-my-team
 // @Tags team
 // @Security BearerAuth
 // @Produce json
+// @Param X-Team-ID header string false "Team to act on, required for users with several teams"
 // @Success 200 {object} dto.TeamWithPlayersResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
 // @Router /api/v1/team [get]
 func (h *TeamHandler) GetMyTeam(c *gin.Context) {
-	localizer := c.MustGet(middleware.LocalizerKey).(*i18n.Localizer)
-
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		msg := apperr.LocalizeError(apperr.ErrUnauthorized, localizer)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
+		_ = c.Error(apperr.ErrUnauthorized)
 
 		return
 	}
 
-	teamWithPlayers, err := h.teamService.GetMyTeam(c.Request.Context(), userID)
+	teamWithPlayers, err := h.teamService.GetMyTeam(c.Request.Context(), userID, middleware.GetTeamID(c))
 	if err != nil {
-		h.logger.Error("failed to get team", zap.Error(err))
-
-		if errors.Is(err, apperr.ErrTeamNotFound) {
-			msg := apperr.LocalizeError(err, localizer)
-			c.JSON(http.StatusNotFound, gin.H{"error": msg})
-
-			return
-		}
-
-		msg := apperr.LocalizeError(apperr.ErrInternal, localizer)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+		_ = c.Error(err)
 
 		return
 	}
@@ -76,20 +68,18 @@ func (h *TeamHandler) GetMyTeam(c *gin.Context) {
 // @Security BearerAuth
 // @Accept json
 // @Produce json
+// @Param X-Team-ID header string false "Team to act on, required for users with several teams"
 // @Param request body dto.UpdateTeamRequest true "Team update data"
 // @Success 200 {object} entity.Team
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
 // @Router /api/v1/team [patch]
 func (h *TeamHandler) UpdateTeam(c *gin.Context) {
-	localizer := c.MustGet(middleware.LocalizerKey).(*i18n.Localizer)
-
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		msg := apperr.LocalizeError(apperr.ErrUnauthorized, localizer)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
+		_ = c.Error(apperr.ErrUnauthorized)
 
 		return
 	}
@@ -97,30 +87,196 @@ func (h *TeamHandler) UpdateTeam(c *gin.Context) {
 	var req dto.UpdateTeamRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn("invalid update team request", zap.Error(err))
+		h.log(c).Warn("invalid update team request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	team, err := h.teamService.UpdateTeam(c.Request.Context(), userID, middleware.GetTeamID(c), &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+// GetFinances
+// @Summary Get team finances
+// @Description Get the team budget and a page of its ledger statement, newest entries first
+// @ID get-team-finances
+// @Tags team
+// @Security BearerAuth
+// @Produce json
+// @Param X-Team-ID header string false "Team to act on, required for users with several teams"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param offset query int false "Number of entries to skip"
+// @Success 200 {object} dto.TeamFinancesResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/team/finances [get]
+func (h *TeamHandler) GetFinances(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	var req dto.FinancesRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log(c).Warn("invalid finances request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	finances, err := h.teamService.GetFinances(c.Request.Context(), userID, middleware.GetTeamID(c), &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, finances)
+}
+
+// ListTeams
+// @Summary List my teams
+// @Description List the teams of the current user, oldest first
+// @ID list-my-teams
+// @Tags team
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.TeamListResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/team/all [get]
+func (h *TeamHandler) ListTeams(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
 
-		msg, _ := localizer.Localize(&i18n.LocalizeConfig{MessageID: "errors.invalid_request"})
-		c.JSON(http.StatusBadRequest, gin.H{"error": msg, "details": err.Error()})
+		return
+	}
+
+	teams, err := h.teamService.ListTeams(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
 
 		return
 	}
 
-	team, err := h.teamService.UpdateTeam(c.Request.Context(), userID, &req)
+	c.JSON(http.StatusOK, teams)
+}
+
+// CreateTeam
+// @Summary Create team
+// @Description Create another team for the current user, with the same budget and squad as the first one
+// @ID create-team
+// @Tags team
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateTeamRequest true "Team name and country"
+// @Success 201 {object} entity.Team
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/team [post]
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	var req dto.CreateTeamRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid create team request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	team, err := h.teamService.CreateTeam(c.Request.Context(), userID, &req)
 	if err != nil {
-		h.logger.Error("failed to update team", zap.Error(err))
+		_ = c.Error(err)
 
-		if errors.Is(err, apperr.ErrTeamNotFound) {
-			msg := apperr.LocalizeError(err, localizer)
-			c.JSON(http.StatusNotFound, gin.H{"error": msg})
+		return
+	}
 
-			return
-		}
+	c.JSON(http.StatusCreated, team)
+}
 
-		msg := apperr.LocalizeError(apperr.ErrInternal, localizer)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+// GetPublicTeam
+// @Summary Get team profile
+// @Description Get the public profile of any team with its squad. The owner and the budget are not shown.
+// @ID get-public-team
+// @Tags teams
+// @Produce json
+// @Param id path string true "Team ID"
+// @Success 200 {object} dto.PublicTeamResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/teams/{id} [get]
+func (h *TeamHandler) GetPublicTeam(c *gin.Context) {
+	teamID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperr.ErrInvalidTeamID)
+
+		return
+	}
+
+	team, err := h.teamService.GetPublicTeam(c.Request.Context(), teamID)
+	if err != nil {
+		_ = c.Error(err)
 
 		return
 	}
 
 	c.JSON(http.StatusOK, team)
 }
+
+// SearchTeams
+// @Summary Search teams
+// @Description Search the public profiles of all teams by name and country, ordered by name
+// @ID search-teams
+// @Tags teams
+// @Produce json
+// @Param name query string false "Case-insensitive substring of the team name"
+// @Param country query string false "Case-insensitive substring of the team country"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param offset query int false "Number of teams to skip"
+// @Success 200 {object} dto.PublicTeamsResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/teams [get]
+func (h *TeamHandler) SearchTeams(c *gin.Context) {
+	var req dto.SearchTeamsRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log(c).Warn("invalid search teams request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	teams, err := h.teamService.SearchTeams(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, teams)
+}
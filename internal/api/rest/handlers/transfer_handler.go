@@ -1,12 +1,12 @@
 package handlers
 
 import (
-	"errors"
 	"net/http"
 	"soccer_manager_service/internal/api/rest/middleware"
 	"soccer_manager_service/internal/dto"
 	"soccer_manager_service/internal/usecase/adapters"
 	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -26,6 +26,10 @@ func NewTransferHandler(transferService adapters.TransferService, logger *zap.Lo
 	}
 }
 
+func (h *TransferHandler) log(c *gin.Context) *zap.Logger {
+	return logger.FromContext(c.Request.Context(), h.logger, zap.String("handler", "TransferHandler"))
+}
+
 // ListPlayer
 // @Summary List player for transfer
 // @Description Put player on transfer market
@@ -35,22 +39,20 @@ func NewTransferHandler(transferService adapters.TransferService, logger *zap.Lo
 // @Accept json
 // @Produce json
 // @Param id path string true "Player ID"
+// @Param X-Team-ID header string false "Team to act on, required for users with several teams"
 // @Param request body dto.ListPlayerRequest true "Transfer data"
 // @Success 201 {object} entity.Transfer
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 403 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 409 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 403 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
 // @Router /api/v1/players/{id}/transfer [post]
 func (h *TransferHandler) ListPlayer(c *gin.Context) {
-	localizer := c.MustGet(middleware.LocalizerKey).(*i18n.Localizer)
-
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		msg := apperr.LocalizeError(apperr.ErrUnauthorized, localizer)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
+		_ = c.Error(apperr.ErrUnauthorized)
 
 		return
 	}
@@ -59,8 +61,7 @@ func (h *TransferHandler) ListPlayer(c *gin.Context) {
 
 	playerID, err := uuid.Parse(playerIDStr)
 	if err != nil {
-		msg, _ := localizer.Localize(&i18n.LocalizeConfig{MessageID: "errors.invalid_player_id"})
-		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		_ = c.Error(apperr.ErrInvalidPlayerID)
 
 		return
 	}
@@ -68,41 +69,15 @@ func (h *TransferHandler) ListPlayer(c *gin.Context) {
 	var req dto.ListPlayerRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn("invalid list player request", zap.Error(err))
-
-		msg, _ := localizer.Localize(&i18n.LocalizeConfig{MessageID: "errors.invalid_request"})
-		c.JSON(http.StatusBadRequest, gin.H{"error": msg, "details": err.Error()})
+		h.log(c).Warn("invalid list player request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
 
 		return
 	}
 
-	transfer, err := h.transferService.ListPlayer(c.Request.Context(), userID, playerID, &req)
+	transfer, err := h.transferService.ListPlayer(c.Request.Context(), userID, middleware.GetTeamID(c), playerID, &req)
 	if err != nil {
-		h.logger.Error("failed to list player", zap.Error(err))
-
-		if errors.Is(err, apperr.ErrPlayerNotFound) {
-			msg := apperr.LocalizeError(err, localizer)
-			c.JSON(http.StatusNotFound, gin.H{"error": msg})
-
-			return
-		}
-
-		if errors.Is(err, apperr.ErrPlayerAlreadyListed) {
-			msg := apperr.LocalizeError(err, localizer)
-			c.JSON(http.StatusConflict, gin.H{"error": msg})
-
-			return
-		}
-
-		if errors.Is(err, apperr.ErrForbidden) {
-			msg := apperr.LocalizeError(err, localizer)
-			c.JSON(http.StatusForbidden, gin.H{"error": msg})
-
-			return
-		}
-
-		msg := apperr.LocalizeError(apperr.ErrInternal, localizer)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+		_ = c.Error(err)
 
 		return
 	}
@@ -118,18 +93,13 @@ func (h *TransferHandler) ListPlayer(c *gin.Context) {
 // @Security BearerAuth
 // @Produce json
 // @Success 200 {object} dto.TransfersResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
 // @Router /api/v1/transfers [get]
 func (h *TransferHandler) GetTransferList(c *gin.Context) {
-	localizer := c.MustGet(middleware.LocalizerKey).(*i18n.Localizer)
-
 	transfers, err := h.transferService.GetTransferList(c.Request.Context())
 	if err != nil {
-		h.logger.Error("failed to get transfer list", zap.Error(err))
-
-		msg := apperr.LocalizeError(apperr.ErrInternal, localizer)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+		_ = c.Error(err)
 
 		return
 	}
@@ -145,19 +115,20 @@ func (h *TransferHandler) GetTransferList(c *gin.Context) {
 // @Security BearerAuth
 // @Produce json
 // @Param id path string true "Transfer ID"
+// @Param X-Team-ID header string false "Team to act on, required for users with several teams"
 // @Success 200 {object} dto.MessageResponse
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 429 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
 // @Router /api/v1/transfers/{id}/buy [post]
 func (h *TransferHandler) BuyPlayer(c *gin.Context) {
 	localizer := c.MustGet(middleware.LocalizerKey).(*i18n.Localizer)
 
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		msg := apperr.LocalizeError(apperr.ErrUnauthorized, localizer)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
+		_ = c.Error(apperr.ErrUnauthorized)
 
 		return
 	}
@@ -166,45 +137,13 @@ func (h *TransferHandler) BuyPlayer(c *gin.Context) {
 
 	transferID, err := uuid.Parse(transferIDStr)
 	if err != nil {
-		msg, _ := localizer.Localize(&i18n.LocalizeConfig{MessageID: "errors.invalid_transfer_id"})
-		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		_ = c.Error(apperr.ErrInvalidTransferID)
 
 		return
 	}
 
-	if err := h.transferService.BuyPlayer(c.Request.Context(), userID, transferID); err != nil {
-		h.logger.Error("failed to buy player", zap.Error(err))
-
-		if errors.Is(err, apperr.ErrTransferNotFound) {
-			msg := apperr.LocalizeError(err, localizer)
-			c.JSON(http.StatusNotFound, gin.H{"error": msg})
-
-			return
-		}
-
-		if errors.Is(err, apperr.ErrTransferNotActive) {
-			msg := apperr.LocalizeError(err, localizer)
-			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
-
-			return
-		}
-
-		if errors.Is(err, apperr.ErrCannotBuyOwnPlayer) {
-			msg := apperr.LocalizeError(err, localizer)
-			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
-
-			return
-		}
-
-		if errors.Is(err, apperr.ErrInsufficientFunds) {
-			msg := apperr.LocalizeError(err, localizer)
-			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
-
-			return
-		}
-
-		msg := apperr.LocalizeError(apperr.ErrInternal, localizer)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+	if err := h.transferService.BuyPlayer(c.Request.Context(), userID, middleware.GetTeamID(c), transferID); err != nil {
+		_ = c.Error(err)
 
 		return
 	}
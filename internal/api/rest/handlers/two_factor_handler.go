@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"net/http"
+	"soccer_manager_service/internal/api/rest/middleware"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/usecase/adapters"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type TwoFactorHandler struct {
+	twoFactorService adapters.TwoFactorService
+	logger           *zap.Logger
+}
+
+func NewTwoFactorHandler(twoFactorService adapters.TwoFactorService, logger *zap.Logger) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorService: twoFactorService,
+		logger:           logger.With(zap.String("handler", "TwoFactorHandler")),
+	}
+}
+
+func (h *TwoFactorHandler) log(c *gin.Context) *zap.Logger {
+	return logger.FromContext(c.Request.Context(), h.logger, zap.String("handler", "TwoFactorHandler"))
+}
+
+// GetStatus
+// @Summary Get two-factor status
+// @Description Report whether two-factor authentication is enabled for the current user and how many recovery codes are left
+// @ID get-two-factor-status
+// @Tags account
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.TwoFactorStatusResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/2fa [get]
+func (h *TwoFactorHandler) GetStatus(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	status, err := h.twoFactorService.Status(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// Enroll
+// @Summary Start two-factor enrollment
+// @Description Generate a new TOTP secret for the current user and return it together with an otpauth:// URI for authenticator apps. Two-factor authentication stays off until a code is verified at /account/2fa/enable.
+// @ID enroll-two-factor
+// @Tags account
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.TwoFactorEnrollRequest true "Current password"
+// @Success 200 {object} dto.TwoFactorEnrollResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/2fa/enroll [post]
+func (h *TwoFactorHandler) Enroll(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	var req dto.TwoFactorEnrollRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid two-factor enroll request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	resp, err := h.twoFactorService.Enroll(c.Request.Context(), userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Enable
+// @Summary Enable two-factor authentication
+// @Description Verify a TOTP code for the enrolled secret and turn two-factor authentication on. The response lists the recovery codes; they are shown only once.
+// @ID enable-two-factor
+// @Tags account
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.TwoFactorEnableRequest true "TOTP code"
+// @Success 200 {object} dto.RecoveryCodesResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/2fa/enable [post]
+func (h *TwoFactorHandler) Enable(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	var req dto.TwoFactorEnableRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid two-factor enable request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	resp, err := h.twoFactorService.Enable(c.Request.Context(), userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Disable
+// @Summary Disable two-factor authentication
+// @Description Turn two-factor authentication off for the current user. Requires the current password and a TOTP or recovery code.
+// @ID disable-two-factor
+// @Tags account
+// @Security BearerAuth
+// @Accept json
+// @Param request body dto.TwoFactorConfirmRequest true "Current password and code"
+// @Success 204
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/2fa [delete]
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	var req dto.TwoFactorConfirmRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid two-factor disable request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	if err := h.twoFactorService.Disable(c.Request.Context(), userID, &req); err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes
+// @Summary Regenerate recovery codes
+// @Description Replace all recovery codes of the current user with a new set. Requires the current password and a TOTP or recovery code.
+// @ID regenerate-recovery-codes
+// @Tags account
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.TwoFactorConfirmRequest true "Current password and code"
+// @Success 200 {object} dto.RecoveryCodesResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/2fa/recovery-codes [post]
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	var req dto.TwoFactorConfirmRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid regenerate recovery codes request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	resp, err := h.twoFactorService.RegenerateRecoveryCodes(c.Request.Context(), userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
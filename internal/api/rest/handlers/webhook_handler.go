@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"net/http"
+	"soccer_manager_service/internal/api/rest/middleware"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/usecase/adapters"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type WebhookHandler struct {
+	webhookService adapters.WebhookService
+	logger         *zap.Logger
+}
+
+func NewWebhookHandler(webhookService adapters.WebhookService, logger *zap.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		logger:         logger.With(zap.String("handler", "WebhookHandler")),
+	}
+}
+
+func (h *WebhookHandler) log(c *gin.Context) *zap.Logger {
+	return logger.FromContext(c.Request.Context(), h.logger, zap.String("handler", "WebhookHandler"))
+}
+
+// CreateWebhook
+// @Summary Create webhook
+// @Description Register an endpoint to be called about the current user's teams. Event types are player.listed, player.sold and team.updated. Requests are POSTed as JSON and signed: X-Webhook-Signature is "sha256=" and the hex HMAC-SHA256, keyed with the secret, of X-Webhook-Timestamp, a dot and the body.
+// @ID create-webhook
+// @Tags account
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateWebhookRequest true "Endpoint URL, secret and event types"
+// @Success 201 {object} entity.Webhook
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 409 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	var req dto.CreateWebhookRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid create webhook request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	webhook, err := h.webhookService.Create(c.Request.Context(), userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// ListWebhooks
+// @Summary List webhooks
+// @Description List the current user's webhooks, newest first
+// @ID list-webhooks
+// @Tags account
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.WebhookListResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return
+	}
+
+	resp, err := h.webhookService.List(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetWebhook
+// @Summary Get webhook
+// @Description Get one of the current user's webhooks
+// @ID get-webhook
+// @Tags account
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} entity.Webhook
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	userID, webhookID, ok := h.ids(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.Get(c.Request.Context(), userID, webhookID)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// UpdateWebhook
+// @Summary Update webhook
+// @Description Change the URL, secret or event types of one of the current user's webhooks, or disable or enable it. Enabling a webhook resets its failure count and resumes its pending deliveries.
+// @ID update-webhook
+// @Tags account
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param request body dto.UpdateWebhookRequest true "Fields to change"
+// @Success 200 {object} entity.Webhook
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/webhooks/{id} [patch]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	userID, webhookID, ok := h.ids(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log(c).Warn("invalid update webhook request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	webhook, err := h.webhookService.Update(c.Request.Context(), userID, webhookID, &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook
+// @Summary Delete webhook
+// @Description Delete one of the current user's webhooks along with its delivery log
+// @ID delete-webhook
+// @Tags account
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Success 204
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	userID, webhookID, ok := h.ids(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.Delete(c.Request.Context(), userID, webhookID); err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PingWebhook
+// @Summary Ping webhook
+// @Description Send a signed ping event to one of the current user's webhooks right away, even if it is disabled, and return the delivery, which says whether the endpoint answered with a 2xx status. Pings are not retried and do not count towards disabling the webhook.
+// @ID ping-webhook
+// @Tags account
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} entity.WebhookDelivery
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/webhooks/{id}/ping [post]
+func (h *WebhookHandler) PingWebhook(c *gin.Context) {
+	userID, webhookID, ok := h.ids(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookService.Ping(c.Request.Context(), userID, webhookID)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// ListWebhookDeliveries
+// @Summary List webhook deliveries
+// @Description List the deliveries to one of the current user's webhooks, newest first, with their status, attempts and the last response status or error
+// @ID list-webhook-deliveries
+// @Tags account
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} dto.WebhookDeliveriesResponse
+// @Failure 400 {object} dto.ProblemResponse
+// @Failure 401 {object} dto.ProblemResponse
+// @Failure 404 {object} dto.ProblemResponse
+// @Failure 500 {object} dto.ProblemResponse
+// @Router /api/v1/account/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListWebhookDeliveries(c *gin.Context) {
+	userID, webhookID, ok := h.ids(c)
+	if !ok {
+		return
+	}
+
+	var req dto.WebhookDeliveriesRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log(c).Warn("invalid list webhook deliveries request", zap.Error(err))
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	resp, err := h.webhookService.ListDeliveries(c.Request.Context(), userID, webhookID, &req)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ids returns the current user and the webhook ID from the path, or records
+// the error and reports false.
+func (h *WebhookHandler) ids(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		_ = c.Error(apperr.ErrUnauthorized)
+
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	webhookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperr.ErrInvalidWebhookID)
+
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, webhookID, true
+}
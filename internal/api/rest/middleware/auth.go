@@ -1,55 +1,142 @@
 package middleware
 
 import (
-	"net/http"
+	"context"
+	"slices"
+	"soccer_manager_service/internal/entity"
+	apperr "soccer_manager_service/pkg/errors"
 	"soccer_manager_service/pkg/jwt"
+	"soccer_manager_service/pkg/logger"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 const (
 	authorizationHeader = "Authorization"
 	userIDKey           = "user_id"
 	emailKey            = "email"
+	roleKey             = "role"
+	apiKeyKey           = "api_key"
+	scopesKey           = "scopes"
 )
 
-func Auth(jwtManager *jwt.Manager) gin.HandlerFunc {
+// SessionChecker reports whether a token issued at issuedAt with role still
+// belongs to a live session of the user.
+type SessionChecker interface {
+	CheckSession(ctx context.Context, userID uuid.UUID, role entity.UserRole, issuedAt time.Time) error
+}
+
+// APIKeyAuthenticator resolves a personal API key to the key and its user.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, raw string) (*entity.APIKey, *entity.User, error)
+}
+
+// Auth authenticates requests with a Bearer access token or, when apiKeys is
+// not nil, a personal API key in its place. Limited credentials, API keys and
+// scoped access tokens, only reach routes set up with apiKeys and must carry
+// the scope RequireScope asks for; elsewhere they fail with
+// ErrInsufficientScope.
+func Auth(jwtManager *jwt.Manager, sessions SessionChecker, apiKeys APIKeyAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader(authorizationHeader)
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authorization header"})
-			c.Abort()
+			abortWithError(c, apperr.ErrMissingAuthorization)
 
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header format"})
-			c.Abort()
+			abortWithError(c, apperr.ErrInvalidAuthorizationFormat)
 
 			return
 		}
 
 		token := parts[1]
 
+		if strings.HasPrefix(token, entity.APIKeyPrefix) {
+			authenticateAPIKey(c, apiKeys, token)
+
+			return
+		}
+
 		claims, err := jwtManager.ValidateToken(token)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
-			c.Abort()
+			abortWithError(c, apperr.ErrInvalidToken)
 
 			return
 		}
 
-		c.Set(userIDKey, claims.UserID)
-		c.Set(emailKey, claims.Email)
+		var issuedAt time.Time
+		if claims.IssuedAt != nil {
+			issuedAt = claims.IssuedAt.Time
+		}
+
+		scopes := claims.Scopes()
+		if len(scopes) > 0 && apiKeys == nil {
+			abortWithError(c, apperr.ErrInsufficientScope)
+
+			return
+		}
+
+		if err := sessions.CheckSession(c.Request.Context(), claims.UserID, entity.UserRole(claims.Role), issuedAt); err != nil {
+			abortWithError(c, err)
+
+			return
+		}
+
+		if len(scopes) > 0 {
+			granted := make([]entity.Scope, len(scopes))
+			for i, scope := range scopes {
+				granted[i] = entity.Scope(scope)
+			}
+
+			c.Set(scopesKey, granted)
+		}
+
+		setUser(c, claims.UserID, claims.Email, entity.UserRole(claims.Role))
 
 		c.Next()
 	}
 }
 
+func authenticateAPIKey(c *gin.Context, apiKeys APIKeyAuthenticator, raw string) {
+	if apiKeys == nil {
+		abortWithError(c, apperr.ErrInsufficientScope)
+
+		return
+	}
+
+	key, user, err := apiKeys.Authenticate(c.Request.Context(), raw)
+	if err != nil {
+		abortWithError(c, err)
+
+		return
+	}
+
+	c.Set(apiKeyKey, key)
+	c.Set(scopesKey, key.Scopes)
+	setUser(c, user.ID, user.Email, user.Role)
+
+	c.Next()
+}
+
+func setUser(c *gin.Context, userID uuid.UUID, email string, role entity.UserRole) {
+	c.Set(userIDKey, userID)
+	c.Set(emailKey, email)
+	c.Set(roleKey, role)
+
+	ctx := c.Request.Context()
+	if reqLogger := logger.FromContext(ctx, nil); reqLogger != nil {
+		reqLogger = reqLogger.With(zap.String("user_id", userID.String()))
+		c.Request = c.Request.WithContext(logger.WithContext(ctx, reqLogger))
+	}
+}
+
 func GetUserID(c *gin.Context) (uuid.UUID, bool) {
 	userID, exists := c.Get(userIDKey)
 	if !exists {
@@ -71,3 +158,74 @@ func GetEmail(c *gin.Context) (string, bool) {
 
 	return e, ok
 }
+
+func GetRole(c *gin.Context) (entity.UserRole, bool) {
+	role, exists := c.Get(roleKey)
+	if !exists {
+		return "", false
+	}
+
+	r, ok := role.(entity.UserRole)
+
+	return r, ok
+}
+
+// GetAPIKey returns the API key the request was authenticated with, if any.
+func GetAPIKey(c *gin.Context) (*entity.APIKey, bool) {
+	value, exists := c.Get(apiKeyKey)
+	if !exists {
+		return nil, false
+	}
+
+	key, ok := value.(*entity.APIKey)
+
+	return key, ok
+}
+
+// GetScopes returns the scopes a limited credential was granted. ok is false
+// for full access tokens.
+func GetScopes(c *gin.Context) ([]entity.Scope, bool) {
+	value, exists := c.Get(scopesKey)
+	if !exists {
+		return nil, false
+	}
+
+	scopes, ok := value.([]entity.Scope)
+
+	return scopes, ok
+}
+
+// RequireScope lets requests made with a limited credential through only when
+// it has scope. Full access tokens are not limited. It must run after Auth.
+func RequireScope(scope entity.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if scopes, ok := GetScopes(c); ok && !slices.Contains(scopes, scope) {
+			abortWithError(c, apperr.ErrInsufficientScope)
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireRole lets the request through only when the authenticated user has
+// one of the given roles. It must run after Auth.
+func RequireRole(roles ...entity.UserRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, ok := GetRole(c)
+		if !ok {
+			abortWithError(c, apperr.ErrUnauthorized)
+
+			return
+		}
+
+		if !slices.Contains(roles, role) {
+			abortWithError(c, apperr.ErrForbidden)
+
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,14 @@
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+// TrustProxies makes c.ClientIP() read header only on requests from proxies,
+// addresses or CIDR ranges. Gin trusts every proxy by default, which would
+// let any client choose the IP its rate limits and login lockouts are keyed
+// on; with no proxies the address of the connection is always used.
+func TrustProxies(router *gin.Engine, proxies []string, header string) error {
+	router.RemoteIPHeaders = []string{header}
+	router.TrustedPlatform = ""
+
+	return router.SetTrustedProxies(proxies)
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"soccer_manager_service/internal/dto"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.uber.org/zap"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	problemTypeBaseURL = "https://soccermanager.com/problems/"
+)
+
+// ErrorHandler renders the last error attached with c.Error as an RFC 7807
+// problem+json response. Handlers and middleware only attach errors and
+// return; the mapping to status, code and localized title lives in apperr.
+func ErrorHandler(log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last()
+		cause := err.Err
+		detail := ""
+
+		if err.IsType(gin.ErrorTypeBind) {
+			if validationErrs, ok := toValidationErrors(cause); ok {
+				cause = validationErrs
+			} else {
+				cause = apperr.ErrInvalidRequest
+				detail = err.Error()
+			}
+		}
+
+		appErr := apperr.As(cause)
+
+		reqLogger := logger.FromContext(c.Request.Context(), log)
+		if appErr.Status >= http.StatusInternalServerError {
+			reqLogger.Error("request failed", zap.Error(err.Err), zap.String("code", appErr.Code))
+		} else {
+			reqLogger.Debug("request rejected", zap.Error(err.Err), zap.String("code", appErr.Code))
+		}
+
+		writeProblem(c, cause, detail)
+	}
+}
+
+// writeProblem aborts the request with cause rendered as a problem+json body,
+// its title localized when the request went through I18nMiddleware.
+func writeProblem(c *gin.Context, cause error, detail string) {
+	appErr := apperr.As(cause)
+	localizer, _ := c.Value(LocalizerKey).(*i18n.Localizer)
+
+	problem := dto.ProblemResponse{
+		Type:      problemTypeBaseURL + appErr.Code,
+		Title:     localizeTitle(cause, appErr, localizer),
+		Status:    appErr.Status,
+		Code:      appErr.Code,
+		Detail:    detail,
+		Instance:  c.Request.URL.Path,
+		RequestID: GetRequestID(c),
+	}
+
+	var validationErrs apperr.ValidationErrors
+	if errors.As(cause, &validationErrs) {
+		for _, ve := range validationErrs {
+			problem.Errors = append(problem.Errors, dto.FieldError{
+				Field:   ve.Field,
+				Rule:    ve.Rule,
+				Param:   ve.Param,
+				Message: localizeValidationError(ve, localizer),
+			})
+		}
+	}
+
+	c.Header("Content-Type", problemContentType)
+	c.AbortWithStatusJSON(appErr.Status, problem)
+}
+
+func localizeTitle(err error, appErr *apperr.Error, localizer *i18n.Localizer) string {
+	if localizer == nil {
+		return appErr.Error()
+	}
+
+	return apperr.LocalizeError(err, localizer)
+}
+
+// abortWithError attaches err for ErrorHandler and stops the chain.
+func abortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
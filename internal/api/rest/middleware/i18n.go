@@ -11,8 +11,9 @@ const LocalizerKey = "localizer"
 func I18nMiddleware(i18nManager *i18n.Manager) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		acceptLanguage := c.GetHeader("Accept-Language")
-		localizer := i18nManager.GetLocalizer(acceptLanguage)
+		localizer, tag := i18nManager.GetLocalizer(acceptLanguage)
 
+		c.Header("Content-Language", tag.String())
 		c.Set(LocalizerKey, localizer)
 		c.Next()
 	}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AccessLog replaces gin's default text logger with one structured zap entry
+// per request, written through the request-scoped logger.
+func AccessLog(log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		fields := []zap.Field{
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("route", c.FullPath()),
+			zap.Int("status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_agent", c.Request.UserAgent()),
+			zap.Int("bytes", c.Writer.Size()),
+		}
+
+		if len(c.Errors) > 0 {
+			fields = append(fields, zap.String("errors", c.Errors.String()))
+		}
+
+		reqLogger := logger.FromContext(c.Request.Context(), log)
+
+		switch {
+		case status >= http.StatusInternalServerError:
+			reqLogger.Error("request completed", fields...)
+		case status >= http.StatusBadRequest:
+			reqLogger.Warn("request completed", fields...)
+		default:
+			reqLogger.Info("request completed", fields...)
+		}
+	}
+}
+
+// Recovery logs panics with the request-scoped logger instead of gin's
+// default stderr writer and answers with the internal_error problem, unless
+// the handler had already started the response.
+func Recovery(log *zap.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		logger.FromContext(c.Request.Context(), log).Error("panic recovered",
+			zap.Any("panic", recovered),
+			zap.Stack("stack"))
+
+		if c.Writer.Written() {
+			c.Abort()
+
+			return
+		}
+
+		writeProblem(c, apperr.ErrInternal, "")
+	})
+}
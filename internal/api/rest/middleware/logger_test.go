@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"soccer_manager_service/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRecovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Recovery(zap.NewNop()), RequestID(zap.NewNop()), ErrorHandler(zap.NewNop()))
+	router.GET("/.well-known/jwks.json", func(_ *gin.Context) {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+
+	var problem dto.ProblemResponse
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, problemContentType, rec.Header().Get("Content-Type"))
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, "internal_error", problem.Code)
+	assert.Equal(t, http.StatusInternalServerError, problem.Status)
+	assert.Equal(t, "/.well-known/jwks.json", problem.Instance)
+	assert.NotEmpty(t, problem.RequestID)
+}
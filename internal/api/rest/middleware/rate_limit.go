@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/entity"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RateLimiter counts one request of subject against the budget of scope.
+type RateLimiter interface {
+	Allow(ctx context.Context, scope, subject string, rate config.Rate) (*entity.RateLimitResult, error)
+}
+
+// RateLimit admits at most rate requests per subject: the authenticated user
+// when it runs after Auth, the client IP otherwise. Every response carries
+// RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers; rejected
+// requests also get Retry-After. When the limiter is unavailable, e.g. while
+// the Redis circuit breaker is open, requests are let through.
+func RateLimit(limiter RateLimiter, scope string, rate config.Rate, log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subject := "ip:" + c.ClientIP()
+		if userID, ok := GetUserID(c); ok {
+			subject = "user:" + userID.String()
+		}
+
+		result, err := limiter.Allow(c.Request.Context(), scope, subject, rate)
+		if err != nil {
+			logger.FromContext(c.Request.Context(), log).Debug("rate limiter unavailable, allowing request",
+				zap.Error(err), zap.String("scope", scope))
+			c.Next()
+
+			return
+		}
+
+		reset := strconv.Itoa(ceilSeconds(result.Reset))
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", reset)
+
+		if !result.Allowed {
+			c.Header("Retry-After", reset)
+			abortWithError(c, apperr.WithData(apperr.ErrRateLimited, map[string]any{"Count": ceilSeconds(result.Reset)}))
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
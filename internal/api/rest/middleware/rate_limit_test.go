@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/entity"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type MockRateLimiter struct {
+	mock.Mock
+}
+
+func (m *MockRateLimiter) Allow(ctx context.Context, scope, subject string, rate config.Rate) (*entity.RateLimitResult, error) {
+	args := m.Called(ctx, scope, subject, rate)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.RateLimitResult), args.Error(1)
+}
+
+func TestRateLimit_ClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rate := config.Rate{Requests: 30, Window: time.Minute}
+	allowed := &entity.RateLimitResult{Allowed: true, Limit: 30, Remaining: 29, Reset: time.Minute}
+
+	// serve sends a request from remoteAddr with a forged X-Forwarded-For
+	// through a router trusting proxies.
+	serve := func(t *testing.T, proxies []string, remoteAddr string, limiter *MockRateLimiter) {
+		t.Helper()
+
+		router := gin.New()
+		assert.NoError(t, TrustProxies(router, proxies, "X-Forwarded-For"))
+
+		router.GET("/teams", RateLimit(limiter, "api", rate, zap.NewNop()), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/teams", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	t.Run("spoofed header is ignored without trusted proxies", func(t *testing.T) {
+		limiter := new(MockRateLimiter)
+		limiter.On("Allow", mock.Anything, "api", "ip:198.51.100.10", rate).Return(allowed, nil)
+
+		serve(t, nil, "198.51.100.10:52000", limiter)
+
+		limiter.AssertExpectations(t)
+	})
+
+	t.Run("spoofed header is ignored from untrusted addresses", func(t *testing.T) {
+		limiter := new(MockRateLimiter)
+		limiter.On("Allow", mock.Anything, "api", "ip:198.51.100.10", rate).Return(allowed, nil)
+
+		serve(t, []string{"10.0.0.0/8"}, "198.51.100.10:52000", limiter)
+
+		limiter.AssertExpectations(t)
+	})
+
+	t.Run("header is used from trusted proxies", func(t *testing.T) {
+		limiter := new(MockRateLimiter)
+		limiter.On("Allow", mock.Anything, "api", "ip:203.0.113.7", rate).Return(allowed, nil)
+
+		serve(t, []string{"10.0.0.0/8"}, "10.1.2.3:52000", limiter)
+
+		limiter.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"soccer_manager_service/pkg/logger"
+	"soccer_manager_service/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const requestIDKey = "request_id"
+
+// RequestID assigns every request an ID, reusing a valid X-Request-ID sent by
+// the client, echoes it back and stores a logger tagged with it in the request
+// context for handlers and usecase services.
+func RequestID(log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !requestid.IsValid(id) {
+			id = requestid.New()
+		}
+
+		c.Set(requestIDKey, id)
+		c.Header(requestid.Header, id)
+
+		ctx := c.Request.Context()
+		fields := []zap.Field{zap.String("request_id", id)}
+
+		span := trace.SpanFromContext(ctx)
+		if spanCtx := span.SpanContext(); spanCtx.IsValid() {
+			span.SetAttributes(attribute.String("http.request.id", id))
+			fields = append(fields, zap.String("trace_id", spanCtx.TraceID().String()))
+		}
+
+		ctx = requestid.WithContext(ctx, id)
+		ctx = logger.WithContext(ctx, log.With(fields...))
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/pkg/logger"
+	"soccer_manager_service/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// serve sends a request with the given X-Request-ID, if any, and returns
+	// the response, the ID seen by the handler and the handler's log entries.
+	serve := func(t *testing.T, path, inbound string) (*httptest.ResponseRecorder, string, []observer.LoggedEntry) {
+		t.Helper()
+
+		core, logs := observer.New(zapcore.InfoLevel)
+		log := zap.New(core)
+
+		var seen string
+
+		router := gin.New()
+		router.Use(RequestID(log), ErrorHandler(zap.NewNop()))
+		router.GET("/ok", func(c *gin.Context) {
+			seen = requestid.FromContext(c.Request.Context())
+			logger.FromContext(c.Request.Context(), zap.NewNop()).Info("handled")
+			c.Status(http.StatusOK)
+		})
+		router.GET("/fail", func(c *gin.Context) {
+			seen = requestid.FromContext(c.Request.Context())
+			_ = c.Error(errors.New("boom"))
+		})
+
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if inbound != "" {
+			req.Header.Set(requestid.Header, inbound)
+		}
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		return rec, seen, logs.All()
+	}
+
+	t.Run("valid id is echoed and logged", func(t *testing.T) {
+		rec, seen, logs := serve(t, "/ok", "edge-7f3a9c")
+
+		assert.Equal(t, "edge-7f3a9c", rec.Header().Get(requestid.Header))
+		assert.Equal(t, "edge-7f3a9c", seen)
+
+		if assert.Len(t, logs, 1) {
+			assert.Equal(t, "handled", logs[0].Message)
+			assert.Equal(t, "edge-7f3a9c", logs[0].ContextMap()["request_id"])
+		}
+	})
+
+	t.Run("invalid ids are replaced", func(t *testing.T) {
+		for _, inbound := range []string{
+			strings.Repeat("a", 129),
+			"with space",
+			"ünïcode",
+		} {
+			rec, seen, logs := serve(t, "/ok", inbound)
+			id := rec.Header().Get(requestid.Header)
+
+			_, err := uuid.Parse(id)
+
+			assert.NoError(t, err, "%q", inbound)
+			assert.Equal(t, id, seen)
+
+			if assert.Len(t, logs, 1) {
+				assert.Equal(t, id, logs[0].ContextMap()["request_id"])
+			}
+		}
+	})
+
+	t.Run("missing id is generated", func(t *testing.T) {
+		rec, seen, _ := serve(t, "/ok", "")
+		id := rec.Header().Get(requestid.Header)
+
+		_, err := uuid.Parse(id)
+
+		assert.NoError(t, err)
+		assert.Equal(t, id, seen)
+
+		other, _, _ := serve(t, "/ok", "")
+		assert.NotEqual(t, id, other.Header().Get(requestid.Header))
+	})
+
+	t.Run("header is set on errors", func(t *testing.T) {
+		rec, _, _ := serve(t, "/fail", "edge-7f3a9c")
+
+		var problem dto.ProblemResponse
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "edge-7f3a9c", rec.Header().Get(requestid.Header))
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, "edge-7f3a9c", problem.RequestID)
+	})
+
+	t.Run("header is set on unknown routes", func(t *testing.T) {
+		rec, _, _ := serve(t, "/missing", "")
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get(requestid.Header))
+	})
+}
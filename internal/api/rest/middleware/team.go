@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	apperr "soccer_manager_service/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// TeamIDHeader selects which of the user's teams a request acts on. It can
+	// be omitted by users with a single team.
+	TeamIDHeader = "X-Team-ID"
+
+	teamIDKey = "team_id"
+)
+
+// SelectTeam reads the team selected with the X-Team-ID header. Whether the
+// user owns the team is checked by the usecase services.
+func SelectTeam() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if header := c.GetHeader(TeamIDHeader); header != "" {
+			teamID, err := uuid.Parse(header)
+			if err != nil {
+				abortWithError(c, apperr.ErrInvalidTeamID)
+
+				return
+			}
+
+			c.Set(teamIDKey, teamID)
+		}
+
+		c.Next()
+	}
+}
+
+// GetTeamID returns the selected team, uuid.Nil when none was selected.
+func GetTeamID(c *gin.Context) uuid.UUID {
+	value, exists := c.Get(teamIDKey)
+	if !exists {
+		return uuid.Nil
+	}
+
+	teamID, _ := value.(uuid.UUID)
+
+	return teamID
+}
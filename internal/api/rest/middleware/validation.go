@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	apperr "soccer_manager_service/pkg/errors"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// RegisterJSONFieldNames makes the gin validator report fields by their JSON
+// names, so validation errors refer to what the client actually sent.
+func RegisterJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+
+		if name == "" {
+			return field.Name
+		}
+
+		return name
+	})
+}
+
+// toValidationErrors converts a binding error into apperr.ValidationErrors.
+// It reports false for errors that are not tied to a field, such as malformed
+// JSON.
+func toValidationErrors(err error) (apperr.ValidationErrors, bool) {
+	var fieldErrs validator.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		result := make(apperr.ValidationErrors, 0, len(fieldErrs))
+
+		for _, fe := range fieldErrs {
+			result = append(result, &apperr.ValidationError{
+				Field:     fe.Field(),
+				Rule:      fe.Tag(),
+				Param:     fe.Param(),
+				MessageID: validationMessageID(fe.Tag(), fe.Kind()),
+				Message:   fmt.Sprintf("failed on the '%s' rule", fe.Tag()),
+			})
+		}
+
+		return result, true
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return apperr.ValidationErrors{{
+			Field:     typeErr.Field,
+			Rule:      "type",
+			Param:     typeErr.Type.String(),
+			MessageID: "validation.type",
+			Message:   fmt.Sprintf("must be of type %s", typeErr.Type),
+		}}, true
+	}
+
+	return nil, false
+}
+
+// validationMessageID picks the message for a failed rule. Length rules read
+// differently for strings, lists and numbers, so they get their own messages.
+func validationMessageID(rule string, kind reflect.Kind) string {
+	switch rule {
+	case "required", "email", "oneof", "url", "uuid", "ip":
+		return "validation." + rule
+	case "min", "max":
+		switch kind {
+		case reflect.String:
+			return "validation." + rule + "_length"
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return "validation." + rule + "_items"
+		default:
+			return "validation." + rule
+		}
+	default:
+		return "validation.invalid"
+	}
+}
+
+func localizeValidationError(ve *apperr.ValidationError, localizer *i18n.Localizer) string {
+	if localizer == nil || ve.MessageID == "" {
+		return ve.Message
+	}
+
+	msg, err := localizer.Localize(&i18n.LocalizeConfig{
+		MessageID: ve.MessageID,
+		TemplateData: map[string]string{
+			"Field": ve.Field,
+			"Param": ve.Param,
+		},
+	})
+	if err != nil {
+		return ve.Message
+	}
+
+	return msg
+}
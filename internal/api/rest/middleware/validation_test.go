@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type validationTestRequest struct {
+	TeamName string   `json:"team_name" binding:"required,min=3,max=10"`
+	Email    string   `json:"email" binding:"required,email"`
+	Age      int      `json:"age" binding:"min=18,max=40"`
+	Tags     []string `json:"tags" binding:"omitempty,max=2"`
+	Role     string   `json:"role" binding:"omitempty,oneof=manager admin"`
+	Website  string   `json:"website" binding:"omitempty,url"`
+	TeamID   string   `json:"team_id" binding:"omitempty,uuid"`
+	ClientIP string   `json:"client_ip" binding:"omitempty,ip"`
+}
+
+func TestErrorHandler_Validation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	RegisterJSONFieldNames()
+
+	i18nManager, err := i18n.NewManager("")
+	assert.NoError(t, err)
+
+	router := gin.New()
+	router.Use(RequestID(zap.NewNop()), I18nMiddleware(i18nManager), ErrorHandler(zap.NewNop()))
+	router.POST("/validate", func(c *gin.Context) {
+		var req validationTestRequest
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			_ = c.Error(err).SetType(gin.ErrorTypeBind)
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	})
+
+	// post sends body and returns the problem, failing unless the request
+	// was rejected as invalid.
+	post := func(t *testing.T, language, body string) dto.ProblemResponse {
+		t.Helper()
+
+		req := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", language)
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		var problem dto.ProblemResponse
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, problemContentType, rec.Header().Get("Content-Type"))
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+
+		return problem
+	}
+
+	t.Run("localized field errors", func(t *testing.T) {
+		problem := post(t, "ru", `{
+			"team_name": "ab",
+			"email": "not-an-email",
+			"age": 16,
+			"tags": ["a", "b", "c"],
+			"role": "owner",
+			"website": "not a url",
+			"team_id": "42",
+			"client_ip": "999.1.1.1"
+		}`)
+
+		assert.Equal(t, "validation_failed", problem.Code)
+		assert.Equal(t, "Ошибка валидации", problem.Title)
+		assert.Equal(t, []dto.FieldError{
+			{Field: "team_name", Rule: "min", Param: "3", Message: "Поле team_name должно содержать не менее 3 символов"},
+			{Field: "email", Rule: "email", Message: "Поле email должно быть корректным адресом электронной почты"},
+			{Field: "age", Rule: "min", Param: "18", Message: "Поле age должно быть не меньше 18"},
+			{Field: "tags", Rule: "max", Param: "2", Message: "Поле tags должно содержать не более 2 элементов"},
+			{Field: "role", Rule: "oneof", Param: "manager admin", Message: "Поле role должно быть одним из: manager admin"},
+			{Field: "website", Rule: "url", Message: "Поле website должно быть корректным URL"},
+			{Field: "team_id", Rule: "uuid", Message: "Поле team_id должно быть корректным UUID"},
+			{Field: "client_ip", Rule: "ip", Message: "Поле client_ip должно быть корректным IP-адресом"},
+		}, problem.Errors)
+	})
+
+	t.Run("max length and value", func(t *testing.T) {
+		problem := post(t, "ru", `{"team_name": "a-very-long-name", "email": "user@example.com", "age": 41}`)
+
+		assert.Equal(t, []dto.FieldError{
+			{Field: "team_name", Rule: "max", Param: "10", Message: "Поле team_name должно содержать не более 10 символов"},
+			{Field: "age", Rule: "max", Param: "40", Message: "Поле age должно быть не больше 40"},
+		}, problem.Errors)
+	})
+
+	t.Run("required", func(t *testing.T) {
+		problem := post(t, "ru", `{"age": 20}`)
+
+		assert.Equal(t, []dto.FieldError{
+			{Field: "team_name", Rule: "required", Message: "Поле team_name обязательно"},
+			{Field: "email", Rule: "required", Message: "Поле email обязательно"},
+		}, problem.Errors)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		problem := post(t, "ru", `{"team_name": "Team", "email": "user@example.com", "age": "twenty"}`)
+
+		assert.Equal(t, []dto.FieldError{
+			{Field: "age", Rule: "type", Param: "int", Message: "Поле age должно иметь тип int"},
+		}, problem.Errors)
+	})
+
+	t.Run("default language", func(t *testing.T) {
+		problem := post(t, "", `{"team_name": "ab", "email": "user@example.com", "age": 20, "website": "nope"}`)
+
+		assert.Equal(t, []dto.FieldError{
+			{Field: "team_name", Rule: "min", Param: "3", Message: "team_name must be at least 3 characters long"},
+			{Field: "website", Rule: "url", Message: "website must be a valid URL"},
+		}, problem.Errors)
+	})
+}
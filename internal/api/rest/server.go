@@ -1,8 +1,11 @@
 package rest
 
 import (
+	"fmt"
 	"soccer_manager_service/internal/api/rest/handlers"
 	"soccer_manager_service/internal/api/rest/middleware"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/entity"
 	"soccer_manager_service/internal/usecase"
 	i18nPkg "soccer_manager_service/pkg/i18n"
 	"soccer_manager_service/pkg/jwt"
@@ -31,21 +34,37 @@ import (
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
-// @description Type "Bearer" followed by a space and JWT token
+// @description Type "Bearer" followed by a space and a JWT access token or a personal API key
 
 type Server struct {
 	router      *gin.Engine
+	config      *config.Config
 	jwtManager  *jwt.Manager
 	usecase     *usecase.Service
 	logger      *zap.Logger
 	i18nManager *i18nPkg.Manager
 }
 
-func NewServer(jwtManager *jwt.Manager, usecase *usecase.Service, logger *zap.Logger, i18nManager *i18nPkg.Manager) *Server {
-	router := gin.Default()
+func NewServer(config *config.Config, jwtManager *jwt.Manager, usecase *usecase.Service, logger *zap.Logger, i18nManager *i18nPkg.Manager) (*Server, error) {
+	middleware.RegisterJSONFieldNames()
+
+	router := gin.New()
+
+	if err := middleware.TrustProxies(router, config.Server.TrustedProxies, config.Server.ClientIPHeader); err != nil {
+		return nil, fmt.Errorf("set trusted proxies: %w", err)
+	}
+
+	router.Use(
+		middleware.Recovery(logger),
+		middleware.Tracing(),
+		middleware.RequestID(logger),
+		middleware.AccessLog(logger),
+		middleware.ErrorHandler(logger),
+	)
 
 	s := &Server{
 		router:      router,
+		config:      config,
 		jwtManager:  jwtManager,
 		usecase:     usecase,
 		logger:      logger,
@@ -54,47 +73,154 @@ func NewServer(jwtManager *jwt.Manager, usecase *usecase.Service, logger *zap.Lo
 
 	s.setupRoutes()
 
-	return s
+	return s, nil
+}
+
+// rateLimit returns the rate limit guard for scope, or a no-op when rate
+// limiting is disabled.
+func (s *Server) rateLimit(scope string, rate config.Rate) gin.HandlerFunc {
+	if !s.config.RateLimit.Enabled {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return middleware.RateLimit(s.usecase.RateLimit, scope, rate, s.logger)
 }
 
 func (s *Server) setupRoutes() {
 	authHandler := handlers.NewAuthHandler(s.usecase.Auth, s.logger)
+	accountHandler := handlers.NewAccountHandler(s.usecase.Account, s.logger)
+	twoFactorHandler := handlers.NewTwoFactorHandler(s.usecase.TwoFactor, s.logger)
 	teamHandler := handlers.NewTeamHandler(s.usecase.Team, s.logger)
 	playerHandler := handlers.NewPlayerHandler(s.usecase.Player, s.logger)
 	transferHandler := handlers.NewTransferHandler(s.usecase.Transfer, s.logger)
+	adminHandler := handlers.NewAdminHandler(s.usecase.Admin, s.logger)
+	apiKeyHandler := handlers.NewAPIKeyHandler(s.usecase.APIKey, s.logger)
+	webhookHandler := handlers.NewWebhookHandler(s.usecase.Webhook, s.logger)
+	leaderboardHandler := handlers.NewLeaderboardHandler(s.usecase.Leaderboard, s.logger)
+	marketHandler := handlers.NewMarketHandler(s.usecase.Market, s.config.Market.Heartbeat, s.logger)
+	jwksHandler := handlers.NewJWKSHandler(s.jwtManager)
 
 	s.router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	s.router.GET("/.well-known/jwks.json", jwksHandler.GetKeySet)
 
 	api := s.router.Group("/api/v1")
 	api.Use(middleware.I18nMiddleware(s.i18nManager))
 	{
+		authMiddleware := middleware.Auth(s.jwtManager, s.usecase.Auth, nil)
+		scopedAuthMiddleware := middleware.Auth(s.jwtManager, s.usecase.Auth, s.usecase.APIKey)
+		limits := s.config.RateLimit
+		apiLimit := s.rateLimit("api", limits.Default)
+
 		auth := api.Group("/auth")
+		auth.Use(s.rateLimit("auth", limits.Auth))
 		{
-			auth.POST("/register", authHandler.Register)
+			auth.POST("/register", s.rateLimit("register", limits.Register), authHandler.Register)
 			auth.POST("/login", authHandler.Login)
+			auth.POST("/login/2fa", authHandler.CompleteTwoFactorLogin)
+			auth.GET("/oidc/providers", authHandler.ListOIDCProviders)
+			auth.GET("/oidc/:provider/authorize", authHandler.StartOIDCLogin)
+			auth.GET("/oidc/:provider/callback", authHandler.CompleteOIDCLogin)
+			auth.POST("/verify-email", authHandler.VerifyEmail)
+			auth.POST("/verify-email/resend", authMiddleware, authHandler.ResendVerificationEmail)
+			auth.POST("/password-reset", authHandler.RequestPasswordReset)
+			auth.POST("/password-reset/confirm", authHandler.ResetPassword)
+		}
+
+		account := api.Group("/account")
+		account.Use(authMiddleware, apiLimit)
+		{
+			account.PUT("/password", accountHandler.ChangePassword)
+			account.PUT("/email", accountHandler.ChangeEmail)
+			account.DELETE("", accountHandler.DeleteAccount)
+			account.GET("/2fa", twoFactorHandler.GetStatus)
+			account.POST("/2fa/enroll", twoFactorHandler.Enroll)
+			account.POST("/2fa/enable", twoFactorHandler.Enable)
+			account.DELETE("/2fa", twoFactorHandler.Disable)
+			account.POST("/2fa/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
+			account.GET("/api-keys", apiKeyHandler.ListAPIKeys)
+			account.POST("/api-keys", apiKeyHandler.CreateAPIKey)
+			account.DELETE("/api-keys/:id", apiKeyHandler.RevokeAPIKey)
+			account.GET("/webhooks", webhookHandler.ListWebhooks)
+			account.POST("/webhooks", webhookHandler.CreateWebhook)
+			account.GET("/webhooks/:id", webhookHandler.GetWebhook)
+			account.PATCH("/webhooks/:id", webhookHandler.UpdateWebhook)
+			account.DELETE("/webhooks/:id", webhookHandler.DeleteWebhook)
+			account.POST("/webhooks/:id/ping", webhookHandler.PingWebhook)
+			account.GET("/webhooks/:id/deliveries", webhookHandler.ListWebhookDeliveries)
+			account.POST("/tokens", accountHandler.CreateScopedToken)
 		}
 
-		authMiddleware := middleware.Auth(s.jwtManager)
+		teamRead := middleware.RequireScope(entity.ScopeTeamRead)
+		teamWrite := middleware.RequireScope(entity.ScopeTeamWrite)
+		marketRead := middleware.RequireScope(entity.ScopeMarketRead)
+		marketTrade := middleware.RequireScope(entity.ScopeMarketTrade)
+		selectTeam := middleware.SelectTeam()
 
 		team := api.Group("/team")
-		team.Use(authMiddleware)
+		team.Use(scopedAuthMiddleware, apiLimit, selectTeam)
+		{
+			team.GET("", teamRead, teamHandler.GetMyTeam)
+			team.POST("", teamWrite, teamHandler.CreateTeam)
+			team.GET("/all", teamRead, teamHandler.ListTeams)
+			team.PATCH("", teamWrite, teamHandler.UpdateTeam)
+			team.GET("/finances", teamRead, teamHandler.GetFinances)
+		}
+
+		// Public profiles need no credentials and never show budgets.
+		teams := api.Group("/teams")
+		teams.Use(apiLimit)
 		{
-			team.GET("", teamHandler.GetMyTeam)
-			team.PATCH("", teamHandler.UpdateTeam)
+			teams.GET("", teamHandler.SearchTeams)
+			teams.GET("/:id", teamHandler.GetPublicTeam)
 		}
 
+		api.GET("/players/:id", apiLimit, playerHandler.GetPlayer)
+
 		players := api.Group("/players")
-		players.Use(authMiddleware)
+		players.Use(scopedAuthMiddleware, apiLimit, selectTeam)
 		{
-			players.PATCH("/:id", playerHandler.UpdatePlayer)
-			players.POST("/:id/transfer", transferHandler.ListPlayer)
+			players.PATCH("/:id", teamWrite, playerHandler.UpdatePlayer)
+			players.POST("/:id/transfer", marketTrade, transferHandler.ListPlayer)
 		}
 
 		transfers := api.Group("/transfers")
-		transfers.Use(authMiddleware)
+		transfers.Use(scopedAuthMiddleware, apiLimit, selectTeam)
+		{
+			transfers.GET("", marketRead, transferHandler.GetTransferList)
+			transfers.POST("/:id/buy", marketTrade, s.rateLimit("buy", limits.Buy), transferHandler.BuyPlayer)
+		}
+
+		// The stream stays open, so it counts as a single request against the
+		// rate limit.
+		market := api.Group("/market")
+		market.Use(scopedAuthMiddleware, apiLimit)
+		{
+			market.GET("/events", marketRead, marketHandler.StreamEvents)
+		}
+
+		leaderboards := api.Group("/leaderboards")
+		leaderboards.Use(scopedAuthMiddleware, apiLimit)
+		{
+			leaderboards.GET("/:kind", teamRead, leaderboardHandler.GetLeaderboard)
+		}
+
+		staffOnly := middleware.RequireRole(entity.RoleModerator, entity.RoleAdmin)
+		adminOnly := middleware.RequireRole(entity.RoleAdmin)
+
+		admin := api.Group("/admin")
+		admin.Use(authMiddleware, apiLimit, staffOnly)
 		{
-			transfers.GET("", transferHandler.GetTransferList)
-			transfers.POST("/:id/buy", transferHandler.BuyPlayer)
+			admin.GET("/users", adminHandler.ListUsers)
+			admin.PUT("/users/:id/role", adminOnly, adminHandler.UpdateUserRole)
+			admin.POST("/users/:id/ban", adminOnly, adminHandler.BanUser)
+			admin.DELETE("/users/:id/ban", adminOnly, adminHandler.UnbanUser)
+			admin.DELETE("/users/:id/login-lockout", adminOnly, adminHandler.UnlockUserLogin)
+			admin.DELETE("/users/:id/two-factor", adminOnly, adminHandler.ResetTwoFactor)
+			admin.DELETE("/login-lockouts", adminOnly, adminHandler.UnlockIPLogin)
+			admin.GET("/teams", adminHandler.ListTeams)
+			admin.POST("/teams/:id/budget", adminOnly, adminHandler.AdjustTeamBudget)
+			admin.POST("/transfers/:id/cancel", adminHandler.CancelTransfer)
+			admin.GET("/audit-log", adminOnly, adminHandler.ListAuditLog)
 		}
 	}
 }
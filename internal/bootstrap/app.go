@@ -20,14 +20,23 @@ func NewApp() *fx.App {
 			newPostgres,
 			newJWTManager,
 			newI18nManager,
+			newMailer,
+			newEventSinks,
+			newWebhookSender,
+			newOIDCProviders,
 			repository.NewRepository,
 			usecase.NewUsecase,
 			rest.NewServer,
 		),
 
 		fx.Invoke(
+			initTracing,
 			runMigrations,
 			startHTTPServer,
+			startLeaderboardRebuild,
+			startMarketEvents,
+			startEventDispatcher,
+			startWebhookDelivery,
 			errWrapInit,
 		),
 
@@ -1,6 +1,7 @@
 package bootstrap
 
 import (
+	"soccer_manager_service/internal/repository/redisrepo"
 	"time"
 
 	"github.com/sony/gobreaker"
@@ -9,7 +10,7 @@ import (
 
 const (
 	postgresBreakerName = "postgres"
-	redisBreakerName    = "redis"
+	redisBreakerName    = redisrepo.BreakerName
 )
 
 func initBreakers(logger *zap.Logger) (map[string]*gobreaker.CircuitBreaker, error) {
@@ -0,0 +1,73 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/eventsink"
+	"soccer_manager_service/internal/ports"
+	"soccer_manager_service/internal/repository/redisrepo"
+	"soccer_manager_service/internal/usecase"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	eventSinkLog   = "log"
+	eventSinkRedis = "redis"
+)
+
+func newEventSinks(config *config.Config, client *redis.Client, logger *zap.Logger) ([]ports.EventSink, error) {
+	logger.Info("initializing event sinks", zap.Strings("sinks", config.Outbox.Sinks))
+
+	sinks := make([]ports.EventSink, 0, len(config.Outbox.Sinks))
+
+	for _, name := range config.Outbox.Sinks {
+		switch name {
+		case eventSinkLog:
+			sinks = append(sinks, eventsink.NewLog(logger))
+		case eventSinkRedis:
+			sinks = append(sinks, redisrepo.NewEventStream(redisrepo.EventStreamParams{
+				Redis:  client,
+				Stream: config.Outbox.RedisStream,
+				MaxLen: config.Outbox.RedisStreamMaxLen,
+				Logger: logger,
+			}))
+		default:
+			return nil, fmt.Errorf("unknown event sink %q", name)
+		}
+	}
+
+	return sinks, nil
+}
+
+// startEventDispatcher delivers the events of the outbox until the app stops.
+// Events claimed when it stops are retried once their lease expires.
+func startEventDispatcher(lc fx.Lifecycle, service *usecase.Service) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+
+				_ = service.Events.Run(ctx)
+			}()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+
+			return nil
+		},
+	})
+}
@@ -2,18 +2,19 @@ package bootstrap
 
 import (
 	"go.uber.org/zap"
+	"soccer_manager_service/internal/config"
 	i18nPkg "soccer_manager_service/pkg/i18n"
 )
 
-func newI18nManager(logger *zap.Logger) (*i18nPkg.Manager, error) {
-	manager, err := i18nPkg.NewManager()
+func newI18nManager(config *config.Config, logger *zap.Logger) (*i18nPkg.Manager, error) {
+	manager, err := i18nPkg.NewManager(config.I18n.LocalesDir)
 	if err != nil {
 		logger.Error("failed to initialize i18n manager", zap.Error(err))
 
 		return nil, err
 	}
 
-	logger.Info("i18n manager initialized")
+	logger.Info("i18n manager initialized", zap.Stringers("languages", manager.LanguageTags()))
 
 	return manager, nil
 }
@@ -1,14 +1,79 @@
 package bootstrap
 
 import (
+	"fmt"
+	"os"
 	"soccer_manager_service/internal/config"
 	"soccer_manager_service/pkg/jwt"
+
+	"go.uber.org/zap"
 )
 
-func newJWTManager(config *config.Config) *jwt.Manager {
-	return jwt.NewManager(
-		config.JWT.Secret,
-		config.JWT.AccessTokenTTL,
-		config.JWT.RefreshTokenTTL,
-	)
+func newJWTManager(config *config.Config, logger *zap.Logger) (*jwt.Manager, error) {
+	keys, err := newJWTKeyRing(config.JWT, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return jwt.NewManager(jwt.Config{
+		Keys:            keys,
+		Issuer:          config.JWT.Issuer,
+		Audience:        config.JWT.Audience,
+		AccessTokenTTL:  config.JWT.AccessTokenTTL,
+		RefreshTokenTTL: config.JWT.RefreshTokenTTL,
+	}), nil
+}
+
+func newJWTKeyRing(config config.JWTConfig, logger *zap.Logger) (*jwt.KeyRing, error) {
+	if config.SigningKeyFile == "" {
+		logger.Info("signing tokens with shared secret")
+
+		return jwt.NewHMACKeyRing(config.Secret), nil
+	}
+
+	signing, err := readJWTKey(config.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	verifying := make([]*jwt.Key, 0, len(config.VerificationKeyFiles)+1)
+
+	for _, path := range config.VerificationKeyFiles {
+		key, err := readJWTKey(path)
+		if err != nil {
+			return nil, err
+		}
+
+		verifying = append(verifying, key)
+	}
+
+	if config.Secret != "" {
+		verifying = append(verifying, jwt.NewHMACKey(config.Secret))
+	}
+
+	keys, err := jwt.NewKeyRing(signing, verifying...)
+	if err != nil {
+		return nil, fmt.Errorf("create jwt key ring: %w", err)
+	}
+
+	logger.Info("signing tokens with private key",
+		zap.String("kid", signing.ID()),
+		zap.String("alg", signing.Algorithm()),
+		zap.Int("verification_keys", len(verifying)))
+
+	return keys, nil
+}
+
+func readJWTKey(path string) (*jwt.Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read jwt key: %w", err)
+	}
+
+	key, err := jwt.ParseKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse jwt key %s: %w", path, err)
+	}
+
+	return key, nil
 }
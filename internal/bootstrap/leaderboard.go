@@ -0,0 +1,53 @@
+package bootstrap
+
+import (
+	"context"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/usecase"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// startLeaderboardRebuild rebuilds the leaderboards from Postgres on start
+// and every LEADERBOARD_REBUILD_INTERVAL after that.
+func startLeaderboardRebuild(lc fx.Lifecycle, service *usecase.Service, config *config.Config, logger *zap.Logger) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(config.Leaderboard.RebuildInterval)
+				defer ticker.Stop()
+
+				for {
+					if err := service.Leaderboard.Rebuild(ctx); err != nil {
+						logger.Error("failed to rebuild leaderboards", zap.Error(err))
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+
+			return nil
+		},
+	})
+}
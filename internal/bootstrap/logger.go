@@ -1,6 +1,7 @@
 package bootstrap
 
 import (
+	"io"
 	"os"
 	"soccer_manager_service/internal/config"
 
@@ -9,6 +10,15 @@ import (
 )
 
 func newLogger(config *config.Config) *zap.Logger {
+	return newLoggerTo(config, os.Stdout)
+}
+
+// newStderrLogger keeps stdout free for the output of command-line tools.
+func newStderrLogger(config *config.Config) *zap.Logger {
+	return newLoggerTo(config, os.Stderr)
+}
+
+func newLoggerTo(config *config.Config, w io.Writer) *zap.Logger {
 	var level zapcore.Level
 
 	switch config.App.LogLevel {
@@ -30,7 +40,7 @@ func newLogger(config *config.Config) *zap.Logger {
 
 	core := zapcore.NewCore(
 		zapcore.NewJSONEncoder(encoderConfig),
-		zapcore.AddSync(os.Stdout),
+		zapcore.AddSync(w),
 		level,
 	)
 
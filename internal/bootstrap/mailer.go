@@ -0,0 +1,37 @@
+package bootstrap
+
+import (
+	"fmt"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/ports"
+	"soccer_manager_service/pkg/mailer"
+
+	"go.uber.org/zap"
+)
+
+const (
+	mailDriverLog  = "log"
+	mailDriverFile = "file"
+	mailDriverSMTP = "smtp"
+)
+
+func newMailer(config *config.Config, logger *zap.Logger) (ports.Mailer, error) {
+	logger.Info("initializing mailer", zap.String("driver", config.Mail.Driver))
+
+	switch config.Mail.Driver {
+	case mailDriverLog, "":
+		return mailer.NewLog(logger), nil
+	case mailDriverFile:
+		return mailer.NewFile(config.Mail.FileDir, config.Mail.From)
+	case mailDriverSMTP:
+		return mailer.NewSMTP(
+			config.Mail.SMTPHost,
+			config.Mail.SMTPPort,
+			config.Mail.SMTPUsername,
+			config.Mail.SMTPPassword,
+			config.Mail.From,
+		), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", config.Mail.Driver)
+	}
+}
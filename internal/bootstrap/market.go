@@ -0,0 +1,53 @@
+package bootstrap
+
+import (
+	"context"
+	"soccer_manager_service/internal/usecase"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// marketResubscribeDelay is how long to wait before subscribing to market
+// events again after the subscription failed.
+const marketResubscribeDelay = 5 * time.Second
+
+// startMarketEvents feeds the market event stream of this instance from
+// Redis. It is stopped before the HTTP server, which ends open streams.
+func startMarketEvents(lc fx.Lifecycle, service *usecase.Service, logger *zap.Logger) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+
+				for {
+					if err := service.Market.Run(ctx); err != nil && ctx.Err() == nil {
+						logger.Error("market event stream failed", zap.Error(err))
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(marketResubscribeDelay):
+					}
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+
+			return nil
+		},
+	})
+}
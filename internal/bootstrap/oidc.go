@@ -0,0 +1,29 @@
+package bootstrap
+
+import (
+	"net/http"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/ports"
+	"soccer_manager_service/pkg/oidc"
+
+	"go.uber.org/zap"
+)
+
+func newOIDCProviders(config *config.Config, logger *zap.Logger) ports.OIDCProviders {
+	client := &http.Client{Timeout: config.OIDC.HTTPTimeout}
+	providers := make(ports.OIDCProviders, len(config.OIDC.Providers))
+
+	for name, provider := range config.OIDC.Providers {
+		logger.Info("initializing oidc provider", zap.String("provider", name), zap.String("issuer", provider.Issuer))
+
+		providers[name] = oidc.New(oidc.Config{
+			Issuer:       provider.Issuer,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			RedirectURL:  provider.RedirectURL,
+			Scopes:       provider.Scopes,
+		}, client)
+	}
+
+	return providers
+}
@@ -0,0 +1,118 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/repository"
+	"soccer_manager_service/internal/usecase"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// Exit codes of the reconcile command.
+const (
+	ReconcileOK          = 0
+	ReconcileIssuesFound = 1
+	ReconcileFailed      = 2
+)
+
+type ReconcileOptions struct {
+	Fix  bool
+	JSON bool
+	Out  io.Writer
+}
+
+// RunReconcile checks the database for inconsistencies, repairs them if
+// opts.Fix is set and writes the report to opts.Out; logs and errors go to
+// stderr. It returns the process exit code: issues left unrepaired make it
+// ReconcileIssuesFound.
+func RunReconcile(opts ReconcileOptions) int {
+	var service *usecase.Service
+
+	app := fx.New(
+		fx.NopLogger,
+		fx.Provide(
+			config.GetConfig,
+			newStderrLogger,
+			initBreakers,
+			newRedis,
+			newPostgres,
+			newJWTManager,
+			newMailer,
+			newEventSinks,
+			newWebhookSender,
+			newOIDCProviders,
+			repository.NewRepository,
+			usecase.NewUsecase,
+		),
+		fx.Invoke(initTracing),
+		fx.Populate(&service),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := app.Start(ctx); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to start: %v\n", err)
+
+		return ReconcileFailed
+	}
+
+	defer func() { _ = app.Stop(context.Background()) }()
+
+	report, err := service.Integrity.Check(context.Background(), opts.Fix)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "integrity check failed: %v\n", err)
+
+		return ReconcileFailed
+	}
+
+	if err := writeReport(opts, report); err != nil {
+		return ReconcileFailed
+	}
+
+	if len(report.Issues) > 0 && !report.Fixed {
+		return ReconcileIssuesFound
+	}
+
+	return ReconcileOK
+}
+
+func writeReport(opts ReconcileOptions, report *entity.IntegrityReport) error {
+	if opts.JSON {
+		encoder := json.NewEncoder(opts.Out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(report)
+	}
+
+	verb := "found"
+	if report.Fixed {
+		verb = "repaired"
+	}
+
+	if _, err := fmt.Fprintf(opts.Out, "%d issue(s) %s\n", len(report.Issues), verb); err != nil {
+		return err
+	}
+
+	if len(report.Issues) == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(opts.Out, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(w, "\nKIND\tENTITY\tDETAIL\tREPAIR")
+
+	for _, issue := range report.Issues {
+		_, _ = fmt.Fprintf(w, "%s\t%s %s\t%s\t%s\n", issue.Kind, issue.EntityType, issue.EntityID, issue.Detail, issue.Repair)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,77 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"soccer_manager_service/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	tracingExporterNone = "none"
+	tracingExporterOTLP = "otlp"
+)
+
+func initTracing(lc fx.Lifecycle, config *config.Config, logger *zap.Logger) error {
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.App.Name),
+		semconv.DeploymentEnvironmentName(config.App.Environment),
+	))
+	if err != nil {
+		return fmt.Errorf("create tracing resource: %w", err)
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.Tracing.SampleRatio))),
+	}
+
+	switch config.Tracing.Exporter {
+	case tracingExporterNone, "":
+	case tracingExporterOTLP:
+		exporterOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Tracing.OTLPEndpoint)}
+		if config.Tracing.OTLPInsecure {
+			exporterOpts = append(exporterOpts, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err := otlptracehttp.New(context.Background(), exporterOpts...)
+		if err != nil {
+			logger.Error("failed to create OTLP trace exporter", zap.Error(err))
+
+			return err
+		}
+
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	default:
+		return fmt.Errorf("unknown tracing exporter %q", config.Tracing.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			logger.Info("shutting down tracer provider")
+
+			return provider.Shutdown(ctx)
+		},
+	})
+
+	logger.Info("tracing initialized", zap.String("exporter", config.Tracing.Exporter))
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package bootstrap
+
+import (
+	"context"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/ports"
+	"soccer_manager_service/internal/usecase"
+	"soccer_manager_service/pkg/webhook"
+
+	"go.uber.org/fx"
+)
+
+func newWebhookSender(config *config.Config) ports.WebhookSender {
+	return webhook.NewClient(config.Webhook.Timeout, config.Webhook.AllowPrivate)
+}
+
+// startWebhookDelivery sends queued webhook deliveries until the app stops.
+// Deliveries in flight when it stops are retried once their lease expires.
+func startWebhookDelivery(lc fx.Lifecycle, service *usecase.Service) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+
+				_ = service.Webhook.Run(ctx)
+			}()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+
+			return nil
+		},
+	})
+}
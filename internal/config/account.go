@@ -0,0 +1,9 @@
+package config
+
+import "time"
+
+type AccountConfig struct {
+	EmailVerificationTTL     time.Duration `envconfig:"ACCOUNT_EMAIL_VERIFICATION_TTL" default:"24h"`
+	PasswordResetTTL         time.Duration `envconfig:"ACCOUNT_PASSWORD_RESET_TTL" default:"1h"`
+	RequireEmailVerification bool          `envconfig:"ACCOUNT_REQUIRE_EMAIL_VERIFICATION" default:"false"`
+}
@@ -0,0 +1,15 @@
+package config
+
+import "errors"
+
+type APIKeyConfig struct {
+	MaxPerUser int `envconfig:"API_KEY_MAX_PER_USER" default:"10"`
+}
+
+func (c APIKeyConfig) validate() error {
+	if c.MaxPerUser <= 0 {
+		return errors.New("API_KEY_MAX_PER_USER must be positive")
+	}
+
+	return nil
+}
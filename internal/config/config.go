@@ -7,12 +7,26 @@ import (
 )
 
 type Config struct {
-	App      AppConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
-	Server   ServerConfig
-	Login    LoginConfig
+	App         AppConfig
+	Database    DatabaseConfig
+	Redis       RedisConfig
+	JWT         JWTConfig
+	Server      ServerConfig
+	Login       LoginConfig
+	Tracing     TracingConfig
+	I18n        I18nConfig
+	Mail        MailConfig
+	Account     AccountConfig
+	Password    PasswordConfig
+	RateLimit   RateLimitConfig
+	TwoFactor   TwoFactorConfig
+	OIDC        OIDCConfig
+	APIKey      APIKeyConfig
+	Team        TeamConfig
+	Leaderboard LeaderboardConfig
+	Market      MarketConfig
+	Outbox      OutboxConfig
+	Webhook     WebhookConfig
 }
 
 func GetConfig() (*Config, error) {
@@ -22,5 +36,53 @@ func GetConfig() (*Config, error) {
 		return nil, fmt.Errorf("read config from env vars: %w", err)
 	}
 
+	if err := conf.Server.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := conf.JWT.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := conf.Password.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := conf.Login.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := conf.TwoFactor.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := conf.APIKey.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := conf.Team.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := conf.Leaderboard.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := conf.Market.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := conf.Outbox.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := conf.Webhook.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := conf.OIDC.load(); err != nil {
+		return nil, err
+	}
+
 	return &conf, nil
 }
@@ -0,0 +1,5 @@
+package config
+
+type I18nConfig struct {
+	LocalesDir string `envconfig:"I18N_LOCALES_DIR" default:""`
+}
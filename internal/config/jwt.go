@@ -1,9 +1,38 @@
 package config
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
+// JWTConfig selects how tokens are signed. With JWT_SIGNING_KEY_FILE set,
+// tokens are signed with that RSA or Ed25519 key and JWT_SECRET, if also set,
+// is only accepted for verification while HS256 tokens expire. Otherwise
+// tokens are signed with the HS256 secret. Scoped access tokens issued for
+// dashboards and integrations live at most ScopedTokenMaxTTL.
 type JWTConfig struct {
-	Secret          string        `envconfig:"JWT_SECRET" required:"true"`
-	AccessTokenTTL  time.Duration `envconfig:"JWT_ACCESS_TOKEN_TTL" default:"15m"`
-	RefreshTokenTTL time.Duration `envconfig:"JWT_REFRESH_TOKEN_TTL" default:"168h"`
+	Secret               string        `envconfig:"JWT_SECRET"`
+	SigningKeyFile       string        `envconfig:"JWT_SIGNING_KEY_FILE"`
+	VerificationKeyFiles []string      `envconfig:"JWT_VERIFICATION_KEY_FILES"`
+	Issuer               string        `envconfig:"JWT_ISSUER" default:"soccer-manager"`
+	Audience             string        `envconfig:"JWT_AUDIENCE" default:"soccer-manager-api"`
+	AccessTokenTTL       time.Duration `envconfig:"JWT_ACCESS_TOKEN_TTL" default:"15m"`
+	RefreshTokenTTL      time.Duration `envconfig:"JWT_REFRESH_TOKEN_TTL" default:"168h"`
+	ScopedTokenMaxTTL    time.Duration `envconfig:"JWT_SCOPED_TOKEN_MAX_TTL" default:"24h"`
+}
+
+func (c JWTConfig) validate() error {
+	if c.Secret == "" && c.SigningKeyFile == "" {
+		return errors.New("JWT_SECRET or JWT_SIGNING_KEY_FILE is required")
+	}
+
+	if c.SigningKeyFile == "" && len(c.VerificationKeyFiles) > 0 {
+		return errors.New("JWT_VERIFICATION_KEY_FILES requires JWT_SIGNING_KEY_FILE")
+	}
+
+	if c.ScopedTokenMaxTTL <= 0 {
+		return errors.New("JWT_SCOPED_TOKEN_MAX_TTL must be positive")
+	}
+
+	return nil
 }
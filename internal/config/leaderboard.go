@@ -0,0 +1,23 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+type LeaderboardConfig struct {
+	RebuildInterval time.Duration `envconfig:"LEADERBOARD_REBUILD_INTERVAL" default:"10m"`
+	TransfersSize   uint          `envconfig:"LEADERBOARD_TRANSFERS_SIZE" default:"1000"`
+}
+
+func (c LeaderboardConfig) validate() error {
+	if c.RebuildInterval <= 0 {
+		return errors.New("LEADERBOARD_REBUILD_INTERVAL must be positive")
+	}
+
+	if c.TransfersSize == 0 {
+		return errors.New("LEADERBOARD_TRANSFERS_SIZE must be positive")
+	}
+
+	return nil
+}
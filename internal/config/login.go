@@ -1,9 +1,31 @@
 package config
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
+// LoginConfig controls login throttling. Failures are counted per client IP
+// and per (IP, email) pair within LoginAttemptTTL. Once a counter reaches its
+// limit the key is locked out for LockoutBase, doubling with every further
+// failure up to LockoutMax.
 type LoginConfig struct {
-	MaxLoginAttempts      int           `envconfig:"LOGIN_MAX_ATTEMPTS" default:"5"`
-	LoginAttemptTTL       time.Duration `envconfig:"LOGIN_ATTEMPT_TTL" default:"15m"`
-	TeamCacheTTL          time.Duration `envconfig:"TEAM_CACHE_TTL" default:"5m"`
+	MaxLoginAttempts   int           `envconfig:"LOGIN_MAX_ATTEMPTS" default:"5"`
+	MaxIPLoginAttempts int           `envconfig:"LOGIN_MAX_IP_ATTEMPTS" default:"20"`
+	LoginAttemptTTL    time.Duration `envconfig:"LOGIN_ATTEMPT_TTL" default:"15m"`
+	LockoutBase        time.Duration `envconfig:"LOGIN_LOCKOUT_BASE" default:"1m"`
+	LockoutMax         time.Duration `envconfig:"LOGIN_LOCKOUT_MAX" default:"1h"`
+	TeamCacheTTL       time.Duration `envconfig:"TEAM_CACHE_TTL" default:"5m"`
+}
+
+func (c LoginConfig) validate() error {
+	if c.MaxLoginAttempts <= 0 || c.MaxIPLoginAttempts <= 0 {
+		return errors.New("LOGIN_MAX_ATTEMPTS and LOGIN_MAX_IP_ATTEMPTS must be positive")
+	}
+
+	if c.LockoutBase <= 0 || c.LockoutMax < c.LockoutBase {
+		return errors.New("LOGIN_LOCKOUT_BASE must be positive and not greater than LOGIN_LOCKOUT_MAX")
+	}
+
+	return nil
 }
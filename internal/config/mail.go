@@ -0,0 +1,12 @@
+package config
+
+type MailConfig struct {
+	Driver       string `envconfig:"MAIL_DRIVER" default:"log"`
+	From         string `envconfig:"MAIL_FROM" default:"Soccer Manager <no-reply@soccer-manager.local>"`
+	FileDir      string `envconfig:"MAIL_FILE_DIR" default:"mail"`
+	SMTPHost     string `envconfig:"SMTP_HOST" default:"localhost"`
+	SMTPPort     int    `envconfig:"SMTP_PORT" default:"587"`
+	SMTPUsername string `envconfig:"SMTP_USERNAME" default:""`
+	SMTPPassword string `envconfig:"SMTP_PASSWORD" default:""`
+	LinkBaseURL  string `envconfig:"MAIL_LINK_BASE_URL" default:"http://localhost:8080"`
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// MarketConfig tunes the market event stream. Heartbeat keeps idle streams
+// alive through proxies; Buffer is how many events a client may fall behind
+// before it is disconnected.
+type MarketConfig struct {
+	Heartbeat time.Duration `envconfig:"MARKET_STREAM_HEARTBEAT" default:"15s"`
+	Buffer    int           `envconfig:"MARKET_STREAM_BUFFER" default:"64"`
+}
+
+func (c MarketConfig) validate() error {
+	if c.Heartbeat <= 0 {
+		return errors.New("MARKET_STREAM_HEARTBEAT must be positive")
+	}
+
+	if c.Buffer <= 0 {
+		return errors.New("MARKET_STREAM_BUFFER must be positive")
+	}
+
+	return nil
+}
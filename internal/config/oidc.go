@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+var oidcProviderName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// OIDCConfig lists the OpenID Connect providers users can sign in with.
+// Each provider named in OIDC_PROVIDERS is configured by variables prefixed
+// with OIDC_<NAME>_, e.g. OIDC_GOOGLE_ISSUER for "google".
+type OIDCConfig struct {
+	ProviderNames []string                      `envconfig:"OIDC_PROVIDERS" default:""`
+	StateTTL      time.Duration                 `envconfig:"OIDC_STATE_TTL" default:"10m"`
+	HTTPTimeout   time.Duration                 `envconfig:"OIDC_HTTP_TIMEOUT" default:"10s"`
+	Providers     map[string]OIDCProviderConfig `ignored:"true"`
+}
+
+type OIDCProviderConfig struct {
+	Issuer       string   `envconfig:"ISSUER" required:"true"`
+	ClientID     string   `envconfig:"CLIENT_ID" required:"true"`
+	ClientSecret string   `envconfig:"CLIENT_SECRET"`
+	RedirectURL  string   `envconfig:"REDIRECT_URL" required:"true"`
+	Scopes       []string `envconfig:"SCOPES" default:"openid,email,profile"`
+}
+
+// load reads the configuration of every provider in ProviderNames.
+func (c *OIDCConfig) load() error {
+	c.Providers = make(map[string]OIDCProviderConfig, len(c.ProviderNames))
+
+	for _, name := range c.ProviderNames {
+		if !oidcProviderName.MatchString(name) {
+			return fmt.Errorf("OIDC_PROVIDERS: invalid provider name %q", name)
+		}
+
+		var provider OIDCProviderConfig
+
+		prefix := "OIDC_" + strings.ToUpper(name)
+
+		if err := envconfig.Process(prefix, &provider); err != nil {
+			return fmt.Errorf("read %s_* config: %w", prefix, err)
+		}
+
+		c.Providers[name] = provider
+	}
+
+	return nil
+}
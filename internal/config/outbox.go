@@ -0,0 +1,46 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// OutboxConfig tunes the domain event dispatcher. A failed event is retried
+// after RetryBackoff, doubling per attempt up to MaxBackoff. Sinks lists the
+// external sinks events are published to: log and redis (a Redis stream
+// capped at about RedisStreamMaxLen entries).
+type OutboxConfig struct {
+	PollInterval      time.Duration `envconfig:"OUTBOX_POLL_INTERVAL" default:"1s"`
+	BatchSize         uint          `envconfig:"OUTBOX_BATCH_SIZE" default:"100"`
+	Lease             time.Duration `envconfig:"OUTBOX_LEASE" default:"30s"`
+	RetryBackoff      time.Duration `envconfig:"OUTBOX_RETRY_BACKOFF" default:"1s"`
+	MaxBackoff        time.Duration `envconfig:"OUTBOX_MAX_BACKOFF" default:"10m"`
+	Retention         time.Duration `envconfig:"OUTBOX_RETENTION" default:"168h"`
+	Sinks             []string      `envconfig:"OUTBOX_SINKS" default:""`
+	RedisStream       string        `envconfig:"OUTBOX_REDIS_STREAM" default:"domain_events"`
+	RedisStreamMaxLen int64         `envconfig:"OUTBOX_REDIS_STREAM_MAXLEN" default:"100000"`
+}
+
+func (c OutboxConfig) validate() error {
+	if c.PollInterval <= 0 {
+		return errors.New("OUTBOX_POLL_INTERVAL must be positive")
+	}
+
+	if c.BatchSize == 0 {
+		return errors.New("OUTBOX_BATCH_SIZE must be positive")
+	}
+
+	if c.Lease <= 0 {
+		return errors.New("OUTBOX_LEASE must be positive")
+	}
+
+	if c.RetryBackoff <= 0 || c.MaxBackoff < c.RetryBackoff {
+		return errors.New("OUTBOX_RETRY_BACKOFF must be positive and at most OUTBOX_MAX_BACKOFF")
+	}
+
+	if c.Retention <= 0 {
+		return errors.New("OUTBOX_RETENTION must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptMaxPasswordLength is the number of bytes bcrypt hashes; longer
+// passwords are rejected by bcrypt.
+const bcryptMaxPasswordLength = 72
+
+type PasswordConfig struct {
+	MinLength      int  `envconfig:"PASSWORD_MIN_LENGTH" default:"8"`
+	MaxLength      int  `envconfig:"PASSWORD_MAX_LENGTH" default:"72"`
+	MinCharClasses int  `envconfig:"PASSWORD_MIN_CHAR_CLASSES" default:"2"`
+	RejectPersonal bool `envconfig:"PASSWORD_REJECT_PERSONAL" default:"true"`
+	RejectCommon   bool `envconfig:"PASSWORD_REJECT_COMMON" default:"true"`
+	BcryptCost     int  `envconfig:"PASSWORD_BCRYPT_COST" default:"10"`
+}
+
+func (c PasswordConfig) validate() error {
+	if c.BcryptCost < bcrypt.MinCost || c.BcryptCost > bcrypt.MaxCost {
+		return fmt.Errorf("PASSWORD_BCRYPT_COST must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+
+	if c.MaxLength <= 0 || c.MaxLength > bcryptMaxPasswordLength {
+		return fmt.Errorf("PASSWORD_MAX_LENGTH must be between 1 and %d", bcryptMaxPasswordLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rate is a request budget written as "<requests>/<window>", e.g. "10/1m".
+type Rate struct {
+	Requests int
+	Window   time.Duration
+}
+
+// Decode implements envconfig.Decoder.
+func (r *Rate) Decode(value string) error {
+	requests, window, ok := strings.Cut(value, "/")
+	if !ok {
+		return fmt.Errorf("rate %q: want <requests>/<window>", value)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n <= 0 {
+		return fmt.Errorf("rate %q: requests must be a positive integer", value)
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil || d <= 0 {
+		return fmt.Errorf("rate %q: window must be a positive duration", value)
+	}
+
+	r.Requests, r.Window = n, d
+
+	return nil
+}
+
+func (r Rate) String() string {
+	return fmt.Sprintf("%d/%s", r.Requests, r.Window)
+}
+
+// RateLimitConfig holds the request budgets of the API route groups. Default
+// applies to every authenticated route; the others are stricter limits for
+// unauthenticated or expensive endpoints on top of it.
+type RateLimitConfig struct {
+	Enabled  bool `envconfig:"RATE_LIMIT_ENABLED" default:"true"`
+	Default  Rate `envconfig:"RATE_LIMIT_DEFAULT" default:"300/1m"`
+	Auth     Rate `envconfig:"RATE_LIMIT_AUTH" default:"30/1m"`
+	Register Rate `envconfig:"RATE_LIMIT_REGISTER" default:"5/1h"`
+	Buy      Rate `envconfig:"RATE_LIMIT_BUY" default:"10/1m"`
+}
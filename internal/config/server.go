@@ -1,12 +1,40 @@
 package config
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+)
 
+// ServerConfig configures the HTTP server. The client IP, which rate limits
+// and login lockouts are keyed on, is taken from ClientIPHeader only for
+// requests arriving from one of TrustedProxies, given as addresses or CIDR
+// ranges; with none, it is always the address of the connection.
 type ServerConfig struct {
-	Host string `envconfig:"SERVER_HOST" default:"0.0.0.0"`
-	Port int    `envconfig:"SERVER_PORT" default:"8080"`
+	Host           string   `envconfig:"SERVER_HOST" default:"0.0.0.0"`
+	Port           int      `envconfig:"SERVER_PORT" default:"8080"`
+	TrustedProxies []string `envconfig:"SERVER_TRUSTED_PROXIES"`
+	ClientIPHeader string   `envconfig:"SERVER_CLIENT_IP_HEADER" default:"X-Forwarded-For"`
 }
 
 func (s *ServerConfig) Address() string {
 	return fmt.Sprintf("%s:%d", s.Host, s.Port)
 }
+
+func (s *ServerConfig) validate() error {
+	for _, proxy := range s.TrustedProxies {
+		if _, err := netip.ParsePrefix(proxy); err == nil {
+			continue
+		}
+
+		if _, err := netip.ParseAddr(proxy); err != nil {
+			return fmt.Errorf("SERVER_TRUSTED_PROXIES: %q is not an IP address or CIDR range", proxy)
+		}
+	}
+
+	if s.ClientIPHeader == "" {
+		return errors.New("SERVER_CLIENT_IP_HEADER must not be empty")
+	}
+
+	return nil
+}
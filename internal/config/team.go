@@ -0,0 +1,15 @@
+package config
+
+import "errors"
+
+type TeamConfig struct {
+	MaxPerUser int `envconfig:"TEAM_MAX_PER_USER" default:"3"`
+}
+
+func (c TeamConfig) validate() error {
+	if c.MaxPerUser <= 0 {
+		return errors.New("TEAM_MAX_PER_USER must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,8 @@
+package config
+
+type TracingConfig struct {
+	Exporter     string  `envconfig:"TRACING_EXPORTER" default:"none"`
+	OTLPEndpoint string  `envconfig:"TRACING_OTLP_ENDPOINT" default:"localhost:4318"`
+	OTLPInsecure bool    `envconfig:"TRACING_OTLP_INSECURE" default:"true"`
+	SampleRatio  float64 `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// twoFactorKeySize is the AES-256 key size used to encrypt TOTP secrets.
+const twoFactorKeySize = 32
+
+type TwoFactorConfig struct {
+	Issuer        string        `envconfig:"TWO_FACTOR_ISSUER" default:"Soccer Manager"`
+	EncryptionKey string        `envconfig:"TWO_FACTOR_ENCRYPTION_KEY" required:"true"`
+	ChallengeTTL  time.Duration `envconfig:"TWO_FACTOR_CHALLENGE_TTL" default:"5m"`
+	RecoveryCodes int           `envconfig:"TWO_FACTOR_RECOVERY_CODES" default:"10"`
+}
+
+// Key decodes EncryptionKey, a base64 encoded 32-byte key.
+func (c TwoFactorConfig) Key() ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(c.EncryptionKey)
+	if err != nil || len(key) != twoFactorKeySize {
+		return nil, fmt.Errorf("TWO_FACTOR_ENCRYPTION_KEY must be %d base64 encoded bytes", twoFactorKeySize)
+	}
+
+	return key, nil
+}
+
+func (c TwoFactorConfig) validate() error {
+	if _, err := c.Key(); err != nil {
+		return err
+	}
+
+	if c.RecoveryCodes <= 0 {
+		return errors.New("TWO_FACTOR_RECOVERY_CODES must be positive")
+	}
+
+	return nil
+}
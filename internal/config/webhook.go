@@ -0,0 +1,85 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// webhookKeySize is the AES-256 key size used to encrypt webhook secrets.
+const webhookKeySize = 32
+
+// WebhookConfig tunes outgoing webhooks. A failed delivery is retried after
+// RetryBackoff, doubling per attempt up to MaxBackoff, until MaxAttempts;
+// an endpoint is disabled after DisableAfter failed attempts in a row.
+// Endpoints on private and loopback addresses are refused unless
+// AllowPrivate is set.
+type WebhookConfig struct {
+	EncryptionKey string        `envconfig:"WEBHOOK_ENCRYPTION_KEY" required:"true"`
+	MaxPerUser    int           `envconfig:"WEBHOOK_MAX_PER_USER" default:"10"`
+	Timeout       time.Duration `envconfig:"WEBHOOK_TIMEOUT" default:"10s"`
+	PollInterval  time.Duration `envconfig:"WEBHOOK_POLL_INTERVAL" default:"1s"`
+	BatchSize     uint          `envconfig:"WEBHOOK_BATCH_SIZE" default:"20"`
+	Lease         time.Duration `envconfig:"WEBHOOK_LEASE" default:"1m"`
+	MaxAttempts   int           `envconfig:"WEBHOOK_MAX_ATTEMPTS" default:"8"`
+	RetryBackoff  time.Duration `envconfig:"WEBHOOK_RETRY_BACKOFF" default:"30s"`
+	MaxBackoff    time.Duration `envconfig:"WEBHOOK_MAX_BACKOFF" default:"1h"`
+	DisableAfter  int           `envconfig:"WEBHOOK_DISABLE_AFTER" default:"20"`
+	Retention     time.Duration `envconfig:"WEBHOOK_RETENTION" default:"720h"`
+	AllowPrivate  bool          `envconfig:"WEBHOOK_ALLOW_PRIVATE" default:"false"`
+}
+
+// Key decodes EncryptionKey, a base64 encoded 32-byte key.
+func (c WebhookConfig) Key() ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(c.EncryptionKey)
+	if err != nil || len(key) != webhookKeySize {
+		return nil, fmt.Errorf("WEBHOOK_ENCRYPTION_KEY must be %d base64 encoded bytes", webhookKeySize)
+	}
+
+	return key, nil
+}
+
+func (c WebhookConfig) validate() error {
+	if _, err := c.Key(); err != nil {
+		return err
+	}
+
+	if c.MaxPerUser <= 0 {
+		return errors.New("WEBHOOK_MAX_PER_USER must be positive")
+	}
+
+	if c.Timeout <= 0 {
+		return errors.New("WEBHOOK_TIMEOUT must be positive")
+	}
+
+	if c.PollInterval <= 0 {
+		return errors.New("WEBHOOK_POLL_INTERVAL must be positive")
+	}
+
+	if c.BatchSize == 0 {
+		return errors.New("WEBHOOK_BATCH_SIZE must be positive")
+	}
+
+	if c.Lease <= c.Timeout {
+		return errors.New("WEBHOOK_LEASE must be longer than WEBHOOK_TIMEOUT")
+	}
+
+	if c.MaxAttempts <= 0 {
+		return errors.New("WEBHOOK_MAX_ATTEMPTS must be positive")
+	}
+
+	if c.RetryBackoff <= 0 || c.MaxBackoff < c.RetryBackoff {
+		return errors.New("WEBHOOK_RETRY_BACKOFF must be positive and at most WEBHOOK_MAX_BACKOFF")
+	}
+
+	if c.DisableAfter <= 0 {
+		return errors.New("WEBHOOK_DISABLE_AFTER must be positive")
+	}
+
+	if c.Retention <= 0 {
+		return errors.New("WEBHOOK_RETENTION must be positive")
+	}
+
+	return nil
+}
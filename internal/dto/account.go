@@ -0,0 +1,31 @@
+package dto
+
+import "soccer_manager_service/internal/entity"
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type ChangeEmailRequest struct {
+	Email           string `json:"email" binding:"required,email"`
+	CurrentPassword string `json:"current_password" binding:"required"`
+}
+
+type DeleteAccountRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+}
+
+// CreateScopedTokenRequest asks for an access token limited to Scopes.
+// ExpiresIn is in seconds; it defaults to and is capped at the configured
+// maximum.
+type CreateScopedTokenRequest struct {
+	Scopes    []entity.Scope `json:"scopes" binding:"required,min=1"`
+	ExpiresIn int            `json:"expires_in" binding:"omitempty,min=60"`
+}
+
+type ScopedTokenResponse struct {
+	AccessToken string         `json:"access_token"`
+	Scopes      []entity.Scope `json:"scopes"`
+	ExpiresIn   int            `json:"expires_in"`
+}
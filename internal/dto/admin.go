@@ -0,0 +1,57 @@
+package dto
+
+import "soccer_manager_service/internal/entity"
+
+type AdminListRequest struct {
+	Search string `form:"search" binding:"omitempty,max=100"`
+	Limit  uint   `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset uint   `form:"offset"`
+}
+
+type AdminUsersResponse struct {
+	Users  []entity.User `json:"users"`
+	Limit  uint          `json:"limit"`
+	Offset uint          `json:"offset"`
+}
+
+type AdminTeamsResponse struct {
+	Teams  []entity.Team `json:"teams"`
+	Limit  uint          `json:"limit"`
+	Offset uint          `json:"offset"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role entity.UserRole `json:"role" binding:"required,oneof=manager moderator admin"`
+}
+
+type BanUserRequest struct {
+	Reason string `json:"reason" binding:"required,min=3,max=255"`
+}
+
+// AdjustBudgetRequest changes a team budget by Amount, which may be negative.
+type AdjustBudgetRequest struct {
+	Amount int64  `json:"amount" binding:"required"`
+	Reason string `json:"reason" binding:"required,min=3,max=255"`
+}
+
+type CancelTransferRequest struct {
+	Reason string `json:"reason" binding:"required,min=3,max=255"`
+}
+
+type AuditLogRequest struct {
+	EntityType string `form:"entity_type" binding:"omitempty,oneof=user team player transfer"`
+	EntityID   string `form:"entity_id" binding:"omitempty,uuid"`
+	ActorID    string `form:"actor_id" binding:"omitempty,uuid"`
+	Limit      uint   `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset     uint   `form:"offset"`
+}
+
+type AuditLogResponse struct {
+	Entries []entity.AuditEntry `json:"entries"`
+	Limit   uint                `json:"limit"`
+	Offset  uint                `json:"offset"`
+}
+
+type UnlockIPRequest struct {
+	IP string `form:"ip" binding:"required,ip"`
+}
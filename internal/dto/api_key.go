@@ -0,0 +1,19 @@
+package dto
+
+import "soccer_manager_service/internal/entity"
+
+type CreateAPIKeyRequest struct {
+	Name   string         `json:"name" binding:"required,max=100"`
+	Scopes []entity.Scope `json:"scopes" binding:"required,min=1"`
+}
+
+// CreateAPIKeyResponse carries the key in plain text. It is shown only once;
+// the server keeps just its hash.
+type CreateAPIKeyResponse struct {
+	APIKey entity.APIKey `json:"api_key"`
+	Key    string        `json:"key"`
+}
+
+type APIKeyListResponse struct {
+	APIKeys []entity.APIKey `json:"api_keys"`
+}
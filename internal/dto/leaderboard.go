@@ -0,0 +1,20 @@
+package dto
+
+import "soccer_manager_service/internal/entity"
+
+type LeaderboardRequest struct {
+	Limit  uint `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset uint `form:"offset"`
+}
+
+// LeaderboardResponse is a page of a leaderboard. Mine holds the ranks of the
+// caller's teams or, on the transfers leaderboard, of their most expensive
+// purchase; it is empty if none is ranked.
+type LeaderboardResponse struct {
+	Kind    entity.LeaderboardKind    `json:"kind"`
+	Entries []entity.LeaderboardEntry `json:"entries"`
+	Mine    []entity.LeaderboardEntry `json:"mine"`
+	Total   int64                     `json:"total"`
+	Limit   uint                      `json:"limit"`
+	Offset  uint                      `json:"offset"`
+}
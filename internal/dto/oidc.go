@@ -0,0 +1,25 @@
+package dto
+
+type OIDCProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+// OIDCAuthorizeRequest optionally names the team that is created if the
+// login turns out to be a new account.
+type OIDCAuthorizeRequest struct {
+	TeamName string `form:"team_name" binding:"omitempty,min=3,max=50"`
+	Country  string `form:"country" binding:"omitempty,min=2,max=50"`
+}
+
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// OIDCCallbackRequest holds the query parameters the provider redirects back
+// with: a code and state on success, an error code otherwise.
+type OIDCCallbackRequest struct {
+	Code             string `form:"code"`
+	State            string `form:"state" binding:"required"`
+	Error            string `form:"error"`
+	ErrorDescription string `form:"error_description"`
+}
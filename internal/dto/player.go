@@ -1,7 +1,30 @@
 package dto
 
+import (
+	"soccer_manager_service/internal/entity"
+
+	"github.com/google/uuid"
+)
+
 type UpdatePlayerRequest struct {
 	FirstName string `json:"first_name" binding:"omitempty,min=2,max=50"`
 	LastName  string `json:"last_name" binding:"omitempty,min=2,max=50"`
 	Country   string `json:"country" binding:"omitempty,min=2,max=50"`
 }
+
+// PublicPlayer is the part of a player anyone may see.
+type PublicPlayer struct {
+	ID          uuid.UUID             `json:"id"`
+	TeamID      uuid.UUID             `json:"team_id"`
+	FirstName   string                `json:"first_name"`
+	LastName    string                `json:"last_name"`
+	Country     string                `json:"country"`
+	Age         int                   `json:"age"`
+	Position    entity.PlayerPosition `json:"position"`
+	MarketValue int64                 `json:"market_value"`
+}
+
+type PublicPlayerResponse struct {
+	Player PublicPlayer `json:"player"`
+	Team   PublicTeam   `json:"team"`
+}
@@ -1,6 +1,20 @@
 package dto
 
-import "soccer_manager_service/internal/entity"
+import (
+	"soccer_manager_service/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateTeamRequest struct {
+	Name    string `json:"name" binding:"required,min=3,max=50"`
+	Country string `json:"country" binding:"required,min=2,max=50"`
+}
+
+type TeamListResponse struct {
+	Teams []entity.Team `json:"teams"`
+}
 
 type UpdateTeamRequest struct {
 	Name    string `json:"name" binding:"omitempty,min=3,max=50"`
@@ -11,3 +25,48 @@ type TeamWithPlayersResponse struct {
 	Team    entity.Team     `json:"team"`
 	Players []entity.Player `json:"players"`
 }
+
+type FinancesRequest struct {
+	Limit  uint `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset uint `form:"offset"`
+}
+
+// TeamFinancesResponse is a page of the team's ledger statement. Verified
+// reports whether the stored budget equals the ledger balance.
+type TeamFinancesResponse struct {
+	TeamID        uuid.UUID              `json:"team_id"`
+	Budget        int64                  `json:"budget"`
+	LedgerBalance int64                  `json:"ledger_balance"`
+	Verified      bool                   `json:"verified"`
+	Entries       []entity.StatementLine `json:"entries"`
+	Limit         uint                   `json:"limit"`
+	Offset        uint                   `json:"offset"`
+}
+
+// PublicTeam is the part of a team anyone may see. It leaves out the owner
+// and the budget.
+type PublicTeam struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Country    string    `json:"country"`
+	TotalValue int64     `json:"total_value"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type PublicTeamResponse struct {
+	Team    PublicTeam     `json:"team"`
+	Players []PublicPlayer `json:"players"`
+}
+
+type SearchTeamsRequest struct {
+	Name    string `form:"name" binding:"omitempty,max=50"`
+	Country string `form:"country" binding:"omitempty,max=50"`
+	Limit   uint   `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset  uint   `form:"offset"`
+}
+
+type PublicTeamsResponse struct {
+	Teams  []PublicTeam `json:"teams"`
+	Limit  uint         `json:"limit"`
+	Offset uint         `json:"offset"`
+}
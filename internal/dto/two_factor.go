@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+type TwoFactorStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+}
+
+type TwoFactorEnrollRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+}
+
+// TwoFactorEnrollResponse carries the new secret, both raw for manual entry
+// and as an otpauth:// URI for QR codes.
+type TwoFactorEnrollResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type TwoFactorEnableRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// TwoFactorConfirmRequest authorizes a change to two-factor settings with the
+// password and a TOTP or recovery code.
+type TwoFactorConfirmRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	Code            string `json:"code" binding:"required"`
+}
+
+// RecoveryCodesResponse lists recovery codes in plain text. They are shown
+// only once; the server keeps just their hashes.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "soccer_manager_service/internal/entity"
+
+type CreateWebhookRequest struct {
+	URL        string             `json:"url" binding:"required,url,max=2048"`
+	Secret     string             `json:"secret" binding:"required,min=16,max=256"`
+	EventTypes []entity.EventType `json:"event_types" binding:"required,min=1"`
+}
+
+// UpdateWebhookRequest changes the fields that are set. Enabling an endpoint
+// resets its failure count.
+type UpdateWebhookRequest struct {
+	URL        string             `json:"url" binding:"omitempty,url,max=2048"`
+	Secret     string             `json:"secret" binding:"omitempty,min=16,max=256"`
+	EventTypes []entity.EventType `json:"event_types" binding:"omitempty,min=1"`
+	Enabled    *bool              `json:"enabled"`
+}
+
+type WebhookListResponse struct {
+	Webhooks []entity.Webhook `json:"webhooks"`
+}
+
+type WebhookDeliveriesRequest struct {
+	Limit  uint `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset uint `form:"offset"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Deliveries []entity.WebhookDelivery `json:"deliveries"`
+	Limit      uint                     `json:"limit"`
+	Offset     uint                     `json:"offset"`
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditEntityUser     = "user"
+	AuditEntityTeam     = "team"
+	AuditEntityPlayer   = "player"
+	AuditEntityTransfer = "transfer"
+	AuditEntityAPIKey   = "api_key"
+	AuditEntityWebhook  = "webhook"
+	// AuditEntityIP entries have no entity ID; the address is in the metadata.
+	AuditEntityIP = "ip"
+)
+
+// AuditChange holds the old and new value of a single changed field.
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditEntry is an append-only record of a state-changing operation. ActorID
+// is nil when the change was not made by an authenticated user, e.g. a failed
+// login.
+type AuditEntry struct {
+	ID         uuid.UUID              `db:"id" json:"id" goqu:"omitempty"`
+	ActorID    *uuid.UUID             `db:"actor_id" json:"actor_id,omitempty" goqu:"omitempty"`
+	Action     string                 `db:"action" json:"action" goqu:"omitempty"`
+	EntityType string                 `db:"entity_type" json:"entity_type" goqu:"omitempty"`
+	EntityID   *uuid.UUID             `db:"entity_id" json:"entity_id,omitempty" goqu:"omitempty"`
+	RequestID  string                 `db:"request_id" json:"request_id,omitempty" goqu:"omitempty"`
+	Changes    map[string]AuditChange `db:"changes" json:"changes" goqu:"omitempty"`
+	Metadata   map[string]any         `db:"metadata" json:"metadata,omitempty" goqu:"omitempty"`
+	CreatedAt  time.Time              `db:"created_at" json:"created_at" goqu:"omitempty"`
+}
+
+type AuditFilter struct {
+	ActorID    *uuid.UUID
+	EntityType string
+	EntityID   *uuid.UUID
+}
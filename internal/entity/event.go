@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventPlayerListed   EventType = "player.listed"
+	EventPlayerSold     EventType = "player.sold"
+	EventTeamRenamed    EventType = "team.renamed"
+	EventTeamUpdated    EventType = "team.updated"
+	EventUserRegistered EventType = "user.registered"
+)
+
+// Event is a domain event. It is written to the outbox in the same database
+// transaction as the change it describes and delivered at least once after
+// that, so consumers must tolerate duplicates; ID is stable across retries.
+type Event struct {
+	ID          uuid.UUID       `json:"id"`
+	Type        EventType       `json:"type"`
+	AggregateID uuid.UUID       `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	Attempts    int             `json:"-"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// Decode unmarshals the payload into v, one of the event payload types below.
+func (e Event) Decode(v any) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+type PlayerListed struct {
+	TransferID  uuid.UUID `json:"transfer_id"`
+	PlayerID    uuid.UUID `json:"player_id"`
+	SellerID    uuid.UUID `json:"seller_id"`
+	AskingPrice int64     `json:"asking_price"`
+}
+
+type PlayerSold struct {
+	TransferID uuid.UUID `json:"transfer_id"`
+	PlayerID   uuid.UUID `json:"player_id"`
+	SellerID   uuid.UUID `json:"seller_id"`
+	BuyerID    uuid.UUID `json:"buyer_id"`
+	Price      int64     `json:"price"`
+}
+
+type TeamRenamed struct {
+	TeamID  uuid.UUID `json:"team_id"`
+	UserID  uuid.UUID `json:"user_id"`
+	OldName string    `json:"old_name"`
+	NewName string    `json:"new_name"`
+}
+
+// TeamUpdated follows any change to a team's name or country and carries
+// both as they are now.
+type TeamUpdated struct {
+	TeamID  uuid.UUID `json:"team_id"`
+	UserID  uuid.UUID `json:"user_id"`
+	Name    string    `json:"name"`
+	Country string    `json:"country"`
+}
+
+type UserRegistered struct {
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+}
+
+// NewEvent wraps payload in a new event about aggregateID.
+func NewEvent(eventType EventType, aggregateID uuid.UUID, payload any) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		ID:          uuid.New(),
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     data,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IntegrityIssueKind string
+
+const (
+	IssueLedgerMismatch          IntegrityIssueKind = "ledger_mismatch"
+	IssueNegativeBudget          IntegrityIssueKind = "negative_budget"
+	IssueTotalValueMismatch      IntegrityIssueKind = "total_value_mismatch"
+	IssueStaleTransfer           IntegrityIssueKind = "stale_transfer"
+	IssueDuplicateActiveTransfer IntegrityIssueKind = "duplicate_active_transfer"
+)
+
+// IntegrityIssue is a single inconsistency found in the database. TeamID is
+// the team whose data the issue (or its repair) affects, if any.
+type IntegrityIssue struct {
+	Kind       IntegrityIssueKind `json:"kind"`
+	EntityType string             `json:"entity_type"`
+	EntityID   uuid.UUID          `json:"entity_id"`
+	TeamID     *uuid.UUID         `json:"team_id,omitempty"`
+	Detail     string             `json:"detail"`
+	Repair     string             `json:"repair"`
+}
+
+type IntegrityReport struct {
+	CheckedAt time.Time        `json:"checked_at"`
+	Fixed     bool             `json:"fixed"`
+	Issues    []IntegrityIssue `json:"issues"`
+}
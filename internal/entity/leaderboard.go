@@ -0,0 +1,47 @@
+package entity
+
+import "github.com/google/uuid"
+
+type LeaderboardKind string
+
+const (
+	LeaderboardTotalValue     LeaderboardKind = "total_value"
+	LeaderboardBudget         LeaderboardKind = "budget"
+	LeaderboardTransferProfit LeaderboardKind = "transfer_profit"
+	LeaderboardTransfers      LeaderboardKind = "transfers"
+)
+
+// TeamLeaderboards rank teams; LeaderboardTransfers ranks completed transfers
+// by price.
+var TeamLeaderboards = []LeaderboardKind{
+	LeaderboardTotalValue,
+	LeaderboardBudget,
+	LeaderboardTransferProfit,
+}
+
+var Leaderboards = []LeaderboardKind{
+	LeaderboardTotalValue,
+	LeaderboardBudget,
+	LeaderboardTransferProfit,
+	LeaderboardTransfers,
+}
+
+// LeaderboardEntry is a team or a transfer on a leaderboard. Rank starts at 1
+// and is 0 for scores that are not ranked yet. Player is set for transfers
+// only; TeamID is then the buying team and is nil once that team is gone.
+type LeaderboardEntry struct {
+	Rank     int64      `json:"rank"`
+	ID       uuid.UUID  `json:"id"`
+	Score    int64      `json:"score"`
+	TeamID   *uuid.UUID `json:"team_id"`
+	TeamName string     `json:"team_name"`
+	Player   string     `json:"player,omitempty"`
+}
+
+// LeaderboardFilter narrows the scores read from the database. TeamIDs
+// selects teams or, for LeaderboardTransfers, the transfers they bought.
+// Limit 0 returns all scores.
+type LeaderboardFilter struct {
+	TeamIDs []uuid.UUID
+	Limit   uint
+}
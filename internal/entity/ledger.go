@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LedgerKind string
+
+const (
+	LedgerKindOpeningBalance  LedgerKind = "opening_balance"
+	LedgerKindSeedMoney       LedgerKind = "seed_money"
+	LedgerKindTransfer        LedgerKind = "transfer"
+	LedgerKindAdminAdjustment LedgerKind = "admin_adjustment"
+	LedgerKindCorrection      LedgerKind = "correction"
+	LedgerKindAccountClosure  LedgerKind = "account_closure"
+)
+
+// LedgerAccountTeam is the account of every team posting; the team itself is
+// identified by TeamID. All other accounts are system accounts that money
+// enters or leaves the game through.
+const (
+	LedgerAccountTeam            = "team"
+	LedgerAccountOpeningBalance  = "system:opening_balance"
+	LedgerAccountSeedCapital     = "system:seed_capital"
+	LedgerAccountAdminAdjustment = "system:admin_adjustment"
+	LedgerAccountCorrection      = "system:correction"
+	LedgerAccountClosedAccounts  = "system:closed_accounts"
+)
+
+// LedgerEntry is one side of a ledger transaction. The amounts of all entries
+// sharing a TransactionID sum to zero.
+type LedgerEntry struct {
+	ID            uuid.UUID  `db:"id" json:"id" goqu:"omitempty"`
+	TransactionID uuid.UUID  `db:"transaction_id" json:"transaction_id" goqu:"omitempty"`
+	Kind          LedgerKind `db:"kind" json:"kind" goqu:"omitempty"`
+	Account       string     `db:"account" json:"account" goqu:"omitempty"`
+	TeamID        *uuid.UUID `db:"team_id" json:"team_id,omitempty" goqu:"omitempty"`
+	Amount        int64      `db:"amount" json:"amount" goqu:"omitempty"`
+	ReferenceID   *uuid.UUID `db:"reference_id" json:"reference_id,omitempty" goqu:"omitempty"`
+	Description   string     `db:"description" json:"description" goqu:"omitempty"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at" goqu:"omitempty"`
+}
+
+// StatementLine is a team ledger entry with the team balance right after it.
+type StatementLine struct {
+	LedgerEntry
+	Balance int64 `json:"balance"`
+}
+
+type LedgerPosting struct {
+	Account string
+	TeamID  *uuid.UUID
+	Amount  int64
+}
+
+// LedgerTransaction is a balanced set of postings recorded atomically.
+type LedgerTransaction struct {
+	Kind        LedgerKind
+	ReferenceID *uuid.UUID
+	Description string
+	Postings    []LedgerPosting
+}
+
+func TeamPosting(teamID uuid.UUID, amount int64) LedgerPosting {
+	return LedgerPosting{Account: LedgerAccountTeam, TeamID: &teamID, Amount: amount}
+}
+
+func SystemPosting(account string, amount int64) LedgerPosting {
+	return LedgerPosting{Account: account, Amount: amount}
+}
+
+// IsBalanced reports whether the postings sum to zero.
+func (t *LedgerTransaction) IsBalanced() bool {
+	var sum int64
+
+	for _, posting := range t.Postings {
+		sum += posting.Amount
+	}
+
+	return len(t.Postings) >= 2 && sum == 0
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MarketEventType string
+
+const (
+	MarketEventListingCreated   MarketEventType = "listing_created"
+	MarketEventListingCancelled MarketEventType = "listing_cancelled"
+	MarketEventListingSold      MarketEventType = "listing_sold"
+	MarketEventPlayerSold       MarketEventType = "player_sold"
+	MarketEventPlayerBought     MarketEventType = "player_bought"
+)
+
+// MarketEvent is a change on the transfer market pushed to streaming
+// clients. Events with a UserID, such as player_sold for the seller, go to
+// that user only; the others go to everyone.
+type MarketEvent struct {
+	ID          uuid.UUID       `json:"id"`
+	Type        MarketEventType `json:"type"`
+	UserID      *uuid.UUID      `json:"user_id,omitempty"`
+	TransferID  uuid.UUID       `json:"transfer_id"`
+	PlayerID    *uuid.UUID      `json:"player_id,omitempty"`
+	PlayerName  string          `json:"player_name,omitempty"`
+	SellerID    *uuid.UUID      `json:"seller_id,omitempty"`
+	BuyerID     *uuid.UUID      `json:"buyer_id,omitempty"`
+	AskingPrice int64           `json:"asking_price,omitempty"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+}
+
+// For reports whether the event is delivered to userID.
+func (e MarketEvent) For(userID uuid.UUID) bool {
+	return e.UserID == nil || *e.UserID == userID
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// RateLimitResult is the outcome of counting one request against a limit.
+// Reset is how long until the oldest counted request leaves the window and
+// frees a slot.
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration
+}
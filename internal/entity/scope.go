@@ -0,0 +1,15 @@
+package entity
+
+// Scope limits what a credential can be used for. Credentials from a login
+// are not limited; API keys and scoped access tokens carry a set of scopes.
+type Scope string
+
+const (
+	ScopeTeamRead    Scope = "team:read"
+	ScopeTeamWrite   Scope = "team:write"
+	ScopeMarketRead  Scope = "market:read"
+	ScopeMarketTrade Scope = "market:trade"
+)
+
+// Scopes lists every scope a credential can be granted.
+var Scopes = []Scope{ScopeTeamRead, ScopeTeamWrite, ScopeMarketRead, ScopeMarketTrade}
@@ -16,3 +16,19 @@ type Team struct {
 	CreatedAt  time.Time `db:"created_at" json:"created_at" goqu:"omitempty"`
 	UpdatedAt  time.Time `db:"updated_at" json:"updated_at" goqu:"omitempty"`
 }
+
+// TeamFilter narrows a team search. Empty fields match every team; others
+// match a case-insensitive substring.
+type TeamFilter struct {
+	Name    string
+	Country string
+}
+
+// TeamFounding is everything creating a team writes at once: the team, the
+// seed money credited to it and its initial squad. The caller picks Team.ID,
+// so that SeedMoney and Players can refer to the team.
+type TeamFounding struct {
+	Team      Team
+	SeedMoney LedgerTransaction
+	Players   []Player
+}
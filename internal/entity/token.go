@@ -0,0 +1,20 @@
+package entity
+
+import "github.com/google/uuid"
+
+type TokenPurpose string
+
+const (
+	TokenPurposeEmailVerification TokenPurpose = "email_verification"
+	TokenPurposePasswordReset     TokenPurpose = "password_reset"
+	TokenPurposeTwoFactorLogin    TokenPurpose = "two_factor_login"
+)
+
+// ActionToken is what a single-use token, emailed or handed out as a login
+// challenge, stands for: an action on behalf of UserID, valid only while the
+// account still has Email.
+type ActionToken struct {
+	Purpose TokenPurpose `json:"purpose"`
+	UserID  uuid.UUID    `json:"user_id"`
+	Email   string       `json:"email"`
+}
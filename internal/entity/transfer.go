@@ -14,13 +14,27 @@ const (
 	TransferStatusCancelled TransferStatus = "cancelled"
 )
 
+// Transfer is a transfer listing. PlayerID and SellerID are always set on
+// active transfers; on completed and cancelled ones they become nil once the
+// player or the selling team is deleted with its account.
 type Transfer struct {
 	ID          uuid.UUID      `db:"id" json:"id" goqu:"omitempty"`
-	PlayerID    uuid.UUID      `db:"player_id" json:"player_id" goqu:"omitempty"`
-	SellerID    uuid.UUID      `db:"seller_id" json:"seller_id" goqu:"omitempty"`
+	PlayerID    *uuid.UUID     `db:"player_id" json:"player_id,omitempty" goqu:"omitempty"`
+	SellerID    *uuid.UUID     `db:"seller_id" json:"seller_id,omitempty" goqu:"omitempty"`
 	BuyerID     *uuid.UUID     `db:"buyer_id" json:"buyer_id,omitempty" goqu:"omitempty"`
 	AskingPrice int64          `db:"asking_price" json:"asking_price" goqu:"omitempty"`
 	Status      TransferStatus `db:"status" json:"status" goqu:"omitempty"`
 	CreatedAt   time.Time      `db:"created_at" json:"created_at" goqu:"omitempty"`
 	CompletedAt *time.Time     `db:"completed_at" json:"completed_at,omitempty" goqu:"omitempty"`
 }
+
+// TransferPurchase is everything buying a listed player changes at once: the
+// transfer is completed by BuyerID, the player moves to the buyer with its new
+// market value, and Payment moves the asking price between the teams.
+type TransferPurchase struct {
+	TransferID     uuid.UUID
+	PlayerID       uuid.UUID
+	BuyerID        uuid.UUID
+	NewMarketValue int64
+	Payment        LedgerTransaction
+}
@@ -6,10 +6,53 @@ import (
 	"github.com/google/uuid"
 )
 
+type UserRole string
+
+const (
+	RoleManager   UserRole = "manager"
+	RoleModerator UserRole = "moderator"
+	RoleAdmin     UserRole = "admin"
+)
+
 type User struct {
-	ID           uuid.UUID `db:"id" json:"id" goqu:"omitempty"`
-	Email        string    `db:"email" json:"email" goqu:"omitempty"`
-	PasswordHash string    `db:"password_hash" json:"-" goqu:"omitempty"`
-	CreatedAt    time.Time `db:"created_at" json:"created_at" goqu:"omitempty"`
-	UpdatedAt    time.Time `db:"updated_at" json:"updated_at" goqu:"omitempty"`
+	ID              uuid.UUID  `db:"id" json:"id" goqu:"omitempty"`
+	Email           string     `db:"email" json:"email" goqu:"omitempty"`
+	PasswordHash    string     `db:"password_hash" json:"-" goqu:"omitempty"`
+	Role            UserRole   `db:"role" json:"role" goqu:"omitempty"`
+	EmailVerifiedAt *time.Time `db:"email_verified_at" json:"email_verified_at,omitempty" goqu:"omitempty"`
+	BannedAt        *time.Time `db:"banned_at" json:"banned_at,omitempty" goqu:"omitempty"`
+	BanReason       *string    `db:"ban_reason" json:"ban_reason,omitempty" goqu:"omitempty"`
+	TOTPSecret      *string    `db:"totp_secret" json:"-" goqu:"omitempty"`
+	TOTPEnabledAt   *time.Time `db:"totp_enabled_at" json:"two_factor_enabled_at,omitempty" goqu:"omitempty"`
+	CreatedAt       time.Time  `db:"created_at" json:"created_at" goqu:"omitempty"`
+	UpdatedAt       time.Time  `db:"updated_at" json:"updated_at" goqu:"omitempty"`
+}
+
+func (u *User) IsBanned() bool {
+	return u.BannedAt != nil
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// HasTwoFactor reports whether logins need a TOTP or recovery code. A secret
+// without TOTPEnabledAt is an enrollment that was never confirmed.
+func (u *User) HasTwoFactor() bool {
+	return u.TOTPEnabledAt != nil && u.TOTPSecret != nil
+}
+
+// AccountDeletion is what deleting an account did besides removing the user,
+// teams and players: the active listings it cancelled and the team budgets it
+// closed out of the ledger.
+type AccountDeletion struct {
+	UserID             uuid.UUID     `json:"user_id"`
+	Teams              []TeamClosure `json:"teams"`
+	CancelledTransfers []uuid.UUID   `json:"cancelled_transfers"`
+}
+
+// TeamClosure is a deleted team and the budget it had left.
+type TeamClosure struct {
+	TeamID         uuid.UUID `json:"team_id"`
+	ClosingBalance int64     `json:"closing_balance"`
 }
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEventPing is sent only by the test ping of an endpoint.
+const WebhookEventPing EventType = "ping"
+
+// WebhookEventTypes are the domain events an endpoint can subscribe to.
+var WebhookEventTypes = []EventType{EventPlayerListed, EventPlayerSold, EventTeamUpdated}
+
+// Webhook is an endpoint a user registered to be called about their teams.
+// Secret is stored encrypted and never returned. Failures counts failed
+// attempts since the last success; an endpoint that fails too often is
+// disabled until its owner enables it again.
+type Webhook struct {
+	ID         uuid.UUID   `json:"id"`
+	UserID     uuid.UUID   `json:"user_id"`
+	URL        string      `json:"url"`
+	Secret     string      `json:"-"`
+	EventTypes []EventType `json:"event_types"`
+	Failures   int         `json:"failures"`
+	DisabledAt *time.Time  `json:"disabled_at,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+func (w *Webhook) Enabled() bool {
+	return w.DisabledAt == nil
+}
+
+func (w *Webhook) Subscribes(eventType EventType) bool {
+	return slices.Contains(w.EventTypes, eventType)
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event sent, or to be sent, to one endpoint. Payload
+// is the request body, the same on every attempt.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	WebhookID      uuid.UUID             `json:"webhook_id"`
+	EventID        uuid.UUID             `json:"event_id"`
+	EventType      EventType             `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	ResponseStatus *int                  `json:"response_status,omitempty"`
+	LastError      *string               `json:"last_error,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	CompletedAt    *time.Time            `json:"completed_at,omitempty"`
+}
+
+// WebhookPayload is the body of a webhook request. ID is the ID of the
+// domain event, so receivers can drop the duplicates retries may cause.
+type WebhookPayload struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      EventType       `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type WebhookPing struct {
+	WebhookID uuid.UUID `json:"webhook_id"`
+}
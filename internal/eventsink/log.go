@@ -0,0 +1,33 @@
+// Package eventsink holds event sinks that need no external system.
+package eventsink
+
+import (
+	"context"
+	"soccer_manager_service/internal/entity"
+
+	"go.uber.org/zap"
+)
+
+// Log writes domain events to the application log. It is meant for local
+// development and debugging.
+type Log struct {
+	logger *zap.Logger
+}
+
+func NewLog(logger *zap.Logger) *Log {
+	return &Log{logger: logger.With(zap.String("sink", "log"))}
+}
+
+func (s *Log) Name() string {
+	return "log"
+}
+
+func (s *Log) Publish(_ context.Context, event entity.Event) error {
+	s.logger.Info("domain event",
+		zap.String("event_id", event.ID.String()),
+		zap.String("type", string(event.Type)),
+		zap.String("aggregate_id", event.AggregateID.String()),
+		zap.ByteString("payload", event.Payload))
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"soccer_manager_service/internal/entity"
+)
+
+// EventHandler consumes domain events in process. It may see an event more
+// than once and must return an error to have it retried.
+type EventHandler func(ctx context.Context, event entity.Event) error
+
+// EventSink publishes domain events outside the process, e.g. to a message
+// broker. Like handlers, sinks get every event at least once.
+type EventSink interface {
+	Name() string
+	Publish(ctx context.Context, event entity.Event) error
+}
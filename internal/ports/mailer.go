@@ -0,0 +1,10 @@
+package ports
+
+import (
+	"context"
+	"soccer_manager_service/pkg/mailer"
+)
+
+type Mailer interface {
+	Send(ctx context.Context, msg mailer.Message) error
+}
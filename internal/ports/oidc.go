@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"soccer_manager_service/pkg/oidc"
+)
+
+// OIDCProvider signs users in with an external OpenID Connect provider using
+// the authorization code flow with PKCE.
+type OIDCProvider interface {
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*oidc.Identity, error)
+}
+
+// OIDCProviders are the configured providers by name.
+type OIDCProviders map[string]OIDCProvider
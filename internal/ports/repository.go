@@ -4,6 +4,7 @@ import (
 	"context"
 	"soccer_manager_service/internal/dto"
 	"soccer_manager_service/internal/entity"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,43 +13,204 @@ type UserRepository interface {
 	Create(ctx context.Context, email, passwordHash string) (*entity.User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
 	GetByEmail(ctx context.Context, email string) (*entity.User, error)
+	List(ctx context.Context, search string, limit, offset uint) ([]entity.User, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, role entity.UserRole) (*entity.User, error)
+	SetBan(ctx context.Context, id uuid.UUID, bannedAt *time.Time, reason *string) (*entity.User, error)
+	MarkEmailVerified(ctx context.Context, id uuid.UUID) (*entity.User, error)
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) (*entity.User, error)
+	UpdateEmail(ctx context.Context, id uuid.UUID, email string) (*entity.User, error)
+	Delete(ctx context.Context, id uuid.UUID) (*entity.AccountDeletion, error)
 }
 
 type TeamRepository interface {
-	Create(ctx context.Context, userID uuid.UUID, name, country string, budget int64) (*entity.Team, error)
+	Create(ctx context.Context, founding entity.TeamFounding) (*entity.Team, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Team, error)
-	GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.Team, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Team, error)
 	Update(ctx context.Context, id uuid.UUID, name, country string) (*entity.Team, error)
-	UpdateBudget(ctx context.Context, id uuid.UUID, budget int64) error
 	UpdateTotalValue(ctx context.Context, id uuid.UUID, totalValue int64) error
+	List(ctx context.Context, search string, limit, offset uint) ([]entity.Team, error)
+	Search(ctx context.Context, filter entity.TeamFilter, limit, offset uint) ([]entity.Team, error)
 }
 
 type PlayerRepository interface {
-	Create(ctx context.Context, teamID uuid.UUID, firstName, lastName, country string, age int, position entity.PlayerPosition, marketValue int64) (*entity.Player, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Player, error)
 	GetByTeamID(ctx context.Context, teamID uuid.UUID) ([]entity.Player, error)
 	Update(ctx context.Context, id uuid.UUID, firstName, lastName, country string) (*entity.Player, error)
-	UpdateMarketValue(ctx context.Context, id uuid.UUID, marketValue int64) error
-	TransferPlayer(ctx context.Context, playerID, newTeamID uuid.UUID) error
 }
 
 type TransferRepository interface {
 	Create(ctx context.Context, playerID, sellerID uuid.UUID, askingPrice int64) (*entity.Transfer, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Transfer, error)
 	GetActiveTransfers(ctx context.Context) ([]entity.Transfer, error)
-	Complete(ctx context.Context, id, buyerID uuid.UUID) error
+	Complete(ctx context.Context, purchase entity.TransferPurchase) error
 	Cancel(ctx context.Context, id uuid.UUID) error
 	GetByPlayerID(ctx context.Context, playerID uuid.UUID) (*entity.Transfer, error)
 }
 
 type LoginAttemptRepository interface {
-	Increment(ctx context.Context, email string) (count int, err error)
-	Get(ctx context.Context, email string) (attempts int, err error)
-	Reset(ctx context.Context, email string) (err error)
+	LockedFor(ctx context.Context, ip, email string) (retryAfter time.Duration, err error)
+	RecordFailure(ctx context.Context, ip, email string) (lockout time.Duration, err error)
+	Reset(ctx context.Context, ip, email string) (err error)
+	Unlock(ctx context.Context, email string) (err error)
+	UnlockIP(ctx context.Context, ip string) (err error)
 }
 
+type ActionTokenRepository interface {
+	Issue(ctx context.Context, token entity.ActionToken, ttl time.Duration) (raw string, err error)
+	Consume(ctx context.Context, purpose entity.TokenPurpose, raw string) (token *entity.ActionToken, err error)
+	Revoke(ctx context.Context, purpose entity.TokenPurpose, userID uuid.UUID) (err error)
+}
+
+// SessionRepository invalidates a user's issued tokens. Tokens issued before
+// the time returned by RevokedBefore must be rejected.
+type SessionRepository interface {
+	RevokeAll(ctx context.Context, userID uuid.UUID, before time.Time) (err error)
+	RevokedBefore(ctx context.Context, userID uuid.UUID) (before *time.Time, err error)
+}
+
+// TwoFactorRepository stores TOTP secrets, which callers encrypt, and the
+// hashes of recovery codes.
+type TwoFactorRepository interface {
+	SetPendingSecret(ctx context.Context, userID uuid.UUID, secret string) (err error)
+	Enable(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) (err error)
+	Disable(ctx context.Context, userID uuid.UUID) (err error)
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) (err error)
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) (ok bool, err error)
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (ok bool, err error)
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (count int, err error)
+}
+
+// IdentityRepository stores the links between users and their accounts at
+// OpenID Connect providers.
+type IdentityRepository interface {
+	GetBySubject(ctx context.Context, provider, subject string) (identity *entity.UserIdentity, err error)
+	Create(ctx context.Context, userID uuid.UUID, provider, subject string, email *string) (identity *entity.UserIdentity, err error)
+	RecordLogin(ctx context.Context, id uuid.UUID, email *string) (err error)
+}
+
+// OIDCStateRepository keeps pending OpenID Connect logins until the provider
+// redirects back.
+type OIDCStateRepository interface {
+	Save(ctx context.Context, state string, login entity.OIDCLoginState, ttl time.Duration) (err error)
+	Consume(ctx context.Context, state string) (login *entity.OIDCLoginState, err error)
+}
+
+// APIKeyRepository stores personal API keys by the hash of the key.
+type APIKeyRepository interface {
+	Create(ctx context.Context, key entity.APIKey) (created *entity.APIKey, err error)
+	GetByHash(ctx context.Context, keyHash string) (key *entity.APIKey, err error)
+	ListByUser(ctx context.Context, userID uuid.UUID) (keys []entity.APIKey, err error)
+	Revoke(ctx context.Context, userID, id uuid.UUID) (key *entity.APIKey, err error)
+	RecordUse(ctx context.Context, id uuid.UUID, usedAt time.Time) (err error)
+}
+
+// RateLimitRepository counts requests per key in a sliding window of the
+// given length and admits at most limit of them.
+type RateLimitRepository interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (result *entity.RateLimitResult, err error)
+}
+
+// TeamCacheRepository caches a team with its players by team ID: the
+// owner's view and, in a separate entry, the public profile. InvalidateTeam
+// drops both.
 type TeamCacheRepository interface {
-	SetTeam(ctx context.Context, userID uuid.UUID, team *dto.TeamWithPlayersResponse) (err error)
-	GetTeam(ctx context.Context, userID uuid.UUID) (team *dto.TeamWithPlayersResponse, err error)
-	InvalidateTeam(ctx context.Context, userID uuid.UUID) (err error)
+	SetTeam(ctx context.Context, teamID uuid.UUID, team *dto.TeamWithPlayersResponse) (err error)
+	GetTeam(ctx context.Context, teamID uuid.UUID) (team *dto.TeamWithPlayersResponse, err error)
+	SetPublicTeam(ctx context.Context, teamID uuid.UUID, team *dto.PublicTeamResponse) (err error)
+	GetPublicTeam(ctx context.Context, teamID uuid.UUID) (team *dto.PublicTeamResponse, err error)
+	InvalidateTeam(ctx context.Context, teamID uuid.UUID) (err error)
+}
+
+// LeaderboardRepository computes leaderboard scores from the database.
+type LeaderboardRepository interface {
+	Scores(ctx context.Context, kind entity.LeaderboardKind, filter entity.LeaderboardFilter) ([]entity.LeaderboardEntry, error)
+	// Describe returns entries with the names of their teams and players.
+	Describe(ctx context.Context, kind entity.LeaderboardKind, entries []entity.LeaderboardEntry) ([]entity.LeaderboardEntry, error)
+}
+
+// LeaderboardCacheRepository keeps the ranked leaderboards.
+type LeaderboardCacheRepository interface {
+	Set(ctx context.Context, kind entity.LeaderboardKind, entries ...entity.LeaderboardEntry) error
+	Remove(ctx context.Context, kind entity.LeaderboardKind, ids ...uuid.UUID) error
+	// Replace swaps the whole leaderboard for entries atomically.
+	Replace(ctx context.Context, kind entity.LeaderboardKind, entries []entity.LeaderboardEntry) error
+	Top(ctx context.Context, kind entity.LeaderboardKind, limit, offset uint) ([]entity.LeaderboardEntry, error)
+	// Rank returns nil if id is not on the leaderboard.
+	Rank(ctx context.Context, kind entity.LeaderboardKind, id uuid.UUID) (*entity.LeaderboardEntry, error)
+	Count(ctx context.Context, kind entity.LeaderboardKind) (int64, error)
+}
+
+// OutboxRepository feeds the event dispatcher from the outbox table, which
+// the other repositories write to.
+type OutboxRepository interface {
+	// Claim returns up to limit pending events, oldest first, and hides them
+	// from other claims for lease.
+	Claim(ctx context.Context, limit uint, lease time.Duration) ([]entity.Event, error)
+	MarkPublished(ctx context.Context, id uuid.UUID) error
+	// Retry makes the event pending again after delay.
+	Retry(ctx context.Context, id uuid.UUID, delay time.Duration, lastError string) error
+	// Prune deletes the events published before publishedBefore.
+	Prune(ctx context.Context, publishedBefore time.Time) (int64, error)
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook entity.Webhook) (*entity.Webhook, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Webhook, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]entity.Webhook, error)
+	// ListSubscribed returns the enabled endpoints of users that subscribe to
+	// eventType.
+	ListSubscribed(ctx context.Context, userIDs []uuid.UUID, eventType entity.EventType) ([]entity.Webhook, error)
+	// Update saves the URL, secret, event types, failure count and disabled
+	// time of webhook.
+	Update(ctx context.Context, webhook entity.Webhook) (*entity.Webhook, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	RecordSuccess(ctx context.Context, id uuid.UUID) error
+	// RecordFailure counts a failed attempt and disables the endpoint once
+	// disableAfter attempts in a row failed. It reports whether this call
+	// disabled it.
+	RecordFailure(ctx context.Context, id uuid.UUID, disableAfter int) (bool, error)
+}
+
+// WebhookDeliveryRepository is the webhook delivery log and the queue of
+// pending deliveries.
+type WebhookDeliveryRepository interface {
+	// Create adds deliveries, skipping events that already have a delivery
+	// to the same endpoint.
+	Create(ctx context.Context, deliveries ...entity.WebhookDelivery) error
+	// Claim returns up to limit pending deliveries to enabled endpoints,
+	// oldest first, counts an attempt for each and hides them from other
+	// claims for lease.
+	Claim(ctx context.Context, limit uint, lease time.Duration) ([]entity.WebhookDelivery, error)
+	// Complete records the final outcome of a delivery.
+	Complete(ctx context.Context, id uuid.UUID, status entity.WebhookDeliveryStatus, responseStatus *int, lastError *string) error
+	// Retry makes the delivery pending again after delay.
+	Retry(ctx context.Context, id uuid.UUID, delay time.Duration, responseStatus *int, lastError string) error
+	// ListByWebhook returns the deliveries to an endpoint, newest first.
+	ListByWebhook(ctx context.Context, webhookID uuid.UUID, limit, offset uint) ([]entity.WebhookDelivery, error)
+	// Prune deletes the deliveries completed before completedBefore.
+	Prune(ctx context.Context, completedBefore time.Time) (int64, error)
+}
+
+// MarketEventRepository fans market events out to every API instance.
+type MarketEventRepository interface {
+	Publish(ctx context.Context, event entity.MarketEvent) error
+	// Subscribe delivers the events published by any instance until ctx is
+	// done, then closes the channel.
+	Subscribe(ctx context.Context) (<-chan entity.MarketEvent, error)
+}
+
+type AuditRepository interface {
+	Create(ctx context.Context, entries ...entity.AuditEntry) error
+	List(ctx context.Context, filter entity.AuditFilter, limit, offset uint) ([]entity.AuditEntry, error)
+}
+
+type LedgerRepository interface {
+	Post(ctx context.Context, txn entity.LedgerTransaction) error
+	Statement(ctx context.Context, teamID uuid.UUID, limit, offset uint) ([]entity.StatementLine, error)
+	Balance(ctx context.Context, teamID uuid.UUID) (int64, error)
+}
+
+type IntegrityRepository interface {
+	Check(ctx context.Context) ([]entity.IntegrityIssue, error)
+	Fix(ctx context.Context) ([]entity.IntegrityIssue, error)
 }
@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+	"soccer_manager_service/pkg/webhook"
+)
+
+type WebhookSender interface {
+	// Send returns the response status, or 0 if there was none.
+	Send(ctx context.Context, req webhook.Request) (int, error)
+}
@@ -0,0 +1,220 @@
+package postgresrepo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/tracing"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var apiKeyColumns = []any{
+	"id",
+	"user_id",
+	"name",
+	"prefix",
+	"key_hash",
+	"scopes",
+	"created_at",
+	"last_used_at",
+	"revoked_at",
+}
+
+type APIKey struct {
+	logger  *zap.Logger
+	builder *goqu.SelectDataset
+	db      *pgxpool.Pool
+}
+
+type APIKeyParams struct {
+	Postgres *pgxpool.Pool
+	Logger   *zap.Logger
+}
+
+func NewAPIKeyRepository(params APIKeyParams) *APIKey {
+	return &APIKey{
+		builder: goqu.Dialect(postgresdb).From(apiKeysTable),
+		logger:  params.Logger.With(zap.String("layer", "APIKeyRepository")),
+		db:      params.Postgres,
+	}
+}
+
+func scanAPIKey(row pgx.Row, key *entity.APIKey) error {
+	return row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		&key.Scopes,
+		&key.CreatedAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+	)
+}
+
+func (r *APIKey) Create(ctx context.Context, key entity.APIKey) (_ *entity.APIKey, err error) {
+	ctx, span := startSpan(ctx, apiKeysTable, "Create")
+	defer func() { tracing.End(span, err) }()
+
+	scopes, err := json.Marshal(key.Scopes)
+	if err != nil {
+		return nil, apperr.SQLError("Create", err)
+	}
+
+	sql, args, err := r.builder.
+		Insert().
+		Rows(goqu.Record{
+			"user_id":  key.UserID,
+			"name":     key.Name,
+			"prefix":   key.Prefix,
+			"key_hash": key.KeyHash,
+			"scopes":   string(scopes),
+		}).
+		Returning(apiKeyColumns...).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Create", err)
+	}
+
+	var created entity.APIKey
+
+	if err := scanAPIKey(r.db.QueryRow(ctx, sql, args...), &created); err != nil {
+		return nil, apperr.SQLQueryError("Create", err)
+	}
+
+	return &created, nil
+}
+
+// GetByHash returns the unrevoked key with the given hash, or
+// ErrAPIKeyNotFound.
+func (r *APIKey) GetByHash(ctx context.Context, keyHash string) (_ *entity.APIKey, err error) {
+	ctx, span := startSpan(ctx, apiKeysTable, "GetByHash")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.builder.
+		Select(apiKeyColumns...).
+		Where(
+			goqu.C("key_hash").Eq(keyHash),
+			goqu.C("revoked_at").IsNull(),
+		).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("GetByHash", err)
+	}
+
+	var key entity.APIKey
+
+	if err := scanAPIKey(r.db.QueryRow(ctx, sql, args...), &key); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrAPIKeyNotFound
+		}
+
+		return nil, apperr.SQLQueryError("GetByHash", err)
+	}
+
+	return &key, nil
+}
+
+// ListByUser returns the user's unrevoked keys, newest first.
+func (r *APIKey) ListByUser(ctx context.Context, userID uuid.UUID) (_ []entity.APIKey, err error) {
+	ctx, span := startSpan(ctx, apiKeysTable, "ListByUser")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.builder.
+		Select(apiKeyColumns...).
+		Where(
+			goqu.C("user_id").Eq(userID),
+			goqu.C("revoked_at").IsNull(),
+		).
+		Order(goqu.C("created_at").Desc()).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("ListByUser", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, apperr.SQLQueryError("ListByUser", err)
+	}
+	defer rows.Close()
+
+	keys := make([]entity.APIKey, 0)
+
+	for rows.Next() {
+		var key entity.APIKey
+
+		if err := scanAPIKey(rows, &key); err != nil {
+			return nil, apperr.SQLQueryError("ListByUser", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.SQLQueryError("ListByUser", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke revokes the user's key id. It fails with ErrAPIKeyNotFound if the
+// user has no such unrevoked key.
+func (r *APIKey) Revoke(ctx context.Context, userID, id uuid.UUID) (_ *entity.APIKey, err error) {
+	ctx, span := startSpan(ctx, apiKeysTable, "Revoke")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.builder.
+		Update().
+		Set(goqu.Record{"revoked_at": time.Now()}).
+		Where(
+			goqu.C("id").Eq(id),
+			goqu.C("user_id").Eq(userID),
+			goqu.C("revoked_at").IsNull(),
+		).
+		Returning(apiKeyColumns...).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Revoke", err)
+	}
+
+	var key entity.APIKey
+
+	if err := scanAPIKey(r.db.QueryRow(ctx, sql, args...), &key); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrAPIKeyNotFound
+		}
+
+		return nil, apperr.SQLQueryError("Revoke", err)
+	}
+
+	return &key, nil
+}
+
+func (r *APIKey) RecordUse(ctx context.Context, id uuid.UUID, usedAt time.Time) (err error) {
+	ctx, span := startSpan(ctx, apiKeysTable, "RecordUse")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.builder.
+		Update().
+		Set(goqu.Record{"last_used_at": usedAt}).
+		Where(goqu.C("id").Eq(id)).
+		ToSQL()
+	if err != nil {
+		return apperr.SQLError("RecordUse", err)
+	}
+
+	if _, err := r.db.Exec(ctx, sql, args...); err != nil {
+		return apperr.SQLExecError("RecordUse", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package postgresrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/tracing"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type Audit struct {
+	logger  *zap.Logger
+	builder *goqu.SelectDataset
+	db      *pgxpool.Pool
+}
+
+type AuditParams struct {
+	Postgres *pgxpool.Pool
+	Logger   *zap.Logger
+}
+
+func NewAuditRepository(params AuditParams) *Audit {
+	return &Audit{
+		builder: goqu.Dialect(postgresdb).From(auditLogTable),
+		logger:  params.Logger.With(zap.String("layer", "AuditRepository")),
+		db:      params.Postgres,
+	}
+}
+
+func (r *Audit) Create(ctx context.Context, entries ...entity.AuditEntry) (err error) {
+	ctx, span := startSpan(ctx, auditLogTable, "Create")
+	defer func() { tracing.End(span, err) }()
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	rows := make([]any, 0, len(entries))
+
+	for _, entry := range entries {
+		changes, err := marshalJSONB(entry.Changes)
+		if err != nil {
+			return apperr.SQLError("Create", err)
+		}
+
+		metadata, err := marshalJSONB(entry.Metadata)
+		if err != nil {
+			return apperr.SQLError("Create", err)
+		}
+
+		rows = append(rows, goqu.Record{
+			"actor_id":    entry.ActorID,
+			"action":      entry.Action,
+			"entity_type": entry.EntityType,
+			"entity_id":   entry.EntityID,
+			"request_id":  entry.RequestID,
+			"changes":     changes,
+			"metadata":    metadata,
+		})
+	}
+
+	sql, args, err := r.builder.Insert().Rows(rows...).ToSQL()
+	if err != nil {
+		return apperr.SQLError("Create", err)
+	}
+
+	if _, err := r.db.Exec(ctx, sql, args...); err != nil {
+		return apperr.SQLExecError("Create", err)
+	}
+
+	return nil
+}
+
+// List returns audit entries matching filter, newest first.
+func (r *Audit) List(ctx context.Context, filter entity.AuditFilter, limit, offset uint) (_ []entity.AuditEntry, err error) {
+	ctx, span := startSpan(ctx, auditLogTable, "List")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.builder.
+		Select(
+			"id",
+			"actor_id",
+			"action",
+			"entity_type",
+			"entity_id",
+			"request_id",
+			"changes",
+			"metadata",
+			"created_at",
+		).
+		Order(goqu.C("created_at").Desc(), goqu.C("id").Asc()).
+		Limit(limit).
+		Offset(offset)
+
+	if filter.ActorID != nil {
+		query = query.Where(goqu.C("actor_id").Eq(*filter.ActorID))
+	}
+
+	if filter.EntityType != "" {
+		query = query.Where(goqu.C("entity_type").Eq(filter.EntityType))
+	}
+
+	if filter.EntityID != nil {
+		query = query.Where(goqu.C("entity_id").Eq(*filter.EntityID))
+	}
+
+	sql, args, err := query.ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("List", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, apperr.SQLQueryError("List", err)
+	}
+	defer rows.Close()
+
+	entries := make([]entity.AuditEntry, 0)
+
+	for rows.Next() {
+		var entry entity.AuditEntry
+
+		err := rows.Scan(
+			&entry.ID,
+			&entry.ActorID,
+			&entry.Action,
+			&entry.EntityType,
+			&entry.EntityID,
+			&entry.RequestID,
+			&entry.Changes,
+			&entry.Metadata,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, apperr.SQLQueryError("List", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.SQLQueryError("List", err)
+	}
+
+	return entries, nil
+}
+
+func marshalJSONB(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("marshal jsonb: %w", err)
+	}
+
+	if string(data) == "null" {
+		return "{}", nil
+	}
+
+	return string(data), nil
+}
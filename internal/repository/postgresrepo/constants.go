@@ -3,8 +3,16 @@ package postgresrepo
 const (
 	postgresdb = "postgres"
 
-	usersTable     = "users"
-	teamsTable     = "teams"
-	playersTable   = "players"
-	transfersTable = "transfers"
+	usersTable             = "users"
+	teamsTable             = "teams"
+	playersTable           = "players"
+	transfersTable         = "transfers"
+	auditLogTable          = "audit_log"
+	ledgerEntriesTable     = "ledger_entries"
+	recoveryCodesTable     = "user_recovery_codes"
+	identitiesTable        = "user_identities"
+	apiKeysTable           = "api_keys"
+	outboxEventsTable      = "outbox_events"
+	webhooksTable          = "webhooks"
+	webhookDeliveriesTable = "webhook_deliveries"
 )
@@ -0,0 +1,149 @@
+package postgresrepo
+
+import (
+	"context"
+	"errors"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/tracing"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var identityColumns = []any{
+	"id",
+	"user_id",
+	"provider",
+	"subject",
+	"email",
+	"created_at",
+	"last_login_at",
+}
+
+type Identity struct {
+	logger  *zap.Logger
+	builder *goqu.SelectDataset
+	db      *pgxpool.Pool
+}
+
+type IdentityParams struct {
+	Postgres *pgxpool.Pool
+	Logger   *zap.Logger
+}
+
+func NewIdentityRepository(params IdentityParams) *Identity {
+	return &Identity{
+		builder: goqu.Dialect(postgresdb).From(identitiesTable),
+		logger:  params.Logger.With(zap.String("layer", "IdentityRepository")),
+		db:      params.Postgres,
+	}
+}
+
+func scanIdentity(row pgx.Row, identity *entity.UserIdentity) error {
+	return row.Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.Subject,
+		&identity.Email,
+		&identity.CreatedAt,
+		&identity.LastLoginAt,
+	)
+}
+
+// GetBySubject returns the identity with the provider's user ID subject. It
+// fails with ErrIdentityNotFound if nobody has linked that account.
+func (r *Identity) GetBySubject(ctx context.Context, provider, subject string) (_ *entity.UserIdentity, err error) {
+	ctx, span := startSpan(ctx, identitiesTable, "GetBySubject")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.builder.
+		Select(identityColumns...).
+		Where(
+			goqu.C("provider").Eq(provider),
+			goqu.C("subject").Eq(subject),
+		).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("GetBySubject", err)
+	}
+
+	var identity entity.UserIdentity
+
+	err = scanIdentity(r.db.QueryRow(ctx, sql, args...), &identity)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrIdentityNotFound
+		}
+
+		return nil, apperr.SQLQueryError("GetBySubject", err)
+	}
+
+	return &identity, nil
+}
+
+// Create links userID to the provider account subject.
+func (r *Identity) Create(ctx context.Context, userID uuid.UUID, provider, subject string, email *string) (_ *entity.UserIdentity, err error) {
+	ctx, span := startSpan(ctx, identitiesTable, "Create")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.builder.
+		Insert().
+		Rows(goqu.Record{
+			"user_id":  userID,
+			"provider": provider,
+			"subject":  subject,
+			"email":    email,
+		}).
+		Returning(identityColumns...).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Create", err)
+	}
+
+	var identity entity.UserIdentity
+
+	err = scanIdentity(r.db.QueryRow(ctx, sql, args...), &identity)
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, apperr.ErrIdentityAlreadyLinked
+		}
+
+		return nil, apperr.SQLQueryError("Create", err)
+	}
+
+	return &identity, nil
+}
+
+// RecordLogin stamps the identity's last login and the email the provider
+// reported this time.
+func (r *Identity) RecordLogin(ctx context.Context, id uuid.UUID, email *string) (err error) {
+	ctx, span := startSpan(ctx, identitiesTable, "RecordLogin")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.builder.
+		Update().
+		Set(goqu.Record{
+			"email":         email,
+			"last_login_at": time.Now(),
+		}).
+		Where(goqu.C("id").Eq(id)).
+		ToSQL()
+	if err != nil {
+		return apperr.SQLError("RecordLogin", err)
+	}
+
+	if _, err := r.db.Exec(ctx, sql, args...); err != nil {
+		return apperr.SQLExecError("RecordLogin", err)
+	}
+
+	return nil
+}
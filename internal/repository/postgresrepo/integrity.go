@@ -0,0 +1,419 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/tracing"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// querier is satisfied by both *pgxpool.Pool and pgx.Tx, so the same checks
+// can run read-only or inside the repair transaction.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+type Integrity struct {
+	logger  *zap.Logger
+	dialect goqu.DialectWrapper
+	db      *pgxpool.Pool
+}
+
+type IntegrityParams struct {
+	Postgres *pgxpool.Pool
+	Logger   *zap.Logger
+}
+
+func NewIntegrityRepository(params IntegrityParams) *Integrity {
+	return &Integrity{
+		dialect: goqu.Dialect(postgresdb),
+		logger:  params.Logger.With(zap.String("layer", "IntegrityRepository")),
+		db:      params.Postgres,
+	}
+}
+
+// integrityCheck finds one kind of inconsistency. When tx is set the issues
+// found are repaired within it.
+type integrityCheck func(ctx context.Context, q querier, tx pgx.Tx) ([]entity.IntegrityIssue, error)
+
+// checks returns the checks in the order they must run: budgets are derived
+// from the ledger before negative budgets are looked for, and stale transfers
+// are cancelled before duplicates are counted.
+func (r *Integrity) checks() []integrityCheck {
+	return []integrityCheck{
+		r.ledgerMismatches,
+		r.negativeBudgets,
+		r.totalValueMismatches,
+		r.staleTransfers,
+		r.duplicateActiveTransfers,
+	}
+}
+
+// Check returns every inconsistency found without changing anything.
+func (r *Integrity) Check(ctx context.Context) (_ []entity.IntegrityIssue, err error) {
+	ctx, span := startSpan(ctx, "integrity", "Check")
+	defer func() { tracing.End(span, err) }()
+
+	issues := make([]entity.IntegrityIssue, 0)
+
+	for _, check := range r.checks() {
+		found, err := check(ctx, r.db, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, found...)
+	}
+
+	return issues, nil
+}
+
+// Fix repairs every inconsistency found in a single transaction and returns
+// the issues it repaired. Nothing is changed if any repair fails.
+func (r *Integrity) Fix(ctx context.Context) (_ []entity.IntegrityIssue, err error) {
+	ctx, span := startSpan(ctx, "integrity", "Fix")
+	defer func() { tracing.End(span, err) }()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperr.SQLError("Fix", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	issues := make([]entity.IntegrityIssue, 0)
+
+	for _, check := range r.checks() {
+		found, err := check(ctx, tx, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, found...)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperr.SQLExecError("Fix", err)
+	}
+
+	return issues, nil
+}
+
+func (r *Integrity) ledgerMismatches(ctx context.Context, q querier, tx pgx.Tx) ([]entity.IntegrityIssue, error) {
+	balance := goqu.Cast(goqu.COALESCE(goqu.SUM(goqu.I("l.amount")), 0), "BIGINT")
+
+	query := r.dialect.
+		From(goqu.T(teamsTable).As("t")).
+		LeftJoin(goqu.T(ledgerEntriesTable).As("l"), goqu.On(goqu.I("l.team_id").Eq(goqu.I("t.id")))).
+		Select(goqu.I("t.id"), goqu.I("t.budget"), balance).
+		GroupBy(goqu.I("t.id")).
+		Having(goqu.I("t.budget").Neq(balance)).
+		Order(goqu.I("t.id").Asc())
+
+	issues := make([]entity.IntegrityIssue, 0)
+	balances := make(map[uuid.UUID]int64)
+
+	err := r.scan(ctx, q, "ledgerMismatches", query, func(rows pgx.Rows) error {
+		var (
+			teamID          uuid.UUID
+			budget, balance int64
+		)
+
+		if err := rows.Scan(&teamID, &budget, &balance); err != nil {
+			return err
+		}
+
+		balances[teamID] = balance
+		issues = append(issues, entity.IntegrityIssue{
+			Kind:       entity.IssueLedgerMismatch,
+			EntityType: entity.AuditEntityTeam,
+			EntityID:   teamID,
+			TeamID:     &teamID,
+			Detail:     fmt.Sprintf("budget %d does not match ledger balance %d", budget, balance),
+			Repair:     fmt.Sprintf("set budget to %d", balance),
+		})
+
+		return nil
+	})
+	if err != nil || tx == nil {
+		return issues, err
+	}
+
+	for _, issue := range issues {
+		if err := r.updateTeam(ctx, tx, "ledgerMismatches", issue.EntityID, goqu.Record{"budget": balances[issue.EntityID]}); err != nil {
+			return nil, err
+		}
+	}
+
+	return issues, nil
+}
+
+func (r *Integrity) negativeBudgets(ctx context.Context, q querier, tx pgx.Tx) ([]entity.IntegrityIssue, error) {
+	query := r.dialect.
+		From(teamsTable).
+		Select("id", "budget").
+		Where(goqu.C("budget").Lt(0)).
+		Order(goqu.C("id").Asc())
+
+	issues := make([]entity.IntegrityIssue, 0)
+	budgets := make(map[uuid.UUID]int64)
+
+	err := r.scan(ctx, q, "negativeBudgets", query, func(rows pgx.Rows) error {
+		var (
+			teamID uuid.UUID
+			budget int64
+		)
+
+		if err := rows.Scan(&teamID, &budget); err != nil {
+			return err
+		}
+
+		budgets[teamID] = budget
+		issues = append(issues, entity.IntegrityIssue{
+			Kind:       entity.IssueNegativeBudget,
+			EntityType: entity.AuditEntityTeam,
+			EntityID:   teamID,
+			TeamID:     &teamID,
+			Detail:     fmt.Sprintf("budget is %d", budget),
+			Repair:     fmt.Sprintf("post a correction of %d to bring the budget to 0", -budget),
+		})
+
+		return nil
+	})
+	if err != nil || tx == nil {
+		return issues, err
+	}
+
+	for _, issue := range issues {
+		amount := -budgets[issue.EntityID]
+
+		err := postLedgerTransaction(ctx, tx, entity.LedgerTransaction{
+			Kind:        entity.LedgerKindCorrection,
+			Description: "Negative budget correction",
+			Postings: []entity.LedgerPosting{
+				entity.TeamPosting(issue.EntityID, amount),
+				entity.SystemPosting(entity.LedgerAccountCorrection, -amount),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return issues, nil
+}
+
+func (r *Integrity) totalValueMismatches(ctx context.Context, q querier, tx pgx.Tx) ([]entity.IntegrityIssue, error) {
+	actual := goqu.Cast(goqu.COALESCE(goqu.SUM(goqu.I("p.market_value")), 0), "BIGINT")
+
+	query := r.dialect.
+		From(goqu.T(teamsTable).As("t")).
+		LeftJoin(goqu.T(playersTable).As("p"), goqu.On(goqu.I("p.team_id").Eq(goqu.I("t.id")))).
+		Select(goqu.I("t.id"), goqu.I("t.total_value"), actual).
+		GroupBy(goqu.I("t.id")).
+		Having(goqu.I("t.total_value").Neq(actual)).
+		Order(goqu.I("t.id").Asc())
+
+	issues := make([]entity.IntegrityIssue, 0)
+	values := make(map[uuid.UUID]int64)
+
+	err := r.scan(ctx, q, "totalValueMismatches", query, func(rows pgx.Rows) error {
+		var (
+			teamID        uuid.UUID
+			stored, value int64
+		)
+
+		if err := rows.Scan(&teamID, &stored, &value); err != nil {
+			return err
+		}
+
+		values[teamID] = value
+		issues = append(issues, entity.IntegrityIssue{
+			Kind:       entity.IssueTotalValueMismatch,
+			EntityType: entity.AuditEntityTeam,
+			EntityID:   teamID,
+			TeamID:     &teamID,
+			Detail:     fmt.Sprintf("total_value %d does not match sum of player market values %d", stored, value),
+			Repair:     fmt.Sprintf("set total_value to %d", value),
+		})
+
+		return nil
+	})
+	if err != nil || tx == nil {
+		return issues, err
+	}
+
+	for _, issue := range issues {
+		if err := r.updateTeam(ctx, tx, "totalValueMismatches", issue.EntityID, goqu.Record{"total_value": values[issue.EntityID]}); err != nil {
+			return nil, err
+		}
+	}
+
+	return issues, nil
+}
+
+func (r *Integrity) staleTransfers(ctx context.Context, q querier, tx pgx.Tx) ([]entity.IntegrityIssue, error) {
+	query := r.dialect.
+		From(goqu.T(transfersTable).As("tr")).
+		Join(goqu.T(playersTable).As("p"), goqu.On(goqu.I("p.id").Eq(goqu.I("tr.player_id")))).
+		Select(goqu.I("tr.id"), goqu.I("tr.player_id"), goqu.I("tr.seller_id"), goqu.I("p.team_id")).
+		Where(
+			goqu.I("tr.status").Eq(entity.TransferStatusActive),
+			goqu.I("p.team_id").Neq(goqu.I("tr.seller_id")),
+		).
+		Order(goqu.I("tr.created_at").Asc())
+
+	issues := make([]entity.IntegrityIssue, 0)
+
+	err := r.scan(ctx, q, "staleTransfers", query, func(rows pgx.Rows) error {
+		var transferID, playerID, sellerID, ownerID uuid.UUID
+
+		if err := rows.Scan(&transferID, &playerID, &sellerID, &ownerID); err != nil {
+			return err
+		}
+
+		issues = append(issues, entity.IntegrityIssue{
+			Kind:       entity.IssueStaleTransfer,
+			EntityType: entity.AuditEntityTransfer,
+			EntityID:   transferID,
+			TeamID:     &sellerID,
+			Detail:     fmt.Sprintf("seller %s no longer owns player %s (owned by %s)", sellerID, playerID, ownerID),
+			Repair:     "cancel the transfer",
+		})
+
+		return nil
+	})
+	if err != nil || tx == nil {
+		return issues, err
+	}
+
+	if err := r.cancelTransfers(ctx, tx, "staleTransfers", issues); err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+func (r *Integrity) duplicateActiveTransfers(ctx context.Context, q querier, tx pgx.Tx) ([]entity.IntegrityIssue, error) {
+	ranked := r.dialect.
+		From(transfersTable).
+		Select(
+			"id",
+			"player_id",
+			"seller_id",
+			goqu.ROW_NUMBER().Over(goqu.W().PartitionBy("player_id").OrderBy(goqu.C("created_at").Desc(), goqu.C("id").Desc())).As("rank"),
+		).
+		Where(goqu.C("status").Eq(entity.TransferStatusActive))
+
+	query := r.dialect.
+		From(ranked.As("ranked")).
+		Select("id", "player_id", "seller_id").
+		Where(goqu.C("rank").Gt(1)).
+		Order(goqu.C("player_id").Asc(), goqu.C("rank").Asc())
+
+	issues := make([]entity.IntegrityIssue, 0)
+
+	err := r.scan(ctx, q, "duplicateActiveTransfers", query, func(rows pgx.Rows) error {
+		var transferID, playerID, sellerID uuid.UUID
+
+		if err := rows.Scan(&transferID, &playerID, &sellerID); err != nil {
+			return err
+		}
+
+		issues = append(issues, entity.IntegrityIssue{
+			Kind:       entity.IssueDuplicateActiveTransfer,
+			EntityType: entity.AuditEntityTransfer,
+			EntityID:   transferID,
+			TeamID:     &sellerID,
+			Detail:     fmt.Sprintf("player %s has a newer active transfer", playerID),
+			Repair:     "cancel the transfer",
+		})
+
+		return nil
+	})
+	if err != nil || tx == nil {
+		return issues, err
+	}
+
+	if err := r.cancelTransfers(ctx, tx, "duplicateActiveTransfers", issues); err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+func (r *Integrity) scan(ctx context.Context, q querier, op string, query *goqu.SelectDataset, fn func(pgx.Rows) error) error {
+	sql, args, err := query.ToSQL()
+	if err != nil {
+		return apperr.SQLError(op, err)
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return apperr.SQLQueryError(op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return apperr.SQLQueryError(op, err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return apperr.SQLQueryError(op, err)
+	}
+
+	return nil
+}
+
+func (r *Integrity) updateTeam(ctx context.Context, tx pgx.Tx, op string, teamID uuid.UUID, record goqu.Record) error {
+	record["updated_at"] = time.Now()
+
+	sql, args, err := r.dialect.Update(teamsTable).Set(record).Where(goqu.C("id").Eq(teamID)).ToSQL()
+	if err != nil {
+		return apperr.SQLError(op, err)
+	}
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return apperr.SQLExecError(op, err)
+	}
+
+	return nil
+}
+
+func (r *Integrity) cancelTransfers(ctx context.Context, tx pgx.Tx, op string, issues []entity.IntegrityIssue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(issues))
+
+	for _, issue := range issues {
+		ids = append(ids, issue.EntityID)
+	}
+
+	sql, args, err := r.dialect.
+		Update(transfersTable).
+		Set(goqu.Record{"status": entity.TransferStatusCancelled}).
+		Where(goqu.C("id").In(ids), goqu.C("status").Eq(entity.TransferStatusActive)).
+		ToSQL()
+	if err != nil {
+		return apperr.SQLError(op, err)
+	}
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return apperr.SQLExecError(op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package postgresrepo
+
+import (
+	"context"
+	"fmt"
+	"soccer_manager_service/internal/entity"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/tracing"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// Leaderboard computes leaderboard scores: a team's total value is the sum of
+// its players' market values, its transfer profit the price of the players it
+// sold minus the price of those it bought.
+type Leaderboard struct {
+	logger  *zap.Logger
+	dialect goqu.DialectWrapper
+	db      *pgxpool.Pool
+}
+
+type LeaderboardParams struct {
+	Postgres *pgxpool.Pool
+	Logger   *zap.Logger
+}
+
+func NewLeaderboardRepository(params LeaderboardParams) *Leaderboard {
+	return &Leaderboard{
+		dialect: goqu.Dialect(postgresdb),
+		logger:  params.Logger.With(zap.String("layer", "LeaderboardRepository")),
+		db:      params.Postgres,
+	}
+}
+
+func (r *Leaderboard) Scores(ctx context.Context, kind entity.LeaderboardKind, filter entity.LeaderboardFilter) (_ []entity.LeaderboardEntry, err error) {
+	ctx, span := startSpan(ctx, "leaderboard", "Scores")
+	defer func() { tracing.End(span, err) }()
+
+	query, err := r.scoresQuery(kind, filter.TeamIDs)
+	if err != nil {
+		return nil, apperr.SQLError("Scores", err)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	sql, args, err := query.ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Scores", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, apperr.SQLQueryError("Scores", err)
+	}
+	defer rows.Close()
+
+	entries := make([]entity.LeaderboardEntry, 0)
+
+	for rows.Next() {
+		var entry entity.LeaderboardEntry
+
+		if err := rows.Scan(&entry.ID, &entry.Score); err != nil {
+			return nil, apperr.SQLQueryError("Scores", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.SQLQueryError("Scores", err)
+	}
+
+	return entries, nil
+}
+
+// scoresQuery selects (id, score) pairs, highest score first.
+func (r *Leaderboard) scoresQuery(kind entity.LeaderboardKind, teamIDs []uuid.UUID) (*goqu.SelectDataset, error) {
+	var (
+		query *goqu.SelectDataset
+		score exp.Orderable
+	)
+
+	switch kind {
+	case entity.LeaderboardTotalValue:
+		score = goqu.Cast(goqu.COALESCE(goqu.SUM(goqu.I("p.market_value")), 0), "BIGINT")
+		query = r.dialect.
+			From(goqu.T(teamsTable).As("t")).
+			LeftJoin(goqu.T(playersTable).As("p"), goqu.On(goqu.I("p.team_id").Eq(goqu.I("t.id")))).
+			GroupBy(goqu.I("t.id"))
+	case entity.LeaderboardBudget:
+		score = goqu.I("t.budget")
+		query = r.dialect.From(goqu.T(teamsTable).As("t"))
+	case entity.LeaderboardTransferProfit:
+		profit := goqu.Case().
+			When(goqu.I("tr.seller_id").Eq(goqu.I("t.id")), goqu.I("tr.asking_price")).
+			Else(goqu.L("-?", goqu.I("tr.asking_price")))
+		score = goqu.Cast(goqu.COALESCE(goqu.SUM(profit), 0), "BIGINT")
+		query = r.dialect.
+			From(goqu.T(teamsTable).As("t")).
+			LeftJoin(goqu.T(transfersTable).As("tr"), goqu.On(
+				goqu.I("tr.status").Eq(entity.TransferStatusCompleted),
+				goqu.Or(goqu.I("tr.seller_id").Eq(goqu.I("t.id")), goqu.I("tr.buyer_id").Eq(goqu.I("t.id"))),
+			)).
+			GroupBy(goqu.I("t.id"))
+	case entity.LeaderboardTransfers:
+		query = r.dialect.
+			From(goqu.T(transfersTable).As("tr")).
+			Select(goqu.I("tr.id"), goqu.I("tr.asking_price")).
+			Where(goqu.I("tr.status").Eq(entity.TransferStatusCompleted)).
+			Order(goqu.I("tr.asking_price").Desc(), goqu.I("tr.completed_at").Asc(), goqu.I("tr.id").Asc())
+
+		if len(teamIDs) > 0 {
+			query = query.Where(goqu.I("tr.buyer_id").In(teamIDs))
+		}
+
+		return query, nil
+	default:
+		return nil, fmt.Errorf("unknown leaderboard %q", kind)
+	}
+
+	query = query.
+		Select(goqu.I("t.id"), score).
+		Order(score.Desc(), goqu.I("t.id").Asc())
+
+	if len(teamIDs) > 0 {
+		query = query.Where(goqu.I("t.id").In(teamIDs))
+	}
+
+	return query, nil
+}
+
+func (r *Leaderboard) Describe(ctx context.Context, kind entity.LeaderboardKind, entries []entity.LeaderboardEntry) (_ []entity.LeaderboardEntry, err error) {
+	ctx, span := startSpan(ctx, "leaderboard", "Describe")
+	defer func() { tracing.End(span, err) }()
+
+	described := make([]entity.LeaderboardEntry, len(entries))
+	copy(described, entries)
+
+	if len(entries) == 0 {
+		return described, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+
+	var query *goqu.SelectDataset
+
+	if kind == entity.LeaderboardTransfers {
+		query = r.dialect.
+			From(goqu.T(transfersTable).As("tr")).
+			LeftJoin(goqu.T(teamsTable).As("t"), goqu.On(goqu.I("t.id").Eq(goqu.I("tr.buyer_id")))).
+			LeftJoin(goqu.T(playersTable).As("p"), goqu.On(goqu.I("p.id").Eq(goqu.I("tr.player_id")))).
+			Select(
+				goqu.I("tr.id"),
+				goqu.I("t.id"),
+				goqu.COALESCE(goqu.I("t.name"), ""),
+				goqu.COALESCE(goqu.L("? || ' ' || ?", goqu.I("p.first_name"), goqu.I("p.last_name")), ""),
+			).
+			Where(goqu.I("tr.id").In(ids))
+	} else {
+		query = r.dialect.
+			From(goqu.T(teamsTable).As("t")).
+			Select(goqu.I("t.id"), goqu.I("t.id"), goqu.I("t.name"), goqu.L("''")).
+			Where(goqu.I("t.id").In(ids))
+	}
+
+	sql, args, err := query.ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Describe", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, apperr.SQLQueryError("Describe", err)
+	}
+	defer rows.Close()
+
+	labels := make(map[uuid.UUID]entity.LeaderboardEntry, len(entries))
+
+	for rows.Next() {
+		var label entity.LeaderboardEntry
+
+		if err := rows.Scan(&label.ID, &label.TeamID, &label.TeamName, &label.Player); err != nil {
+			return nil, apperr.SQLQueryError("Describe", err)
+		}
+
+		labels[label.ID] = label
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.SQLQueryError("Describe", err)
+	}
+
+	for i := range described {
+		label := labels[described[i].ID]
+		described[i].TeamID = label.TeamID
+		described[i].TeamName = label.TeamName
+		described[i].Player = label.Player
+	}
+
+	return described, nil
+}
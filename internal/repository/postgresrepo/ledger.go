@@ -0,0 +1,244 @@
+package postgresrepo
+
+import (
+	"context"
+	"errors"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/tracing"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var errUnbalancedTransaction = errors.New("ledger transaction is not balanced")
+
+type Ledger struct {
+	logger  *zap.Logger
+	builder *goqu.SelectDataset
+	db      *pgxpool.Pool
+}
+
+type LedgerParams struct {
+	Postgres *pgxpool.Pool
+	Logger   *zap.Logger
+}
+
+func NewLedgerRepository(params LedgerParams) *Ledger {
+	return &Ledger{
+		builder: goqu.Dialect(postgresdb).From(ledgerEntriesTable),
+		logger:  params.Logger.With(zap.String("layer", "LedgerRepository")),
+		db:      params.Postgres,
+	}
+}
+
+// Post records a balanced transaction and applies its team postings to
+// teams.budget in a single database transaction. A posting that would make a
+// team budget negative fails the whole transaction with ErrInsufficientFunds.
+func (r *Ledger) Post(ctx context.Context, txn entity.LedgerTransaction) (err error) {
+	ctx, span := startSpan(ctx, ledgerEntriesTable, "Post")
+	defer func() { tracing.End(span, err) }()
+
+	if !txn.IsBalanced() {
+		return apperr.SQLError("Post", errUnbalancedTransaction)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return apperr.SQLError("Post", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := postLedgerTransaction(ctx, tx, txn); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperr.SQLExecError("Post", err)
+	}
+
+	return nil
+}
+
+// postLedgerTransaction inserts the entries of txn and applies its team
+// postings to teams.budget within tx.
+func postLedgerTransaction(ctx context.Context, tx pgx.Tx, txn entity.LedgerTransaction) error {
+	transactionID := uuid.New()
+	rows := make([]any, 0, len(txn.Postings))
+
+	for _, posting := range txn.Postings {
+		rows = append(rows, goqu.Record{
+			"transaction_id": transactionID,
+			"kind":           txn.Kind,
+			"account":        posting.Account,
+			"team_id":        posting.TeamID,
+			"amount":         posting.Amount,
+			"reference_id":   txn.ReferenceID,
+			"description":    txn.Description,
+		})
+	}
+
+	sql, args, err := goqu.Dialect(postgresdb).Insert(ledgerEntriesTable).Rows(rows...).ToSQL()
+	if err != nil {
+		return apperr.SQLError("Post", err)
+	}
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return apperr.SQLExecError("Post", err)
+	}
+
+	for _, posting := range txn.Postings {
+		if posting.TeamID == nil {
+			continue
+		}
+
+		if err := applyToBudget(ctx, tx, *posting.TeamID, posting.Amount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applyToBudget(ctx context.Context, tx pgx.Tx, teamID uuid.UUID, amount int64) error {
+	teams := goqu.Dialect(postgresdb).From(teamsTable)
+
+	query := teams.
+		Update().
+		Set(goqu.Record{
+			"budget":     goqu.L("budget + ?", amount),
+			"updated_at": time.Now(),
+		}).
+		Where(
+			goqu.C("id").Eq(teamID),
+			goqu.L("budget + ? >= 0", amount),
+		)
+
+	sql, args, err := query.ToSQL()
+	if err != nil {
+		return apperr.SQLError("Post", err)
+	}
+
+	result, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return apperr.SQLExecError("Post", err)
+	}
+
+	if result.RowsAffected() > 0 {
+		return nil
+	}
+
+	sql, args, err = teams.Select("budget").Where(goqu.C("id").Eq(teamID)).ToSQL()
+	if err != nil {
+		return apperr.SQLError("Post", err)
+	}
+
+	var budget int64
+
+	if err := tx.QueryRow(ctx, sql, args...).Scan(&budget); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperr.ErrTeamNotFound
+		}
+
+		return apperr.SQLQueryError("Post", err)
+	}
+
+	return apperr.WithData(apperr.ErrInsufficientFunds, map[string]any{
+		"Needed":    -amount,
+		"Available": budget,
+	})
+}
+
+// Statement returns a page of a team's ledger entries, newest first, each with
+// the team balance right after the entry.
+func (r *Ledger) Statement(ctx context.Context, teamID uuid.UUID, limit, offset uint) (_ []entity.StatementLine, err error) {
+	ctx, span := startSpan(ctx, ledgerEntriesTable, "Statement")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.builder.
+		Select(
+			"id",
+			"transaction_id",
+			"kind",
+			"account",
+			"team_id",
+			"amount",
+			"reference_id",
+			"description",
+			"created_at",
+			goqu.Cast(goqu.SUM("amount").Over(goqu.W().OrderBy(goqu.C("created_at").Asc(), goqu.C("id").Asc())), "BIGINT"),
+		).
+		Where(goqu.C("team_id").Eq(teamID)).
+		Order(goqu.C("created_at").Desc(), goqu.C("id").Desc()).
+		Limit(limit).
+		Offset(offset)
+
+	sql, args, err := query.ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Statement", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, apperr.SQLQueryError("Statement", err)
+	}
+	defer rows.Close()
+
+	lines := make([]entity.StatementLine, 0)
+
+	for rows.Next() {
+		var line entity.StatementLine
+
+		err := rows.Scan(
+			&line.ID,
+			&line.TransactionID,
+			&line.Kind,
+			&line.Account,
+			&line.TeamID,
+			&line.Amount,
+			&line.ReferenceID,
+			&line.Description,
+			&line.CreatedAt,
+			&line.Balance,
+		)
+		if err != nil {
+			return nil, apperr.SQLQueryError("Statement", err)
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.SQLQueryError("Statement", err)
+	}
+
+	return lines, nil
+}
+
+// Balance returns the sum of all ledger entries of a team, which must equal
+// its teams.budget.
+func (r *Ledger) Balance(ctx context.Context, teamID uuid.UUID) (_ int64, err error) {
+	ctx, span := startSpan(ctx, ledgerEntriesTable, "Balance")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.builder.
+		Select(goqu.Cast(goqu.COALESCE(goqu.SUM("amount"), 0), "BIGINT")).
+		Where(goqu.C("team_id").Eq(teamID))
+
+	sql, args, err := query.ToSQL()
+	if err != nil {
+		return 0, apperr.SQLError("Balance", err)
+	}
+
+	var balance int64
+
+	if err := r.db.QueryRow(ctx, sql, args...).Scan(&balance); err != nil {
+		return 0, apperr.SQLQueryError("Balance", err)
+	}
+
+	return balance, nil
+}
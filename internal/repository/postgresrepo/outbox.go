@@ -0,0 +1,182 @@
+package postgresrepo
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"soccer_manager_service/internal/entity"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/tracing"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// Outbox hands the events written by the other repositories to the
+// dispatcher. Several instances may claim concurrently: SKIP LOCKED keeps
+// them off each other's rows and the lease keeps a claimed event from being
+// claimed again while it is delivered.
+type Outbox struct {
+	logger  *zap.Logger
+	builder *goqu.SelectDataset
+	db      *pgxpool.Pool
+}
+
+type OutboxParams struct {
+	Postgres *pgxpool.Pool
+	Logger   *zap.Logger
+}
+
+func NewOutboxRepository(params OutboxParams) *Outbox {
+	return &Outbox{
+		builder: goqu.Dialect(postgresdb).From(outboxEventsTable),
+		logger:  params.Logger.With(zap.String("layer", "OutboxRepository")),
+		db:      params.Postgres,
+	}
+}
+
+func (r *Outbox) Claim(ctx context.Context, limit uint, lease time.Duration) (_ []entity.Event, err error) {
+	ctx, span := startSpan(ctx, outboxEventsTable, "Claim")
+	defer func() { tracing.End(span, err) }()
+
+	pending := r.builder.
+		Select(goqu.C("id")).
+		Where(
+			goqu.C("published_at").IsNull(),
+			goqu.C("available_at").Lte(goqu.L("NOW()")),
+		).
+		Order(goqu.C("created_at").Asc(), goqu.C("id").Asc()).
+		Limit(limit).
+		ForUpdate(exp.SkipLocked)
+
+	query := r.builder.
+		Update().
+		Set(goqu.Record{
+			"attempts":     goqu.L("attempts + 1"),
+			"available_at": goqu.L("NOW() + ? * INTERVAL '1 millisecond'", lease.Milliseconds()),
+		}).
+		Where(goqu.C("id").In(pending)).
+		Returning("id", "type", "aggregate_id", "payload", "attempts", "created_at")
+
+	sql, args, err := query.ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Claim", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, apperr.SQLQueryError("Claim", err)
+	}
+	defer rows.Close()
+
+	events := make([]entity.Event, 0)
+
+	for rows.Next() {
+		var event entity.Event
+
+		if err := rows.Scan(&event.ID, &event.Type, &event.AggregateID, &event.Payload, &event.Attempts, &event.CreatedAt); err != nil {
+			return nil, apperr.SQLQueryError("Claim", err)
+		}
+
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.SQLQueryError("Claim", err)
+	}
+
+	// RETURNING does not keep the order of the subquery.
+	slices.SortFunc(events, func(a, b entity.Event) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), slices.Compare(a.ID[:], b.ID[:]))
+	})
+
+	return events, nil
+}
+
+func (r *Outbox) MarkPublished(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, outboxEventsTable, "MarkPublished")
+	defer func() { tracing.End(span, err) }()
+
+	return r.update(ctx, "MarkPublished", id, goqu.Record{
+		"published_at": goqu.L("NOW()"),
+		"last_error":   nil,
+	})
+}
+
+func (r *Outbox) Retry(ctx context.Context, id uuid.UUID, delay time.Duration, lastError string) (err error) {
+	ctx, span := startSpan(ctx, outboxEventsTable, "Retry")
+	defer func() { tracing.End(span, err) }()
+
+	return r.update(ctx, "Retry", id, goqu.Record{
+		"available_at": goqu.L("NOW() + ? * INTERVAL '1 millisecond'", delay.Milliseconds()),
+		"last_error":   lastError,
+	})
+}
+
+func (r *Outbox) Prune(ctx context.Context, publishedBefore time.Time) (_ int64, err error) {
+	ctx, span := startSpan(ctx, outboxEventsTable, "Prune")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.builder.
+		Delete().
+		Where(goqu.C("published_at").Lt(publishedBefore))
+
+	sql, args, err := query.ToSQL()
+	if err != nil {
+		return 0, apperr.SQLError("Prune", err)
+	}
+
+	result, err := r.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, apperr.SQLExecError("Prune", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *Outbox) update(ctx context.Context, op string, id uuid.UUID, record goqu.Record) error {
+	sql, args, err := r.builder.Update().Set(record).Where(goqu.C("id").Eq(id)).ToSQL()
+	if err != nil {
+		return apperr.SQLError(op, err)
+	}
+
+	if _, err := r.db.Exec(ctx, sql, args...); err != nil {
+		return apperr.SQLExecError(op, err)
+	}
+
+	return nil
+}
+
+// insertOutboxEvents writes events within tx, so that they are published if
+// and only if the change they describe is committed.
+func insertOutboxEvents(ctx context.Context, tx pgx.Tx, op string, events ...entity.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	rows := make([]any, 0, len(events))
+	for _, event := range events {
+		rows = append(rows, goqu.Record{
+			"id":           event.ID,
+			"type":         event.Type,
+			"aggregate_id": event.AggregateID,
+			"payload":      string(event.Payload),
+		})
+	}
+
+	sql, args, err := goqu.Dialect(postgresdb).Insert(outboxEventsTable).Rows(rows...).ToSQL()
+	if err != nil {
+		return apperr.SQLError(op, err)
+	}
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return apperr.SQLExecError(op, err)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"soccer_manager_service/internal/entity"
 	"soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/tracing"
 	"time"
 
 	"github.com/doug-martin/goqu/v9"
@@ -33,47 +34,10 @@ func NewPlayerRepository(params PlayerParams) *Player {
 	}
 }
 
-func (r *Player) Create(ctx context.Context, teamID uuid.UUID, firstName, lastName, country string, age int, position entity.PlayerPosition, marketValue int64) (*entity.Player, error) {
-	query := r.builder.
-		Insert().
-		Rows(goqu.Record{
-			"team_id":      teamID,
-			"first_name":   firstName,
-			"last_name":    lastName,
-			"country":      country,
-			"age":          age,
-			"position":     position,
-			"market_value": marketValue,
-		}).
-		Returning(goqu.Star())
-
-	sql, args, err := query.ToSQL()
-	if err != nil {
-		return nil, apperr.SQLError("Create", err)
-	}
+func (r *Player) GetByID(ctx context.Context, id uuid.UUID) (_ *entity.Player, err error) {
+	ctx, span := startSpan(ctx, playersTable, "GetByID")
+	defer func() { tracing.End(span, err) }()
 
-	var player entity.Player
-
-	err = r.db.QueryRow(ctx, sql, args...).Scan(
-		&player.ID,
-		&player.TeamID,
-		&player.FirstName,
-		&player.LastName,
-		&player.Country,
-		&player.Age,
-		&player.Position,
-		&player.MarketValue,
-		&player.CreatedAt,
-		&player.UpdatedAt,
-	)
-	if err != nil {
-		return nil, apperr.SQLQueryError("Create", err)
-	}
-
-	return &player, nil
-}
-
-func (r *Player) GetByID(ctx context.Context, id uuid.UUID) (*entity.Player, error) {
 	query := r.builder.
 		Select(goqu.Star()).
 		Where(goqu.C("id").Eq(id))
@@ -108,7 +72,10 @@ func (r *Player) GetByID(ctx context.Context, id uuid.UUID) (*entity.Player, err
 	return &player, nil
 }
 
-func (r *Player) GetByTeamID(ctx context.Context, teamID uuid.UUID) ([]entity.Player, error) {
+func (r *Player) GetByTeamID(ctx context.Context, teamID uuid.UUID) (_ []entity.Player, err error) {
+	ctx, span := startSpan(ctx, playersTable, "GetByTeamID")
+	defer func() { tracing.End(span, err) }()
+
 	query := r.builder.
 		Select(goqu.Star()).
 		Where(goqu.C("team_id").Eq(teamID)).
@@ -152,7 +119,10 @@ func (r *Player) GetByTeamID(ctx context.Context, teamID uuid.UUID) ([]entity.Pl
 	return players, nil
 }
 
-func (r *Player) Update(ctx context.Context, id uuid.UUID, firstName, lastName, country string) (*entity.Player, error) {
+func (r *Player) Update(ctx context.Context, id uuid.UUID, firstName, lastName, country string) (_ *entity.Player, err error) {
+	ctx, span := startSpan(ctx, playersTable, "Update")
+	defer func() { tracing.End(span, err) }()
+
 	record := goqu.Record{"updated_at": time.Now()}
 
 	if firstName != "" {
@@ -203,23 +173,25 @@ func (r *Player) Update(ctx context.Context, id uuid.UUID, firstName, lastName,
 	return &player, nil
 }
 
-func (r *Player) UpdateMarketValue(ctx context.Context, id uuid.UUID, marketValue int64) error {
-	query := r.builder.
-		Update().
+// movePlayer moves a sold player to the buying team with its new market value
+// within tx.
+func movePlayer(ctx context.Context, tx pgx.Tx, playerID, teamID uuid.UUID, marketValue int64) error {
+	sql, args, err := goqu.Dialect(postgresdb).
+		Update(playersTable).
 		Set(goqu.Record{
+			"team_id":      teamID,
 			"market_value": marketValue,
 			"updated_at":   time.Now(),
 		}).
-		Where(goqu.C("id").Eq(id))
-
-	sql, args, err := query.ToSQL()
+		Where(goqu.C("id").Eq(playerID)).
+		ToSQL()
 	if err != nil {
-		return apperr.SQLError("UpdateMarketValue", err)
+		return apperr.SQLError("MovePlayer", err)
 	}
 
-	result, err := r.db.Exec(ctx, sql, args...)
+	result, err := tx.Exec(ctx, sql, args...)
 	if err != nil {
-		return apperr.SQLExecError("UpdateMarketValue", err)
+		return apperr.SQLExecError("MovePlayer", err)
 	}
 
 	if result.RowsAffected() == 0 {
@@ -229,27 +201,33 @@ func (r *Player) UpdateMarketValue(ctx context.Context, id uuid.UUID, marketValu
 	return nil
 }
 
-func (r *Player) TransferPlayer(ctx context.Context, playerID, newTeamID uuid.UUID) error {
-	query := r.builder.
-		Update().
-		Set(goqu.Record{
-			"team_id":    newTeamID,
-			"updated_at": time.Now(),
-		}).
-		Where(goqu.C("id").Eq(playerID))
+// insertPlayers creates players within tx.
+func insertPlayers(ctx context.Context, tx pgx.Tx, players []entity.Player) error {
+	if len(players) == 0 {
+		return nil
+	}
 
-	sql, args, err := query.ToSQL()
-	if err != nil {
-		return apperr.SQLError("TransferPlayer", err)
+	rows := make([]any, 0, len(players))
+
+	for _, player := range players {
+		rows = append(rows, goqu.Record{
+			"team_id":      player.TeamID,
+			"first_name":   player.FirstName,
+			"last_name":    player.LastName,
+			"country":      player.Country,
+			"age":          player.Age,
+			"position":     player.Position,
+			"market_value": player.MarketValue,
+		})
 	}
 
-	result, err := r.db.Exec(ctx, sql, args...)
+	sql, args, err := goqu.Dialect(postgresdb).Insert(playersTable).Rows(rows...).ToSQL()
 	if err != nil {
-		return apperr.SQLExecError("TransferPlayer", err)
+		return apperr.SQLError("CreatePlayers", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return apperr.ErrPlayerNotFound
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return apperr.SQLExecError("CreatePlayers", err)
 	}
 
 	return nil
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"soccer_manager_service/internal/entity"
 	"soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/tracing"
 	"time"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -34,25 +36,67 @@ func NewTeamRepository(params TeamParams) *Team {
 	}
 }
 
-func (r *Team) Create(ctx context.Context, userID uuid.UUID, name, country string, budget int64) (*entity.Team, error) {
+// Create inserts the team, posts its seed money and creates its initial squad
+// in one transaction, so that a team never exists without its budget or its
+// players.
+func (r *Team) Create(ctx context.Context, founding entity.TeamFounding) (_ *entity.Team, err error) {
+	ctx, span := startSpan(ctx, teamsTable, "Create")
+	defer func() { tracing.End(span, err) }()
+
+	if !founding.SeedMoney.IsBalanced() {
+		return nil, apperr.SQLError("Create", errUnbalancedTransaction)
+	}
+
 	query := r.builder.
 		Insert().
 		Rows(goqu.Record{
-			"user_id": userID,
-			"name":    name,
-			"country": country,
-			"budget":  budget,
-		}).
-		Returning(goqu.Star())
+			"id":          founding.Team.ID,
+			"user_id":     founding.Team.UserID,
+			"name":        founding.Team.Name,
+			"country":     founding.Team.Country,
+			"total_value": founding.Team.TotalValue,
+		})
 
 	sql, args, err := query.ToSQL()
 	if err != nil {
 		return nil, apperr.SQLError("Create", err)
 	}
 
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperr.SQLError("Create", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, apperr.ErrTeamAlreadyExists
+		}
+
+		return nil, apperr.SQLExecError("Create", err)
+	}
+
+	if err := postLedgerTransaction(ctx, tx, founding.SeedMoney); err != nil {
+		return nil, err
+	}
+
+	if err := insertPlayers(ctx, tx, founding.Players); err != nil {
+		return nil, err
+	}
+
+	sql, args, err = r.builder.
+		Select(goqu.Star()).
+		Where(goqu.C("id").Eq(founding.Team.ID)).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Create", err)
+	}
+
 	var team entity.Team
 
-	err = r.db.QueryRow(ctx, sql, args...).Scan(
+	err = tx.QueryRow(ctx, sql, args...).Scan(
 		&team.ID,
 		&team.UserID,
 		&team.Name,
@@ -63,19 +107,20 @@ func (r *Team) Create(ctx context.Context, userID uuid.UUID, name, country strin
 		&team.UpdatedAt,
 	)
 	if err != nil {
-		var pgErr *pgconn.PgError
-
-		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return nil, apperr.ErrTeamAlreadyExists
-		}
-
 		return nil, apperr.SQLQueryError("Create", err)
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperr.SQLExecError("Create", err)
+	}
+
 	return &team, nil
 }
 
-func (r *Team) GetByID(ctx context.Context, id uuid.UUID) (*entity.Team, error) {
+func (r *Team) GetByID(ctx context.Context, id uuid.UUID) (_ *entity.Team, err error) {
+	ctx, span := startSpan(ctx, teamsTable, "GetByID")
+	defer func() { tracing.End(span, err) }()
+
 	query := r.builder.
 		Select(goqu.Star()).
 		Where(goqu.C("id").Eq(id))
@@ -108,40 +153,60 @@ func (r *Team) GetByID(ctx context.Context, id uuid.UUID) (*entity.Team, error)
 	return &team, nil
 }
 
-func (r *Team) GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.Team, error) {
+// ListByUserID returns the user's teams, oldest first.
+func (r *Team) ListByUserID(ctx context.Context, userID uuid.UUID) (_ []entity.Team, err error) {
+	ctx, span := startSpan(ctx, teamsTable, "ListByUserID")
+	defer func() { tracing.End(span, err) }()
+
 	query := r.builder.
 		Select(goqu.Star()).
-		Where(goqu.C("user_id").Eq(userID))
+		Where(goqu.C("user_id").Eq(userID)).
+		Order(goqu.C("created_at").Asc(), goqu.C("id").Asc())
 
 	sql, args, err := query.ToSQL()
 	if err != nil {
-		return nil, apperr.SQLError("GetByUserID", err)
+		return nil, apperr.SQLError("ListByUserID", err)
 	}
 
-	var team entity.Team
-
-	err = r.db.QueryRow(ctx, sql, args...).Scan(
-		&team.ID,
-		&team.UserID,
-		&team.Name,
-		&team.Country,
-		&team.Budget,
-		&team.TotalValue,
-		&team.CreatedAt,
-		&team.UpdatedAt,
-	)
+	rows, err := r.db.Query(ctx, sql, args...)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, apperr.ErrTeamNotFound
+		return nil, apperr.SQLQueryError("ListByUserID", err)
+	}
+	defer rows.Close()
+
+	teams := make([]entity.Team, 0)
+
+	for rows.Next() {
+		var team entity.Team
+
+		err := rows.Scan(
+			&team.ID,
+			&team.UserID,
+			&team.Name,
+			&team.Country,
+			&team.Budget,
+			&team.TotalValue,
+			&team.CreatedAt,
+			&team.UpdatedAt,
+		)
+		if err != nil {
+			return nil, apperr.SQLQueryError("ListByUserID", err)
 		}
 
-		return nil, apperr.SQLQueryError("GetByUserID", err)
+		teams = append(teams, team)
 	}
 
-	return &team, nil
+	if err := rows.Err(); err != nil {
+		return nil, apperr.SQLQueryError("ListByUserID", err)
+	}
+
+	return teams, nil
 }
 
-func (r *Team) Update(ctx context.Context, id uuid.UUID, name, country string) (*entity.Team, error) {
+func (r *Team) Update(ctx context.Context, id uuid.UUID, name, country string) (_ *entity.Team, err error) {
+	ctx, span := startSpan(ctx, teamsTable, "Update")
+	defer func() { tracing.End(span, err) }()
+
 	record := goqu.Record{"updated_at": time.Now()}
 
 	if name != "" {
@@ -163,9 +228,34 @@ func (r *Team) Update(ctx context.Context, id uuid.UUID, name, country string) (
 		return nil, apperr.SQLError("Update", err)
 	}
 
+	current, currentArgs, err := r.builder.
+		Select(goqu.C("name"), goqu.C("country")).
+		Where(goqu.C("id").Eq(id)).
+		ForUpdate(exp.Wait).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Update", err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperr.SQLError("Update", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var oldName, oldCountry string
+
+	if err := tx.QueryRow(ctx, current, currentArgs...).Scan(&oldName, &oldCountry); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrTeamNotFound
+		}
+
+		return nil, apperr.SQLQueryError("Update", err)
+	}
+
 	var team entity.Team
 
-	err = r.db.QueryRow(ctx, sql, args...).Scan(
+	err = tx.QueryRow(ctx, sql, args...).Scan(
 		&team.ID,
 		&team.UserID,
 		&team.Name,
@@ -176,33 +266,70 @@ func (r *Team) Update(ctx context.Context, id uuid.UUID, name, country string) (
 		&team.UpdatedAt,
 	)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, apperr.ErrTeamNotFound
+		return nil, apperr.SQLQueryError("Update", err)
+	}
+
+	var events []entity.Event
+
+	if team.Name != oldName {
+		event, err := entity.NewEvent(entity.EventTeamRenamed, team.ID, entity.TeamRenamed{
+			TeamID:  team.ID,
+			UserID:  team.UserID,
+			OldName: oldName,
+			NewName: team.Name,
+		})
+		if err != nil {
+			return nil, apperr.SQLError("Update", err)
 		}
 
-		return nil, apperr.SQLQueryError("Update", err)
+		events = append(events, event)
+	}
+
+	if team.Name != oldName || team.Country != oldCountry {
+		event, err := entity.NewEvent(entity.EventTeamUpdated, team.ID, entity.TeamUpdated{
+			TeamID:  team.ID,
+			UserID:  team.UserID,
+			Name:    team.Name,
+			Country: team.Country,
+		})
+		if err != nil {
+			return nil, apperr.SQLError("Update", err)
+		}
+
+		events = append(events, event)
+	}
+
+	if err := insertOutboxEvents(ctx, tx, "Update", events...); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperr.SQLExecError("Update", err)
 	}
 
 	return &team, nil
 }
 
-func (r *Team) UpdateBudget(ctx context.Context, id uuid.UUID, budget int64) error {
+func (r *Team) UpdateTotalValue(ctx context.Context, id uuid.UUID, totalValue int64) (err error) {
+	ctx, span := startSpan(ctx, teamsTable, "UpdateTotalValue")
+	defer func() { tracing.End(span, err) }()
+
 	query := r.builder.
 		Update().
 		Set(goqu.Record{
-			"budget":     budget,
-			"updated_at": time.Now(),
+			"total_value": totalValue,
+			"updated_at":  time.Now(),
 		}).
 		Where(goqu.C("id").Eq(id))
 
 	sql, args, err := query.ToSQL()
 	if err != nil {
-		return apperr.SQLError("UpdateBudget", err)
+		return apperr.SQLError("UpdateTotalValue", err)
 	}
 
 	result, err := r.db.Exec(ctx, sql, args...)
 	if err != nil {
-		return apperr.SQLExecError("UpdateBudget", err)
+		return apperr.SQLExecError("UpdateTotalValue", err)
 	}
 
 	if result.RowsAffected() == 0 {
@@ -212,28 +339,121 @@ func (r *Team) UpdateBudget(ctx context.Context, id uuid.UUID, budget int64) err
 	return nil
 }
 
-func (r *Team) UpdateTotalValue(ctx context.Context, id uuid.UUID, totalValue int64) error {
+// List returns teams ordered by creation date, newest first. A non-empty
+// search filters by a case-insensitive substring of the name or country.
+func (r *Team) List(ctx context.Context, search string, limit, offset uint) (_ []entity.Team, err error) {
+	ctx, span := startSpan(ctx, teamsTable, "List")
+	defer func() { tracing.End(span, err) }()
+
 	query := r.builder.
-		Update().
-		Set(goqu.Record{
-			"total_value": totalValue,
-			"updated_at":  time.Now(),
-		}).
-		Where(goqu.C("id").Eq(id))
+		Select(goqu.Star()).
+		Order(goqu.C("created_at").Desc(), goqu.C("id").Asc()).
+		Limit(limit).
+		Offset(offset)
+
+	if search != "" {
+		pattern := "%" + escapeLike(search) + "%"
+		query = query.Where(goqu.Or(
+			goqu.C("name").ILike(pattern),
+			goqu.C("country").ILike(pattern),
+		))
+	}
 
 	sql, args, err := query.ToSQL()
 	if err != nil {
-		return apperr.SQLError("UpdateTotalValue", err)
+		return nil, apperr.SQLError("List", err)
 	}
 
-	result, err := r.db.Exec(ctx, sql, args...)
+	rows, err := r.db.Query(ctx, sql, args...)
 	if err != nil {
-		return apperr.SQLExecError("UpdateTotalValue", err)
+		return nil, apperr.SQLQueryError("List", err)
 	}
+	defer rows.Close()
+
+	teams := make([]entity.Team, 0)
+
+	for rows.Next() {
+		var team entity.Team
+
+		err := rows.Scan(
+			&team.ID,
+			&team.UserID,
+			&team.Name,
+			&team.Country,
+			&team.Budget,
+			&team.TotalValue,
+			&team.CreatedAt,
+			&team.UpdatedAt,
+		)
+		if err != nil {
+			return nil, apperr.SQLQueryError("List", err)
+		}
 
-	if result.RowsAffected() == 0 {
-		return apperr.ErrTeamNotFound
+		teams = append(teams, team)
 	}
 
-	return nil
+	if err := rows.Err(); err != nil {
+		return nil, apperr.SQLQueryError("List", err)
+	}
+
+	return teams, nil
+}
+
+// Search returns the teams matching filter ordered by name.
+func (r *Team) Search(ctx context.Context, filter entity.TeamFilter, limit, offset uint) (_ []entity.Team, err error) {
+	ctx, span := startSpan(ctx, teamsTable, "Search")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.builder.
+		Select(goqu.Star()).
+		Order(goqu.C("name").Asc(), goqu.C("id").Asc()).
+		Limit(limit).
+		Offset(offset)
+
+	if filter.Name != "" {
+		query = query.Where(goqu.C("name").ILike("%" + escapeLike(filter.Name) + "%"))
+	}
+
+	if filter.Country != "" {
+		query = query.Where(goqu.C("country").ILike("%" + escapeLike(filter.Country) + "%"))
+	}
+
+	sql, args, err := query.ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Search", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, apperr.SQLQueryError("Search", err)
+	}
+	defer rows.Close()
+
+	teams := make([]entity.Team, 0)
+
+	for rows.Next() {
+		var team entity.Team
+
+		err := rows.Scan(
+			&team.ID,
+			&team.UserID,
+			&team.Name,
+			&team.Country,
+			&team.Budget,
+			&team.TotalValue,
+			&team.CreatedAt,
+			&team.UpdatedAt,
+		)
+		if err != nil {
+			return nil, apperr.SQLQueryError("Search", err)
+		}
+
+		teams = append(teams, team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.SQLQueryError("Search", err)
+	}
+
+	return teams, nil
 }
@@ -0,0 +1,24 @@
+package postgresrepo
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("soccer_manager_service/internal/repository/postgresrepo")
+
+// startSpan opens a client span for a single query. op matches the operation
+// name passed to apperr.SQLError and friends so traces and errors line up.
+func startSpan(ctx context.Context, table, op string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, postgresdb+"."+table+"."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBCollectionName(table),
+			semconv.DBOperationName(op),
+		),
+	)
+}
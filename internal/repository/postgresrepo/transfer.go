@@ -5,6 +5,7 @@ import (
 	"errors"
 	"soccer_manager_service/internal/entity"
 	"soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/tracing"
 	"time"
 
 	"github.com/doug-martin/goqu/v9"
@@ -33,7 +34,10 @@ func NewTransferRepository(params TransferParams) *Transfer {
 	}
 }
 
-func (r *Transfer) Create(ctx context.Context, playerID, sellerID uuid.UUID, askingPrice int64) (*entity.Transfer, error) {
+func (r *Transfer) Create(ctx context.Context, playerID, sellerID uuid.UUID, askingPrice int64) (_ *entity.Transfer, err error) {
+	ctx, span := startSpan(ctx, transfersTable, "Create")
+	defer func() { tracing.End(span, err) }()
+
 	query := r.builder.
 		Insert().
 		Rows(goqu.Record{
@@ -49,9 +53,41 @@ func (r *Transfer) Create(ctx context.Context, playerID, sellerID uuid.UUID, ask
 		return nil, apperr.SQLError("Create", err)
 	}
 
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperr.SQLError("Create", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
 	var transfer entity.Transfer
 
-	err = r.db.QueryRow(ctx, sql, args...).Scan(
+	if err := scanTransfer(tx.QueryRow(ctx, sql, args...), &transfer); err != nil {
+		return nil, apperr.SQLQueryError("Create", err)
+	}
+
+	event, err := entity.NewEvent(entity.EventPlayerListed, transfer.ID, entity.PlayerListed{
+		TransferID:  transfer.ID,
+		PlayerID:    playerID,
+		SellerID:    sellerID,
+		AskingPrice: askingPrice,
+	})
+	if err != nil {
+		return nil, apperr.SQLError("Create", err)
+	}
+
+	if err := insertOutboxEvents(ctx, tx, "Create", event); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperr.SQLExecError("Create", err)
+	}
+
+	return &transfer, nil
+}
+
+func scanTransfer(row pgx.Row, transfer *entity.Transfer) error {
+	return row.Scan(
 		&transfer.ID,
 		&transfer.PlayerID,
 		&transfer.SellerID,
@@ -61,14 +97,12 @@ func (r *Transfer) Create(ctx context.Context, playerID, sellerID uuid.UUID, ask
 		&transfer.CreatedAt,
 		&transfer.CompletedAt,
 	)
-	if err != nil {
-		return nil, apperr.SQLQueryError("Create", err)
-	}
-
-	return &transfer, nil
 }
 
-func (r *Transfer) GetByID(ctx context.Context, id uuid.UUID) (*entity.Transfer, error) {
+func (r *Transfer) GetByID(ctx context.Context, id uuid.UUID) (_ *entity.Transfer, err error) {
+	ctx, span := startSpan(ctx, transfersTable, "GetByID")
+	defer func() { tracing.End(span, err) }()
+
 	query := r.builder.
 		Select(goqu.Star()).
 		Where(goqu.C("id").Eq(id))
@@ -80,16 +114,7 @@ func (r *Transfer) GetByID(ctx context.Context, id uuid.UUID) (*entity.Transfer,
 
 	var transfer entity.Transfer
 
-	err = r.db.QueryRow(ctx, sql, args...).Scan(
-		&transfer.ID,
-		&transfer.PlayerID,
-		&transfer.SellerID,
-		&transfer.BuyerID,
-		&transfer.AskingPrice,
-		&transfer.Status,
-		&transfer.CreatedAt,
-		&transfer.CompletedAt,
-	)
+	err = scanTransfer(r.db.QueryRow(ctx, sql, args...), &transfer)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperr.ErrTransferNotFound
@@ -101,7 +126,10 @@ func (r *Transfer) GetByID(ctx context.Context, id uuid.UUID) (*entity.Transfer,
 	return &transfer, nil
 }
 
-func (r *Transfer) GetActiveTransfers(ctx context.Context) ([]entity.Transfer, error) {
+func (r *Transfer) GetActiveTransfers(ctx context.Context) (_ []entity.Transfer, err error) {
+	ctx, span := startSpan(ctx, transfersTable, "GetActiveTransfers")
+	defer func() { tracing.End(span, err) }()
+
 	query := r.builder.
 		Select(goqu.Star()).
 		Where(goqu.C("status").Eq(entity.TransferStatusActive)).
@@ -123,17 +151,7 @@ func (r *Transfer) GetActiveTransfers(ctx context.Context) ([]entity.Transfer, e
 	for rows.Next() {
 		var transfer entity.Transfer
 
-		err := rows.Scan(
-			&transfer.ID,
-			&transfer.PlayerID,
-			&transfer.SellerID,
-			&transfer.BuyerID,
-			&transfer.AskingPrice,
-			&transfer.Status,
-			&transfer.CreatedAt,
-			&transfer.CompletedAt,
-		)
-		if err != nil {
+		if err := scanTransfer(rows, &transfer); err != nil {
 			return nil, apperr.SQLQueryError("GetActiveTransfers", err)
 		}
 
@@ -143,36 +161,112 @@ func (r *Transfer) GetActiveTransfers(ctx context.Context) ([]entity.Transfer, e
 	return transfers, nil
 }
 
-func (r *Transfer) Complete(ctx context.Context, id, buyerID uuid.UUID) error {
+// Complete records a purchase in a single transaction: it completes the
+// transfer, posts the payment, moves the player and records PlayerSold. Only
+// one completion of a listing can succeed; the others get
+// ErrTransferNotActive and change nothing, so losing buyers are not charged.
+func (r *Transfer) Complete(ctx context.Context, purchase entity.TransferPurchase) (err error) {
+	ctx, span := startSpan(ctx, transfersTable, "Complete")
+	defer func() { tracing.End(span, err) }()
+
+	if !purchase.Payment.IsBalanced() {
+		return apperr.SQLError("Complete", errUnbalancedTransaction)
+	}
+
 	now := time.Now()
 
 	query := r.builder.
 		Update().
 		Set(goqu.Record{
-			"buyer_id":     buyerID,
+			"buyer_id":     purchase.BuyerID,
 			"status":       entity.TransferStatusCompleted,
 			"completed_at": now,
 		}).
-		Where(goqu.C("id").Eq(id))
+		Where(
+			goqu.C("id").Eq(purchase.TransferID),
+			goqu.C("status").Eq(entity.TransferStatusActive),
+		).
+		Returning(goqu.Star())
 
 	sql, args, err := query.ToSQL()
 	if err != nil {
 		return apperr.SQLError("Complete", err)
 	}
 
-	result, err := r.db.Exec(ctx, sql, args...)
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return apperr.SQLExecError("Complete", err)
+		return apperr.SQLError("Complete", err)
 	}
+	defer func() { _ = tx.Rollback(ctx) }()
 
-	if result.RowsAffected() == 0 {
-		return apperr.ErrTransferNotFound
+	var transfer entity.Transfer
+
+	if err := scanTransfer(tx.QueryRow(ctx, sql, args...), &transfer); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return r.notActive(ctx, tx, "Complete", purchase.TransferID)
+		}
+
+		return apperr.SQLQueryError("Complete", err)
+	}
+
+	if err := postLedgerTransaction(ctx, tx, purchase.Payment); err != nil {
+		return err
+	}
+
+	if err := movePlayer(ctx, tx, purchase.PlayerID, purchase.BuyerID, purchase.NewMarketValue); err != nil {
+		return err
+	}
+
+	sold := entity.PlayerSold{TransferID: transfer.ID, BuyerID: purchase.BuyerID, Price: transfer.AskingPrice}
+	if transfer.PlayerID != nil {
+		sold.PlayerID = *transfer.PlayerID
+	}
+
+	if transfer.SellerID != nil {
+		sold.SellerID = *transfer.SellerID
+	}
+
+	event, err := entity.NewEvent(entity.EventPlayerSold, transfer.ID, sold)
+	if err != nil {
+		return apperr.SQLError("Complete", err)
+	}
+
+	if err := insertOutboxEvents(ctx, tx, "Complete", event); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperr.SQLExecError("Complete", err)
 	}
 
 	return nil
 }
 
-func (r *Transfer) Cancel(ctx context.Context, id uuid.UUID) error {
+// notActive tells a transfer that is no longer active, ErrTransferNotActive,
+// from one that does not exist.
+func (r *Transfer) notActive(ctx context.Context, tx pgx.Tx, op string, id uuid.UUID) error {
+	sql, args, err := r.builder.Select(goqu.L("1")).Where(goqu.C("id").Eq(id)).ToSQL()
+	if err != nil {
+		return apperr.SQLError(op, err)
+	}
+
+	var exists int
+
+	if err := tx.QueryRow(ctx, sql, args...).Scan(&exists); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperr.ErrTransferNotFound
+		}
+
+		return apperr.SQLQueryError(op, err)
+	}
+
+	return apperr.ErrTransferNotActive
+}
+
+func (r *Transfer) Cancel(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, transfersTable, "Cancel")
+	defer func() { tracing.End(span, err) }()
+
 	query := r.builder.
 		Update().
 		Set(goqu.Record{
@@ -197,7 +291,10 @@ func (r *Transfer) Cancel(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *Transfer) GetByPlayerID(ctx context.Context, playerID uuid.UUID) (*entity.Transfer, error) {
+func (r *Transfer) GetByPlayerID(ctx context.Context, playerID uuid.UUID) (_ *entity.Transfer, err error) {
+	ctx, span := startSpan(ctx, transfersTable, "GetByPlayerID")
+	defer func() { tracing.End(span, err) }()
+
 	query := r.builder.
 		Select(goqu.Star()).
 		Where(
@@ -212,16 +309,7 @@ func (r *Transfer) GetByPlayerID(ctx context.Context, playerID uuid.UUID) (*enti
 
 	var transfer entity.Transfer
 
-	err = r.db.QueryRow(ctx, sql, args...).Scan(
-		&transfer.ID,
-		&transfer.PlayerID,
-		&transfer.SellerID,
-		&transfer.BuyerID,
-		&transfer.AskingPrice,
-		&transfer.Status,
-		&transfer.CreatedAt,
-		&transfer.CompletedAt,
-	)
+	err = scanTransfer(r.db.QueryRow(ctx, sql, args...), &transfer)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperr.ErrTransferNotFound
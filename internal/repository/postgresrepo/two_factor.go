@@ -0,0 +1,305 @@
+package postgresrepo
+
+import (
+	"context"
+	"soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/tracing"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// TwoFactor stores the TOTP columns of users and their recovery codes. The
+// secret is stored as given, already encrypted by the caller; recovery codes
+// only as hashes.
+type TwoFactor struct {
+	logger  *zap.Logger
+	dialect goqu.DialectWrapper
+	db      *pgxpool.Pool
+}
+
+type TwoFactorParams struct {
+	Postgres *pgxpool.Pool
+	Logger   *zap.Logger
+}
+
+func NewTwoFactorRepository(params TwoFactorParams) *TwoFactor {
+	return &TwoFactor{
+		dialect: goqu.Dialect(postgresdb),
+		logger:  params.Logger.With(zap.String("layer", "TwoFactorRepository")),
+		db:      params.Postgres,
+	}
+}
+
+// SetPendingSecret starts an enrollment, replacing any earlier unconfirmed
+// secret. It fails with ErrTwoFactorAlreadyEnabled once 2FA is on.
+func (r *TwoFactor) SetPendingSecret(ctx context.Context, userID uuid.UUID, secret string) (err error) {
+	ctx, span := startSpan(ctx, usersTable, "SetPendingSecret")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.dialect.
+		Update(usersTable).
+		Set(goqu.Record{
+			"totp_secret":    secret,
+			"totp_last_step": nil,
+			"updated_at":     time.Now(),
+		}).
+		Where(
+			goqu.C("id").Eq(userID),
+			goqu.C("totp_enabled_at").IsNull(),
+		).
+		ToSQL()
+	if err != nil {
+		return apperr.SQLError("SetPendingSecret", err)
+	}
+
+	result, err := r.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return apperr.SQLExecError("SetPendingSecret", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperr.ErrTwoFactorAlreadyEnabled
+	}
+
+	return nil
+}
+
+// Enable confirms the pending secret, records step as used and stores the
+// first set of recovery codes, all in one transaction.
+func (r *TwoFactor) Enable(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) (err error) {
+	ctx, span := startSpan(ctx, usersTable, "Enable")
+	defer func() { tracing.End(span, err) }()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return apperr.SQLError("Enable", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	now := time.Now()
+
+	sql, args, err := r.dialect.
+		Update(usersTable).
+		Set(goqu.Record{
+			"totp_enabled_at": now,
+			"totp_last_step":  step,
+			"updated_at":      now,
+		}).
+		Where(
+			goqu.C("id").Eq(userID),
+			goqu.C("totp_secret").IsNotNull(),
+			goqu.C("totp_enabled_at").IsNull(),
+		).
+		ToSQL()
+	if err != nil {
+		return apperr.SQLError("Enable", err)
+	}
+
+	result, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return apperr.SQLExecError("Enable", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperr.ErrTwoFactorAlreadyEnabled
+	}
+
+	if err := r.replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperr.SQLExecError("Enable", err)
+	}
+
+	return nil
+}
+
+// Disable removes the secret and all recovery codes.
+func (r *TwoFactor) Disable(ctx context.Context, userID uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, usersTable, "Disable")
+	defer func() { tracing.End(span, err) }()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return apperr.SQLError("Disable", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	sql, args, err := r.dialect.
+		Update(usersTable).
+		Set(goqu.Record{
+			"totp_secret":     nil,
+			"totp_enabled_at": nil,
+			"totp_last_step":  nil,
+			"updated_at":      time.Now(),
+		}).
+		Where(goqu.C("id").Eq(userID)).
+		ToSQL()
+	if err != nil {
+		return apperr.SQLError("Disable", err)
+	}
+
+	result, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return apperr.SQLExecError("Disable", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperr.ErrUserNotFound
+	}
+
+	if err := r.replaceRecoveryCodes(ctx, tx, userID, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperr.SQLExecError("Disable", err)
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes invalidates all recovery codes of the user and stores
+// codeHashes instead.
+func (r *TwoFactor) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) (err error) {
+	ctx, span := startSpan(ctx, recoveryCodesTable, "ReplaceRecoveryCodes")
+	defer func() { tracing.End(span, err) }()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return apperr.SQLError("ReplaceRecoveryCodes", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := r.replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperr.SQLExecError("ReplaceRecoveryCodes", err)
+	}
+
+	return nil
+}
+
+// UseStep records step as the last accepted TOTP time step. It reports false
+// if step is not newer than the last one, i.e. the code was already used.
+func (r *TwoFactor) UseStep(ctx context.Context, userID uuid.UUID, step int64) (_ bool, err error) {
+	ctx, span := startSpan(ctx, usersTable, "UseStep")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.dialect.
+		Update(usersTable).
+		Set(goqu.Record{"totp_last_step": step}).
+		Where(
+			goqu.C("id").Eq(userID),
+			goqu.Or(
+				goqu.C("totp_last_step").IsNull(),
+				goqu.C("totp_last_step").Lt(step),
+			),
+		).
+		ToSQL()
+	if err != nil {
+		return false, apperr.SQLError("UseStep", err)
+	}
+
+	result, err := r.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, apperr.SQLExecError("UseStep", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// UseRecoveryCode marks an unused recovery code as used. It reports false if
+// the user has no unused code with that hash.
+func (r *TwoFactor) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (_ bool, err error) {
+	ctx, span := startSpan(ctx, recoveryCodesTable, "UseRecoveryCode")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.dialect.
+		Update(recoveryCodesTable).
+		Set(goqu.Record{"used_at": time.Now()}).
+		Where(
+			goqu.C("user_id").Eq(userID),
+			goqu.C("code_hash").Eq(codeHash),
+			goqu.C("used_at").IsNull(),
+		).
+		ToSQL()
+	if err != nil {
+		return false, apperr.SQLError("UseRecoveryCode", err)
+	}
+
+	result, err := r.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, apperr.SQLExecError("UseRecoveryCode", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// CountRecoveryCodes returns how many unused recovery codes the user has left.
+func (r *TwoFactor) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (_ int, err error) {
+	ctx, span := startSpan(ctx, recoveryCodesTable, "CountRecoveryCodes")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.dialect.
+		From(recoveryCodesTable).
+		Select(goqu.COUNT("*")).
+		Where(
+			goqu.C("user_id").Eq(userID),
+			goqu.C("used_at").IsNull(),
+		).
+		ToSQL()
+	if err != nil {
+		return 0, apperr.SQLError("CountRecoveryCodes", err)
+	}
+
+	var count int
+
+	if err := r.db.QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, apperr.SQLQueryError("CountRecoveryCodes", err)
+	}
+
+	return count, nil
+}
+
+func (r *TwoFactor) replaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID uuid.UUID, codeHashes []string) error {
+	sql, args, err := r.dialect.
+		Delete(recoveryCodesTable).
+		Where(goqu.C("user_id").Eq(userID)).
+		ToSQL()
+	if err != nil {
+		return apperr.SQLError("replaceRecoveryCodes", err)
+	}
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return apperr.SQLExecError("replaceRecoveryCodes", err)
+	}
+
+	if len(codeHashes) == 0 {
+		return nil
+	}
+
+	rows := make([]any, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		rows = append(rows, goqu.Record{"user_id": userID, "code_hash": hash})
+	}
+
+	sql, args, err = r.dialect.Insert(recoveryCodesTable).Rows(rows...).ToSQL()
+	if err != nil {
+		return apperr.SQLError("replaceRecoveryCodes", err)
+	}
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return apperr.SQLExecError("replaceRecoveryCodes", err)
+	}
+
+	return nil
+}
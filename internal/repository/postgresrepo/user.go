@@ -5,9 +5,12 @@ import (
 	"errors"
 	"soccer_manager_service/internal/entity"
 	"soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/tracing"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
 	_ "github.com/doug-martin/goqu/v9/dialect/postgres"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -15,6 +18,20 @@ import (
 	"go.uber.org/zap"
 )
 
+var userColumns = []any{
+	"id",
+	"email",
+	"password_hash",
+	"role",
+	"email_verified_at",
+	"banned_at",
+	"ban_reason",
+	"totp_secret",
+	"totp_enabled_at",
+	"created_at",
+	"updated_at",
+}
+
 type User struct {
 	logger  *zap.Logger
 	builder *goqu.SelectDataset
@@ -34,29 +51,48 @@ func NewUserRepository(params UserParams) *User {
 	}
 }
 
-func (r *User) Create(ctx context.Context, email, passwordHash string) (*entity.User, error) {
+func scanUser(row pgx.Row, user *entity.User) error {
+	return row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.PasswordHash,
+		&user.Role,
+		&user.EmailVerifiedAt,
+		&user.BannedAt,
+		&user.BanReason,
+		&user.TOTPSecret,
+		&user.TOTPEnabledAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+}
+
+func (r *User) Create(ctx context.Context, email, passwordHash string) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, usersTable, "Create")
+	defer func() { tracing.End(span, err) }()
+
 	query := r.builder.
 		Insert().
 		Rows(goqu.Record{
 			"email":         email,
 			"password_hash": passwordHash,
 		}).
-		Returning(goqu.Star())
+		Returning(userColumns...)
 
 	sql, args, err := query.ToSQL()
 	if err != nil {
 		return nil, apperr.SQLError("Create", err)
 	}
 
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperr.SQLError("Create", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
 	var user entity.User
 
-	err = r.db.QueryRow(ctx, sql, args...).Scan(
-		&user.ID,
-		&user.Email,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	err = scanUser(tx.QueryRow(ctx, sql, args...), &user)
 	if err != nil {
 		var pgErr *pgconn.PgError
 
@@ -67,12 +103,31 @@ func (r *User) Create(ctx context.Context, email, passwordHash string) (*entity.
 		return nil, apperr.SQLQueryError("Create", err)
 	}
 
+	event, err := entity.NewEvent(entity.EventUserRegistered, user.ID, entity.UserRegistered{
+		UserID: user.ID,
+		Email:  user.Email,
+	})
+	if err != nil {
+		return nil, apperr.SQLError("Create", err)
+	}
+
+	if err := insertOutboxEvents(ctx, tx, "Create", event); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperr.SQLExecError("Create", err)
+	}
+
 	return &user, nil
 }
 
-func (r *User) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+func (r *User) GetByID(ctx context.Context, id uuid.UUID) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, usersTable, "GetByID")
+	defer func() { tracing.End(span, err) }()
+
 	query := r.builder.
-		Select(goqu.Star()).
+		Select(userColumns...).
 		Where(goqu.C("id").Eq(id))
 
 	sql, args, err := query.ToSQL()
@@ -82,13 +137,7 @@ func (r *User) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
 
 	var user entity.User
 
-	err = r.db.QueryRow(ctx, sql, args...).Scan(
-		&user.ID,
-		&user.Email,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	err = scanUser(r.db.QueryRow(ctx, sql, args...), &user)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperr.ErrUserNotFound
@@ -100,9 +149,12 @@ func (r *User) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
 	return &user, nil
 }
 
-func (r *User) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+func (r *User) GetByEmail(ctx context.Context, email string) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, usersTable, "GetByEmail")
+	defer func() { tracing.End(span, err) }()
+
 	query := r.builder.
-		Select(goqu.Star()).
+		Select(userColumns...).
 		Where(goqu.C("email").Eq(email))
 
 	sql, args, err := query.ToSQL()
@@ -112,13 +164,7 @@ func (r *User) GetByEmail(ctx context.Context, email string) (*entity.User, erro
 
 	var user entity.User
 
-	err = r.db.QueryRow(ctx, sql, args...).Scan(
-		&user.ID,
-		&user.Email,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	err = scanUser(r.db.QueryRow(ctx, sql, args...), &user)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperr.ErrUserNotFound
@@ -129,3 +175,252 @@ func (r *User) GetByEmail(ctx context.Context, email string) (*entity.User, erro
 
 	return &user, nil
 }
+
+// List returns users ordered by creation date, newest first. A non-empty
+// search filters by a case-insensitive substring of the email.
+func (r *User) List(ctx context.Context, search string, limit, offset uint) (_ []entity.User, err error) {
+	ctx, span := startSpan(ctx, usersTable, "List")
+	defer func() { tracing.End(span, err) }()
+
+	query := r.builder.
+		Select(userColumns...).
+		Order(goqu.C("created_at").Desc(), goqu.C("id").Asc()).
+		Limit(limit).
+		Offset(offset)
+
+	if search != "" {
+		query = query.Where(goqu.C("email").ILike("%" + escapeLike(search) + "%"))
+	}
+
+	sql, args, err := query.ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("List", err)
+	}
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, apperr.SQLQueryError("List", err)
+	}
+	defer rows.Close()
+
+	users := make([]entity.User, 0)
+
+	for rows.Next() {
+		var user entity.User
+
+		if err := scanUser(rows, &user); err != nil {
+			return nil, apperr.SQLQueryError("List", err)
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.SQLQueryError("List", err)
+	}
+
+	return users, nil
+}
+
+func (r *User) UpdateRole(ctx context.Context, id uuid.UUID, role entity.UserRole) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, usersTable, "UpdateRole")
+	defer func() { tracing.End(span, err) }()
+
+	return r.update(ctx, "UpdateRole", id, goqu.Record{"role": role})
+}
+
+// SetBan bans the user when bannedAt is set and lifts the ban when it is nil.
+func (r *User) SetBan(ctx context.Context, id uuid.UUID, bannedAt *time.Time, reason *string) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, usersTable, "SetBan")
+	defer func() { tracing.End(span, err) }()
+
+	return r.update(ctx, "SetBan", id, goqu.Record{
+		"banned_at":  bannedAt,
+		"ban_reason": reason,
+	})
+}
+
+func (r *User) MarkEmailVerified(ctx context.Context, id uuid.UUID) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, usersTable, "MarkEmailVerified")
+	defer func() { tracing.End(span, err) }()
+
+	return r.update(ctx, "MarkEmailVerified", id, goqu.Record{"email_verified_at": time.Now()})
+}
+
+func (r *User) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, usersTable, "UpdatePassword")
+	defer func() { tracing.End(span, err) }()
+
+	return r.update(ctx, "UpdatePassword", id, goqu.Record{"password_hash": passwordHash})
+}
+
+// UpdateEmail changes the email of a user and marks it unverified.
+func (r *User) UpdateEmail(ctx context.Context, id uuid.UUID, email string) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, usersTable, "UpdateEmail")
+	defer func() { tracing.End(span, err) }()
+
+	return r.update(ctx, "UpdateEmail", id, goqu.Record{
+		"email":             email,
+		"email_verified_at": nil,
+	})
+}
+
+// Delete removes a user together with their teams and players in a single
+// transaction. Active listings of the teams are cancelled and their remaining
+// budgets are posted to the closed accounts ledger account first; completed
+// transfers stay, with the deleted teams and players set to NULL.
+func (r *User) Delete(ctx context.Context, id uuid.UUID) (_ *entity.AccountDeletion, err error) {
+	ctx, span := startSpan(ctx, usersTable, "Delete")
+	defer func() { tracing.End(span, err) }()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperr.SQLError("Delete", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	deletion := &entity.AccountDeletion{UserID: id, CancelledTransfers: []uuid.UUID{}}
+
+	sql, args, err := goqu.Dialect(postgresdb).
+		From(teamsTable).
+		Select("id", "budget").
+		Where(goqu.C("user_id").Eq(id)).
+		ForUpdate(exp.Wait).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Delete", err)
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, apperr.SQLQueryError("Delete", err)
+	}
+
+	deletion.Teams, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.TeamClosure, error) {
+		var closure entity.TeamClosure
+
+		return closure, row.Scan(&closure.TeamID, &closure.ClosingBalance)
+	})
+	if err != nil {
+		return nil, apperr.SQLQueryError("Delete", err)
+	}
+
+	for _, closure := range deletion.Teams {
+		cancelled, err := cancelActiveTransfers(ctx, tx, closure.TeamID)
+		if err != nil {
+			return nil, err
+		}
+
+		deletion.CancelledTransfers = append(deletion.CancelledTransfers, cancelled...)
+
+		if closure.ClosingBalance == 0 {
+			continue
+		}
+
+		err = postLedgerTransaction(ctx, tx, entity.LedgerTransaction{
+			Kind:        entity.LedgerKindAccountClosure,
+			Description: "Account closed",
+			Postings: []entity.LedgerPosting{
+				entity.TeamPosting(closure.TeamID, -closure.ClosingBalance),
+				entity.SystemPosting(entity.LedgerAccountClosedAccounts, closure.ClosingBalance),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sql, args, err = goqu.Dialect(postgresdb).Delete(usersTable).Where(goqu.C("id").Eq(id)).ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Delete", err)
+	}
+
+	result, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return nil, apperr.SQLExecError("Delete", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, apperr.ErrUserNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperr.SQLExecError("Delete", err)
+	}
+
+	return deletion, nil
+}
+
+func cancelActiveTransfers(ctx context.Context, tx pgx.Tx, sellerID uuid.UUID) ([]uuid.UUID, error) {
+	sql, args, err := goqu.Dialect(postgresdb).
+		Update(transfersTable).
+		Set(goqu.Record{"status": entity.TransferStatusCancelled}).
+		Where(
+			goqu.C("seller_id").Eq(sellerID),
+			goqu.C("status").Eq(entity.TransferStatusActive),
+		).
+		Returning("id").
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Delete", err)
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, apperr.SQLQueryError("Delete", err)
+	}
+
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0)
+
+	for rows.Next() {
+		var id uuid.UUID
+
+		if err := rows.Scan(&id); err != nil {
+			return nil, apperr.SQLQueryError("Delete", err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.SQLQueryError("Delete", err)
+	}
+
+	return ids, nil
+}
+
+func (r *User) update(ctx context.Context, op string, id uuid.UUID, record goqu.Record) (*entity.User, error) {
+	record["updated_at"] = time.Now()
+
+	query := r.builder.
+		Update().
+		Set(record).
+		Where(goqu.C("id").Eq(id)).
+		Returning(userColumns...)
+
+	sql, args, err := query.ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError(op, err)
+	}
+
+	var user entity.User
+
+	err = scanUser(r.db.QueryRow(ctx, sql, args...), &user)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrUserNotFound
+		}
+
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, apperr.ErrUserAlreadyExists
+		}
+
+		return nil, apperr.SQLQueryError(op, err)
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,305 @@
+package postgresrepo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/tracing"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var webhookColumns = []any{
+	"id",
+	"user_id",
+	"url",
+	"secret",
+	"event_types",
+	"failures",
+	"disabled_at",
+	"created_at",
+	"updated_at",
+}
+
+type Webhook struct {
+	logger  *zap.Logger
+	builder *goqu.SelectDataset
+	db      *pgxpool.Pool
+}
+
+type WebhookParams struct {
+	Postgres *pgxpool.Pool
+	Logger   *zap.Logger
+}
+
+func NewWebhookRepository(params WebhookParams) *Webhook {
+	return &Webhook{
+		builder: goqu.Dialect(postgresdb).From(webhooksTable),
+		logger:  params.Logger.With(zap.String("layer", "WebhookRepository")),
+		db:      params.Postgres,
+	}
+}
+
+func scanWebhook(row pgx.Row, webhook *entity.Webhook) error {
+	return row.Scan(
+		&webhook.ID,
+		&webhook.UserID,
+		&webhook.URL,
+		&webhook.Secret,
+		&webhook.EventTypes,
+		&webhook.Failures,
+		&webhook.DisabledAt,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+}
+
+func (r *Webhook) Create(ctx context.Context, webhook entity.Webhook) (_ *entity.Webhook, err error) {
+	ctx, span := startSpan(ctx, webhooksTable, "Create")
+	defer func() { tracing.End(span, err) }()
+
+	eventTypes, err := json.Marshal(webhook.EventTypes)
+	if err != nil {
+		return nil, apperr.SQLError("Create", err)
+	}
+
+	sql, args, err := r.builder.
+		Insert().
+		Rows(goqu.Record{
+			"user_id":     webhook.UserID,
+			"url":         webhook.URL,
+			"secret":      webhook.Secret,
+			"event_types": string(eventTypes),
+		}).
+		Returning(webhookColumns...).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Create", err)
+	}
+
+	var created entity.Webhook
+
+	if err := scanWebhook(r.db.QueryRow(ctx, sql, args...), &created); err != nil {
+		return nil, apperr.SQLQueryError("Create", err)
+	}
+
+	return &created, nil
+}
+
+func (r *Webhook) GetByID(ctx context.Context, id uuid.UUID) (_ *entity.Webhook, err error) {
+	ctx, span := startSpan(ctx, webhooksTable, "GetByID")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.builder.
+		Select(webhookColumns...).
+		Where(goqu.C("id").Eq(id)).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("GetByID", err)
+	}
+
+	var webhook entity.Webhook
+
+	if err := scanWebhook(r.db.QueryRow(ctx, sql, args...), &webhook); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrWebhookNotFound
+		}
+
+		return nil, apperr.SQLQueryError("GetByID", err)
+	}
+
+	return &webhook, nil
+}
+
+// ListByUser returns the user's endpoints, newest first.
+func (r *Webhook) ListByUser(ctx context.Context, userID uuid.UUID) (_ []entity.Webhook, err error) {
+	ctx, span := startSpan(ctx, webhooksTable, "ListByUser")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.builder.
+		Select(webhookColumns...).
+		Where(goqu.C("user_id").Eq(userID)).
+		Order(goqu.C("created_at").Desc()).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("ListByUser", err)
+	}
+
+	return r.list(ctx, "ListByUser", sql, args)
+}
+
+func (r *Webhook) ListSubscribed(ctx context.Context, userIDs []uuid.UUID, eventType entity.EventType) (_ []entity.Webhook, err error) {
+	ctx, span := startSpan(ctx, webhooksTable, "ListSubscribed")
+	defer func() { tracing.End(span, err) }()
+
+	if len(userIDs) == 0 {
+		return []entity.Webhook{}, nil
+	}
+
+	subscribed, err := json.Marshal([]entity.EventType{eventType})
+	if err != nil {
+		return nil, apperr.SQLError("ListSubscribed", err)
+	}
+
+	sql, args, err := r.builder.
+		Select(webhookColumns...).
+		Where(
+			goqu.C("user_id").In(userIDs),
+			goqu.C("disabled_at").IsNull(),
+			goqu.L("event_types @> ?::jsonb", string(subscribed)),
+		).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("ListSubscribed", err)
+	}
+
+	return r.list(ctx, "ListSubscribed", sql, args)
+}
+
+func (r *Webhook) list(ctx context.Context, op, sql string, args []any) ([]entity.Webhook, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, apperr.SQLQueryError(op, err)
+	}
+	defer rows.Close()
+
+	webhooks := make([]entity.Webhook, 0)
+
+	for rows.Next() {
+		var webhook entity.Webhook
+
+		if err := scanWebhook(rows, &webhook); err != nil {
+			return nil, apperr.SQLQueryError(op, err)
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.SQLQueryError(op, err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *Webhook) Update(ctx context.Context, webhook entity.Webhook) (_ *entity.Webhook, err error) {
+	ctx, span := startSpan(ctx, webhooksTable, "Update")
+	defer func() { tracing.End(span, err) }()
+
+	eventTypes, err := json.Marshal(webhook.EventTypes)
+	if err != nil {
+		return nil, apperr.SQLError("Update", err)
+	}
+
+	sql, args, err := r.builder.
+		Update().
+		Set(goqu.Record{
+			"url":         webhook.URL,
+			"secret":      webhook.Secret,
+			"event_types": string(eventTypes),
+			"failures":    webhook.Failures,
+			"disabled_at": webhook.DisabledAt,
+			"updated_at":  time.Now(),
+		}).
+		Where(goqu.C("id").Eq(webhook.ID)).
+		Returning(webhookColumns...).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Update", err)
+	}
+
+	var updated entity.Webhook
+
+	if err := scanWebhook(r.db.QueryRow(ctx, sql, args...), &updated); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrWebhookNotFound
+		}
+
+		return nil, apperr.SQLQueryError("Update", err)
+	}
+
+	return &updated, nil
+}
+
+// Delete removes the endpoint together with its delivery log.
+func (r *Webhook) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, webhooksTable, "Delete")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.builder.Delete().Where(goqu.C("id").Eq(id)).ToSQL()
+	if err != nil {
+		return apperr.SQLError("Delete", err)
+	}
+
+	result, err := r.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return apperr.SQLExecError("Delete", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperr.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *Webhook) RecordSuccess(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, webhooksTable, "RecordSuccess")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.builder.
+		Update().
+		Set(goqu.Record{"failures": 0}).
+		Where(
+			goqu.C("id").Eq(id),
+			goqu.C("failures").Neq(0),
+		).
+		ToSQL()
+	if err != nil {
+		return apperr.SQLError("RecordSuccess", err)
+	}
+
+	if _, err := r.db.Exec(ctx, sql, args...); err != nil {
+		return apperr.SQLExecError("RecordSuccess", err)
+	}
+
+	return nil
+}
+
+func (r *Webhook) RecordFailure(ctx context.Context, id uuid.UUID, disableAfter int) (_ bool, err error) {
+	ctx, span := startSpan(ctx, webhooksTable, "RecordFailure")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.builder.
+		Update().
+		Set(goqu.Record{
+			"failures": goqu.L("failures + 1"),
+			"disabled_at": goqu.L(
+				"CASE WHEN disabled_at IS NULL AND failures + 1 >= ? THEN NOW() ELSE disabled_at END", disableAfter),
+		}).
+		Where(goqu.C("id").Eq(id)).
+		Returning("failures").
+		ToSQL()
+	if err != nil {
+		return false, apperr.SQLError("RecordFailure", err)
+	}
+
+	var failures int
+
+	if err := r.db.QueryRow(ctx, sql, args...).Scan(&failures); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, apperr.ErrWebhookNotFound
+		}
+
+		return false, apperr.SQLQueryError("RecordFailure", err)
+	}
+
+	return failures == disableAfter, nil
+}
@@ -0,0 +1,241 @@
+package postgresrepo
+
+import (
+	"context"
+	"soccer_manager_service/internal/entity"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/tracing"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var webhookDeliveryColumns = []any{
+	"id",
+	"webhook_id",
+	"event_id",
+	"event_type",
+	"payload",
+	"status",
+	"attempts",
+	"response_status",
+	"last_error",
+	"created_at",
+	"completed_at",
+}
+
+type WebhookDelivery struct {
+	logger  *zap.Logger
+	builder *goqu.SelectDataset
+	db      *pgxpool.Pool
+}
+
+type WebhookDeliveryParams struct {
+	Postgres *pgxpool.Pool
+	Logger   *zap.Logger
+}
+
+func NewWebhookDeliveryRepository(params WebhookDeliveryParams) *WebhookDelivery {
+	return &WebhookDelivery{
+		builder: goqu.Dialect(postgresdb).From(webhookDeliveriesTable),
+		logger:  params.Logger.With(zap.String("layer", "WebhookDeliveryRepository")),
+		db:      params.Postgres,
+	}
+}
+
+func scanWebhookDelivery(row pgx.Row, delivery *entity.WebhookDelivery) error {
+	return row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.ResponseStatus,
+		&delivery.LastError,
+		&delivery.CreatedAt,
+		&delivery.CompletedAt,
+	)
+}
+
+func (r *WebhookDelivery) Create(ctx context.Context, deliveries ...entity.WebhookDelivery) (err error) {
+	ctx, span := startSpan(ctx, webhookDeliveriesTable, "Create")
+	defer func() { tracing.End(span, err) }()
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	rows := make([]any, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		rows = append(rows, goqu.Record{
+			"id":              delivery.ID,
+			"webhook_id":      delivery.WebhookID,
+			"event_id":        delivery.EventID,
+			"event_type":      delivery.EventType,
+			"payload":         string(delivery.Payload),
+			"status":          delivery.Status,
+			"attempts":        delivery.Attempts,
+			"response_status": delivery.ResponseStatus,
+			"last_error":      delivery.LastError,
+			"completed_at":    delivery.CompletedAt,
+		})
+	}
+
+	sql, args, err := r.builder.
+		Insert().
+		Rows(rows...).
+		OnConflict(goqu.DoNothing()).
+		ToSQL()
+	if err != nil {
+		return apperr.SQLError("Create", err)
+	}
+
+	if _, err := r.db.Exec(ctx, sql, args...); err != nil {
+		return apperr.SQLExecError("Create", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookDelivery) Claim(ctx context.Context, limit uint, lease time.Duration) (_ []entity.WebhookDelivery, err error) {
+	ctx, span := startSpan(ctx, webhookDeliveriesTable, "Claim")
+	defer func() { tracing.End(span, err) }()
+
+	enabled := goqu.Dialect(postgresdb).
+		From(webhooksTable).
+		Select(goqu.C("id")).
+		Where(goqu.C("disabled_at").IsNull())
+
+	pending := r.builder.
+		Select(goqu.C("id")).
+		Where(
+			goqu.C("status").Eq(entity.WebhookDeliveryPending),
+			goqu.C("available_at").Lte(goqu.L("NOW()")),
+			goqu.C("webhook_id").In(enabled),
+		).
+		Order(goqu.C("created_at").Asc()).
+		Limit(limit).
+		ForUpdate(exp.SkipLocked)
+
+	sql, args, err := r.builder.
+		Update().
+		Set(goqu.Record{
+			"attempts":     goqu.L("attempts + 1"),
+			"available_at": goqu.L("NOW() + ? * INTERVAL '1 millisecond'", lease.Milliseconds()),
+		}).
+		Where(goqu.C("id").In(pending)).
+		Returning(webhookDeliveryColumns...).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("Claim", err)
+	}
+
+	return r.list(ctx, "Claim", sql, args)
+}
+
+func (r *WebhookDelivery) Complete(ctx context.Context, id uuid.UUID, status entity.WebhookDeliveryStatus, responseStatus *int, lastError *string) (err error) {
+	ctx, span := startSpan(ctx, webhookDeliveriesTable, "Complete")
+	defer func() { tracing.End(span, err) }()
+
+	return r.update(ctx, "Complete", id, goqu.Record{
+		"status":          status,
+		"response_status": responseStatus,
+		"last_error":      lastError,
+		"completed_at":    goqu.L("NOW()"),
+	})
+}
+
+func (r *WebhookDelivery) Retry(ctx context.Context, id uuid.UUID, delay time.Duration, responseStatus *int, lastError string) (err error) {
+	ctx, span := startSpan(ctx, webhookDeliveriesTable, "Retry")
+	defer func() { tracing.End(span, err) }()
+
+	return r.update(ctx, "Retry", id, goqu.Record{
+		"response_status": responseStatus,
+		"last_error":      lastError,
+		"available_at":    goqu.L("NOW() + ? * INTERVAL '1 millisecond'", delay.Milliseconds()),
+	})
+}
+
+func (r *WebhookDelivery) ListByWebhook(ctx context.Context, webhookID uuid.UUID, limit, offset uint) (_ []entity.WebhookDelivery, err error) {
+	ctx, span := startSpan(ctx, webhookDeliveriesTable, "ListByWebhook")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.builder.
+		Select(webhookDeliveryColumns...).
+		Where(goqu.C("webhook_id").Eq(webhookID)).
+		Order(goqu.C("created_at").Desc(), goqu.C("id").Desc()).
+		Limit(limit).
+		Offset(offset).
+		ToSQL()
+	if err != nil {
+		return nil, apperr.SQLError("ListByWebhook", err)
+	}
+
+	return r.list(ctx, "ListByWebhook", sql, args)
+}
+
+func (r *WebhookDelivery) Prune(ctx context.Context, completedBefore time.Time) (_ int64, err error) {
+	ctx, span := startSpan(ctx, webhookDeliveriesTable, "Prune")
+	defer func() { tracing.End(span, err) }()
+
+	sql, args, err := r.builder.
+		Delete().
+		Where(goqu.C("completed_at").Lt(completedBefore)).
+		ToSQL()
+	if err != nil {
+		return 0, apperr.SQLError("Prune", err)
+	}
+
+	result, err := r.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, apperr.SQLExecError("Prune", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *WebhookDelivery) list(ctx context.Context, op, sql string, args []any) ([]entity.WebhookDelivery, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, apperr.SQLQueryError(op, err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]entity.WebhookDelivery, 0)
+
+	for rows.Next() {
+		var delivery entity.WebhookDelivery
+
+		if err := scanWebhookDelivery(rows, &delivery); err != nil {
+			return nil, apperr.SQLQueryError(op, err)
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperr.SQLQueryError(op, err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookDelivery) update(ctx context.Context, op string, id uuid.UUID, record goqu.Record) error {
+	sql, args, err := r.builder.Update().Set(record).Where(goqu.C("id").Eq(id)).ToSQL()
+	if err != nil {
+		return apperr.SQLError(op, err)
+	}
+
+	if _, err := r.db.Exec(ctx, sql, args...); err != nil {
+		return apperr.SQLExecError(op, err)
+	}
+
+	return nil
+}
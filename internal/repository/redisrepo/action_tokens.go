@@ -0,0 +1,165 @@
+package redisrepo
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/pkg/tracing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const actionTokenBytes = 32
+
+// ActionToken stores single-use tokens for emailed links. Only a SHA-256 hash
+// of each token is kept, and issuing a new token for a user and purpose
+// revokes the previous one.
+type ActionToken struct {
+	client *redis.Client
+	logger *zap.Logger
+}
+
+type ActionTokenParams struct {
+	fx.In
+
+	Redis  *redis.Client
+	Logger *zap.Logger
+}
+
+func NewActionToken(params ActionTokenParams) *ActionToken {
+	return &ActionToken{
+		client: params.Redis,
+		logger: params.Logger.With(zap.String("repository", "ActionToken")),
+	}
+}
+
+func (r *ActionToken) Issue(ctx context.Context, token entity.ActionToken, ttl time.Duration) (raw string, err error) {
+	ctx, span := startSpan(ctx, "ActionToken", "Issue")
+	defer func() { tracing.End(span, err) }()
+
+	if token.UserID == uuid.Nil {
+		return "", errors.New("empty user_id")
+	}
+
+	buf := make([]byte, actionTokenBytes)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+
+	raw = base64.RawURLEncoding.EncodeToString(buf)
+	hash := hashActionToken(raw)
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", fmt.Errorf("marshal token: %w", err)
+	}
+
+	userKey := createActionTokenUserKey(token.Purpose, token.UserID)
+
+	previous, err := r.client.Get(ctx, userKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		r.logger.Error("failed to get previous token", zap.Error(err), zap.String("user_id", token.UserID.String()))
+
+		return "", fmt.Errorf("get previous token: %w", err)
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if previous != "" {
+			pipe.Del(ctx, createActionTokenKey(token.Purpose, previous))
+		}
+
+		pipe.Set(ctx, createActionTokenKey(token.Purpose, hash), data, ttl)
+		pipe.Set(ctx, userKey, hash, ttl)
+
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("failed to store token", zap.Error(err), zap.String("user_id", token.UserID.String()))
+
+		return "", fmt.Errorf("store token: %w", err)
+	}
+
+	return raw, nil
+}
+
+// Consume returns the token and deletes it, so it can be used only once. It
+// returns nil if the token does not exist or has expired.
+func (r *ActionToken) Consume(ctx context.Context, purpose entity.TokenPurpose, raw string) (token *entity.ActionToken, err error) {
+	ctx, span := startSpan(ctx, "ActionToken", "Consume")
+	defer func() { tracing.End(span, err) }()
+
+	if raw == "" {
+		return nil, nil
+	}
+
+	data, err := r.client.GetDel(ctx, createActionTokenKey(purpose, hashActionToken(raw))).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		r.logger.Error("failed to consume token", zap.Error(err))
+
+		return nil, fmt.Errorf("consume token: %w", err)
+	}
+
+	var actionToken entity.ActionToken
+
+	if err := json.Unmarshal([]byte(data), &actionToken); err != nil {
+		return nil, fmt.Errorf("unmarshal token: %w", err)
+	}
+
+	if err := r.client.Del(ctx, createActionTokenUserKey(purpose, actionToken.UserID)).Err(); err != nil {
+		r.logger.Warn("failed to delete token index", zap.Error(err), zap.String("user_id", actionToken.UserID.String()))
+	}
+
+	return &actionToken, nil
+}
+
+// Revoke deletes the outstanding token of a user for purpose, if any.
+func (r *ActionToken) Revoke(ctx context.Context, purpose entity.TokenPurpose, userID uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "ActionToken", "Revoke")
+	defer func() { tracing.End(span, err) }()
+
+	userKey := createActionTokenUserKey(purpose, userID)
+
+	hash, err := r.client.GetDel(ctx, userKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+
+		return fmt.Errorf("revoke token: %w", err)
+	}
+
+	if err := r.client.Del(ctx, createActionTokenKey(purpose, hash)).Err(); err != nil {
+		return fmt.Errorf("revoke token: %w", err)
+	}
+
+	return nil
+}
+
+func hashActionToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func createActionTokenKey(purpose entity.TokenPurpose, hash string) string {
+	return fmt.Sprintf("action_token:%s:%s", purpose, hash)
+}
+
+func createActionTokenUserKey(purpose entity.TokenPurpose, userID uuid.UUID) string {
+	return fmt.Sprintf("action_token:%s:user:%s", purpose, userID)
+}
@@ -0,0 +1,67 @@
+package redisrepo
+
+import (
+	"context"
+	"fmt"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/pkg/tracing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// EventStream is an event sink appending domain events to a Redis stream,
+// which consumers read with consumer groups. The stream is trimmed to about
+// MaxLen entries.
+type EventStream struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+	logger *zap.Logger
+}
+
+type EventStreamParams struct {
+	Redis  *redis.Client
+	Stream string
+	MaxLen int64
+	Logger *zap.Logger
+}
+
+func NewEventStream(params EventStreamParams) *EventStream {
+	return &EventStream{
+		client: params.Redis,
+		stream: params.Stream,
+		maxLen: params.MaxLen,
+		logger: params.Logger.With(zap.String("repository", "EventStream")),
+	}
+}
+
+func (r *EventStream) Name() string {
+	return "redis"
+}
+
+func (r *EventStream) Publish(ctx context.Context, event entity.Event) (err error) {
+	ctx, span := startSpan(ctx, "EventStream", "Publish")
+	defer func() { tracing.End(span, err) }()
+
+	err = r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: r.stream,
+		MaxLen: r.maxLen,
+		Approx: true,
+		Values: map[string]any{
+			"id":           event.ID.String(),
+			"type":         string(event.Type),
+			"aggregate_id": event.AggregateID.String(),
+			"payload":      string(event.Payload),
+			"created_at":   event.CreatedAt.UTC().Format(time.RFC3339Nano),
+		},
+	}).Err()
+	if err != nil {
+		r.logger.Error("failed to append event to stream", zap.Error(err), zap.String("event_id", event.ID.String()))
+
+		return fmt.Errorf("append event to stream: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package redisrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/pkg/tracing"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Leaderboard keeps each leaderboard in a sorted set of team or transfer IDs
+// scored by value, so ranks are read without touching Postgres.
+type Leaderboard struct {
+	client *redis.Client
+	logger *zap.Logger
+}
+
+type LeaderboardParams struct {
+	fx.In
+
+	Redis  *redis.Client
+	Logger *zap.Logger
+}
+
+func NewLeaderboard(params LeaderboardParams) *Leaderboard {
+	return &Leaderboard{
+		client: params.Redis,
+		logger: params.Logger.With(zap.String("repository", "Leaderboard")),
+	}
+}
+
+func (r *Leaderboard) Set(ctx context.Context, kind entity.LeaderboardKind, entries ...entity.LeaderboardEntry) (err error) {
+	ctx, span := startSpan(ctx, "Leaderboard", "Set")
+	defer func() { tracing.End(span, err) }()
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	if err := r.client.ZAdd(ctx, createLeaderboardKey(kind), leaderboardMembers(entries)...).Err(); err != nil {
+		r.logger.Error("failed to set leaderboard scores", zap.Error(err), zap.String("kind", string(kind)))
+
+		return fmt.Errorf("set leaderboard scores: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Leaderboard) Remove(ctx context.Context, kind entity.LeaderboardKind, ids ...uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "Leaderboard", "Remove")
+	defer func() { tracing.End(span, err) }()
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	members := make([]any, 0, len(ids))
+	for _, id := range ids {
+		members = append(members, id.String())
+	}
+
+	if err := r.client.ZRem(ctx, createLeaderboardKey(kind), members...).Err(); err != nil {
+		r.logger.Error("failed to remove leaderboard members", zap.Error(err), zap.String("kind", string(kind)))
+
+		return fmt.Errorf("remove leaderboard members: %w", err)
+	}
+
+	return nil
+}
+
+// Replace builds the new leaderboard under a temporary key and renames it in
+// one transaction, so readers never see a partial leaderboard.
+func (r *Leaderboard) Replace(ctx context.Context, kind entity.LeaderboardKind, entries []entity.LeaderboardEntry) (err error) {
+	ctx, span := startSpan(ctx, "Leaderboard", "Replace")
+	defer func() { tracing.End(span, err) }()
+
+	key := createLeaderboardKey(kind)
+	staging := key + ":rebuild"
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, staging)
+
+		if len(entries) == 0 {
+			pipe.Del(ctx, key)
+
+			return nil
+		}
+
+		pipe.ZAdd(ctx, staging, leaderboardMembers(entries)...)
+		pipe.Rename(ctx, staging, key)
+
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("failed to replace leaderboard", zap.Error(err), zap.String("kind", string(kind)))
+
+		return fmt.Errorf("replace leaderboard: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Leaderboard) Top(ctx context.Context, kind entity.LeaderboardKind, limit, offset uint) (_ []entity.LeaderboardEntry, err error) {
+	ctx, span := startSpan(ctx, "Leaderboard", "Top")
+	defer func() { tracing.End(span, err) }()
+
+	members, err := r.client.ZRevRangeWithScores(ctx, createLeaderboardKey(kind), int64(offset), int64(offset+limit)-1).Result()
+	if err != nil {
+		r.logger.Error("failed to read leaderboard", zap.Error(err), zap.String("kind", string(kind)))
+
+		return nil, fmt.Errorf("read leaderboard: %w", err)
+	}
+
+	entries := make([]entity.LeaderboardEntry, 0, len(members))
+
+	for i, member := range members {
+		id, err := uuid.Parse(fmt.Sprint(member.Member))
+		if err != nil {
+			r.logger.Warn("skipping invalid leaderboard member", zap.Any("member", member.Member))
+
+			continue
+		}
+
+		entries = append(entries, entity.LeaderboardEntry{
+			Rank:  int64(offset) + int64(i) + 1,
+			ID:    id,
+			Score: int64(member.Score),
+		})
+	}
+
+	return entries, nil
+}
+
+func (r *Leaderboard) Rank(ctx context.Context, kind entity.LeaderboardKind, id uuid.UUID) (_ *entity.LeaderboardEntry, err error) {
+	ctx, span := startSpan(ctx, "Leaderboard", "Rank")
+	defer func() { tracing.End(span, err) }()
+
+	key := createLeaderboardKey(kind)
+
+	var (
+		rank  *redis.IntCmd
+		score *redis.FloatCmd
+	)
+
+	_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		rank = pipe.ZRevRank(ctx, key, id.String())
+		score = pipe.ZScore(ctx, key, id.String())
+
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+
+	if err != nil {
+		r.logger.Error("failed to read leaderboard rank", zap.Error(err), zap.String("kind", string(kind)))
+
+		return nil, fmt.Errorf("read leaderboard rank: %w", err)
+	}
+
+	return &entity.LeaderboardEntry{
+		Rank:  rank.Val() + 1,
+		ID:    id,
+		Score: int64(score.Val()),
+	}, nil
+}
+
+func (r *Leaderboard) Count(ctx context.Context, kind entity.LeaderboardKind) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "Leaderboard", "Count")
+	defer func() { tracing.End(span, err) }()
+
+	count, err := r.client.ZCard(ctx, createLeaderboardKey(kind)).Result()
+	if err != nil {
+		r.logger.Error("failed to count leaderboard", zap.Error(err), zap.String("kind", string(kind)))
+
+		return 0, fmt.Errorf("count leaderboard: %w", err)
+	}
+
+	return count, nil
+}
+
+func leaderboardMembers(entries []entity.LeaderboardEntry) []redis.Z {
+	members := make([]redis.Z, 0, len(entries))
+	for _, entry := range entries {
+		members = append(members, redis.Z{Score: float64(entry.Score), Member: entry.ID.String()})
+	}
+
+	return members
+}
+
+func createLeaderboardKey(kind entity.LeaderboardKind) string {
+	return fmt.Sprintf("leaderboard:%s", kind)
+}
@@ -5,13 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"soccer_manager_service/internal/config"
-	"strconv"
+	"soccer_manager_service/pkg/tracing"
+	"strings"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
 
+// LoginAttempt throttles logins with two failure counters: one per client IP,
+// which catches password spraying across many emails, and one per (IP, email)
+// pair, so that failures from one address cannot lock a user out everywhere.
+// A counter that reaches its limit puts a lockout key next to it whose TTL
+// grows exponentially with every further failure.
 type LoginAttempt struct {
 	client *redis.Client
 	config *config.Config
@@ -34,74 +41,218 @@ func NewLoginAttempt(params LoginAttemptParams) *LoginAttempt {
 	}
 }
 
-func (r *LoginAttempt) Increment(ctx context.Context, email string) (count int, err error) {
-	if email == "" {
-		return 0, errors.New("empty email")
+func (r *LoginAttempt) LockedFor(ctx context.Context, ip, email string) (retryAfter time.Duration, err error) {
+	ctx, span := startSpan(ctx, "LoginAttempt", "LockedFor")
+	defer func() { tracing.End(span, err) }()
+
+	if err := validateLoginAttemptKey(ip, email); err != nil {
+		return 0, err
 	}
 
-	key := createLoginAttemptsKey(email)
+	email = normalizeLoginEmail(email)
 
 	pipe := r.client.Pipeline()
 
-	incrCmd := pipe.Incr(ctx, key)
-	pipe.Expire(ctx, key, r.config.Login.LoginAttemptTTL)
+	ipTTL := pipe.PTTL(ctx, createIPLockoutKey(ip))
+	pairTTL := pipe.PTTL(ctx, createIPEmailLockoutKey(ip, email))
 
 	if _, err := pipe.Exec(ctx); err != nil {
-		r.logger.Error("failed to increment login attempts", zap.Error(err), zap.String("email", email))
+		r.logger.Error("failed to get login lockout", zap.Error(err), zap.String("ip", ip), zap.String("email", email))
 
-		return 0, fmt.Errorf("increment login attempts: %w", err)
+		return 0, fmt.Errorf("get login lockout: %w", err)
 	}
 
-	count64, err := incrCmd.Result()
-	if err != nil {
-		return 0, fmt.Errorf("get incr result: %w", err)
+	// PTTL reports a missing key as a negative duration.
+	return max(ipTTL.Val(), pairTTL.Val(), 0), nil
+}
+
+func (r *LoginAttempt) RecordFailure(ctx context.Context, ip, email string) (lockout time.Duration, err error) {
+	ctx, span := startSpan(ctx, "LoginAttempt", "RecordFailure")
+	defer func() { tracing.End(span, err) }()
+
+	if err := validateLoginAttemptKey(ip, email); err != nil {
+		return 0, err
+	}
+
+	email = normalizeLoginEmail(email)
+	window := r.config.Login.LoginAttemptTTL
+	ipKey := createIPAttemptsKey(ip)
+	pairKey := createIPEmailAttemptsKey(ip, email)
+	ipsKey := createEmailIPsKey(email)
+
+	pipe := r.client.TxPipeline()
+
+	ipCount := pipe.Incr(ctx, ipKey)
+	pipe.Expire(ctx, ipKey, window)
+	pairCount := pipe.Incr(ctx, pairKey)
+	pipe.Expire(ctx, pairKey, window)
+	pipe.SAdd(ctx, ipsKey, ip)
+	pipe.Expire(ctx, ipsKey, window+r.config.Login.LockoutMax)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.logger.Error("failed to record login failure", zap.Error(err), zap.String("ip", ip), zap.String("email", email))
+
+		return 0, fmt.Errorf("record login failure: %w", err)
+	}
+
+	ipLockout := r.lockout(ipCount.Val(), r.config.Login.MaxIPLoginAttempts)
+	pairLockout := r.lockout(pairCount.Val(), r.config.Login.MaxLoginAttempts)
+
+	if ipLockout == 0 && pairLockout == 0 {
+		return 0, nil
+	}
+
+	// Counters outlive their lockout so that the next failure after it
+	// expires escalates the backoff instead of starting over.
+	pipe = r.client.TxPipeline()
+
+	if ipLockout > 0 {
+		pipe.Set(ctx, createIPLockoutKey(ip), 1, ipLockout)
+		pipe.Expire(ctx, ipKey, window+ipLockout)
+	}
+
+	if pairLockout > 0 {
+		pipe.Set(ctx, createIPEmailLockoutKey(ip, email), 1, pairLockout)
+		pipe.Expire(ctx, pairKey, window+pairLockout)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.logger.Error("failed to lock out login", zap.Error(err), zap.String("ip", ip), zap.String("email", email))
+
+		return 0, fmt.Errorf("lock out login: %w", err)
+	}
+
+	return max(ipLockout, pairLockout), nil
+}
+
+// Reset clears the (IP, email) counter after a successful login. The IP
+// counter is left alone: one valid account must not wipe out the failures a
+// sprayer has accumulated against others.
+func (r *LoginAttempt) Reset(ctx context.Context, ip, email string) (err error) {
+	ctx, span := startSpan(ctx, "LoginAttempt", "Reset")
+	defer func() { tracing.End(span, err) }()
+
+	if err := validateLoginAttemptKey(ip, email); err != nil {
+		return err
 	}
 
-	return int(count64), nil
+	email = normalizeLoginEmail(email)
+
+	if err := r.client.Del(ctx, createIPEmailAttemptsKey(ip, email), createIPEmailLockoutKey(ip, email)).Err(); err != nil {
+		r.logger.Error("failed to reset login attempts", zap.Error(err), zap.String("ip", ip), zap.String("email", email))
+
+		return fmt.Errorf("reset login attempts: %w", err)
+	}
+
+	return nil
 }
 
-func (r *LoginAttempt) Get(ctx context.Context, email string) (attempts int, err error) {
+// Unlock clears the counters and lockouts of email from every IP it failed
+// to log in from.
+func (r *LoginAttempt) Unlock(ctx context.Context, email string) (err error) {
+	ctx, span := startSpan(ctx, "LoginAttempt", "Unlock")
+	defer func() { tracing.End(span, err) }()
+
 	if email == "" {
-		return 0, errors.New("empty email")
+		return errors.New("empty email")
 	}
 
-	key := createLoginAttemptsKey(email)
+	email = normalizeLoginEmail(email)
+	ipsKey := createEmailIPsKey(email)
 
-	val, err := r.client.Get(ctx, key).Result()
+	ips, err := r.client.SMembers(ctx, ipsKey).Result()
 	if err != nil {
-		if errors.Is(err, redis.Nil) {
-			return 0, nil
-		}
+		r.logger.Error("failed to get login attempt ips", zap.Error(err), zap.String("email", email))
 
-		r.logger.Error("failed to get login attempts", zap.Error(err), zap.String("email", email))
+		return fmt.Errorf("get login attempt ips: %w", err)
+	}
 
-		return 0, fmt.Errorf("get login attempts: %w", err)
+	keys := make([]string, 0, 2*len(ips)+1)
+	keys = append(keys, ipsKey)
+
+	for _, ip := range ips {
+		keys = append(keys, createIPEmailAttemptsKey(ip, email), createIPEmailLockoutKey(ip, email))
 	}
 
-	attempts, err = strconv.Atoi(val)
-	if err != nil {
-		return 0, fmt.Errorf("parse attempts: %w", err)
+	if err := r.client.Del(ctx, keys...).Err(); err != nil {
+		r.logger.Error("failed to unlock login", zap.Error(err), zap.String("email", email))
+
+		return fmt.Errorf("unlock login: %w", err)
 	}
 
-	return attempts, nil
+	return nil
 }
 
-func (r *LoginAttempt) Reset(ctx context.Context, email string) (err error) {
-	if email == "" {
-		return errors.New("empty email")
+// UnlockIP clears the per-IP counter and lockout of ip. Lockouts of single
+// emails from that IP stay in place.
+func (r *LoginAttempt) UnlockIP(ctx context.Context, ip string) (err error) {
+	ctx, span := startSpan(ctx, "LoginAttempt", "UnlockIP")
+	defer func() { tracing.End(span, err) }()
+
+	if ip == "" {
+		return errors.New("empty ip")
 	}
 
-	key := createLoginAttemptsKey(email)
+	if err := r.client.Del(ctx, createIPAttemptsKey(ip), createIPLockoutKey(ip)).Err(); err != nil {
+		r.logger.Error("failed to unlock login ip", zap.Error(err), zap.String("ip", ip))
 
-	if err := r.client.Del(ctx, key).Err(); err != nil {
-		r.logger.Error("failed to reset login attempts", zap.Error(err), zap.String("email", email))
+		return fmt.Errorf("unlock login ip: %w", err)
+	}
 
-		return fmt.Errorf("reset login attempts: %w", err)
+	return nil
+}
+
+// lockout returns how long to lock a key out after its count-th failure:
+// nothing below limit, LockoutBase on reaching it, then doubling with every
+// further failure up to LockoutMax.
+func (r *LoginAttempt) lockout(count int64, limit int) time.Duration {
+	over := count - int64(limit)
+	if over < 0 {
+		return 0
+	}
+
+	base, maxLockout := r.config.Login.LockoutBase, r.config.Login.LockoutMax
+
+	lockout := base
+	for i := int64(0); i < over && lockout < maxLockout; i++ {
+		lockout *= 2
+	}
+
+	return min(lockout, maxLockout)
+}
+
+func validateLoginAttemptKey(ip, email string) error {
+	if ip == "" {
+		return errors.New("empty ip")
+	}
+
+	if email == "" {
+		return errors.New("empty email")
 	}
 
 	return nil
 }
 
-func createLoginAttemptsKey(email string) string {
-	return fmt.Sprintf("login_attempts:%s", email)
+func normalizeLoginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func createIPAttemptsKey(ip string) string {
+	return fmt.Sprintf("login_attempts:ip:%s", ip)
+}
+
+func createIPEmailAttemptsKey(ip, email string) string {
+	return fmt.Sprintf("login_attempts:ip_email:%s:%s", ip, email)
+}
+
+func createEmailIPsKey(email string) string {
+	return fmt.Sprintf("login_attempts:email_ips:%s", email)
+}
+
+func createIPLockoutKey(ip string) string {
+	return fmt.Sprintf("login_lockout:ip:%s", ip)
+}
+
+func createIPEmailLockoutKey(ip, email string) string {
+	return fmt.Sprintf("login_lockout:ip_email:%s:%s", ip, email)
 }
@@ -0,0 +1,109 @@
+package redisrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/pkg/tracing"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const marketEventsChannel = "market_events"
+
+// MarketEvents carries market events between API instances over a Redis
+// pub/sub channel. Pub/sub is fire and forget: an instance that is not
+// subscribed when an event is published never sees it.
+type MarketEvents struct {
+	client *redis.Client
+	logger *zap.Logger
+}
+
+type MarketEventsParams struct {
+	fx.In
+
+	Redis  *redis.Client
+	Logger *zap.Logger
+}
+
+func NewMarketEvents(params MarketEventsParams) *MarketEvents {
+	return &MarketEvents{
+		client: params.Redis,
+		logger: params.Logger.With(zap.String("repository", "MarketEvents")),
+	}
+}
+
+func (r *MarketEvents) Publish(ctx context.Context, event entity.MarketEvent) (err error) {
+	ctx, span := startSpan(ctx, "MarketEvents", "Publish")
+	defer func() { tracing.End(span, err) }()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		r.logger.Error("failed to marshal market event", zap.Error(err), zap.String("type", string(event.Type)))
+
+		return fmt.Errorf("marshal market event: %w", err)
+	}
+
+	if err := r.client.Publish(ctx, marketEventsChannel, data).Err(); err != nil {
+		r.logger.Error("failed to publish market event", zap.Error(err), zap.String("type", string(event.Type)))
+
+		return fmt.Errorf("publish market event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MarketEvents) Subscribe(ctx context.Context) (_ <-chan entity.MarketEvent, err error) {
+	spanCtx, span := startSpan(ctx, "MarketEvents", "Subscribe")
+	defer func() { tracing.End(span, err) }()
+
+	pubsub := r.client.Subscribe(ctx, marketEventsChannel)
+
+	// Receive waits for the subscription to be confirmed so that connection
+	// errors surface here rather than as a silent stream.
+	if _, err := pubsub.Receive(spanCtx); err != nil {
+		_ = pubsub.Close()
+
+		r.logger.Error("failed to subscribe to market events", zap.Error(err))
+
+		return nil, fmt.Errorf("subscribe to market events: %w", err)
+	}
+
+	events := make(chan entity.MarketEvent)
+
+	go func() {
+		defer close(events)
+		defer func() { _ = pubsub.Close() }()
+
+		messages := pubsub.Channel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var event entity.MarketEvent
+				if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+					r.logger.Warn("skipping invalid market event", zap.Error(err))
+
+					continue
+				}
+
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
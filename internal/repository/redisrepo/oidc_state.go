@@ -0,0 +1,88 @@
+package redisrepo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/pkg/tracing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// OIDCState keeps pending OpenID Connect logins, keyed by a hash of the state
+// parameter sent to the provider. Each state can be redeemed once.
+type OIDCState struct {
+	client *redis.Client
+	logger *zap.Logger
+}
+
+type OIDCStateParams struct {
+	fx.In
+
+	Redis  *redis.Client
+	Logger *zap.Logger
+}
+
+func NewOIDCState(params OIDCStateParams) *OIDCState {
+	return &OIDCState{
+		client: params.Redis,
+		logger: params.Logger.With(zap.String("repository", "OIDCState")),
+	}
+}
+
+func (r *OIDCState) Save(ctx context.Context, state string, login entity.OIDCLoginState, ttl time.Duration) (err error) {
+	ctx, span := startSpan(ctx, "OIDCState", "Save")
+	defer func() { tracing.End(span, err) }()
+
+	data, err := json.Marshal(login)
+	if err != nil {
+		return fmt.Errorf("marshal oidc state: %w", err)
+	}
+
+	if err := r.client.Set(ctx, createOIDCStateKey(state), data, ttl).Err(); err != nil {
+		r.logger.Error("failed to store oidc state", zap.Error(err), zap.String("provider", login.Provider))
+
+		return fmt.Errorf("store oidc state: %w", err)
+	}
+
+	return nil
+}
+
+// Consume returns the login stored under state and deletes it. It returns nil
+// if the state does not exist or has expired.
+func (r *OIDCState) Consume(ctx context.Context, state string) (_ *entity.OIDCLoginState, err error) {
+	ctx, span := startSpan(ctx, "OIDCState", "Consume")
+	defer func() { tracing.End(span, err) }()
+
+	if state == "" {
+		return nil, nil
+	}
+
+	data, err := r.client.GetDel(ctx, createOIDCStateKey(state)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		r.logger.Error("failed to consume oidc state", zap.Error(err))
+
+		return nil, fmt.Errorf("consume oidc state: %w", err)
+	}
+
+	var login entity.OIDCLoginState
+
+	if err := json.Unmarshal([]byte(data), &login); err != nil {
+		return nil, fmt.Errorf("unmarshal oidc state: %w", err)
+	}
+
+	return &login, nil
+}
+
+func createOIDCStateKey(state string) string {
+	return "oidc_state:" + hashActionToken(state)
+}
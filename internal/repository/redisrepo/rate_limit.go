@@ -0,0 +1,110 @@
+package redisrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/pkg/tracing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sony/gobreaker"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// BreakerName is the circuit breaker guarding Redis calls on the request
+// path; while it is open they fail fast with gobreaker.ErrOpenState.
+const BreakerName = "redis"
+
+// slidingWindowScript keeps one sorted-set member per admitted request,
+// scored by its time in milliseconds. It drops the members that left the
+// window, admits the request if fewer than limit remain and returns
+// {allowed, count, ms until the oldest member leaves}. The clock is Redis's
+// own so that all API instances agree on it.
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+local member = ARGV[3]
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+
+local count = redis.call('ZCARD', key)
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', key, now, member)
+	count = count + 1
+	allowed = 1
+end
+
+redis.call('PEXPIRE', key, window)
+
+local reset = window
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, count, reset}
+`)
+
+type RateLimit struct {
+	client  *redis.Client
+	breaker *gobreaker.CircuitBreaker
+	logger  *zap.Logger
+}
+
+type RateLimitParams struct {
+	fx.In
+
+	Redis   *redis.Client
+	Breaker *gobreaker.CircuitBreaker
+	Logger  *zap.Logger
+}
+
+func NewRateLimit(params RateLimitParams) *RateLimit {
+	return &RateLimit{
+		client:  params.Redis,
+		breaker: params.Breaker,
+		logger:  params.Logger.With(zap.String("repository", "RateLimit")),
+	}
+}
+
+func (r *RateLimit) Allow(ctx context.Context, key string, limit int, window time.Duration) (_ *entity.RateLimitResult, err error) {
+	ctx, span := startSpan(ctx, "RateLimit", "Allow")
+	defer func() { tracing.End(span, err) }()
+
+	res, err := r.breaker.Execute(func() (any, error) {
+		return slidingWindowScript.Run(ctx, r.client, []string{createRateLimitKey(key)},
+			window.Milliseconds(), limit, uuid.NewString()).Int64Slice()
+	})
+	if err != nil {
+		if !errors.Is(err, gobreaker.ErrOpenState) {
+			r.logger.Error("failed to check rate limit", zap.Error(err), zap.String("key", key))
+		}
+
+		return nil, fmt.Errorf("rate limit: %w", err)
+	}
+
+	values, ok := res.([]int64)
+	if !ok || len(values) != 3 {
+		return nil, fmt.Errorf("rate limit: unexpected script result %v", res)
+	}
+
+	return &entity.RateLimitResult{
+		Allowed:   values[0] == 1,
+		Limit:     limit,
+		Remaining: limit - int(values[1]),
+		Reset:     time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
+
+func createRateLimitKey(key string) string {
+	return fmt.Sprintf("rate_limit:%s", key)
+}
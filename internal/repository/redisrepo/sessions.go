@@ -0,0 +1,82 @@
+package redisrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/pkg/tracing"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Session records, per user, the moment all tokens issued before it stopped
+// being valid. The record outlives the longest-lived token and then expires.
+type Session struct {
+	client *redis.Client
+	config *config.Config
+	logger *zap.Logger
+}
+
+type SessionParams struct {
+	fx.In
+
+	Redis  *redis.Client
+	Config *config.Config
+	Logger *zap.Logger
+}
+
+func NewSession(params SessionParams) *Session {
+	return &Session{
+		client: params.Redis,
+		config: params.Config,
+		logger: params.Logger.With(zap.String("repository", "Session")),
+	}
+}
+
+func (r *Session) RevokeAll(ctx context.Context, userID uuid.UUID, before time.Time) (err error) {
+	ctx, span := startSpan(ctx, "Session", "RevokeAll")
+	defer func() { tracing.End(span, err) }()
+
+	key := createSessionsRevokedKey(userID)
+
+	if err := r.client.Set(ctx, key, before.Unix(), r.config.JWT.RefreshTokenTTL).Err(); err != nil {
+		r.logger.Error("failed to revoke sessions", zap.Error(err), zap.String("user_id", userID.String()))
+
+		return fmt.Errorf("revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Session) RevokedBefore(ctx context.Context, userID uuid.UUID) (_ *time.Time, err error) {
+	ctx, span := startSpan(ctx, "Session", "RevokedBefore")
+	defer func() { tracing.End(span, err) }()
+
+	val, err := r.client.Get(ctx, createSessionsRevokedKey(userID)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("get sessions revocation: %w", err)
+	}
+
+	unix, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse sessions revocation: %w", err)
+	}
+
+	revokedAt := time.Unix(unix, 0)
+
+	return &revokedAt, nil
+}
+
+func createSessionsRevokedKey(userID uuid.UUID) string {
+	return fmt.Sprintf("sessions_revoked:%s", userID)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"soccer_manager_service/internal/config"
 	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/pkg/tracing"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
@@ -36,51 +37,57 @@ func NewTeamCache(params TeamCacheParams) *TeamCache {
 	}
 }
 
-func (r *TeamCache) SetTeam(ctx context.Context, userID uuid.UUID, team *dto.TeamWithPlayersResponse) (err error) {
-	if userID == uuid.Nil {
-		return errors.New("empty user_id")
+func (r *TeamCache) SetTeam(ctx context.Context, teamID uuid.UUID, team *dto.TeamWithPlayersResponse) (err error) {
+	ctx, span := startSpan(ctx, "TeamCache", "SetTeam")
+	defer func() { tracing.End(span, err) }()
+
+	if teamID == uuid.Nil {
+		return errors.New("empty team_id")
 	}
 
 	if team == nil {
 		return errors.New("empty team")
 	}
 
-	key := createTeamCacheKey(userID)
+	key := createTeamCacheKey(teamID)
 
 	data, err := json.Marshal(team)
 	if err != nil {
-		r.logger.Error("failed to marshal team", zap.Error(err), zap.String("user_id", userID.String()))
+		r.logger.Error("failed to marshal team", zap.Error(err), zap.String("team_id", teamID.String()))
 
 		return fmt.Errorf("marshal team: %w", err)
 	}
 
 	if err := r.client.Set(ctx, key, data, r.config.Login.TeamCacheTTL).Err(); err != nil {
-		r.logger.Error("failed to cache team", zap.Error(err), zap.String("user_id", userID.String()))
+		r.logger.Error("failed to cache team", zap.Error(err), zap.String("team_id", teamID.String()))
 
 		return fmt.Errorf("cache team: %w", err)
 	}
 
-	r.logger.Debug("team cached successfully", zap.String("user_id", userID.String()))
+	r.logger.Debug("team cached successfully", zap.String("team_id", teamID.String()))
 
 	return nil
 }
 
-func (r *TeamCache) GetTeam(ctx context.Context, userID uuid.UUID) (team *dto.TeamWithPlayersResponse, err error) {
-	if userID == uuid.Nil {
-		return nil, errors.New("empty user_id")
+func (r *TeamCache) GetTeam(ctx context.Context, teamID uuid.UUID) (team *dto.TeamWithPlayersResponse, err error) {
+	ctx, span := startSpan(ctx, "TeamCache", "GetTeam")
+	defer func() { tracing.End(span, err) }()
+
+	if teamID == uuid.Nil {
+		return nil, errors.New("empty team_id")
 	}
 
-	key := createTeamCacheKey(userID)
+	key := createTeamCacheKey(teamID)
 
 	data, err := r.client.Get(ctx, key).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
-			r.logger.Debug("team not found in cache", zap.String("user_id", userID.String()))
+			r.logger.Debug("team not found in cache", zap.String("team_id", teamID.String()))
 
 			return nil, nil
 		}
 
-		r.logger.Error("failed to get cached team", zap.Error(err), zap.String("user_id", userID.String()))
+		r.logger.Error("failed to get cached team", zap.Error(err), zap.String("team_id", teamID.String()))
 
 		return nil, fmt.Errorf("get cached team: %w", err)
 	}
@@ -88,34 +95,104 @@ func (r *TeamCache) GetTeam(ctx context.Context, userID uuid.UUID) (team *dto.Te
 	var teamWithPlayers dto.TeamWithPlayersResponse
 
 	if err := json.Unmarshal([]byte(data), &teamWithPlayers); err != nil {
-		r.logger.Error("failed to unmarshal team", zap.Error(err), zap.String("user_id", userID.String()))
+		r.logger.Error("failed to unmarshal team", zap.Error(err), zap.String("team_id", teamID.String()))
 
 		return nil, fmt.Errorf("unmarshal team: %w", err)
 	}
 
-	r.logger.Debug("team retrieved from cache", zap.String("user_id", userID.String()))
+	r.logger.Debug("team retrieved from cache", zap.String("team_id", teamID.String()))
 
 	return &teamWithPlayers, nil
 }
 
-func (r *TeamCache) InvalidateTeam(ctx context.Context, userID uuid.UUID) (err error) {
-	if userID == uuid.Nil {
-		return errors.New("empty user_id")
+func (r *TeamCache) SetPublicTeam(ctx context.Context, teamID uuid.UUID, team *dto.PublicTeamResponse) (err error) {
+	ctx, span := startSpan(ctx, "TeamCache", "SetPublicTeam")
+	defer func() { tracing.End(span, err) }()
+
+	if teamID == uuid.Nil {
+		return errors.New("empty team_id")
+	}
+
+	if team == nil {
+		return errors.New("empty team")
+	}
+
+	data, err := json.Marshal(team)
+	if err != nil {
+		r.logger.Error("failed to marshal public team", zap.Error(err), zap.String("team_id", teamID.String()))
+
+		return fmt.Errorf("marshal public team: %w", err)
+	}
+
+	if err := r.client.Set(ctx, createPublicTeamCacheKey(teamID), data, r.config.Login.TeamCacheTTL).Err(); err != nil {
+		r.logger.Error("failed to cache public team", zap.Error(err), zap.String("team_id", teamID.String()))
+
+		return fmt.Errorf("cache public team: %w", err)
+	}
+
+	r.logger.Debug("public team cached successfully", zap.String("team_id", teamID.String()))
+
+	return nil
+}
+
+func (r *TeamCache) GetPublicTeam(ctx context.Context, teamID uuid.UUID) (team *dto.PublicTeamResponse, err error) {
+	ctx, span := startSpan(ctx, "TeamCache", "GetPublicTeam")
+	defer func() { tracing.End(span, err) }()
+
+	if teamID == uuid.Nil {
+		return nil, errors.New("empty team_id")
 	}
 
-	key := createTeamCacheKey(userID)
+	data, err := r.client.Get(ctx, createPublicTeamCacheKey(teamID)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			r.logger.Debug("public team not found in cache", zap.String("team_id", teamID.String()))
+
+			return nil, nil
+		}
 
-	if err := r.client.Del(ctx, key).Err(); err != nil {
-		r.logger.Error("failed to invalidate team cache", zap.Error(err), zap.String("user_id", userID.String()))
+		r.logger.Error("failed to get cached public team", zap.Error(err), zap.String("team_id", teamID.String()))
+
+		return nil, fmt.Errorf("get cached public team: %w", err)
+	}
+
+	var publicTeam dto.PublicTeamResponse
+
+	if err := json.Unmarshal([]byte(data), &publicTeam); err != nil {
+		r.logger.Error("failed to unmarshal public team", zap.Error(err), zap.String("team_id", teamID.String()))
+
+		return nil, fmt.Errorf("unmarshal public team: %w", err)
+	}
+
+	r.logger.Debug("public team retrieved from cache", zap.String("team_id", teamID.String()))
+
+	return &publicTeam, nil
+}
+
+// InvalidateTeam drops both the owner's and the public entry of the team.
+func (r *TeamCache) InvalidateTeam(ctx context.Context, teamID uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "TeamCache", "InvalidateTeam")
+	defer func() { tracing.End(span, err) }()
+
+	if teamID == uuid.Nil {
+		return errors.New("empty team_id")
+	}
+
+	if err := r.client.Del(ctx, createTeamCacheKey(teamID), createPublicTeamCacheKey(teamID)).Err(); err != nil {
+		r.logger.Error("failed to invalidate team cache", zap.Error(err), zap.String("team_id", teamID.String()))
 
 		return fmt.Errorf("invalidate team cache: %w", err)
 	}
 
-	r.logger.Debug("team cache invalidated", zap.String("user_id", userID.String()))
+	r.logger.Debug("team cache invalidated", zap.String("team_id", teamID.String()))
 
 	return nil
 }
 
-func createTeamCacheKey(userID uuid.UUID) string {
-	return fmt.Sprintf("team_cache:%s", userID.String())
+func createTeamCacheKey(teamID uuid.UUID) string {
+	return fmt.Sprintf("team_cache:%s", teamID.String())
+}
+
+func createPublicTeamCacheKey(teamID uuid.UUID) string {
+	return fmt.Sprintf("public_team_cache:%s", teamID.String())
 }
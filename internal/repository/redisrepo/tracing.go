@@ -0,0 +1,21 @@
+package redisrepo
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("soccer_manager_service/internal/repository/redisrepo")
+
+func startSpan(ctx context.Context, repository, op string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, redisdb+"."+repository+"."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNameRedis,
+			semconv.DBOperationName(op),
+		),
+	)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
+	"github.com/sony/gobreaker"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -17,26 +18,59 @@ type Params struct {
 	Postgres *pgxpool.Pool
 	Redis    *redis.Client
 	Config   *config.Config
+	Breakers map[string]*gobreaker.CircuitBreaker
 }
 
 type Repository struct {
-	User         ports.UserRepository
-	Team         ports.TeamRepository
-	Player       ports.PlayerRepository
-	Transfer     ports.TransferRepository
-	LoginAttempt ports.LoginAttemptRepository
-	TeamCache    ports.TeamCacheRepository
+	User             ports.UserRepository
+	Team             ports.TeamRepository
+	Player           ports.PlayerRepository
+	Transfer         ports.TransferRepository
+	LoginAttempt     ports.LoginAttemptRepository
+	TeamCache        ports.TeamCacheRepository
+	ActionToken      ports.ActionTokenRepository
+	Session          ports.SessionRepository
+	Audit            ports.AuditRepository
+	Ledger           ports.LedgerRepository
+	Integrity        ports.IntegrityRepository
+	RateLimit        ports.RateLimitRepository
+	TwoFactor        ports.TwoFactorRepository
+	Identity         ports.IdentityRepository
+	OIDCState        ports.OIDCStateRepository
+	APIKey           ports.APIKeyRepository
+	Leaderboard      ports.LeaderboardRepository
+	LeaderboardCache ports.LeaderboardCacheRepository
+	MarketEvents     ports.MarketEventRepository
+	Outbox           ports.OutboxRepository
+	Webhook          ports.WebhookRepository
+	WebhookDelivery  ports.WebhookDeliveryRepository
 }
 
 func NewRepository(deps Params) *Repository {
 	f := newRepositoryFactory(deps)
 
 	return &Repository{
-		User:         f.CreateUserRepository(),
-		Team:         f.CreateTeamRepository(),
-		Player:       f.CreatePlayerRepository(),
-		Transfer:     f.CreateTransferRepository(),
-		LoginAttempt: f.CreateLoginAttemptRepository(),
-		TeamCache:    f.CreateTeamCacheRepository(),
+		User:             f.CreateUserRepository(),
+		Team:             f.CreateTeamRepository(),
+		Player:           f.CreatePlayerRepository(),
+		Transfer:         f.CreateTransferRepository(),
+		LoginAttempt:     f.CreateLoginAttemptRepository(),
+		TeamCache:        f.CreateTeamCacheRepository(),
+		ActionToken:      f.CreateActionTokenRepository(),
+		Session:          f.CreateSessionRepository(),
+		Audit:            f.CreateAuditRepository(),
+		Ledger:           f.CreateLedgerRepository(),
+		Integrity:        f.CreateIntegrityRepository(),
+		RateLimit:        f.CreateRateLimitRepository(),
+		TwoFactor:        f.CreateTwoFactorRepository(),
+		Identity:         f.CreateIdentityRepository(),
+		OIDCState:        f.CreateOIDCStateRepository(),
+		APIKey:           f.CreateAPIKeyRepository(),
+		Leaderboard:      f.CreateLeaderboardRepository(),
+		LeaderboardCache: f.CreateLeaderboardCacheRepository(),
+		MarketEvents:     f.CreateMarketEventRepository(),
+		Outbox:           f.CreateOutboxRepository(),
+		Webhook:          f.CreateWebhookRepository(),
+		WebhookDelivery:  f.CreateWebhookDeliveryRepository(),
 	}
 }
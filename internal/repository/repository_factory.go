@@ -42,6 +42,76 @@ func (f *repositoryFactory) CreateTransferRepository() ports.TransferRepository
 	})
 }
 
+func (f *repositoryFactory) CreateAuditRepository() ports.AuditRepository {
+	return postgresrepo.NewAuditRepository(postgresrepo.AuditParams{
+		Postgres: f.deps.Postgres,
+		Logger:   f.deps.Logger,
+	})
+}
+
+func (f *repositoryFactory) CreateLedgerRepository() ports.LedgerRepository {
+	return postgresrepo.NewLedgerRepository(postgresrepo.LedgerParams{
+		Postgres: f.deps.Postgres,
+		Logger:   f.deps.Logger,
+	})
+}
+
+func (f *repositoryFactory) CreateIntegrityRepository() ports.IntegrityRepository {
+	return postgresrepo.NewIntegrityRepository(postgresrepo.IntegrityParams{
+		Postgres: f.deps.Postgres,
+		Logger:   f.deps.Logger,
+	})
+}
+
+func (f *repositoryFactory) CreateTwoFactorRepository() ports.TwoFactorRepository {
+	return postgresrepo.NewTwoFactorRepository(postgresrepo.TwoFactorParams{
+		Postgres: f.deps.Postgres,
+		Logger:   f.deps.Logger,
+	})
+}
+
+func (f *repositoryFactory) CreateIdentityRepository() ports.IdentityRepository {
+	return postgresrepo.NewIdentityRepository(postgresrepo.IdentityParams{
+		Postgres: f.deps.Postgres,
+		Logger:   f.deps.Logger,
+	})
+}
+
+func (f *repositoryFactory) CreateAPIKeyRepository() ports.APIKeyRepository {
+	return postgresrepo.NewAPIKeyRepository(postgresrepo.APIKeyParams{
+		Postgres: f.deps.Postgres,
+		Logger:   f.deps.Logger,
+	})
+}
+
+func (f *repositoryFactory) CreateLeaderboardRepository() ports.LeaderboardRepository {
+	return postgresrepo.NewLeaderboardRepository(postgresrepo.LeaderboardParams{
+		Postgres: f.deps.Postgres,
+		Logger:   f.deps.Logger,
+	})
+}
+
+func (f *repositoryFactory) CreateOutboxRepository() ports.OutboxRepository {
+	return postgresrepo.NewOutboxRepository(postgresrepo.OutboxParams{
+		Postgres: f.deps.Postgres,
+		Logger:   f.deps.Logger,
+	})
+}
+
+func (f *repositoryFactory) CreateWebhookRepository() ports.WebhookRepository {
+	return postgresrepo.NewWebhookRepository(postgresrepo.WebhookParams{
+		Postgres: f.deps.Postgres,
+		Logger:   f.deps.Logger,
+	})
+}
+
+func (f *repositoryFactory) CreateWebhookDeliveryRepository() ports.WebhookDeliveryRepository {
+	return postgresrepo.NewWebhookDeliveryRepository(postgresrepo.WebhookDeliveryParams{
+		Postgres: f.deps.Postgres,
+		Logger:   f.deps.Logger,
+	})
+}
+
 func (f *repositoryFactory) CreateLoginAttemptRepository() ports.LoginAttemptRepository {
 	return redisrepo.NewLoginAttempt(redisrepo.LoginAttemptParams{
 		Redis:  f.deps.Redis,
@@ -57,3 +127,47 @@ func (f *repositoryFactory) CreateTeamCacheRepository() ports.TeamCacheRepositor
 		Config: f.deps.Config,
 	})
 }
+
+func (f *repositoryFactory) CreateActionTokenRepository() ports.ActionTokenRepository {
+	return redisrepo.NewActionToken(redisrepo.ActionTokenParams{
+		Redis:  f.deps.Redis,
+		Logger: f.deps.Logger,
+	})
+}
+
+func (f *repositoryFactory) CreateSessionRepository() ports.SessionRepository {
+	return redisrepo.NewSession(redisrepo.SessionParams{
+		Redis:  f.deps.Redis,
+		Logger: f.deps.Logger,
+		Config: f.deps.Config,
+	})
+}
+
+func (f *repositoryFactory) CreateRateLimitRepository() ports.RateLimitRepository {
+	return redisrepo.NewRateLimit(redisrepo.RateLimitParams{
+		Redis:   f.deps.Redis,
+		Breaker: f.deps.Breakers[redisrepo.BreakerName],
+		Logger:  f.deps.Logger,
+	})
+}
+
+func (f *repositoryFactory) CreateOIDCStateRepository() ports.OIDCStateRepository {
+	return redisrepo.NewOIDCState(redisrepo.OIDCStateParams{
+		Redis:  f.deps.Redis,
+		Logger: f.deps.Logger,
+	})
+}
+
+func (f *repositoryFactory) CreateLeaderboardCacheRepository() ports.LeaderboardCacheRepository {
+	return redisrepo.NewLeaderboard(redisrepo.LeaderboardParams{
+		Redis:  f.deps.Redis,
+		Logger: f.deps.Logger,
+	})
+}
+
+func (f *repositoryFactory) CreateMarketEventRepository() ports.MarketEventRepository {
+	return redisrepo.NewMarketEvents(redisrepo.MarketEventsParams{
+		Redis:  f.deps.Redis,
+		Logger: f.deps.Logger,
+	})
+}
@@ -0,0 +1,308 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/jwt"
+	"soccer_manager_service/pkg/logger"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// AccountService lets users manage their own account. Changes to the account
+// require the current password, and changing the password or email revokes
+// all existing sessions and returns a fresh token pair.
+type AccountService struct {
+	userRepository             ports.UserRepository
+	teamRepository             ports.TeamRepository
+	teamCacheRepository        ports.TeamCacheRepository
+	sessionRepository          ports.SessionRepository
+	actionTokenRepository      ports.ActionTokenRepository
+	auditRepository            ports.AuditRepository
+	mailer                     ports.Mailer
+	passwords                  *passwordPolicy
+	jwtManager                 *jwt.Manager
+	leaderboardCacheRepository ports.LeaderboardCacheRepository
+	marketEventRepository      ports.MarketEventRepository
+	logger                     *zap.Logger
+	config                     *config.Config
+}
+
+type AccountServiceParams struct {
+	UserRepository             ports.UserRepository
+	TeamRepository             ports.TeamRepository
+	TeamCacheRepository        ports.TeamCacheRepository
+	SessionRepository          ports.SessionRepository
+	ActionTokenRepository      ports.ActionTokenRepository
+	AuditRepository            ports.AuditRepository
+	Mailer                     ports.Mailer
+	JWTManager                 *jwt.Manager
+	LeaderboardCacheRepository ports.LeaderboardCacheRepository
+	MarketEventRepository      ports.MarketEventRepository
+	Logger                     *zap.Logger
+	Config                     *config.Config
+}
+
+func NewAccountService(params AccountServiceParams) *AccountService {
+	return &AccountService{
+		userRepository:             params.UserRepository,
+		teamRepository:             params.TeamRepository,
+		teamCacheRepository:        params.TeamCacheRepository,
+		sessionRepository:          params.SessionRepository,
+		actionTokenRepository:      params.ActionTokenRepository,
+		auditRepository:            params.AuditRepository,
+		mailer:                     params.Mailer,
+		passwords:                  newPasswordPolicy(params.Config.Password),
+		jwtManager:                 params.JWTManager,
+		leaderboardCacheRepository: params.LeaderboardCacheRepository,
+		marketEventRepository:      params.MarketEventRepository,
+		logger:                     params.Logger.With(zap.String("service", "AccountService")),
+		config:                     params.Config,
+	}
+}
+
+func (s *AccountService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger, zap.String("service", "AccountService"))
+}
+
+func (s *AccountService) ChangePassword(ctx context.Context, userID uuid.UUID, req *dto.ChangePasswordRequest) (accessToken, refreshToken string, err error) {
+	log := s.log(ctx)
+
+	user, err := authenticate(ctx, s.userRepository, log, userID, req.CurrentPassword)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := s.passwords.Validate(req.NewPassword, append(teamNames(ctx, s.teamRepository, log, userID), user.Email)...); err != nil {
+		log.Warn("password rejected by policy", zap.Error(err))
+
+		return "", "", err
+	}
+
+	hashedPassword, err := s.passwords.Hash(req.NewPassword)
+	if err != nil {
+		log.Error("failed to hash password", zap.Error(err))
+
+		return "", "", err
+	}
+
+	if err := s.sessionRepository.RevokeAll(ctx, userID, time.Now()); err != nil {
+		log.Error("failed to revoke sessions", zap.Error(err))
+
+		return "", "", err
+	}
+
+	updated, err := s.userRepository.UpdatePassword(ctx, userID, hashedPassword)
+	if err != nil {
+		log.Error("failed to update password", zap.Error(err))
+
+		return "", "", err
+	}
+
+	accessToken, refreshToken, err = generateTokens(s.jwtManager, updated)
+	if err != nil {
+		log.Error("failed to generate tokens", zap.Error(err))
+
+		return "", "", err
+	}
+
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &user.ID, "user.password_changed", entity.AuditEntityUser, user.ID, nil, nil))
+
+	log.Info("password changed", zap.String("user_id", userID.String()))
+
+	return accessToken, refreshToken, nil
+}
+
+// ChangeEmail moves the account to a new address, which must be verified
+// again; a verification link is mailed to it.
+func (s *AccountService) ChangeEmail(ctx context.Context, userID uuid.UUID, req *dto.ChangeEmailRequest) (accessToken, refreshToken string, err error) {
+	log := s.log(ctx)
+
+	user, err := authenticate(ctx, s.userRepository, log, userID, req.CurrentPassword)
+	if err != nil {
+		return "", "", err
+	}
+
+	if strings.EqualFold(user.Email, req.Email) {
+		return "", "", apperr.ErrEmailUnchanged
+	}
+
+	if _, err := s.userRepository.GetByEmail(ctx, req.Email); err == nil {
+		log.Warn("email already taken")
+
+		return "", "", apperr.ErrUserAlreadyExists
+	} else if !errors.Is(err, apperr.ErrUserNotFound) {
+		log.Error("failed to check email", zap.Error(err))
+
+		return "", "", err
+	}
+
+	if err := s.sessionRepository.RevokeAll(ctx, userID, time.Now()); err != nil {
+		log.Error("failed to revoke sessions", zap.Error(err))
+
+		return "", "", err
+	}
+
+	updated, err := s.userRepository.UpdateEmail(ctx, userID, req.Email)
+	if err != nil {
+		log.Error("failed to update email", zap.Error(err))
+
+		return "", "", err
+	}
+
+	if err := sendVerificationEmail(ctx, s.actionTokenRepository, s.mailer, s.config, updated); err != nil {
+		log.Error("failed to send verification email", zap.Error(err))
+	}
+
+	accessToken, refreshToken, err = generateTokens(s.jwtManager, updated)
+	if err != nil {
+		log.Error("failed to generate tokens", zap.Error(err))
+
+		return "", "", err
+	}
+
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &user.ID, "user.email_changed", entity.AuditEntityUser, user.ID, user, updated))
+
+	log.Info("email changed", zap.String("user_id", userID.String()))
+
+	return accessToken, refreshToken, nil
+}
+
+// DeleteAccount deletes the user with their team and players. Active
+// listings are cancelled; completed transfers stay in the counterparties'
+// history.
+func (s *AccountService) DeleteAccount(ctx context.Context, userID uuid.UUID, req *dto.DeleteAccountRequest) error {
+	log := s.log(ctx)
+
+	user, err := authenticate(ctx, s.userRepository, log, userID, req.CurrentPassword)
+	if err != nil {
+		return err
+	}
+
+	deletion, err := s.userRepository.Delete(ctx, userID)
+	if err != nil {
+		log.Error("failed to delete account", zap.Error(err))
+
+		return err
+	}
+
+	if err := s.sessionRepository.RevokeAll(ctx, userID, time.Now()); err != nil {
+		log.Error("failed to revoke sessions", zap.Error(err))
+	}
+
+	for _, closure := range deletion.Teams {
+		if err := s.teamCacheRepository.InvalidateTeam(ctx, closure.TeamID); err != nil {
+			log.Warn("failed to invalidate team cache", zap.Error(err))
+		}
+
+		for _, kind := range entity.TeamLeaderboards {
+			if err := s.leaderboardCacheRepository.Remove(ctx, kind, closure.TeamID); err != nil {
+				log.Warn("failed to remove team from leaderboard", zap.String("kind", string(kind)), zap.Error(err))
+			}
+		}
+	}
+
+	entries := []entity.AuditEntry{
+		newAuditEntry(ctx, &user.ID, "user.deleted", entity.AuditEntityUser, user.ID, user, nil),
+	}
+
+	for _, closure := range deletion.Teams {
+		entry := newAuditEntry(ctx, &user.ID, "team.deleted", entity.AuditEntityTeam, closure.TeamID, nil, nil)
+		entry.Metadata = map[string]any{"closing_balance": closure.ClosingBalance}
+		entries = append(entries, entry)
+	}
+
+	for _, transferID := range deletion.CancelledTransfers {
+		entry := newAuditEntry(ctx, &user.ID, "transfer.cancelled", entity.AuditEntityTransfer, transferID,
+			map[string]any{"status": entity.TransferStatusActive},
+			map[string]any{"status": entity.TransferStatusCancelled})
+		entry.Metadata = map[string]any{"reason": "account deleted"}
+		entries = append(entries, entry)
+	}
+
+	recordAudit(ctx, s.auditRepository, log, entries...)
+
+	for _, transferID := range deletion.CancelledTransfers {
+		publishMarketEvents(ctx, s.marketEventRepository, log,
+			newMarketEvent(entity.MarketEventListingCancelled, &entity.Transfer{ID: transferID}, nil))
+	}
+
+	log.Info("account deleted",
+		zap.String("user_id", userID.String()),
+		zap.Int("cancelled_transfers", len(deletion.CancelledTransfers)))
+
+	return nil
+}
+
+// CreateScopedToken issues an access token limited to the requested scopes,
+// e.g. a read-only token for a dashboard. Like the session it was issued
+// from, it stops working when all sessions are revoked.
+func (s *AccountService) CreateScopedToken(ctx context.Context, userID uuid.UUID, req *dto.CreateScopedTokenRequest) (*dto.ScopedTokenResponse, error) {
+	log := s.log(ctx)
+
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user", zap.Error(err))
+
+		return nil, err
+	}
+
+	ttl := s.config.JWT.ScopedTokenMaxTTL
+	if req.ExpiresIn > 0 {
+		ttl = min(ttl, time.Duration(req.ExpiresIn)*time.Second)
+	}
+
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+
+	accessToken, err := s.jwtManager.GenerateScopedAccessToken(user.ID, user.Email, string(user.Role), names, ttl)
+	if err != nil {
+		log.Error("failed to generate scoped token", zap.Error(err))
+
+		return nil, err
+	}
+
+	entry := newAuditEntry(ctx, &user.ID, "user.scoped_token_issued", entity.AuditEntityUser, user.ID, nil, nil)
+	entry.Metadata = map[string]any{"scopes": scopes, "expires_in": int(ttl.Seconds())}
+	recordAudit(ctx, s.auditRepository, log, entry)
+
+	log.Info("scoped token issued", zap.String("user_id", userID.String()), zap.Strings("scopes", names))
+
+	return &dto.ScopedTokenResponse{AccessToken: accessToken, Scopes: scopes, ExpiresIn: int(ttl.Seconds())}, nil
+}
+
+// authenticate loads the user and checks their current password.
+func authenticate(ctx context.Context, users ports.UserRepository, log *zap.Logger, userID uuid.UUID, password string) (*entity.User, error) {
+	user, err := users.GetByID(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user", zap.Error(err))
+
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		log.Warn("invalid current password", zap.String("user_id", userID.String()))
+
+		return nil, apperr.ErrInvalidCurrentPassword
+	}
+
+	return user, nil
+}
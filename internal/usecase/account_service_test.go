@@ -0,0 +1,421 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/entity"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/jwt"
+	"soccer_manager_service/pkg/mailer"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAccountService_ChangePassword(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	jwtManager := jwt.NewManager(jwt.Config{
+		Keys:            jwt.NewHMACKeyRing("test-secret"),
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+	})
+
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockSessionRepo := new(MockSessionRepository)
+
+		user := &entity.User{ID: userID, Email: "test@example.com", PasswordHash: hashPassword("old-password")}
+
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{{Name: "Test Team"}}, nil)
+		mockSessionRepo.On("RevokeAll", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil)
+		mockUserRepo.On("UpdatePassword", ctx, userID, mock.MatchedBy(func(hash string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(hash), []byte("new-password")) == nil
+		})).Return(user, nil)
+
+		service := NewAccountService(AccountServiceParams{
+			UserRepository:    mockUserRepo,
+			TeamRepository:    mockTeamRepo,
+			SessionRepository: mockSessionRepo,
+			AuditRepository:   newMockAuditRepository(),
+			JWTManager:        jwtManager,
+			Logger:            logger,
+			Config:            &config.Config{},
+		})
+
+		accessToken, refreshToken, err := service.ChangePassword(ctx, userID, &dto.ChangePasswordRequest{
+			CurrentPassword: "old-password",
+			NewPassword:     "new-password",
+		})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, accessToken)
+		assert.NotEmpty(t, refreshToken)
+		mockUserRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockSessionRepo := new(MockSessionRepository)
+
+		user := &entity.User{ID: userID, Email: "test@example.com", PasswordHash: hashPassword("old-password")}
+
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+
+		service := NewAccountService(AccountServiceParams{
+			UserRepository:    mockUserRepo,
+			SessionRepository: mockSessionRepo,
+			AuditRepository:   newMockAuditRepository(),
+			JWTManager:        jwtManager,
+			Logger:            logger,
+			Config:            &config.Config{},
+		})
+
+		_, _, err := service.ChangePassword(ctx, userID, &dto.ChangePasswordRequest{
+			CurrentPassword: "wrong-password",
+			NewPassword:     "new-password",
+		})
+
+		assert.Equal(t, apperr.ErrInvalidCurrentPassword, err)
+		mockSessionRepo.AssertNotCalled(t, "RevokeAll", mock.Anything, mock.Anything, mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("new password contains team name", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockSessionRepo := new(MockSessionRepository)
+
+		user := &entity.User{ID: userID, Email: "test@example.com", PasswordHash: hashPassword("old-password")}
+
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{{Name: "Red Lions"}}, nil)
+
+		service := NewAccountService(AccountServiceParams{
+			UserRepository:    mockUserRepo,
+			TeamRepository:    mockTeamRepo,
+			SessionRepository: mockSessionRepo,
+			AuditRepository:   newMockAuditRepository(),
+			JWTManager:        jwtManager,
+			Logger:            logger,
+			Config:            &config.Config{Password: config.PasswordConfig{RejectPersonal: true}},
+		})
+
+		_, _, err := service.ChangePassword(ctx, userID, &dto.ChangePasswordRequest{
+			CurrentPassword: "old-password",
+			NewPassword:     "redlions2025",
+		})
+
+		assert.ErrorIs(t, err, apperr.ErrPasswordContainsPersonal)
+		mockSessionRepo.AssertNotCalled(t, "RevokeAll", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccountService_ChangeEmail(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	jwtManager := jwt.NewManager(jwt.Config{
+		Keys:            jwt.NewHMACKeyRing("test-secret"),
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+	})
+
+	cfg := &config.Config{
+		Mail: config.MailConfig{
+			LinkBaseURL: "https://example.com",
+		},
+		Account: config.AccountConfig{
+			EmailVerificationTTL: 24 * time.Hour,
+		},
+	}
+
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockSessionRepo := new(MockSessionRepository)
+		mockTokenRepo := new(MockActionTokenRepository)
+		mockMailer := new(MockMailer)
+
+		verifiedAt := time.Now()
+		user := &entity.User{ID: userID, Email: "old@example.com", PasswordHash: hashPassword("password"), EmailVerifiedAt: &verifiedAt}
+		updated := &entity.User{ID: userID, Email: "new@example.com", PasswordHash: user.PasswordHash}
+
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+		mockUserRepo.On("GetByEmail", ctx, "new@example.com").Return(nil, apperr.ErrUserNotFound)
+		mockSessionRepo.On("RevokeAll", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil)
+		mockUserRepo.On("UpdateEmail", ctx, userID, "new@example.com").Return(updated, nil)
+		mockTokenRepo.On("Issue", ctx, entity.ActionToken{
+			Purpose: entity.TokenPurposeEmailVerification,
+			UserID:  userID,
+			Email:   "new@example.com",
+		}, 24*time.Hour).Return("verify-token", nil)
+		mockMailer.On("Send", ctx, mock.MatchedBy(func(msg mailer.Message) bool {
+			return msg.To == "new@example.com"
+		})).Return(nil)
+
+		service := NewAccountService(AccountServiceParams{
+			UserRepository:        mockUserRepo,
+			SessionRepository:     mockSessionRepo,
+			ActionTokenRepository: mockTokenRepo,
+			AuditRepository:       newMockAuditRepository(),
+			Mailer:                mockMailer,
+			JWTManager:            jwtManager,
+			Logger:                logger,
+			Config:                cfg,
+		})
+
+		accessToken, _, err := service.ChangeEmail(ctx, userID, &dto.ChangeEmailRequest{
+			Email:           "new@example.com",
+			CurrentPassword: "password",
+		})
+
+		assert.NoError(t, err)
+
+		claims, err := jwtManager.ValidateToken(accessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, "new@example.com", claims.Email)
+		mockUserRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("email taken", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockSessionRepo := new(MockSessionRepository)
+
+		user := &entity.User{ID: userID, Email: "old@example.com", PasswordHash: hashPassword("password")}
+
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+		mockUserRepo.On("GetByEmail", ctx, "taken@example.com").Return(&entity.User{ID: uuid.New()}, nil)
+
+		service := NewAccountService(AccountServiceParams{
+			UserRepository:    mockUserRepo,
+			SessionRepository: mockSessionRepo,
+			AuditRepository:   newMockAuditRepository(),
+			JWTManager:        jwtManager,
+			Logger:            logger,
+			Config:            cfg,
+		})
+
+		_, _, err := service.ChangeEmail(ctx, userID, &dto.ChangeEmailRequest{
+			Email:           "taken@example.com",
+			CurrentPassword: "password",
+		})
+
+		assert.Equal(t, apperr.ErrUserAlreadyExists, err)
+		mockUserRepo.AssertNotCalled(t, "UpdateEmail", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("same email", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+
+		user := &entity.User{ID: userID, Email: "old@example.com", PasswordHash: hashPassword("password")}
+
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+
+		service := NewAccountService(AccountServiceParams{
+			UserRepository:  mockUserRepo,
+			AuditRepository: newMockAuditRepository(),
+			JWTManager:      jwtManager,
+			Logger:          logger,
+			Config:          cfg,
+		})
+
+		_, _, err := service.ChangeEmail(ctx, userID, &dto.ChangeEmailRequest{
+			Email:           "OLD@example.com",
+			CurrentPassword: "password",
+		})
+
+		assert.Equal(t, apperr.ErrEmailUnchanged, err)
+	})
+}
+
+func TestAccountService_DeleteAccount(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	userID := uuid.New()
+	teamID := uuid.New()
+	secondTeamID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockSessionRepo := new(MockSessionRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+		mockAuditRepo := new(MockAuditRepository)
+
+		user := &entity.User{ID: userID, Email: "test@example.com", PasswordHash: hashPassword("password")}
+		transferID := uuid.New()
+
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+		mockUserRepo.On("Delete", ctx, userID).Return(&entity.AccountDeletion{
+			UserID: userID,
+			Teams: []entity.TeamClosure{
+				{TeamID: teamID, ClosingBalance: 3000000},
+				{TeamID: secondTeamID},
+			},
+			CancelledTransfers: []uuid.UUID{transferID},
+		}, nil)
+		mockSessionRepo.On("RevokeAll", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil)
+		mockCacheRepo.On("InvalidateTeam", ctx, teamID).Return(nil)
+		mockCacheRepo.On("InvalidateTeam", ctx, secondTeamID).Return(nil)
+
+		mockBoards := new(MockLeaderboardCacheRepository)
+		for _, kind := range entity.TeamLeaderboards {
+			mockBoards.On("Remove", ctx, kind, []uuid.UUID{teamID}).Return(nil)
+			mockBoards.On("Remove", ctx, kind, []uuid.UUID{secondTeamID}).Return(nil)
+		}
+
+		mockAuditRepo.On("Create", ctx, mock.MatchedBy(func(entries []entity.AuditEntry) bool {
+			return len(entries) == 4 &&
+				entries[0].Action == "user.deleted" &&
+				entries[1].Action == "team.deleted" &&
+				*entries[1].EntityID == teamID &&
+				entries[1].Metadata["closing_balance"] == int64(3000000) &&
+				entries[2].Action == "team.deleted" &&
+				*entries[2].EntityID == secondTeamID &&
+				entries[3].Action == "transfer.cancelled" &&
+				*entries[3].EntityID == transferID
+		})).Return(nil)
+
+		mockMarketEvents := newMockMarketEvents()
+
+		service := NewAccountService(AccountServiceParams{
+			UserRepository:             mockUserRepo,
+			SessionRepository:          mockSessionRepo,
+			TeamCacheRepository:        mockCacheRepo,
+			AuditRepository:            mockAuditRepo,
+			LeaderboardCacheRepository: mockBoards,
+			MarketEventRepository:      mockMarketEvents,
+			Logger:                     logger,
+			Config:                     &config.Config{},
+		})
+
+		err := service.DeleteAccount(ctx, userID, &dto.DeleteAccountRequest{CurrentPassword: "password"})
+
+		assert.NoError(t, err)
+		if published := publishedMarketEvents(mockMarketEvents); assert.Len(t, published, 1) {
+			assert.Equal(t, entity.MarketEventListingCancelled, published[0].Type)
+			assert.Equal(t, transferID, published[0].TransferID)
+		}
+		mockUserRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+		mockCacheRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
+		mockBoards.AssertExpectations(t)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+
+		user := &entity.User{ID: userID, Email: "test@example.com", PasswordHash: hashPassword("password")}
+
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+
+		service := NewAccountService(AccountServiceParams{
+			UserRepository:  mockUserRepo,
+			AuditRepository: newMockAuditRepository(),
+			Logger:          logger,
+			Config:          &config.Config{},
+		})
+
+		err := service.DeleteAccount(ctx, userID, &dto.DeleteAccountRequest{CurrentPassword: "wrong"})
+
+		assert.Equal(t, apperr.ErrInvalidCurrentPassword, err)
+		mockUserRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccountService_CreateScopedToken(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	jwtManager := jwt.NewManager(jwt.Config{
+		Keys:           jwt.NewHMACKeyRing("test-secret"),
+		AccessTokenTTL: time.Minute,
+	})
+	conf := &config.Config{JWT: config.JWTConfig{ScopedTokenMaxTTL: time.Hour}}
+
+	userID := uuid.New()
+	user := &entity.User{ID: userID, Email: "test@example.com", Role: entity.RoleManager}
+
+	newService := func(mockUserRepo *MockUserRepository) *AccountService {
+		return NewAccountService(AccountServiceParams{
+			UserRepository:  mockUserRepo,
+			AuditRepository: newMockAuditRepository(),
+			JWTManager:      jwtManager,
+			Logger:          logger,
+			Config:          conf,
+		})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+
+		resp, err := newService(mockUserRepo).CreateScopedToken(ctx, userID, &dto.CreateScopedTokenRequest{
+			Scopes:    []entity.Scope{entity.ScopeTeamRead, entity.ScopeMarketRead, entity.ScopeTeamRead},
+			ExpiresIn: 600,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.Scope{entity.ScopeTeamRead, entity.ScopeMarketRead}, resp.Scopes)
+		assert.Equal(t, 600, resp.ExpiresIn)
+
+		claims, err := jwtManager.ValidateToken(resp.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, userID, claims.UserID)
+		assert.Equal(t, []string{"team:read", "market:read"}, claims.Scopes())
+		assert.WithinDuration(t, time.Now().Add(10*time.Minute), claims.ExpiresAt.Time, 5*time.Second)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("lifetime is capped", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+
+		resp, err := newService(mockUserRepo).CreateScopedToken(ctx, userID, &dto.CreateScopedTokenRequest{
+			Scopes:    []entity.Scope{entity.ScopeMarketTrade},
+			ExpiresIn: 86400,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3600, resp.ExpiresIn)
+	})
+
+	t.Run("defaults to maximum lifetime", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+
+		resp, err := newService(mockUserRepo).CreateScopedToken(ctx, userID, &dto.CreateScopedTokenRequest{
+			Scopes: []entity.Scope{entity.ScopeTeamWrite},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3600, resp.ExpiresIn)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+
+		resp, err := newService(mockUserRepo).CreateScopedToken(ctx, userID, &dto.CreateScopedTokenRequest{
+			Scopes: []entity.Scope{entity.ScopeTeamRead, "admin:all"},
+		})
+
+		assert.ErrorIs(t, err, apperr.ErrInvalidScope)
+		assert.Nil(t, resp)
+		mockUserRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+}
@@ -2,28 +2,116 @@ package adapters
 
 import (
 	"context"
+	"soccer_manager_service/internal/config"
 	"soccer_manager_service/internal/dto"
 	"soccer_manager_service/internal/entity"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type AuthService interface {
 	Register(ctx context.Context, req *dto.RegisterRequest) (accessToken, refreshToken string, err error)
-	Login(ctx context.Context, req *dto.LoginRequest) (accessToken, refreshToken string, err error)
+	Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error)
+	CompleteTwoFactorLogin(ctx context.Context, req *dto.TwoFactorLoginRequest) (accessToken, refreshToken string, err error)
+	ListOIDCProviders(ctx context.Context) (*dto.OIDCProvidersResponse, error)
+	StartOIDCLogin(ctx context.Context, provider string, req *dto.OIDCAuthorizeRequest) (*dto.OIDCAuthorizeResponse, error)
+	CompleteOIDCLogin(ctx context.Context, provider string, req *dto.OIDCCallbackRequest) (*dto.LoginResponse, error)
+	ResendVerificationEmail(ctx context.Context, userID uuid.UUID) error
+	VerifyEmail(ctx context.Context, req *dto.VerifyEmailRequest) error
+	RequestPasswordReset(ctx context.Context, req *dto.PasswordResetRequest) error
+	ResetPassword(ctx context.Context, req *dto.ConfirmPasswordResetRequest) error
+	CheckSession(ctx context.Context, userID uuid.UUID, role entity.UserRole, issuedAt time.Time) error
+}
+
+type APIKeyService interface {
+	Create(ctx context.Context, userID uuid.UUID, req *dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error)
+	List(ctx context.Context, userID uuid.UUID) (*dto.APIKeyListResponse, error)
+	Revoke(ctx context.Context, userID, keyID uuid.UUID) error
+	Authenticate(ctx context.Context, raw string) (*entity.APIKey, *entity.User, error)
+}
+
+type WebhookService interface {
+	Create(ctx context.Context, userID uuid.UUID, req *dto.CreateWebhookRequest) (*entity.Webhook, error)
+	List(ctx context.Context, userID uuid.UUID) (*dto.WebhookListResponse, error)
+	Get(ctx context.Context, userID, webhookID uuid.UUID) (*entity.Webhook, error)
+	Update(ctx context.Context, userID, webhookID uuid.UUID, req *dto.UpdateWebhookRequest) (*entity.Webhook, error)
+	Delete(ctx context.Context, userID, webhookID uuid.UUID) error
+	Ping(ctx context.Context, userID, webhookID uuid.UUID) (*entity.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, userID, webhookID uuid.UUID, req *dto.WebhookDeliveriesRequest) (*dto.WebhookDeliveriesResponse, error)
+	Run(ctx context.Context) error
+	Deliver(ctx context.Context) (int, error)
+}
+
+type AccountService interface {
+	ChangePassword(ctx context.Context, userID uuid.UUID, req *dto.ChangePasswordRequest) (accessToken, refreshToken string, err error)
+	ChangeEmail(ctx context.Context, userID uuid.UUID, req *dto.ChangeEmailRequest) (accessToken, refreshToken string, err error)
+	DeleteAccount(ctx context.Context, userID uuid.UUID, req *dto.DeleteAccountRequest) error
+	CreateScopedToken(ctx context.Context, userID uuid.UUID, req *dto.CreateScopedTokenRequest) (*dto.ScopedTokenResponse, error)
+}
+
+type TwoFactorService interface {
+	Status(ctx context.Context, userID uuid.UUID) (*dto.TwoFactorStatusResponse, error)
+	Enroll(ctx context.Context, userID uuid.UUID, req *dto.TwoFactorEnrollRequest) (*dto.TwoFactorEnrollResponse, error)
+	Enable(ctx context.Context, userID uuid.UUID, req *dto.TwoFactorEnableRequest) (*dto.RecoveryCodesResponse, error)
+	Disable(ctx context.Context, userID uuid.UUID, req *dto.TwoFactorConfirmRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, req *dto.TwoFactorConfirmRequest) (*dto.RecoveryCodesResponse, error)
 }
 
 type TeamService interface {
-	GetMyTeam(ctx context.Context, userID uuid.UUID) (*dto.TeamWithPlayersResponse, error)
-	UpdateTeam(ctx context.Context, userID uuid.UUID, req *dto.UpdateTeamRequest) (*entity.Team, error)
+	GetMyTeam(ctx context.Context, userID, teamID uuid.UUID) (*dto.TeamWithPlayersResponse, error)
+	UpdateTeam(ctx context.Context, userID, teamID uuid.UUID, req *dto.UpdateTeamRequest) (*entity.Team, error)
+	GetFinances(ctx context.Context, userID, teamID uuid.UUID, req *dto.FinancesRequest) (*dto.TeamFinancesResponse, error)
+	ListTeams(ctx context.Context, userID uuid.UUID) (*dto.TeamListResponse, error)
+	CreateTeam(ctx context.Context, userID uuid.UUID, req *dto.CreateTeamRequest) (*entity.Team, error)
+	GetPublicTeam(ctx context.Context, teamID uuid.UUID) (*dto.PublicTeamResponse, error)
+	SearchTeams(ctx context.Context, req *dto.SearchTeamsRequest) (*dto.PublicTeamsResponse, error)
 }
 
 type PlayerService interface {
-	UpdatePlayer(ctx context.Context, userID, playerID uuid.UUID, req *dto.UpdatePlayerRequest) (*entity.Player, error)
+	GetPublicPlayer(ctx context.Context, playerID uuid.UUID) (*dto.PublicPlayerResponse, error)
+	UpdatePlayer(ctx context.Context, userID, teamID, playerID uuid.UUID, req *dto.UpdatePlayerRequest) (*entity.Player, error)
 }
 
 type TransferService interface {
-	ListPlayer(ctx context.Context, userID, playerID uuid.UUID, req *dto.ListPlayerRequest) (*entity.Transfer, error)
+	ListPlayer(ctx context.Context, userID, teamID, playerID uuid.UUID, req *dto.ListPlayerRequest) (*entity.Transfer, error)
 	GetTransferList(ctx context.Context) ([]dto.TransferListItemResponse, error)
-	BuyPlayer(ctx context.Context, userID, transferID uuid.UUID) error
+	BuyPlayer(ctx context.Context, userID, teamID, transferID uuid.UUID) error
+}
+
+type LeaderboardService interface {
+	Get(ctx context.Context, userID uuid.UUID, kind entity.LeaderboardKind, req *dto.LeaderboardRequest) (*dto.LeaderboardResponse, error)
+	Rebuild(ctx context.Context) error
+}
+
+type EventService interface {
+	Run(ctx context.Context) error
+	Dispatch(ctx context.Context) (int, error)
+}
+
+type MarketService interface {
+	Run(ctx context.Context) error
+	Subscribe(ctx context.Context, userID uuid.UUID) (<-chan entity.MarketEvent, func())
+}
+
+type AdminService interface {
+	ListUsers(ctx context.Context, req *dto.AdminListRequest) (*dto.AdminUsersResponse, error)
+	ListTeams(ctx context.Context, req *dto.AdminListRequest) (*dto.AdminTeamsResponse, error)
+	UpdateUserRole(ctx context.Context, actorID, userID uuid.UUID, req *dto.UpdateUserRoleRequest) (*entity.User, error)
+	BanUser(ctx context.Context, actorID, userID uuid.UUID, req *dto.BanUserRequest) (*entity.User, error)
+	UnbanUser(ctx context.Context, actorID, userID uuid.UUID) (*entity.User, error)
+	UnlockUserLogin(ctx context.Context, actorID, userID uuid.UUID) error
+	UnlockIPLogin(ctx context.Context, actorID uuid.UUID, req *dto.UnlockIPRequest) error
+	ResetTwoFactor(ctx context.Context, actorID, userID uuid.UUID) error
+	AdjustTeamBudget(ctx context.Context, actorID, teamID uuid.UUID, req *dto.AdjustBudgetRequest) (*entity.Team, error)
+	CancelTransfer(ctx context.Context, actorID, transferID uuid.UUID, req *dto.CancelTransferRequest) error
+	ListAuditLog(ctx context.Context, req *dto.AuditLogRequest) (*dto.AuditLogResponse, error)
+}
+
+type IntegrityService interface {
+	Check(ctx context.Context, fix bool) (*entity.IntegrityReport, error)
+}
+
+type RateLimitService interface {
+	Allow(ctx context.Context, scope, subject string, rate config.Rate) (*entity.RateLimitResult, error)
 }
@@ -0,0 +1,423 @@
+package usecase
+
+import (
+	"context"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const defaultAdminListLimit = 20
+
+type AdminService struct {
+	userRepository             ports.UserRepository
+	teamRepository             ports.TeamRepository
+	transferRepository         ports.TransferRepository
+	teamCacheRepository        ports.TeamCacheRepository
+	auditRepository            ports.AuditRepository
+	ledgerRepository           ports.LedgerRepository
+	loginAttemptRepository     ports.LoginAttemptRepository
+	twoFactorRepository        ports.TwoFactorRepository
+	leaderboardRepository      ports.LeaderboardRepository
+	leaderboardCacheRepository ports.LeaderboardCacheRepository
+	marketEventRepository      ports.MarketEventRepository
+	sessionRepository          ports.SessionRepository
+	logger                     *zap.Logger
+}
+
+type AdminServiceParams struct {
+	UserRepository             ports.UserRepository
+	TeamRepository             ports.TeamRepository
+	TransferRepository         ports.TransferRepository
+	TeamCacheRepository        ports.TeamCacheRepository
+	AuditRepository            ports.AuditRepository
+	LedgerRepository           ports.LedgerRepository
+	LoginAttemptRepository     ports.LoginAttemptRepository
+	TwoFactorRepository        ports.TwoFactorRepository
+	LeaderboardRepository      ports.LeaderboardRepository
+	LeaderboardCacheRepository ports.LeaderboardCacheRepository
+	MarketEventRepository      ports.MarketEventRepository
+	SessionRepository          ports.SessionRepository
+	Logger                     *zap.Logger
+}
+
+func NewAdminService(params AdminServiceParams) *AdminService {
+	return &AdminService{
+		userRepository:             params.UserRepository,
+		teamRepository:             params.TeamRepository,
+		transferRepository:         params.TransferRepository,
+		teamCacheRepository:        params.TeamCacheRepository,
+		auditRepository:            params.AuditRepository,
+		ledgerRepository:           params.LedgerRepository,
+		loginAttemptRepository:     params.LoginAttemptRepository,
+		twoFactorRepository:        params.TwoFactorRepository,
+		leaderboardRepository:      params.LeaderboardRepository,
+		leaderboardCacheRepository: params.LeaderboardCacheRepository,
+		marketEventRepository:      params.MarketEventRepository,
+		sessionRepository:          params.SessionRepository,
+		logger:                     params.Logger.With(zap.String("service", "AdminService")),
+	}
+}
+
+func (s *AdminService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger, zap.String("service", "AdminService"))
+}
+
+// audit records a successful admin action together with the reason given for
+// it, if any.
+func (s *AdminService) audit(ctx context.Context, actorID uuid.UUID, action, entityType string, entityID uuid.UUID, before, after any, reason string) {
+	entry := newAuditEntry(ctx, &actorID, action, entityType, entityID, before, after)
+
+	if reason != "" {
+		entry.Metadata = map[string]any{"reason": reason}
+	}
+
+	recordAudit(ctx, s.auditRepository, s.log(ctx), entry)
+}
+
+// revokeSessions signs the user out everywhere, so tokens issued with the old
+// role or before a ban stop working right away.
+func (s *AdminService) revokeSessions(ctx context.Context, userID uuid.UUID) error {
+	if err := s.sessionRepository.RevokeAll(ctx, userID, time.Now()); err != nil {
+		s.log(ctx).Error("failed to revoke sessions", zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *AdminService) ListUsers(ctx context.Context, req *dto.AdminListRequest) (*dto.AdminUsersResponse, error) {
+	limit := listLimit(req.Limit)
+
+	users, err := s.userRepository.List(ctx, req.Search, limit, req.Offset)
+	if err != nil {
+		s.log(ctx).Error("failed to list users", zap.Error(err))
+
+		return nil, err
+	}
+
+	return &dto.AdminUsersResponse{
+		Users:  users,
+		Limit:  limit,
+		Offset: req.Offset,
+	}, nil
+}
+
+func (s *AdminService) ListTeams(ctx context.Context, req *dto.AdminListRequest) (*dto.AdminTeamsResponse, error) {
+	limit := listLimit(req.Limit)
+
+	teams, err := s.teamRepository.List(ctx, req.Search, limit, req.Offset)
+	if err != nil {
+		s.log(ctx).Error("failed to list teams", zap.Error(err))
+
+		return nil, err
+	}
+
+	return &dto.AdminTeamsResponse{
+		Teams:  teams,
+		Limit:  limit,
+		Offset: req.Offset,
+	}, nil
+}
+
+func (s *AdminService) UpdateUserRole(ctx context.Context, actorID, userID uuid.UUID, req *dto.UpdateUserRoleRequest) (*entity.User, error) {
+	log := s.log(ctx)
+
+	if actorID == userID {
+		log.Warn("admin tried to change own role")
+
+		return nil, apperr.ErrCannotModifyOwnAccount
+	}
+
+	before, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user", zap.Error(err))
+
+		return nil, err
+	}
+
+	if err := s.revokeSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.UpdateRole(ctx, userID, req.Role)
+	if err != nil {
+		log.Error("failed to update user role", zap.Error(err))
+
+		return nil, err
+	}
+
+	s.audit(ctx, actorID, "user.role_changed", entity.AuditEntityUser, userID, before, user, "")
+
+	return user, nil
+}
+
+func (s *AdminService) BanUser(ctx context.Context, actorID, userID uuid.UUID, req *dto.BanUserRequest) (*entity.User, error) {
+	log := s.log(ctx)
+
+	if actorID == userID {
+		log.Warn("admin tried to ban own account")
+
+		return nil, apperr.ErrCannotModifyOwnAccount
+	}
+
+	before, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user", zap.Error(err))
+
+		return nil, err
+	}
+
+	if err := s.revokeSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	bannedAt := time.Now()
+
+	user, err := s.userRepository.SetBan(ctx, userID, &bannedAt, &req.Reason)
+	if err != nil {
+		log.Error("failed to ban user", zap.Error(err))
+
+		return nil, err
+	}
+
+	s.audit(ctx, actorID, "user.banned", entity.AuditEntityUser, userID, before, user, req.Reason)
+
+	return user, nil
+}
+
+func (s *AdminService) UnbanUser(ctx context.Context, actorID, userID uuid.UUID) (*entity.User, error) {
+	log := s.log(ctx)
+
+	before, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user", zap.Error(err))
+
+		return nil, err
+	}
+
+	if err := s.revokeSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.SetBan(ctx, userID, nil, nil)
+	if err != nil {
+		log.Error("failed to unban user", zap.Error(err))
+
+		return nil, err
+	}
+
+	s.audit(ctx, actorID, "user.unbanned", entity.AuditEntityUser, userID, before, user, "")
+
+	return user, nil
+}
+
+// UnlockUserLogin lifts the login lockouts of a user's email from every IP.
+func (s *AdminService) UnlockUserLogin(ctx context.Context, actorID, userID uuid.UUID) error {
+	log := s.log(ctx)
+
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user", zap.Error(err))
+
+		return err
+	}
+
+	if err := s.loginAttemptRepository.Unlock(ctx, user.Email); err != nil {
+		log.Error("failed to unlock login", zap.Error(err))
+
+		return err
+	}
+
+	s.audit(ctx, actorID, "user.login_unlocked", entity.AuditEntityUser, userID, nil, nil, "")
+
+	log.Info("user login unlocked", zap.String("user_id", userID.String()))
+
+	return nil
+}
+
+// ResetTwoFactor turns two-factor authentication off for a user who lost
+// both their authenticator and their recovery codes.
+func (s *AdminService) ResetTwoFactor(ctx context.Context, actorID, userID uuid.UUID) error {
+	log := s.log(ctx)
+
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user", zap.Error(err))
+
+		return err
+	}
+
+	if !user.HasTwoFactor() {
+		return apperr.ErrTwoFactorNotEnabled
+	}
+
+	if err := s.twoFactorRepository.Disable(ctx, userID); err != nil {
+		log.Error("failed to reset two-factor", zap.Error(err))
+
+		return err
+	}
+
+	s.audit(ctx, actorID, "user.two_factor_reset", entity.AuditEntityUser, userID, nil, nil, "")
+
+	log.Info("user two-factor reset", zap.String("user_id", userID.String()))
+
+	return nil
+}
+
+// UnlockIPLogin lifts the per-IP login lockout of an address.
+func (s *AdminService) UnlockIPLogin(ctx context.Context, actorID uuid.UUID, req *dto.UnlockIPRequest) error {
+	log := s.log(ctx)
+
+	if err := s.loginAttemptRepository.UnlockIP(ctx, req.IP); err != nil {
+		log.Error("failed to unlock login ip", zap.Error(err))
+
+		return err
+	}
+
+	entry := newAuditEntry(ctx, &actorID, "ip.login_unlocked", entity.AuditEntityIP, uuid.Nil, nil, nil)
+	entry.EntityID = nil
+	entry.Metadata = map[string]any{"ip": req.IP}
+	recordAudit(ctx, s.auditRepository, log, entry)
+
+	log.Info("ip login unlocked", zap.String("ip", req.IP))
+
+	return nil
+}
+
+func (s *AdminService) AdjustTeamBudget(ctx context.Context, actorID, teamID uuid.UUID, req *dto.AdjustBudgetRequest) (*entity.Team, error) {
+	log := s.log(ctx)
+
+	team, err := s.teamRepository.GetByID(ctx, teamID)
+	if err != nil {
+		log.Error("failed to get team", zap.Error(err))
+
+		return nil, err
+	}
+
+	before := *team
+	oldBudget := team.Budget
+	newBudget := oldBudget + req.Amount
+
+	if newBudget < 0 {
+		log.Warn("budget adjustment would make budget negative",
+			zap.Int64("budget", oldBudget),
+			zap.Int64("amount", req.Amount))
+
+		return nil, apperr.WithData(apperr.ErrInsufficientFunds, map[string]any{
+			"Needed":    -req.Amount,
+			"Available": oldBudget,
+		})
+	}
+
+	err = s.ledgerRepository.Post(ctx, entity.LedgerTransaction{
+		Kind:        entity.LedgerKindAdminAdjustment,
+		Description: req.Reason,
+		Postings: []entity.LedgerPosting{
+			entity.TeamPosting(teamID, req.Amount),
+			entity.SystemPosting(entity.LedgerAccountAdminAdjustment, -req.Amount),
+		},
+	})
+	if err != nil {
+		log.Error("failed to post budget adjustment", zap.Error(err))
+
+		return nil, err
+	}
+
+	team.Budget = newBudget
+
+	if err := s.teamCacheRepository.InvalidateTeam(ctx, team.ID); err != nil {
+		log.Error("failed to invalidate team cache", zap.Error(err))
+	}
+
+	updateLeaderboards(ctx, s.leaderboardRepository, s.leaderboardCacheRepository, log, team.ID)
+
+	s.audit(ctx, actorID, "team.budget_adjusted", entity.AuditEntityTeam, teamID, before, team, req.Reason)
+
+	return team, nil
+}
+
+func (s *AdminService) CancelTransfer(ctx context.Context, actorID, transferID uuid.UUID, req *dto.CancelTransferRequest) error {
+	log := s.log(ctx)
+
+	transfer, err := s.transferRepository.GetByID(ctx, transferID)
+	if err != nil {
+		log.Error("failed to get transfer", zap.Error(err))
+
+		return err
+	}
+
+	if transfer.Status != entity.TransferStatusActive {
+		log.Warn("transfer is not active", zap.String("status", string(transfer.Status)))
+
+		return apperr.ErrTransferNotActive
+	}
+
+	if err := s.transferRepository.Cancel(ctx, transferID); err != nil {
+		log.Error("failed to cancel transfer", zap.Error(err))
+
+		return err
+	}
+
+	cancelled := *transfer
+	cancelled.Status = entity.TransferStatusCancelled
+
+	s.audit(ctx, actorID, "transfer.cancelled", entity.AuditEntityTransfer, transferID, transfer, cancelled, req.Reason)
+
+	publishMarketEvents(ctx, s.marketEventRepository, log,
+		newMarketEvent(entity.MarketEventListingCancelled, &cancelled, nil))
+
+	return nil
+}
+
+func (s *AdminService) ListAuditLog(ctx context.Context, req *dto.AuditLogRequest) (*dto.AuditLogResponse, error) {
+	filter := entity.AuditFilter{EntityType: req.EntityType}
+
+	if req.EntityID != "" {
+		entityID, err := uuid.Parse(req.EntityID)
+		if err != nil {
+			return nil, apperr.ErrInvalidInput
+		}
+
+		filter.EntityID = &entityID
+	}
+
+	if req.ActorID != "" {
+		actorID, err := uuid.Parse(req.ActorID)
+		if err != nil {
+			return nil, apperr.ErrInvalidInput
+		}
+
+		filter.ActorID = &actorID
+	}
+
+	limit := listLimit(req.Limit)
+
+	entries, err := s.auditRepository.List(ctx, filter, limit, req.Offset)
+	if err != nil {
+		s.log(ctx).Error("failed to list audit log", zap.Error(err))
+
+		return nil, err
+	}
+
+	return &dto.AuditLogResponse{
+		Entries: entries,
+		Limit:   limit,
+		Offset:  req.Offset,
+	}, nil
+}
+
+func listLimit(limit uint) uint {
+	if limit == 0 {
+		return defaultAdminListLimit
+	}
+
+	return limit
+}
@@ -0,0 +1,510 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/entity"
+	apperr "soccer_manager_service/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Create(ctx context.Context, entries ...entity.AuditEntry) error {
+	args := m.Called(ctx, entries)
+
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) List(ctx context.Context, filter entity.AuditFilter, limit, offset uint) ([]entity.AuditEntry, error) {
+	args := m.Called(ctx, filter, limit, offset)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.AuditEntry), args.Error(1)
+}
+
+// newMockAuditRepository accepts any audit entries, for tests that do not
+// assert on the audit log.
+func newMockAuditRepository() *MockAuditRepository {
+	m := new(MockAuditRepository)
+	m.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	return m
+}
+
+func TestAdminService_ListUsers(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("default limit", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+
+		users := []entity.User{{ID: uuid.New(), Email: "test@example.com", Role: entity.RoleManager}}
+
+		mockUserRepo.On("List", ctx, "test", uint(20), uint(0)).Return(users, nil)
+
+		service := NewAdminService(AdminServiceParams{
+			UserRepository:  mockUserRepo,
+			AuditRepository: newMockAuditRepository(),
+			Logger:          logger,
+		})
+
+		result, err := service.ListUsers(ctx, &dto.AdminListRequest{Search: "test"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, users, result.Users)
+		assert.Equal(t, uint(20), result.Limit)
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestAdminService_UpdateUserRole(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+
+		before := &entity.User{ID: userID, Role: entity.RoleManager}
+		after := &entity.User{ID: userID, Role: entity.RoleModerator}
+
+		mockUserRepo.On("GetByID", ctx, userID).Return(before, nil)
+		mockUserRepo.On("UpdateRole", ctx, userID, entity.RoleModerator).Return(after, nil)
+
+		mockSessionRepo := new(MockSessionRepository)
+		mockSessionRepo.On("RevokeAll", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil)
+
+		service := NewAdminService(AdminServiceParams{
+			UserRepository:    mockUserRepo,
+			AuditRepository:   newMockAuditRepository(),
+			SessionRepository: mockSessionRepo,
+			Logger:            logger,
+		})
+
+		result, err := service.UpdateUserRole(ctx, actorID, userID, &dto.UpdateUserRoleRequest{Role: entity.RoleModerator})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.RoleModerator, result.Role)
+		mockUserRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("own account", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+
+		service := NewAdminService(AdminServiceParams{
+			UserRepository:  mockUserRepo,
+			AuditRepository: newMockAuditRepository(),
+			Logger:          logger,
+		})
+
+		result, err := service.UpdateUserRole(ctx, actorID, actorID, &dto.UpdateUserRoleRequest{Role: entity.RoleManager})
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperr.ErrCannotModifyOwnAccount, err)
+		mockUserRepo.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAdminService_BanUser(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+
+		mockAuditRepo := new(MockAuditRepository)
+
+		reason := "cheating"
+		user := &entity.User{ID: userID}
+		banned := &entity.User{ID: userID, BanReason: &reason}
+
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+		mockUserRepo.On("SetBan", ctx, userID, mock.AnythingOfType("*time.Time"), &reason).Return(banned, nil)
+		mockAuditRepo.On("Create", ctx, mock.MatchedBy(func(entries []entity.AuditEntry) bool {
+			return len(entries) == 1 &&
+				entries[0].Action == "user.banned" &&
+				*entries[0].ActorID == actorID &&
+				*entries[0].EntityID == userID &&
+				entries[0].Changes["ban_reason"].After == reason &&
+				entries[0].Metadata["reason"] == reason
+		})).Return(nil)
+
+		mockSessionRepo := new(MockSessionRepository)
+		mockSessionRepo.On("RevokeAll", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil)
+
+		service := NewAdminService(AdminServiceParams{
+			UserRepository:    mockUserRepo,
+			AuditRepository:   mockAuditRepo,
+			SessionRepository: mockSessionRepo,
+			Logger:            logger,
+		})
+
+		result, err := service.BanUser(ctx, actorID, userID, &dto.BanUserRequest{Reason: reason})
+
+		assert.NoError(t, err)
+		assert.Equal(t, banned, result)
+		mockUserRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("own account", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+
+		service := NewAdminService(AdminServiceParams{
+			UserRepository:  mockUserRepo,
+			AuditRepository: newMockAuditRepository(),
+			Logger:          logger,
+		})
+
+		result, err := service.BanUser(ctx, actorID, actorID, &dto.BanUserRequest{Reason: "cheating"})
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperr.ErrCannotModifyOwnAccount, err)
+		mockUserRepo.AssertNotCalled(t, "SetBan", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAdminService_UnbanUser(t *testing.T) {
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	userID := uuid.New()
+
+	reason := "cheating"
+	bannedAt := time.Now()
+
+	mockUserRepo := new(MockUserRepository)
+	mockUserRepo.On("GetByID", ctx, userID).Return(&entity.User{ID: userID, BannedAt: &bannedAt, BanReason: &reason}, nil)
+	mockUserRepo.On("SetBan", ctx, userID, (*time.Time)(nil), (*string)(nil)).Return(&entity.User{ID: userID}, nil)
+
+	mockSessionRepo := new(MockSessionRepository)
+	mockSessionRepo.On("RevokeAll", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil)
+
+	service := NewAdminService(AdminServiceParams{
+		UserRepository:    mockUserRepo,
+		AuditRepository:   newMockAuditRepository(),
+		SessionRepository: mockSessionRepo,
+		Logger:            zap.NewNop(),
+	})
+
+	result, err := service.UnbanUser(ctx, actorID, userID)
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsBanned())
+	mockSessionRepo.AssertExpectations(t)
+}
+
+// TestAdminService_RevokesExistingTokens checks that tokens issued before a
+// ban or a role change stop passing CheckSession.
+func TestAdminService_RevokesExistingTokens(t *testing.T) {
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	userID := uuid.New()
+	issuedAt := time.Now().Add(-time.Hour)
+
+	setup := func(current *entity.User) (*AdminService, *AuthService) {
+		var revokedBefore time.Time
+
+		mockSessionRepo := new(MockSessionRepository)
+		mockSessionRepo.On("RevokeAll", ctx, userID, mock.AnythingOfType("time.Time")).
+			Run(func(args mock.Arguments) { revokedBefore = args.Get(2).(time.Time) }).
+			Return(nil)
+		mockSessionRepo.On("RevokedBefore", ctx, userID).Return(&revokedBefore, nil)
+
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("GetByID", ctx, userID).Return(current, nil)
+		mockUserRepo.On("UpdateRole", ctx, userID, mock.Anything).Return(current, nil)
+		mockUserRepo.On("SetBan", ctx, userID, mock.Anything, mock.Anything).Return(current, nil)
+
+		admin := NewAdminService(AdminServiceParams{
+			UserRepository:    mockUserRepo,
+			AuditRepository:   newMockAuditRepository(),
+			SessionRepository: mockSessionRepo,
+			Logger:            zap.NewNop(),
+		})
+
+		auth := NewAuthService(AuthServiceParams{
+			UserRepository:    mockUserRepo,
+			SessionRepository: mockSessionRepo,
+			Logger:            zap.NewNop(),
+			Config:            &config.Config{},
+		})
+
+		return admin, auth
+	}
+
+	t.Run("ban", func(t *testing.T) {
+		admin, auth := setup(&entity.User{ID: userID, Role: entity.RoleManager})
+
+		_, err := admin.BanUser(ctx, actorID, userID, &dto.BanUserRequest{Reason: "cheating"})
+		assert.NoError(t, err)
+
+		assert.Equal(t, apperr.ErrInvalidToken, auth.CheckSession(ctx, userID, entity.RoleManager, issuedAt))
+	})
+
+	t.Run("demotion", func(t *testing.T) {
+		admin, auth := setup(&entity.User{ID: userID, Role: entity.RoleManager})
+
+		_, err := admin.UpdateUserRole(ctx, actorID, userID, &dto.UpdateUserRoleRequest{Role: entity.RoleManager})
+		assert.NoError(t, err)
+
+		assert.Equal(t, apperr.ErrInvalidToken, auth.CheckSession(ctx, userID, entity.RoleAdmin, issuedAt))
+	})
+}
+
+func TestAdminService_UnlockLogin(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("user", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
+		mockAuditRepo := new(MockAuditRepository)
+
+		mockUserRepo.On("GetByID", ctx, userID).Return(&entity.User{ID: userID, Email: "test@example.com"}, nil)
+		mockLoginAttemptRepo.On("Unlock", ctx, "test@example.com").Return(nil)
+		mockAuditRepo.On("Create", ctx, mock.MatchedBy(func(entries []entity.AuditEntry) bool {
+			return len(entries) == 1 &&
+				entries[0].Action == "user.login_unlocked" &&
+				*entries[0].EntityID == userID
+		})).Return(nil)
+
+		service := NewAdminService(AdminServiceParams{
+			UserRepository:         mockUserRepo,
+			LoginAttemptRepository: mockLoginAttemptRepo,
+			AuditRepository:        mockAuditRepo,
+			Logger:                 logger,
+		})
+
+		err := service.UnlockUserLogin(ctx, actorID, userID)
+
+		assert.NoError(t, err)
+		mockLoginAttemptRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
+	})
+
+	t.Run("ip", func(t *testing.T) {
+		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
+		mockAuditRepo := new(MockAuditRepository)
+
+		mockLoginAttemptRepo.On("UnlockIP", ctx, testClientIP).Return(nil)
+		mockAuditRepo.On("Create", ctx, mock.MatchedBy(func(entries []entity.AuditEntry) bool {
+			return len(entries) == 1 &&
+				entries[0].Action == "ip.login_unlocked" &&
+				entries[0].EntityType == entity.AuditEntityIP &&
+				entries[0].EntityID == nil &&
+				entries[0].Metadata["ip"] == testClientIP
+		})).Return(nil)
+
+		service := NewAdminService(AdminServiceParams{
+			LoginAttemptRepository: mockLoginAttemptRepo,
+			AuditRepository:        mockAuditRepo,
+			Logger:                 logger,
+		})
+
+		err := service.UnlockIPLogin(ctx, actorID, &dto.UnlockIPRequest{IP: testClientIP})
+
+		assert.NoError(t, err)
+		mockLoginAttemptRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
+	})
+}
+
+func TestAdminService_AdjustTeamBudget(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	userID := uuid.New()
+	teamID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+		mockLedgerRepo := new(MockLedgerRepository)
+
+		team := &entity.Team{ID: teamID, UserID: userID, Budget: 1000000}
+
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(team, nil)
+		mockLedgerRepo.On("Post", ctx, entity.LedgerTransaction{
+			Kind:        entity.LedgerKindAdminAdjustment,
+			Description: "compensation",
+			Postings: []entity.LedgerPosting{
+				entity.TeamPosting(teamID, 500000),
+				entity.SystemPosting(entity.LedgerAccountAdminAdjustment, -500000),
+			},
+		}).Return(nil)
+		mockCacheRepo.On("InvalidateTeam", ctx, teamID).Return(nil)
+
+		mockScores := new(MockLeaderboardRepository)
+		mockBoards := new(MockLeaderboardCacheRepository)
+		for _, kind := range entity.TeamLeaderboards {
+			scores := []entity.LeaderboardEntry{{ID: teamID, Score: 1500000}}
+			mockScores.On("Scores", ctx, kind, entity.LeaderboardFilter{TeamIDs: []uuid.UUID{teamID}}).Return(scores, nil)
+			mockBoards.On("Set", ctx, kind, scores).Return(nil)
+		}
+
+		service := NewAdminService(AdminServiceParams{
+			TeamRepository:             mockTeamRepo,
+			TeamCacheRepository:        mockCacheRepo,
+			AuditRepository:            newMockAuditRepository(),
+			LedgerRepository:           mockLedgerRepo,
+			LeaderboardRepository:      mockScores,
+			LeaderboardCacheRepository: mockBoards,
+			Logger:                     logger,
+		})
+
+		result, err := service.AdjustTeamBudget(ctx, actorID, teamID, &dto.AdjustBudgetRequest{
+			Amount: 500000,
+			Reason: "compensation",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1500000), result.Budget)
+		mockTeamRepo.AssertExpectations(t)
+		mockCacheRepo.AssertExpectations(t)
+		mockLedgerRepo.AssertExpectations(t)
+		mockBoards.AssertExpectations(t)
+	})
+
+	t.Run("negative budget", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+		mockLedgerRepo := new(MockLedgerRepository)
+
+		team := &entity.Team{ID: teamID, UserID: userID, Budget: 1000000}
+
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(team, nil)
+
+		service := NewAdminService(AdminServiceParams{
+			TeamRepository:      mockTeamRepo,
+			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
+			LedgerRepository:    mockLedgerRepo,
+			Logger:              logger,
+		})
+
+		result, err := service.AdjustTeamBudget(ctx, actorID, teamID, &dto.AdjustBudgetRequest{
+			Amount: -2000000,
+			Reason: "penalty",
+		})
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, apperr.ErrInsufficientFunds)
+		mockLedgerRepo.AssertNotCalled(t, "Post", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAdminService_CancelTransfer(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	transferID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockTransferRepo := new(MockTransferRepository)
+
+		playerID := uuid.New()
+		transfer := &entity.Transfer{ID: transferID, PlayerID: &playerID, Status: entity.TransferStatusActive}
+
+		mockTransferRepo.On("GetByID", ctx, transferID).Return(transfer, nil)
+		mockTransferRepo.On("Cancel", ctx, transferID).Return(nil)
+
+		mockMarketEvents := new(MockMarketEventRepository)
+		mockMarketEvents.On("Publish", ctx, mock.MatchedBy(func(event entity.MarketEvent) bool {
+			return event.Type == entity.MarketEventListingCancelled &&
+				event.TransferID == transferID &&
+				*event.PlayerID == playerID &&
+				event.UserID == nil
+		})).Return(nil)
+
+		service := NewAdminService(AdminServiceParams{
+			TransferRepository:    mockTransferRepo,
+			AuditRepository:       newMockAuditRepository(),
+			MarketEventRepository: mockMarketEvents,
+			Logger:                logger,
+		})
+
+		err := service.CancelTransfer(ctx, actorID, transferID, &dto.CancelTransferRequest{Reason: "suspicious price"})
+
+		assert.NoError(t, err)
+		mockMarketEvents.AssertExpectations(t)
+		mockTransferRepo.AssertExpectations(t)
+	})
+
+	t.Run("transfer not active", func(t *testing.T) {
+		mockTransferRepo := new(MockTransferRepository)
+
+		transfer := &entity.Transfer{ID: transferID, Status: entity.TransferStatusCompleted}
+
+		mockTransferRepo.On("GetByID", ctx, transferID).Return(transfer, nil)
+
+		service := NewAdminService(AdminServiceParams{
+			TransferRepository: mockTransferRepo,
+			AuditRepository:    newMockAuditRepository(),
+			Logger:             logger,
+		})
+
+		err := service.CancelTransfer(ctx, actorID, transferID, &dto.CancelTransferRequest{Reason: "suspicious price"})
+
+		assert.Equal(t, apperr.ErrTransferNotActive, err)
+		mockTransferRepo.AssertNotCalled(t, "Cancel", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAdminService_ListAuditLog(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	t.Run("filter by entity", func(t *testing.T) {
+		mockAuditRepo := new(MockAuditRepository)
+
+		teamID := uuid.New()
+		entries := []entity.AuditEntry{{ID: uuid.New(), Action: "team.updated", EntityType: entity.AuditEntityTeam, EntityID: &teamID}}
+
+		filter := entity.AuditFilter{EntityType: entity.AuditEntityTeam, EntityID: &teamID}
+		mockAuditRepo.On("List", ctx, filter, uint(50), uint(10)).Return(entries, nil)
+
+		service := NewAdminService(AdminServiceParams{
+			AuditRepository: mockAuditRepo,
+			Logger:          logger,
+		})
+
+		result, err := service.ListAuditLog(ctx, &dto.AuditLogRequest{
+			EntityType: entity.AuditEntityTeam,
+			EntityID:   teamID.String(),
+			Limit:      50,
+			Offset:     10,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entries, result.Entries)
+		mockAuditRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// apiKeyDisplayLength is how much of a key is kept in plain text to tell
+	// keys apart.
+	apiKeyDisplayLength = len(entity.APIKeyPrefix) + 8
+
+	// apiKeyUseResolution limits how often a key's last use is written.
+	apiKeyUseResolution = time.Minute
+)
+
+// APIKeyService manages personal API keys and authenticates requests made
+// with them. Keys act for their user, limited to their scopes.
+type APIKeyService struct {
+	apiKeyRepository ports.APIKeyRepository
+	userRepository   ports.UserRepository
+	auditRepository  ports.AuditRepository
+	logger           *zap.Logger
+	config           *config.Config
+}
+
+type APIKeyServiceParams struct {
+	APIKeyRepository ports.APIKeyRepository
+	UserRepository   ports.UserRepository
+	AuditRepository  ports.AuditRepository
+	Logger           *zap.Logger
+	Config           *config.Config
+}
+
+func NewAPIKeyService(params APIKeyServiceParams) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepository: params.APIKeyRepository,
+		userRepository:   params.UserRepository,
+		auditRepository:  params.AuditRepository,
+		logger:           params.Logger.With(zap.String("service", "APIKeyService")),
+		config:           params.Config,
+	}
+}
+
+func (s *APIKeyService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger, zap.String("service", "APIKeyService"))
+}
+
+// Create issues a new key. The key itself is only part of the response.
+func (s *APIKeyService) Create(ctx context.Context, userID uuid.UUID, req *dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error) {
+	log := s.log(ctx)
+
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.apiKeyRepository.ListByUser(ctx, userID)
+	if err != nil {
+		log.Error("failed to list api keys", zap.Error(err))
+
+		return nil, err
+	}
+
+	if len(existing) >= s.config.APIKey.MaxPerUser {
+		log.Warn("api key limit reached", zap.String("user_id", userID.String()))
+
+		return nil, apperr.ErrAPIKeyLimitReached
+	}
+
+	raw, err := newAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.apiKeyRepository.Create(ctx, entity.APIKey{
+		UserID:  userID,
+		Name:    strings.TrimSpace(req.Name),
+		Prefix:  raw[:apiKeyDisplayLength],
+		KeyHash: hashAPIKey(raw),
+		Scopes:  scopes,
+	})
+	if err != nil {
+		log.Error("failed to create api key", zap.Error(err))
+
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &userID, "api_key.created", entity.AuditEntityAPIKey, key.ID, nil, key))
+
+	log.Info("api key created", zap.String("user_id", userID.String()), zap.String("api_key_id", key.ID.String()))
+
+	return &dto.CreateAPIKeyResponse{APIKey: *key, Key: raw}, nil
+}
+
+func (s *APIKeyService) List(ctx context.Context, userID uuid.UUID) (*dto.APIKeyListResponse, error) {
+	keys, err := s.apiKeyRepository.ListByUser(ctx, userID)
+	if err != nil {
+		s.log(ctx).Error("failed to list api keys", zap.Error(err))
+
+		return nil, err
+	}
+
+	return &dto.APIKeyListResponse{APIKeys: keys}, nil
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, userID, keyID uuid.UUID) error {
+	log := s.log(ctx)
+
+	key, err := s.apiKeyRepository.Revoke(ctx, userID, keyID)
+	if err != nil {
+		if !errors.Is(err, apperr.ErrAPIKeyNotFound) {
+			log.Error("failed to revoke api key", zap.Error(err))
+		}
+
+		return err
+	}
+
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &userID, "api_key.revoked", entity.AuditEntityAPIKey, key.ID, nil, nil))
+
+	log.Info("api key revoked", zap.String("user_id", userID.String()), zap.String("api_key_id", key.ID.String()))
+
+	return nil
+}
+
+// Authenticate returns the key and its user for a raw key from a request.
+// Unknown and revoked keys fail with ErrInvalidAPIKey, keys of banned users
+// with ErrAccountBanned.
+func (s *APIKeyService) Authenticate(ctx context.Context, raw string) (*entity.APIKey, *entity.User, error) {
+	log := s.log(ctx)
+
+	if !strings.HasPrefix(raw, entity.APIKeyPrefix) {
+		return nil, nil, apperr.ErrInvalidAPIKey
+	}
+
+	key, err := s.apiKeyRepository.GetByHash(ctx, hashAPIKey(raw))
+	if err != nil {
+		if errors.Is(err, apperr.ErrAPIKeyNotFound) {
+			log.Warn("unknown api key", zap.String("prefix", raw[:min(len(raw), apiKeyDisplayLength)]))
+
+			return nil, nil, apperr.ErrInvalidAPIKey
+		}
+
+		log.Error("failed to get api key", zap.Error(err))
+
+		return nil, nil, err
+	}
+
+	user, err := s.userRepository.GetByID(ctx, key.UserID)
+	if err != nil {
+		log.Error("failed to get user", zap.Error(err))
+
+		return nil, nil, err
+	}
+
+	if user.IsBanned() {
+		log.Warn("api key of banned user", zap.String("user_id", user.ID.String()))
+
+		return nil, nil, apperr.ErrAccountBanned
+	}
+
+	now := time.Now()
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyUseResolution {
+		if err := s.apiKeyRepository.RecordUse(ctx, key.ID, now); err != nil {
+			log.Error("failed to record api key use", zap.Error(err))
+		} else {
+			key.LastUsedAt = &now
+		}
+	}
+
+	return key, user, nil
+}
+
+// normalizeScopes rejects unknown scopes and drops duplicates.
+func normalizeScopes(requested []entity.Scope) ([]entity.Scope, error) {
+	scopes := make([]entity.Scope, 0, len(requested))
+
+	for _, scope := range requested {
+		if !slices.Contains(entity.Scopes, scope) {
+			return nil, apperr.ErrInvalidScope
+		}
+
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes, nil
+}
+
+func newAPIKey() (string, error) {
+	buf := make([]byte, 32)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate api key: %w", err)
+	}
+
+	return entity.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashAPIKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/entity"
+	apperr "soccer_manager_service/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key entity.APIKey) (*entity.APIKey, error) {
+	args := m.Called(ctx, key)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	args := m.Called(ctx, keyHash)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]entity.APIKey, error) {
+	args := m.Called(ctx, userID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, userID, id uuid.UUID) (*entity.APIKey, error) {
+	args := m.Called(ctx, userID, id)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) RecordUse(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	args := m.Called(ctx, id, usedAt)
+
+	return args.Error(0)
+}
+
+func testAPIKeyConfig() *config.Config {
+	return &config.Config{
+		APIKey: config.APIKeyConfig{MaxPerUser: 2},
+	}
+}
+
+func TestAPIKeyService_Create(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockAPIKeyRepo := new(MockAPIKeyRepository)
+
+		var stored entity.APIKey
+
+		mockAPIKeyRepo.On("ListByUser", ctx, userID).Return([]entity.APIKey{{ID: uuid.New()}}, nil)
+		mockAPIKeyRepo.On("Create", ctx, mock.AnythingOfType("entity.APIKey")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(entity.APIKey) }).
+			Return(&entity.APIKey{ID: uuid.New(), UserID: userID, Name: "bot"}, nil)
+
+		service := NewAPIKeyService(APIKeyServiceParams{
+			APIKeyRepository: mockAPIKeyRepo,
+			AuditRepository:  newMockAuditRepository(),
+			Logger:           logger,
+			Config:           testAPIKeyConfig(),
+		})
+
+		resp, err := service.Create(ctx, userID, &dto.CreateAPIKeyRequest{
+			Name:   " bot ",
+			Scopes: []entity.Scope{entity.ScopeMarketRead, entity.ScopeMarketTrade, entity.ScopeMarketRead},
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(resp.Key, entity.APIKeyPrefix))
+		assert.Equal(t, userID, stored.UserID)
+		assert.Equal(t, "bot", stored.Name)
+		assert.Equal(t, hashAPIKey(resp.Key), stored.KeyHash)
+		assert.True(t, strings.HasPrefix(resp.Key, stored.Prefix))
+		assert.NotEqual(t, resp.Key, stored.Prefix)
+		assert.Equal(t, []entity.Scope{entity.ScopeMarketRead, entity.ScopeMarketTrade}, stored.Scopes)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		service := NewAPIKeyService(APIKeyServiceParams{
+			APIKeyRepository: new(MockAPIKeyRepository),
+			AuditRepository:  newMockAuditRepository(),
+			Logger:           logger,
+			Config:           testAPIKeyConfig(),
+		})
+
+		resp, err := service.Create(ctx, userID, &dto.CreateAPIKeyRequest{
+			Name:   "bot",
+			Scopes: []entity.Scope{"admin"},
+		})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperr.ErrInvalidScope, err)
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		mockAPIKeyRepo := new(MockAPIKeyRepository)
+
+		mockAPIKeyRepo.On("ListByUser", ctx, userID).Return([]entity.APIKey{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+
+		service := NewAPIKeyService(APIKeyServiceParams{
+			APIKeyRepository: mockAPIKeyRepo,
+			AuditRepository:  newMockAuditRepository(),
+			Logger:           logger,
+			Config:           testAPIKeyConfig(),
+		})
+
+		resp, err := service.Create(ctx, userID, &dto.CreateAPIKeyRequest{
+			Name:   "bot",
+			Scopes: []entity.Scope{entity.ScopeTeamRead},
+		})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperr.ErrAPIKeyLimitReached, err)
+		mockAPIKeyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	raw := entity.APIKeyPrefix + "secret"
+
+	t.Run("success records use", func(t *testing.T) {
+		mockAPIKeyRepo := new(MockAPIKeyRepository)
+		mockUserRepo := new(MockUserRepository)
+
+		user := &entity.User{ID: uuid.New(), Email: "test@example.com", Role: entity.RoleManager}
+		key := &entity.APIKey{ID: uuid.New(), UserID: user.ID, Scopes: []entity.Scope{entity.ScopeMarketRead}}
+
+		mockAPIKeyRepo.On("GetByHash", ctx, hashAPIKey(raw)).Return(key, nil)
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		mockAPIKeyRepo.On("RecordUse", ctx, key.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+		service := NewAPIKeyService(APIKeyServiceParams{
+			APIKeyRepository: mockAPIKeyRepo,
+			UserRepository:   mockUserRepo,
+			Logger:           logger,
+			Config:           testAPIKeyConfig(),
+		})
+
+		gotKey, gotUser, err := service.Authenticate(ctx, raw)
+
+		assert.NoError(t, err)
+		assert.Equal(t, key, gotKey)
+		assert.Equal(t, user, gotUser)
+		assert.NotNil(t, gotKey.LastUsedAt)
+		mockAPIKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("recent use is not recorded again", func(t *testing.T) {
+		mockAPIKeyRepo := new(MockAPIKeyRepository)
+		mockUserRepo := new(MockUserRepository)
+
+		lastUsed := time.Now().Add(-10 * time.Second)
+		user := &entity.User{ID: uuid.New()}
+		key := &entity.APIKey{ID: uuid.New(), UserID: user.ID, LastUsedAt: &lastUsed}
+
+		mockAPIKeyRepo.On("GetByHash", ctx, hashAPIKey(raw)).Return(key, nil)
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+		service := NewAPIKeyService(APIKeyServiceParams{
+			APIKeyRepository: mockAPIKeyRepo,
+			UserRepository:   mockUserRepo,
+			Logger:           logger,
+			Config:           testAPIKeyConfig(),
+		})
+
+		_, _, err := service.Authenticate(ctx, raw)
+
+		assert.NoError(t, err)
+		mockAPIKeyRepo.AssertNotCalled(t, "RecordUse", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		mockAPIKeyRepo := new(MockAPIKeyRepository)
+
+		mockAPIKeyRepo.On("GetByHash", ctx, hashAPIKey(raw)).Return(nil, apperr.ErrAPIKeyNotFound)
+
+		service := NewAPIKeyService(APIKeyServiceParams{
+			APIKeyRepository: mockAPIKeyRepo,
+			Logger:           logger,
+			Config:           testAPIKeyConfig(),
+		})
+
+		key, user, err := service.Authenticate(ctx, raw)
+
+		assert.Nil(t, key)
+		assert.Nil(t, user)
+		assert.Equal(t, apperr.ErrInvalidAPIKey, err)
+	})
+
+	t.Run("not an api key", func(t *testing.T) {
+		mockAPIKeyRepo := new(MockAPIKeyRepository)
+
+		service := NewAPIKeyService(APIKeyServiceParams{
+			APIKeyRepository: mockAPIKeyRepo,
+			Logger:           logger,
+			Config:           testAPIKeyConfig(),
+		})
+
+		_, _, err := service.Authenticate(ctx, "secret")
+
+		assert.Equal(t, apperr.ErrInvalidAPIKey, err)
+		mockAPIKeyRepo.AssertNotCalled(t, "GetByHash", mock.Anything, mock.Anything)
+	})
+
+	t.Run("banned user", func(t *testing.T) {
+		mockAPIKeyRepo := new(MockAPIKeyRepository)
+		mockUserRepo := new(MockUserRepository)
+
+		bannedAt := time.Now()
+		user := &entity.User{ID: uuid.New(), BannedAt: &bannedAt}
+		key := &entity.APIKey{ID: uuid.New(), UserID: user.ID}
+
+		mockAPIKeyRepo.On("GetByHash", ctx, hashAPIKey(raw)).Return(key, nil)
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+		service := NewAPIKeyService(APIKeyServiceParams{
+			APIKeyRepository: mockAPIKeyRepo,
+			UserRepository:   mockUserRepo,
+			Logger:           logger,
+			Config:           testAPIKeyConfig(),
+		})
+
+		_, _, err := service.Authenticate(ctx, raw)
+
+		assert.Equal(t, apperr.ErrAccountBanned, err)
+	})
+}
+
+func TestAPIKeyService_Revoke(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	userID := uuid.New()
+	keyID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockAPIKeyRepo := new(MockAPIKeyRepository)
+
+		mockAPIKeyRepo.On("Revoke", ctx, userID, keyID).Return(&entity.APIKey{ID: keyID, UserID: userID}, nil)
+
+		service := NewAPIKeyService(APIKeyServiceParams{
+			APIKeyRepository: mockAPIKeyRepo,
+			AuditRepository:  newMockAuditRepository(),
+			Logger:           logger,
+			Config:           testAPIKeyConfig(),
+		})
+
+		assert.NoError(t, service.Revoke(ctx, userID, keyID))
+		mockAPIKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockAPIKeyRepo := new(MockAPIKeyRepository)
+
+		mockAPIKeyRepo.On("Revoke", ctx, userID, keyID).Return(nil, apperr.ErrAPIKeyNotFound)
+
+		service := NewAPIKeyService(APIKeyServiceParams{
+			APIKeyRepository: mockAPIKeyRepo,
+			AuditRepository:  newMockAuditRepository(),
+			Logger:           logger,
+			Config:           testAPIKeyConfig(),
+		})
+
+		assert.Equal(t, apperr.ErrAPIKeyNotFound, service.Revoke(ctx, userID, keyID))
+	})
+}
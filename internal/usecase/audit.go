@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+	"soccer_manager_service/pkg/requestid"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// auditIgnoredFields are bookkeeping columns that change on every write and
+// carry no information for an audit trail.
+var auditIgnoredFields = map[string]struct{}{
+	"created_at": {},
+	"updated_at": {},
+}
+
+// newAuditEntry builds an audit entry for the request in ctx. before and after
+// are snapshots of the entity (either may be nil); only the fields that differ
+// between them end up in Changes.
+func newAuditEntry(ctx context.Context, actorID *uuid.UUID, action, entityType string, entityID uuid.UUID, before, after any) entity.AuditEntry {
+	return entity.AuditEntry{
+		ActorID:    actorID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   &entityID,
+		RequestID:  requestid.FromContext(ctx),
+		Changes:    auditDiff(before, after),
+	}
+}
+
+func auditDiff(before, after any) map[string]entity.AuditChange {
+	beforeFields := auditFields(before)
+	afterFields := auditFields(after)
+
+	changes := make(map[string]entity.AuditChange)
+
+	for field, value := range afterFields {
+		if old, ok := beforeFields[field]; !ok || !reflect.DeepEqual(old, value) {
+			changes[field] = entity.AuditChange{Before: beforeFields[field], After: value}
+		}
+	}
+
+	for field, value := range beforeFields {
+		if _, ok := afterFields[field]; !ok {
+			changes[field] = entity.AuditChange{Before: value}
+		}
+	}
+
+	return changes
+}
+
+// auditFields flattens v to its JSON fields, so that an entity's json tags
+// decide what is audited (e.g. password hashes are never included).
+func auditFields(v any) map[string]any {
+	fields := make(map[string]any)
+
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+		return fields
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fields
+	}
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fields
+	}
+
+	for field := range auditIgnoredFields {
+		delete(fields, field)
+	}
+
+	return fields
+}
+
+// recordAudit writes entries to the audit log. The operation they describe has
+// already happened, so a failure is logged rather than returned.
+func recordAudit(ctx context.Context, repository ports.AuditRepository, log *zap.Logger, entries ...entity.AuditEntry) {
+	if err := repository.Create(ctx, entries...); err != nil {
+		log.Error("failed to write audit log", zap.Error(err))
+	}
+}
@@ -2,204 +2,920 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand"
+	"math"
+	"slices"
 	"soccer_manager_service/internal/config"
 	"soccer_manager_service/internal/dto"
 	"soccer_manager_service/internal/entity"
 	"soccer_manager_service/internal/ports"
 	apperr "soccer_manager_service/pkg/errors"
 	"soccer_manager_service/pkg/jwt"
+	"soccer_manager_service/pkg/logger"
+	"soccer_manager_service/pkg/oidc"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
-var (
-	firstNames = []string{"Oliver", "Jack", "Harry", "George", "Noah", "Charlie", "Leo", "Oscar", "Jacob", "Liam"}
-	lastNames  = []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Martinez", "Hernandez"}
-	countries  = []string{"England", "Spain", "Germany", "France", "Italy", "Brazil", "Argentina", "Portugal", "Netherlands", "Belgium"}
+const (
+	// maxTeamNameLength matches the validation of team names in requests.
+	maxTeamNameLength = 50
+
+	// defaultTeamCountry is used for teams created by a provider login that
+	// did not name a country.
+	defaultTeamCountry = "Unknown"
 )
 
 type AuthService struct {
-	userRepository         ports.UserRepository
-	teamRepository         ports.TeamRepository
-	playerRepository       ports.PlayerRepository
-	loginAttemptRepository ports.LoginAttemptRepository
-	jwtManager             *jwt.Manager
-	logger                 *zap.Logger
-	config                 *config.Config
+	userRepository             ports.UserRepository
+	teamRepository             ports.TeamRepository
+	loginAttemptRepository     ports.LoginAttemptRepository
+	auditRepository            ports.AuditRepository
+	actionTokenRepository      ports.ActionTokenRepository
+	sessionRepository          ports.SessionRepository
+	identityRepository         ports.IdentityRepository
+	oidcStateRepository        ports.OIDCStateRepository
+	oidcProviders              ports.OIDCProviders
+	mailer                     ports.Mailer
+	passwords                  *passwordPolicy
+	twoFactor                  *twoFactor
+	jwtManager                 *jwt.Manager
+	leaderboardRepository      ports.LeaderboardRepository
+	leaderboardCacheRepository ports.LeaderboardCacheRepository
+	logger                     *zap.Logger
+	config                     *config.Config
 }
 
 type AuthServiceParams struct {
-	UserRepository         ports.UserRepository
-	TeamRepository         ports.TeamRepository
-	PlayerRepository       ports.PlayerRepository
-	LoginAttemptRepository ports.LoginAttemptRepository
-	JWTManager             *jwt.Manager
-	Logger                 *zap.Logger
-	Config                 *config.Config
+	UserRepository             ports.UserRepository
+	TeamRepository             ports.TeamRepository
+	LoginAttemptRepository     ports.LoginAttemptRepository
+	AuditRepository            ports.AuditRepository
+	ActionTokenRepository      ports.ActionTokenRepository
+	SessionRepository          ports.SessionRepository
+	TwoFactorRepository        ports.TwoFactorRepository
+	IdentityRepository         ports.IdentityRepository
+	OIDCStateRepository        ports.OIDCStateRepository
+	OIDCProviders              ports.OIDCProviders
+	Mailer                     ports.Mailer
+	JWTManager                 *jwt.Manager
+	LeaderboardRepository      ports.LeaderboardRepository
+	LeaderboardCacheRepository ports.LeaderboardCacheRepository
+	Logger                     *zap.Logger
+	Config                     *config.Config
 }
 
 func NewAuthService(params AuthServiceParams) *AuthService {
 	return &AuthService{
-		userRepository:         params.UserRepository,
-		teamRepository:         params.TeamRepository,
-		playerRepository:       params.PlayerRepository,
-		loginAttemptRepository: params.LoginAttemptRepository,
-		jwtManager:             params.JWTManager,
-		logger:                 params.Logger.With(zap.String("service", "AuthService")),
-		config:                 params.Config,
+		userRepository:             params.UserRepository,
+		teamRepository:             params.TeamRepository,
+		loginAttemptRepository:     params.LoginAttemptRepository,
+		auditRepository:            params.AuditRepository,
+		actionTokenRepository:      params.ActionTokenRepository,
+		sessionRepository:          params.SessionRepository,
+		identityRepository:         params.IdentityRepository,
+		oidcStateRepository:        params.OIDCStateRepository,
+		oidcProviders:              params.OIDCProviders,
+		mailer:                     params.Mailer,
+		passwords:                  newPasswordPolicy(params.Config.Password),
+		twoFactor:                  newTwoFactor(params.TwoFactorRepository, params.Config.TwoFactor),
+		jwtManager:                 params.JWTManager,
+		leaderboardRepository:      params.LeaderboardRepository,
+		leaderboardCacheRepository: params.LeaderboardCacheRepository,
+		logger:                     params.Logger.With(zap.String("service", "AuthService")),
+		config:                     params.Config,
 	}
 }
 
-func (s *AuthService) incrementLoginAttemptsOnError(ctx context.Context, email string) {
-	if _, err := s.loginAttemptRepository.Increment(ctx, email); err != nil {
-		s.logger.Error("failed to increment login attempts", zap.Error(err), zap.String("email", email))
+func (s *AuthService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger, zap.String("service", "AuthService"))
+}
+
+func (s *AuthService) recordLoginFailure(ctx context.Context, ip, email string) {
+	lockout, err := s.loginAttemptRepository.RecordFailure(ctx, ip, email)
+	if err != nil {
+		s.log(ctx).Error("failed to record login failure", zap.Error(err), zap.String("email", email))
+
+		return
+	}
+
+	if lockout > 0 {
+		s.log(ctx).Warn("login locked out",
+			zap.String("email", email),
+			zap.String("ip", ip),
+			zap.Duration("lockout", lockout))
 	}
 }
 
+// tooManyAttempts reports a login lockout, rounded up to whole minutes for the
+// message and exact for the Retry-After header.
+func tooManyAttempts(retryAfter time.Duration) error {
+	retryMinutes := int(math.Ceil(retryAfter.Minutes()))
+
+	return apperr.WithRetryAfter(
+		apperr.WithData(apperr.ErrTooManyAttempts, map[string]any{"Count": retryMinutes}),
+		retryAfter)
+}
+
 func (s *AuthService) Register(ctx context.Context, req *dto.RegisterRequest) (accessToken, refreshToken string, err error) {
-	s.logger.Info("registering new user", zap.String("email", req.Email))
+	log := s.log(ctx)
+
+	log.Info("registering new user", zap.String("email", req.Email))
 
 	existing, err := s.userRepository.GetByEmail(ctx, req.Email)
 	if err == nil && existing != nil {
 		return "", "", apperr.ErrUserAlreadyExists
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err := s.passwords.Validate(req.Password, req.Email, req.TeamName); err != nil {
+		log.Warn("password rejected by policy", zap.Error(err))
+
+		return "", "", err
+	}
+
+	hashedPassword, err := s.passwords.Hash(req.Password)
 	if err != nil {
-		s.logger.Error("failed to hash password", zap.Error(err))
+		log.Error("failed to hash password", zap.Error(err))
+
+		return "", "", err
+	}
 
-		return "", "", fmt.Errorf("hash password: %w", err)
+	user, team, err := s.createAccount(ctx, req.Email, hashedPassword, req.TeamName, req.Country)
+	if err != nil {
+		return "", "", err
 	}
 
-	user, err := s.userRepository.Create(ctx, req.Email, string(hashedPassword))
+	accessToken, refreshToken, err = generateTokens(s.jwtManager, user)
 	if err != nil {
-		s.logger.Error("failed to create user", zap.Error(err))
+		log.Error("failed to generate tokens", zap.Error(err))
 
 		return "", "", err
 	}
 
-	team, err := s.teamRepository.Create(ctx, user.ID, req.TeamName, req.Country, 5000000)
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &user.ID, "auth.registered", entity.AuditEntityUser, user.ID, nil, user),
+		newAuditEntry(ctx, &user.ID, "team.created", entity.AuditEntityTeam, team.ID, nil, team))
+
+	if err := sendVerificationEmail(ctx, s.actionTokenRepository, s.mailer, s.config, user); err != nil {
+		log.Error("failed to send verification email", zap.Error(err))
+	}
+
+	log.Info("user registered successfully", zap.String("user_id", user.ID.String()))
+
+	return accessToken, refreshToken, nil
+}
+
+// createAccount creates a user together with their first team.
+func (s *AuthService) createAccount(ctx context.Context, email, passwordHash, teamName, country string) (*entity.User, *entity.Team, error) {
+	log := s.log(ctx)
+
+	user, err := s.userRepository.Create(ctx, email, passwordHash)
+	if err != nil {
+		log.Error("failed to create user", zap.Error(err))
+
+		return nil, nil, err
+	}
+
+	team, err := createTeam(ctx, s.teamRepository, log, user.ID, teamName, country)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updateLeaderboards(ctx, s.leaderboardRepository, s.leaderboardCacheRepository, log, team.ID)
+
+	return user, team, nil
+}
+
+func (s *AuthService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
+	log := s.log(ctx)
+
+	log.Info("user login attempt", zap.String("email", req.Email))
+
+	retryAfter, err := s.loginAttemptRepository.LockedFor(ctx, req.ClientIP, req.Email)
+	if err != nil {
+		log.Error("failed to get login lockout", zap.Error(err))
+
+		return nil, fmt.Errorf("get login lockout: %w", err)
+	}
+
+	if retryAfter > 0 {
+		log.Warn("login locked out",
+			zap.String("email", req.Email),
+			zap.String("ip", req.ClientIP),
+			zap.Duration("retry_after", retryAfter))
+
+		return nil, tooManyAttempts(retryAfter)
+	}
+
+	user, err := s.userRepository.GetByEmail(ctx, req.Email)
+	if err != nil {
+		log.Warn("user not found", zap.String("email", req.Email))
+		s.recordLoginFailure(ctx, req.ClientIP, req.Email)
+
+		return nil, apperr.ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		log.Warn("invalid password", zap.String("email", req.Email))
+		s.recordLoginFailure(ctx, req.ClientIP, req.Email)
+		recordAudit(ctx, s.auditRepository, log,
+			newAuditEntry(ctx, nil, "auth.login_failed", entity.AuditEntityUser, user.ID, nil, nil))
+
+		return nil, apperr.ErrInvalidCredentials
+	}
+
+	if user.IsBanned() {
+		log.Warn("banned user login attempt", zap.String("user_id", user.ID.String()))
+		recordAudit(ctx, s.auditRepository, log,
+			newAuditEntry(ctx, nil, "auth.login_rejected", entity.AuditEntityUser, user.ID, nil, nil))
+
+		return nil, apperr.ErrAccountBanned
+	}
+
+	if s.config.Account.RequireEmailVerification && !user.IsEmailVerified() {
+		log.Warn("unverified user login attempt", zap.String("user_id", user.ID.String()))
+
+		return nil, apperr.ErrEmailNotVerified
+	}
+
+	if s.passwords.NeedsRehash(user.PasswordHash) {
+		s.rehashPassword(ctx, user, req.Password)
+	}
+
+	if user.HasTwoFactor() {
+		return s.twoFactorChallenge(ctx, user)
+	}
+
+	if err := s.loginAttemptRepository.Reset(ctx, req.ClientIP, req.Email); err != nil {
+		log.Error("failed to reset login attempts", zap.Error(err))
+	}
+
+	accessToken, refreshToken, err := generateTokens(s.jwtManager, user)
+	if err != nil {
+		log.Error("failed to generate tokens", zap.Error(err))
+
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &user.ID, "auth.login_succeeded", entity.AuditEntityUser, user.ID, nil, nil))
+
+	log.Info("user logged in successfully", zap.String("user_id", user.ID.String()))
+
+	return &dto.LoginResponse{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// twoFactorChallenge issues the short-lived token that stands in for the
+// password during the second login step. Login attempts are only reset once
+// the second factor has been checked too.
+func (s *AuthService) twoFactorChallenge(ctx context.Context, user *entity.User) (*dto.LoginResponse, error) {
+	log := s.log(ctx)
+	ttl := s.config.TwoFactor.ChallengeTTL
+
+	challenge, err := s.actionTokenRepository.Issue(ctx, entity.ActionToken{
+		Purpose: entity.TokenPurposeTwoFactorLogin,
+		UserID:  user.ID,
+		Email:   user.Email,
+	}, ttl)
+	if err != nil {
+		log.Error("failed to issue two-factor challenge", zap.Error(err))
+
+		return nil, err
+	}
+
+	log.Info("two-factor challenge issued", zap.String("user_id", user.ID.String()))
+
+	return &dto.LoginResponse{
+		TwoFactorRequired:  true,
+		ChallengeToken:     challenge,
+		ChallengeExpiresIn: int(ttl.Seconds()),
+	}, nil
+}
+
+// CompleteTwoFactorLogin exchanges a login challenge and a TOTP or recovery
+// code for a token pair. The challenge is single-use, so a wrong code means
+// signing in with the password again; wrong codes also count towards the
+// login lockout.
+func (s *AuthService) CompleteTwoFactorLogin(ctx context.Context, req *dto.TwoFactorLoginRequest) (accessToken, refreshToken string, err error) {
+	log := s.log(ctx)
+
+	user, err := s.consumeActionToken(ctx, entity.TokenPurposeTwoFactorLogin, req.ChallengeToken)
 	if err != nil {
-		s.logger.Error("failed to create team", zap.Error(err))
+		if errors.Is(err, apperr.ErrInvalidConfirmationToken) {
+			return "", "", apperr.ErrInvalidTwoFactorChallenge
+		}
 
 		return "", "", err
 	}
 
-	if err := s.createInitialPlayers(ctx, team.ID); err != nil {
-		s.logger.Error("failed to create initial players", zap.Error(err))
+	if !user.HasTwoFactor() {
+		log.Warn("two-factor challenge for account without two-factor", zap.String("user_id", user.ID.String()))
+
+		return "", "", apperr.ErrInvalidTwoFactorChallenge
+	}
+
+	retryAfter, err := s.loginAttemptRepository.LockedFor(ctx, req.ClientIP, user.Email)
+	if err != nil {
+		log.Error("failed to get login lockout", zap.Error(err))
+
+		return "", "", fmt.Errorf("get login lockout: %w", err)
+	}
+
+	if retryAfter > 0 {
+		return "", "", tooManyAttempts(retryAfter)
+	}
+
+	if user.IsBanned() {
+		log.Warn("banned user login attempt", zap.String("user_id", user.ID.String()))
+
+		return "", "", apperr.ErrAccountBanned
+	}
+
+	method, err := s.twoFactor.verify(ctx, user, req.Code)
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidTwoFactorCode) {
+			log.Warn("invalid two-factor code", zap.String("user_id", user.ID.String()))
+			s.recordLoginFailure(ctx, req.ClientIP, user.Email)
+			entry := newAuditEntry(ctx, nil, "auth.login_failed", entity.AuditEntityUser, user.ID, nil, nil)
+			entry.Metadata = map[string]any{"step": "second_factor"}
+			recordAudit(ctx, s.auditRepository, log, entry)
+
+			return "", "", err
+		}
+
+		log.Error("failed to verify two-factor code", zap.Error(err))
 
 		return "", "", err
 	}
 
-	totalValue := int64(20 * 1000000)
+	if err := s.loginAttemptRepository.Reset(ctx, req.ClientIP, user.Email); err != nil {
+		log.Error("failed to reset login attempts", zap.Error(err))
+	}
 
-	if err := s.teamRepository.UpdateTotalValue(ctx, team.ID, totalValue); err != nil {
-		s.logger.Error("failed to update team total value", zap.Error(err))
+	accessToken, refreshToken, err = generateTokens(s.jwtManager, user)
+	if err != nil {
+		log.Error("failed to generate tokens", zap.Error(err))
 
 		return "", "", err
 	}
 
-	accessToken, err = s.jwtManager.GenerateAccessToken(user.ID, user.Email)
+	entry := newAuditEntry(ctx, &user.ID, "auth.login_succeeded", entity.AuditEntityUser, user.ID, nil, nil)
+	entry.Metadata = map[string]any{"second_factor": method}
+	recordAudit(ctx, s.auditRepository, log, entry)
+
+	log.Info("user logged in with two-factor", zap.String("user_id", user.ID.String()), zap.String("method", method))
+
+	return accessToken, refreshToken, nil
+}
+
+func (s *AuthService) ListOIDCProviders(_ context.Context) (*dto.OIDCProvidersResponse, error) {
+	providers := make([]string, 0, len(s.oidcProviders))
+
+	for name := range s.oidcProviders {
+		providers = append(providers, name)
+	}
+
+	slices.Sort(providers)
+
+	return &dto.OIDCProvidersResponse{Providers: providers}, nil
+}
+
+// StartOIDCLogin returns the provider URL to send the user to. The state,
+// nonce and PKCE verifier stay on the server until the callback.
+func (s *AuthService) StartOIDCLogin(ctx context.Context, provider string, req *dto.OIDCAuthorizeRequest) (*dto.OIDCAuthorizeResponse, error) {
+	log := s.log(ctx)
+
+	oidcProvider, ok := s.oidcProviders[provider]
+	if !ok {
+		return nil, apperr.ErrUnknownIdentityProvider
+	}
+
+	var state, nonce, verifier string
+
+	for _, value := range []*string{&state, &nonce, &verifier} {
+		random, err := oidc.RandomString()
+		if err != nil {
+			return nil, err
+		}
+
+		*value = random
+	}
+
+	authURL, err := oidcProvider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallenge(verifier))
 	if err != nil {
-		s.logger.Error("failed to generate access token", zap.Error(err))
+		log.Error("failed to build authorization url", zap.Error(err), zap.String("provider", provider))
 
-		return "", "", fmt.Errorf("generate access token: %w", err)
+		return nil, err
 	}
 
-	refreshToken, err = s.jwtManager.GenerateRefreshToken(user.ID, user.Email)
+	err = s.oidcStateRepository.Save(ctx, state, entity.OIDCLoginState{
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		TeamName:     req.TeamName,
+		Country:      req.Country,
+	}, s.config.OIDC.StateTTL)
 	if err != nil {
-		s.logger.Error("failed to generate refresh token", zap.Error(err))
+		log.Error("failed to store oidc state", zap.Error(err))
 
-		return "", "", fmt.Errorf("generate refresh token: %w", err)
+		return nil, err
+	}
+
+	return &dto.OIDCAuthorizeResponse{AuthorizationURL: authURL}, nil
+}
+
+// CompleteOIDCLogin handles the provider's redirect back. The provider
+// account is matched to a user by its linked identity, otherwise by a
+// verified email; failing both, a new account with a team is created. Users
+// with two-factor authentication get a challenge as with a password login.
+func (s *AuthService) CompleteOIDCLogin(ctx context.Context, provider string, req *dto.OIDCCallbackRequest) (*dto.LoginResponse, error) {
+	log := s.log(ctx).With(zap.String("provider", provider))
+
+	oidcProvider, ok := s.oidcProviders[provider]
+	if !ok {
+		return nil, apperr.ErrUnknownIdentityProvider
+	}
+
+	login, err := s.oidcStateRepository.Consume(ctx, req.State)
+	if err != nil {
+		log.Error("failed to consume oidc state", zap.Error(err))
+
+		return nil, err
+	}
+
+	if login == nil || login.Provider != provider {
+		log.Warn("invalid or expired oidc state")
+
+		return nil, apperr.ErrInvalidOIDCState
+	}
+
+	if req.Error != "" || req.Code == "" {
+		log.Warn("identity provider returned an error",
+			zap.String("error", req.Error),
+			zap.String("error_description", req.ErrorDescription))
+
+		return nil, apperr.ErrIdentityProviderFailed
+	}
+
+	identity, err := oidcProvider.Exchange(ctx, req.Code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		log.Warn("failed to exchange authorization code", zap.Error(err))
+
+		return nil, apperr.ErrIdentityProviderFailed
+	}
+
+	user, err := s.oidcUser(ctx, provider, identity, login)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsBanned() {
+		log.Warn("banned user login attempt", zap.String("user_id", user.ID.String()))
+		recordAudit(ctx, s.auditRepository, log,
+			newAuditEntry(ctx, nil, "auth.login_rejected", entity.AuditEntityUser, user.ID, nil, nil))
+
+		return nil, apperr.ErrAccountBanned
+	}
+
+	if s.config.Account.RequireEmailVerification && !user.IsEmailVerified() {
+		log.Warn("unverified user login attempt", zap.String("user_id", user.ID.String()))
+
+		return nil, apperr.ErrEmailNotVerified
+	}
+
+	if user.HasTwoFactor() {
+		return s.twoFactorChallenge(ctx, user)
+	}
+
+	accessToken, refreshToken, err := generateTokens(s.jwtManager, user)
+	if err != nil {
+		log.Error("failed to generate tokens", zap.Error(err))
+
+		return nil, err
+	}
+
+	entry := newAuditEntry(ctx, &user.ID, "auth.login_succeeded", entity.AuditEntityUser, user.ID, nil, nil)
+	entry.Metadata = map[string]any{"provider": provider}
+	recordAudit(ctx, s.auditRepository, log, entry)
+
+	log.Info("user logged in with oidc", zap.String("user_id", user.ID.String()))
+
+	return &dto.LoginResponse{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// oidcUser returns the user the provider account belongs to, linking or
+// creating one on first login. An existing account is only linked when both
+// sides have verified the email address, so that nobody can take over an
+// account by registering its address at a provider.
+func (s *AuthService) oidcUser(ctx context.Context, provider string, identity *oidc.Identity, login *entity.OIDCLoginState) (*entity.User, error) {
+	log := s.log(ctx).With(zap.String("provider", provider))
+
+	var email *string
+	if identity.Email != "" {
+		email = &identity.Email
 	}
 
-	s.logger.Info("user registered successfully", zap.String("user_id", user.ID.String()))
+	linked, err := s.identityRepository.GetBySubject(ctx, provider, identity.Subject)
+	if err == nil {
+		if err := s.identityRepository.RecordLogin(ctx, linked.ID, email); err != nil {
+			log.Error("failed to record identity login", zap.Error(err))
+		}
+
+		user, err := s.userRepository.GetByID(ctx, linked.UserID)
+		if err != nil {
+			log.Error("failed to get user", zap.Error(err))
+
+			return nil, err
+		}
+
+		return user, nil
+	}
+
+	if !errors.Is(err, apperr.ErrIdentityNotFound) {
+		log.Error("failed to get identity", zap.Error(err))
+
+		return nil, err
+	}
+
+	if email == nil {
+		return nil, apperr.ErrIdentityEmailRequired
+	}
+
+	user, err := s.userRepository.GetByEmail(ctx, identity.Email)
+
+	switch {
+	case err == nil:
+		if !identity.EmailVerified || !user.IsEmailVerified() {
+			log.Warn("oidc email matches an account that cannot be linked", zap.String("user_id", user.ID.String()))
+
+			return nil, apperr.ErrIdentityEmailConflict
+		}
+
+		if _, err := s.identityRepository.Create(ctx, user.ID, provider, identity.Subject, email); err != nil {
+			log.Error("failed to link identity", zap.Error(err))
+
+			return nil, err
+		}
+
+		entry := newAuditEntry(ctx, &user.ID, "user.identity_linked", entity.AuditEntityUser, user.ID, nil, nil)
+		entry.Metadata = map[string]any{"provider": provider}
+		recordAudit(ctx, s.auditRepository, log, entry)
+
+		log.Info("identity linked", zap.String("user_id", user.ID.String()))
+
+		return user, nil
+	case errors.Is(err, apperr.ErrUserNotFound):
+		return s.registerOIDCUser(ctx, provider, identity, login)
+	default:
+		log.Error("failed to get user", zap.Error(err))
+
+		return nil, err
+	}
+}
+
+// registerOIDCUser creates an account for a first-time provider login. It has
+// no password; one can be set through a password reset.
+func (s *AuthService) registerOIDCUser(ctx context.Context, provider string, identity *oidc.Identity, login *entity.OIDCLoginState) (*entity.User, error) {
+	log := s.log(ctx).With(zap.String("provider", provider))
+
+	teamName := login.TeamName
+	if teamName == "" {
+		teamName = defaultTeamName(identity)
+	}
+
+	country := login.Country
+	if country == "" {
+		country = defaultTeamCountry
+	}
+
+	user, team, err := s.createAccount(ctx, identity.Email, "", teamName, country)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.identityRepository.Create(ctx, user.ID, provider, identity.Subject, &identity.Email); err != nil {
+		log.Error("failed to link identity", zap.Error(err))
+
+		return nil, err
+	}
+
+	if identity.EmailVerified {
+		if user, err = s.userRepository.MarkEmailVerified(ctx, user.ID); err != nil {
+			log.Error("failed to mark email verified", zap.Error(err))
+
+			return nil, err
+		}
+	} else if err := sendVerificationEmail(ctx, s.actionTokenRepository, s.mailer, s.config, user); err != nil {
+		log.Error("failed to send verification email", zap.Error(err))
+	}
+
+	registered := newAuditEntry(ctx, &user.ID, "auth.registered", entity.AuditEntityUser, user.ID, nil, user)
+	registered.Metadata = map[string]any{"provider": provider}
+	recordAudit(ctx, s.auditRepository, log,
+		registered,
+		newAuditEntry(ctx, &user.ID, "team.created", entity.AuditEntityTeam, team.ID, nil, team))
+
+	log.Info("user registered with oidc", zap.String("user_id", user.ID.String()))
+
+	return user, nil
+}
+
+// defaultTeamName names the team of a provider login that did not choose a
+// name, after the user's name or, failing that, their email.
+func defaultTeamName(identity *oidc.Identity) string {
+	owner := strings.TrimSpace(identity.Name)
+	if owner == "" {
+		owner, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	name := []rune(owner + " FC")
+	if len(name) > maxTeamNameLength {
+		name = name[:maxTeamNameLength]
+	}
+
+	return strings.TrimSpace(string(name))
+}
+
+// generateTokens issues a new access and refresh token pair for user.
+func generateTokens(jwtManager *jwt.Manager, user *entity.User) (accessToken, refreshToken string, err error) {
+	accessToken, err = jwtManager.GenerateAccessToken(user.ID, user.Email, string(user.Role))
+	if err != nil {
+		return "", "", fmt.Errorf("generate access token: %w", err)
+	}
+
+	refreshToken, err = jwtManager.GenerateRefreshToken(user.ID, user.Email, string(user.Role))
+	if err != nil {
+		return "", "", fmt.Errorf("generate refresh token: %w", err)
+	}
 
 	return accessToken, refreshToken, nil
 }
 
-func (s *AuthService) Login(ctx context.Context, req *dto.LoginRequest) (accessToken, refreshToken string, err error) {
-	s.logger.Info("user login attempt", zap.String("email", req.Email))
+func (s *AuthService) ResendVerificationEmail(ctx context.Context, userID uuid.UUID) error {
+	log := s.log(ctx)
+
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user", zap.Error(err))
+
+		return err
+	}
+
+	if user.IsEmailVerified() {
+		return apperr.ErrEmailAlreadyVerified
+	}
+
+	if err := sendVerificationEmail(ctx, s.actionTokenRepository, s.mailer, s.config, user); err != nil {
+		log.Error("failed to send verification email", zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *AuthService) VerifyEmail(ctx context.Context, req *dto.VerifyEmailRequest) error {
+	log := s.log(ctx)
 
-	attempts, err := s.loginAttemptRepository.Get(ctx, req.Email)
+	user, err := s.consumeActionToken(ctx, entity.TokenPurposeEmailVerification, req.Token)
 	if err != nil {
-		s.logger.Error("failed to get login attempts", zap.Error(err))
+		return err
+	}
 
-		return "", "", fmt.Errorf("get login attempts: %w", err)
+	if user.IsEmailVerified() {
+		return nil
 	}
 
-	if attempts >= s.config.Login.MaxLoginAttempts {
-		s.logger.Warn("too many login attempts", zap.String("email", req.Email), zap.Int("attempts", attempts))
+	verified, err := s.userRepository.MarkEmailVerified(ctx, user.ID)
+	if err != nil {
+		log.Error("failed to mark email verified", zap.Error(err))
 
-		return "", "", apperr.ErrTooManyAttempts
+		return err
 	}
 
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &user.ID, "auth.email_verified", entity.AuditEntityUser, user.ID, user, verified))
+
+	log.Info("email verified", zap.String("user_id", user.ID.String()))
+
+	return nil
+}
+
+// RequestPasswordReset emails a reset link if the address belongs to an
+// account. It succeeds either way, so it cannot be used to probe which
+// addresses are registered.
+func (s *AuthService) RequestPasswordReset(ctx context.Context, req *dto.PasswordResetRequest) error {
+	log := s.log(ctx)
+
 	user, err := s.userRepository.GetByEmail(ctx, req.Email)
 	if err != nil {
-		s.logger.Warn("user not found", zap.String("email", req.Email))
-		s.incrementLoginAttemptsOnError(ctx, req.Email)
+		if errors.Is(err, apperr.ErrUserNotFound) {
+			log.Info("password reset requested for unknown email")
+
+			return nil
+		}
+
+		log.Error("failed to get user", zap.Error(err))
+
+		return err
+	}
+
+	if user.IsBanned() {
+		log.Warn("password reset requested for banned user", zap.String("user_id", user.ID.String()))
+
+		return nil
+	}
+
+	ttl := s.config.Account.PasswordResetTTL
+
+	token, err := s.actionTokenRepository.Issue(ctx, entity.ActionToken{
+		Purpose: entity.TokenPurposePasswordReset,
+		UserID:  user.ID,
+		Email:   user.Email,
+	}, ttl)
+	if err != nil {
+		log.Error("failed to issue password reset token", zap.Error(err))
 
-		return "", "", apperr.ErrInvalidCredentials
+		return err
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
-		s.logger.Warn("invalid password", zap.String("email", req.Email))
-		s.incrementLoginAttemptsOnError(ctx, req.Email)
+	if err := s.mailer.Send(ctx, passwordResetEmail(s.config.Mail.LinkBaseURL, user.Email, token, ttl)); err != nil {
+		log.Error("failed to send password reset email", zap.Error(err))
 
-		return "", "", apperr.ErrInvalidCredentials
+		return err
 	}
 
-	if err := s.loginAttemptRepository.Reset(ctx, req.Email); err != nil {
-		s.logger.Error("failed to reset login attempts", zap.Error(err))
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, nil, "auth.password_reset_requested", entity.AuditEntityUser, user.ID, nil, nil))
+
+	return nil
+}
+
+func (s *AuthService) ResetPassword(ctx context.Context, req *dto.ConfirmPasswordResetRequest) error {
+	log := s.log(ctx)
+
+	user, err := s.consumeActionToken(ctx, entity.TokenPurposePasswordReset, req.Token)
+	if err != nil {
+		return err
 	}
 
-	accessToken, err = s.jwtManager.GenerateAccessToken(user.ID, user.Email)
+	if err := s.passwords.Validate(req.NewPassword, append(teamNames(ctx, s.teamRepository, log, user.ID), user.Email)...); err != nil {
+		log.Warn("password rejected by policy", zap.Error(err))
+
+		return err
+	}
+
+	hashedPassword, err := s.passwords.Hash(req.NewPassword)
 	if err != nil {
-		s.logger.Error("failed to generate access token", zap.Error(err))
+		log.Error("failed to hash password", zap.Error(err))
 
-		return "", "", fmt.Errorf("generate access token: %w", err)
+		return err
 	}
 
-	refreshToken, err = s.jwtManager.GenerateRefreshToken(user.ID, user.Email)
+	if err := s.sessionRepository.RevokeAll(ctx, user.ID, time.Now()); err != nil {
+		log.Error("failed to revoke sessions", zap.Error(err))
+
+		return err
+	}
+
+	if _, err := s.userRepository.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+		log.Error("failed to update password", zap.Error(err))
+
+		return err
+	}
+
+	if err := s.loginAttemptRepository.Unlock(ctx, user.Email); err != nil {
+		log.Error("failed to unlock login", zap.Error(err))
+	}
+
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &user.ID, "auth.password_reset", entity.AuditEntityUser, user.ID, nil, nil))
+
+	log.Info("password reset", zap.String("user_id", user.ID.String()))
+
+	return nil
+}
+
+// rehashPassword re-hashes the password of a user who just logged in with the
+// current bcrypt cost. Login does not depend on it, so failures are only
+// logged.
+func (s *AuthService) rehashPassword(ctx context.Context, user *entity.User, password string) {
+	log := s.log(ctx)
+
+	hashedPassword, err := s.passwords.Hash(password)
 	if err != nil {
-		s.logger.Error("failed to generate refresh token", zap.Error(err))
+		log.Error("failed to rehash password", zap.Error(err))
 
-		return "", "", fmt.Errorf("generate refresh token: %w", err)
+		return
 	}
 
-	s.logger.Info("user logged in successfully", zap.String("user_id", user.ID.String()))
+	if _, err := s.userRepository.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+		log.Error("failed to store rehashed password", zap.Error(err))
 
-	return accessToken, refreshToken, nil
+		return
+	}
+
+	log.Info("password rehashed", zap.String("user_id", user.ID.String()))
 }
 
-func (s *AuthService) createInitialPlayers(ctx context.Context, teamID uuid.UUID) error {
-	positions := []struct {
-		position entity.PlayerPosition
-		count    int
-	}{
-		{entity.PositionGoalkeeper, 3},
-		{entity.PositionDefender, 6},
-		{entity.PositionMidfielder, 6},
-		{entity.PositionAttacker, 5},
-	}
-
-	for _, p := range positions {
-		for i := 0; i < p.count; i++ {
-			firstName := firstNames[rand.Intn(len(firstNames))]
-			lastName := lastNames[rand.Intn(len(lastNames))]
-			country := countries[rand.Intn(len(countries))]
-			age := 18 + rand.Intn(23)
-
-			_, err := s.playerRepository.Create(ctx, teamID, firstName, lastName, country, age, p.position, 1000000)
-			if err != nil {
-				return err
-			}
+// teamNames returns the names of the user's teams, none if they cannot be
+// listed.
+func teamNames(ctx context.Context, teams ports.TeamRepository, log *zap.Logger, userID uuid.UUID) []string {
+	owned, err := teams.ListByUserID(ctx, userID)
+	if err != nil {
+		log.Warn("failed to list teams", zap.Error(err))
+
+		return nil
+	}
+
+	names := make([]string, len(owned))
+	for i, team := range owned {
+		names[i] = team.Name
+	}
+
+	return names
+}
+
+// CheckSession rejects tokens issued before the user's sessions were last
+// revoked, e.g. by a password change, tokens of deleted and banned users, and
+// tokens whose role is no longer the user's.
+func (s *AuthService) CheckSession(ctx context.Context, userID uuid.UUID, role entity.UserRole, issuedAt time.Time) error {
+	log := s.log(ctx)
+
+	revokedBefore, err := s.sessionRepository.RevokedBefore(ctx, userID)
+	if err != nil {
+		log.Error("failed to get sessions revocation", zap.Error(err))
+
+		return err
+	}
+
+	if revokedBefore != nil && issuedAt.Before(*revokedBefore) {
+		return apperr.ErrInvalidToken
+	}
+
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrUserNotFound) {
+			return apperr.ErrInvalidToken
 		}
+
+		log.Error("failed to get user", zap.Error(err))
+
+		return err
+	}
+
+	if user.IsBanned() {
+		return apperr.ErrAccountBanned
+	}
+
+	if user.Role != role {
+		log.Warn("token role no longer matches user", zap.String("user_id", userID.String()))
+
+		return apperr.ErrInvalidToken
 	}
 
 	return nil
 }
+
+// consumeActionToken redeems a single-use token and returns its user. Tokens
+// issued for an address the account no longer has are rejected.
+func (s *AuthService) consumeActionToken(ctx context.Context, purpose entity.TokenPurpose, raw string) (*entity.User, error) {
+	log := s.log(ctx)
+
+	token, err := s.actionTokenRepository.Consume(ctx, purpose, raw)
+	if err != nil {
+		log.Error("failed to consume token", zap.Error(err))
+
+		return nil, err
+	}
+
+	if token == nil {
+		log.Warn("invalid or expired token", zap.String("purpose", string(purpose)))
+
+		return nil, apperr.ErrInvalidConfirmationToken
+	}
+
+	user, err := s.userRepository.GetByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrUserNotFound) {
+			return nil, apperr.ErrInvalidConfirmationToken
+		}
+
+		log.Error("failed to get user", zap.Error(err))
+
+		return nil, err
+	}
+
+	if user.Email != token.Email {
+		log.Warn("token issued for a previous email", zap.String("user_id", user.ID.String()))
+
+		return nil, apperr.ErrInvalidConfirmationToken
+	}
+
+	return user, nil
+}
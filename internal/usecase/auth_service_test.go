@@ -3,12 +3,20 @@ package usecase
 import (
 	"context"
 	"errors"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/dto"
 	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
 	apperr "soccer_manager_service/pkg/errors"
 	"soccer_manager_service/pkg/jwt"
+	"soccer_manager_service/pkg/mailer"
+	"soccer_manager_service/pkg/oidc"
+	"soccer_manager_service/pkg/oidc/oidctest"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -56,44 +64,192 @@ func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*ent
 	return args.Get(0).(*entity.User), args.Error(1)
 }
 
+func (m *MockUserRepository) List(ctx context.Context, search string, limit, offset uint) ([]entity.User, error) {
+	args := m.Called(ctx, search, limit, offset)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateRole(ctx context.Context, id uuid.UUID, role entity.UserRole) (*entity.User, error) {
+	args := m.Called(ctx, id, role)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) SetBan(ctx context.Context, id uuid.UUID, bannedAt *time.Time, reason *string) (*entity.User, error) {
+	args := m.Called(ctx, id, bannedAt, reason)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	args := m.Called(ctx, id)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) (*entity.User, error) {
+	args := m.Called(ctx, id, passwordHash)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateEmail(ctx context.Context, id uuid.UUID, email string) (*entity.User, error) {
+	args := m.Called(ctx, id, email)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) (*entity.AccountDeletion, error) {
+	args := m.Called(ctx, id)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.AccountDeletion), args.Error(1)
+}
+
 type MockLoginAttemptRepository struct {
 	mock.Mock
 }
 
-func (m *MockLoginAttemptRepository) Increment(ctx context.Context, email string) (int, error) {
-	args := m.Called(ctx, email)
+func (m *MockLoginAttemptRepository) LockedFor(ctx context.Context, ip, email string) (time.Duration, error) {
+	args := m.Called(ctx, ip, email)
 
-	return args.Int(0), args.Error(1)
+	return args.Get(0).(time.Duration), args.Error(1)
 }
 
-func (m *MockLoginAttemptRepository) Get(ctx context.Context, email string) (int, error) {
-	args := m.Called(ctx, email)
+func (m *MockLoginAttemptRepository) RecordFailure(ctx context.Context, ip, email string) (time.Duration, error) {
+	args := m.Called(ctx, ip, email)
 
-	return args.Int(0), args.Error(1)
+	return args.Get(0).(time.Duration), args.Error(1)
 }
 
-func (m *MockLoginAttemptRepository) Reset(ctx context.Context, email string) error {
+func (m *MockLoginAttemptRepository) Reset(ctx context.Context, ip, email string) error {
+	args := m.Called(ctx, ip, email)
+
+	return args.Error(0)
+}
+
+func (m *MockLoginAttemptRepository) Unlock(ctx context.Context, email string) error {
 	args := m.Called(ctx, email)
 
 	return args.Error(0)
 }
 
+func (m *MockLoginAttemptRepository) UnlockIP(ctx context.Context, ip string) error {
+	args := m.Called(ctx, ip)
+
+	return args.Error(0)
+}
+
+const testClientIP = "203.0.113.7"
+
+type MockActionTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockActionTokenRepository) Issue(ctx context.Context, token entity.ActionToken, ttl time.Duration) (string, error) {
+	args := m.Called(ctx, token, ttl)
+
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockActionTokenRepository) Consume(ctx context.Context, purpose entity.TokenPurpose, raw string) (*entity.ActionToken, error) {
+	args := m.Called(ctx, purpose, raw)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.ActionToken), args.Error(1)
+}
+
+func (m *MockActionTokenRepository) Revoke(ctx context.Context, purpose entity.TokenPurpose, userID uuid.UUID) error {
+	args := m.Called(ctx, purpose, userID)
+
+	return args.Error(0)
+}
+
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) RevokeAll(ctx context.Context, userID uuid.UUID, before time.Time) error {
+	args := m.Called(ctx, userID, before)
+
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) RevokedBefore(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	args := m.Called(ctx, userID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(ctx context.Context, msg mailer.Message) error {
+	args := m.Called(ctx, msg)
+
+	return args.Error(0)
+}
+
 func TestAuthService_Register(t *testing.T) {
 	logger := zap.NewNop()
 	ctx := context.Background()
-	jwtManager := jwt.NewManager("test-secret", 0, 0)
+	jwtManager := jwt.NewManager(jwt.Config{Keys: jwt.NewHMACKeyRing("test-secret")})
 
 	cfg := &config.Config{
 		Login: config.LoginConfig{
 			MaxLoginAttempts: 5,
 		},
+		Mail: config.MailConfig{
+			LinkBaseURL: "https://example.com",
+		},
+		Account: config.AccountConfig{
+			EmailVerificationTTL: 24 * time.Hour,
+		},
 	}
 
 	t.Run("success", func(t *testing.T) {
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		mockPlayerRepo := new(MockPlayerRepository)
 		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
+		mockTokenRepo := new(MockActionTokenRepository)
+		mockMailer := new(MockMailer)
+		mockScores, mockBoards := newMockLeaderboards()
 
 		userID := uuid.New()
 		teamID := uuid.New()
@@ -110,23 +266,32 @@ func TestAuthService_Register(t *testing.T) {
 
 		mockUserRepo.On("GetByEmail", ctx, "test@example.com").Return(nil, apperr.ErrUserNotFound)
 		mockUserRepo.On("Create", ctx, "test@example.com", mock.AnythingOfType("string")).Return(user, nil)
-		mockTeamRepo.On("Create", ctx, userID, "Test Team", "England", int64(5000000)).Return(team, nil)
-		mockPlayerRepo.On("Create", ctx, teamID, mock.AnythingOfType("string"), mock.AnythingOfType("string"), 
-			mock.AnythingOfType("string"), mock.AnythingOfType("int"), mock.AnythingOfType("entity.PlayerPosition"), 
-			int64(1000000)).Return(&entity.Player{}, nil).Times(20)
-		mockTeamRepo.On("UpdateTotalValue", ctx, teamID, int64(20000000)).Return(nil)
+		mockTeamRepo.On("Create", ctx, isTeamFounding(userID, "Test Team", "England")).Return(team, nil)
+		mockTokenRepo.On("Issue", ctx, entity.ActionToken{
+			Purpose: entity.TokenPurposeEmailVerification,
+			UserID:  userID,
+			Email:   "test@example.com",
+		}, 24*time.Hour).Return("verify-token", nil)
+		mockMailer.On("Send", ctx, mock.MatchedBy(func(msg mailer.Message) bool {
+			return msg.To == "test@example.com" &&
+				strings.Contains(msg.Body, "https://example.com/verify-email?token=verify-token")
+		})).Return(nil)
 
 		service := NewAuthService(AuthServiceParams{
-			UserRepository:         mockUserRepo,
-			TeamRepository:         mockTeamRepo,
-			PlayerRepository:       mockPlayerRepo,
-			LoginAttemptRepository: mockLoginAttemptRepo,
-			JWTManager:             jwtManager,
-			Logger:                 logger,
-			Config:                 cfg,
+			UserRepository:             mockUserRepo,
+			TeamRepository:             mockTeamRepo,
+			LoginAttemptRepository:     mockLoginAttemptRepo,
+			JWTManager:                 jwtManager,
+			AuditRepository:            newMockAuditRepository(),
+			ActionTokenRepository:      mockTokenRepo,
+			LeaderboardRepository:      mockScores,
+			LeaderboardCacheRepository: mockBoards,
+			Mailer:                     mockMailer,
+			Logger:                     logger,
+			Config:                     cfg,
 		})
 
-		req := &entity.RegisterRequest{
+		req := &dto.RegisterRequest{
 			Email:    "test@example.com",
 			Password: "password123",
 			TeamName: "Test Team",
@@ -138,15 +303,16 @@ func TestAuthService_Register(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotEmpty(t, accessToken)
 		assert.NotEmpty(t, refreshToken)
+		mockScores.AssertCalled(t, "Scores", ctx, entity.LeaderboardTotalValue, entity.LeaderboardFilter{TeamIDs: []uuid.UUID{teamID}})
 		mockUserRepo.AssertExpectations(t)
 		mockTeamRepo.AssertExpectations(t)
-		mockPlayerRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
 	})
 
 	t.Run("user already exists", func(t *testing.T) {
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		mockPlayerRepo := new(MockPlayerRepository)
 		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
 
 		existingUser := &entity.User{
@@ -159,14 +325,14 @@ func TestAuthService_Register(t *testing.T) {
 		service := NewAuthService(AuthServiceParams{
 			UserRepository:         mockUserRepo,
 			TeamRepository:         mockTeamRepo,
-			PlayerRepository:       mockPlayerRepo,
 			LoginAttemptRepository: mockLoginAttemptRepo,
 			JWTManager:             jwtManager,
+			AuditRepository:        newMockAuditRepository(),
 			Logger:                 logger,
 			Config:                 cfg,
 		})
 
-		req := &entity.RegisterRequest{
+		req := &dto.RegisterRequest{
 			Email:    "test@example.com",
 			Password: "password123",
 			TeamName: "Test Team",
@@ -185,7 +351,6 @@ func TestAuthService_Register(t *testing.T) {
 	t.Run("failed to create user", func(t *testing.T) {
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		mockPlayerRepo := new(MockPlayerRepository)
 		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
 
 		mockUserRepo.On("GetByEmail", ctx, "test@example.com").Return(nil, apperr.ErrUserNotFound)
@@ -194,14 +359,14 @@ func TestAuthService_Register(t *testing.T) {
 		service := NewAuthService(AuthServiceParams{
 			UserRepository:         mockUserRepo,
 			TeamRepository:         mockTeamRepo,
-			PlayerRepository:       mockPlayerRepo,
 			LoginAttemptRepository: mockLoginAttemptRepo,
 			JWTManager:             jwtManager,
+			AuditRepository:        newMockAuditRepository(),
 			Logger:                 logger,
 			Config:                 cfg,
 		})
 
-		req := &entity.RegisterRequest{
+		req := &dto.RegisterRequest{
 			Email:    "test@example.com",
 			Password: "password123",
 			TeamName: "Test Team",
@@ -219,7 +384,6 @@ func TestAuthService_Register(t *testing.T) {
 	t.Run("failed to create team", func(t *testing.T) {
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		mockPlayerRepo := new(MockPlayerRepository)
 		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
 
 		userID := uuid.New()
@@ -230,19 +394,19 @@ func TestAuthService_Register(t *testing.T) {
 
 		mockUserRepo.On("GetByEmail", ctx, "test@example.com").Return(nil, apperr.ErrUserNotFound)
 		mockUserRepo.On("Create", ctx, "test@example.com", mock.AnythingOfType("string")).Return(user, nil)
-		mockTeamRepo.On("Create", ctx, userID, "Test Team", "England", int64(5000000)).Return(nil, errors.New("database error"))
+		mockTeamRepo.On("Create", ctx, isTeamFounding(userID, "Test Team", "England")).Return(nil, errors.New("database error"))
 
 		service := NewAuthService(AuthServiceParams{
 			UserRepository:         mockUserRepo,
 			TeamRepository:         mockTeamRepo,
-			PlayerRepository:       mockPlayerRepo,
 			LoginAttemptRepository: mockLoginAttemptRepo,
 			JWTManager:             jwtManager,
+			AuditRepository:        newMockAuditRepository(),
 			Logger:                 logger,
 			Config:                 cfg,
 		})
 
-		req := &entity.RegisterRequest{
+		req := &dto.RegisterRequest{
 			Email:    "test@example.com",
 			Password: "password123",
 			TeamName: "Test Team",
@@ -262,7 +426,7 @@ func TestAuthService_Register(t *testing.T) {
 func TestAuthService_Login(t *testing.T) {
 	logger := zap.NewNop()
 	ctx := context.Background()
-	jwtManager := jwt.NewManager("test-secret", 0, 0)
+	jwtManager := jwt.NewManager(jwt.Config{Keys: jwt.NewHMACKeyRing("test-secret")})
 
 	cfg := &config.Config{
 		Login: config.LoginConfig{
@@ -273,7 +437,6 @@ func TestAuthService_Login(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		mockPlayerRepo := new(MockPlayerRepository)
 		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
 
 		userID := uuid.New()
@@ -283,30 +446,32 @@ func TestAuthService_Login(t *testing.T) {
 			PasswordHash: hashPassword("password"),
 		}
 
-		mockLoginAttemptRepo.On("Get", ctx, "test@example.com").Return(0, nil)
+		mockLoginAttemptRepo.On("LockedFor", ctx, testClientIP, "test@example.com").Return(time.Duration(0), nil)
 		mockUserRepo.On("GetByEmail", ctx, "test@example.com").Return(user, nil)
-		mockLoginAttemptRepo.On("Reset", ctx, "test@example.com").Return(nil)
+		mockLoginAttemptRepo.On("Reset", ctx, testClientIP, "test@example.com").Return(nil)
 
 		service := NewAuthService(AuthServiceParams{
 			UserRepository:         mockUserRepo,
 			TeamRepository:         mockTeamRepo,
-			PlayerRepository:       mockPlayerRepo,
 			LoginAttemptRepository: mockLoginAttemptRepo,
 			JWTManager:             jwtManager,
+			AuditRepository:        newMockAuditRepository(),
 			Logger:                 logger,
 			Config:                 cfg,
 		})
 
-		req := &entity.LoginRequest{
+		req := &dto.LoginRequest{
 			Email:    "test@example.com",
 			Password: "password",
+			ClientIP: testClientIP,
 		}
 
-		accessToken, refreshToken, err := service.Login(ctx, req)
+		resp, err := service.Login(ctx, req)
 
 		assert.NoError(t, err)
-		assert.NotEmpty(t, accessToken)
-		assert.NotEmpty(t, refreshToken)
+		assert.NotEmpty(t, resp.AccessToken)
+		assert.NotEmpty(t, resp.RefreshToken)
+		assert.False(t, resp.TwoFactorRequired)
 		mockUserRepo.AssertExpectations(t)
 		mockLoginAttemptRepo.AssertExpectations(t)
 	})
@@ -314,65 +479,68 @@ func TestAuthService_Login(t *testing.T) {
 	t.Run("too many attempts", func(t *testing.T) {
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		mockPlayerRepo := new(MockPlayerRepository)
 		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
 
-		mockLoginAttemptRepo.On("Get", ctx, "test@example.com").Return(5, nil)
+		mockLoginAttemptRepo.On("LockedFor", ctx, testClientIP, "test@example.com").Return(90*time.Second, nil)
 
 		service := NewAuthService(AuthServiceParams{
 			UserRepository:         mockUserRepo,
 			TeamRepository:         mockTeamRepo,
-			PlayerRepository:       mockPlayerRepo,
 			LoginAttemptRepository: mockLoginAttemptRepo,
 			JWTManager:             jwtManager,
+			AuditRepository:        newMockAuditRepository(),
 			Logger:                 logger,
 			Config:                 cfg,
 		})
 
-		req := &entity.LoginRequest{
+		req := &dto.LoginRequest{
 			Email:    "test@example.com",
 			Password: "password",
+			ClientIP: testClientIP,
 		}
 
-		accessToken, refreshToken, err := service.Login(ctx, req)
+		resp, err := service.Login(ctx, req)
 
 		assert.Error(t, err)
-		assert.Empty(t, accessToken)
-		assert.Empty(t, refreshToken)
-		assert.Equal(t, apperr.ErrTooManyAttempts, err)
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, apperr.ErrTooManyAttempts)
+
+		retryAfter, ok := apperr.RetryAfter(err)
+		assert.True(t, ok)
+		assert.Equal(t, 90*time.Second, retryAfter)
 		mockLoginAttemptRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "GetByEmail", ctx, "test@example.com")
 	})
 
 	t.Run("user not found", func(t *testing.T) {
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		mockPlayerRepo := new(MockPlayerRepository)
 		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
 
-		mockLoginAttemptRepo.On("Get", ctx, "test@example.com").Return(0, nil)
+		mockLoginAttemptRepo.On("LockedFor", ctx, testClientIP, "test@example.com").Return(time.Duration(0), nil)
 		mockUserRepo.On("GetByEmail", ctx, "test@example.com").Return(nil, apperr.ErrUserNotFound)
-		mockLoginAttemptRepo.On("Increment", ctx, "test@example.com").Return(1, nil)
+		mockLoginAttemptRepo.On("RecordFailure", ctx, testClientIP, "test@example.com").Return(time.Duration(0), nil)
 
 		service := NewAuthService(AuthServiceParams{
 			UserRepository:         mockUserRepo,
 			TeamRepository:         mockTeamRepo,
-			PlayerRepository:       mockPlayerRepo,
 			LoginAttemptRepository: mockLoginAttemptRepo,
 			JWTManager:             jwtManager,
+			AuditRepository:        newMockAuditRepository(),
 			Logger:                 logger,
 			Config:                 cfg,
 		})
 
-		req := &entity.LoginRequest{
+		req := &dto.LoginRequest{
 			Email:    "test@example.com",
 			Password: "password",
+			ClientIP: testClientIP,
 		}
 
-		accessToken, refreshToken, err := service.Login(ctx, req)
+		resp, err := service.Login(ctx, req)
 
 		assert.Error(t, err)
-		assert.Empty(t, accessToken)
-		assert.Empty(t, refreshToken)
+		assert.Nil(t, resp)
 		assert.Equal(t, apperr.ErrInvalidCredentials, err)
 		mockUserRepo.AssertExpectations(t)
 		mockLoginAttemptRepo.AssertExpectations(t)
@@ -381,7 +549,6 @@ func TestAuthService_Login(t *testing.T) {
 	t.Run("invalid password", func(t *testing.T) {
 		mockUserRepo := new(MockUserRepository)
 		mockTeamRepo := new(MockTeamRepository)
-		mockPlayerRepo := new(MockPlayerRepository)
 		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
 
 		userID := uuid.New()
@@ -391,32 +558,773 @@ func TestAuthService_Login(t *testing.T) {
 			PasswordHash: hashPassword("password"),
 		}
 
-		mockLoginAttemptRepo.On("Get", ctx, "test@example.com").Return(0, nil)
+		mockLoginAttemptRepo.On("LockedFor", ctx, testClientIP, "test@example.com").Return(time.Duration(0), nil)
 		mockUserRepo.On("GetByEmail", ctx, "test@example.com").Return(user, nil)
-		mockLoginAttemptRepo.On("Increment", ctx, "test@example.com").Return(1, nil)
+		mockLoginAttemptRepo.On("RecordFailure", ctx, testClientIP, "test@example.com").Return(time.Duration(0), nil)
 
 		service := NewAuthService(AuthServiceParams{
 			UserRepository:         mockUserRepo,
 			TeamRepository:         mockTeamRepo,
-			PlayerRepository:       mockPlayerRepo,
 			LoginAttemptRepository: mockLoginAttemptRepo,
 			JWTManager:             jwtManager,
+			AuditRepository:        newMockAuditRepository(),
 			Logger:                 logger,
 			Config:                 cfg,
 		})
 
-		req := &entity.LoginRequest{
+		req := &dto.LoginRequest{
 			Email:    "test@example.com",
 			Password: "wrongpassword",
+			ClientIP: testClientIP,
 		}
 
-		accessToken, refreshToken, err := service.Login(ctx, req)
+		resp, err := service.Login(ctx, req)
 
 		assert.Error(t, err)
-		assert.Empty(t, accessToken)
-		assert.Empty(t, refreshToken)
+		assert.Nil(t, resp)
 		assert.Equal(t, apperr.ErrInvalidCredentials, err)
 		mockUserRepo.AssertExpectations(t)
 		mockLoginAttemptRepo.AssertExpectations(t)
 	})
+
+	t.Run("banned user", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
+
+		bannedAt := time.Now()
+		user := &entity.User{
+			ID:           uuid.New(),
+			Email:        "test@example.com",
+			PasswordHash: hashPassword("password"),
+			BannedAt:     &bannedAt,
+		}
+
+		mockLoginAttemptRepo.On("LockedFor", ctx, testClientIP, "test@example.com").Return(time.Duration(0), nil)
+		mockUserRepo.On("GetByEmail", ctx, "test@example.com").Return(user, nil)
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:         mockUserRepo,
+			TeamRepository:         mockTeamRepo,
+			LoginAttemptRepository: mockLoginAttemptRepo,
+			JWTManager:             jwtManager,
+			AuditRepository:        newMockAuditRepository(),
+			Logger:                 logger,
+			Config:                 cfg,
+		})
+
+		req := &dto.LoginRequest{
+			Email:    "test@example.com",
+			Password: "password",
+			ClientIP: testClientIP,
+		}
+
+		resp, err := service.Login(ctx, req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperr.ErrAccountBanned, err)
+		mockUserRepo.AssertExpectations(t)
+		mockLoginAttemptRepo.AssertNotCalled(t, "Reset", ctx, testClientIP, "test@example.com")
+	})
+}
+
+func TestAuthService_LoginRequiresEmailVerification(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	jwtManager := jwt.NewManager(jwt.Config{Keys: jwt.NewHMACKeyRing("test-secret")})
+
+	cfg := &config.Config{
+		Login: config.LoginConfig{
+			MaxLoginAttempts: 5,
+		},
+		Account: config.AccountConfig{
+			RequireEmailVerification: true,
+		},
+	}
+
+	mockUserRepo := new(MockUserRepository)
+	mockLoginAttemptRepo := new(MockLoginAttemptRepository)
+
+	user := &entity.User{
+		ID:           uuid.New(),
+		Email:        "test@example.com",
+		PasswordHash: hashPassword("password"),
+	}
+
+	mockLoginAttemptRepo.On("LockedFor", ctx, testClientIP, "test@example.com").Return(time.Duration(0), nil)
+	mockUserRepo.On("GetByEmail", ctx, "test@example.com").Return(user, nil)
+
+	service := NewAuthService(AuthServiceParams{
+		UserRepository:         mockUserRepo,
+		LoginAttemptRepository: mockLoginAttemptRepo,
+		JWTManager:             jwtManager,
+		AuditRepository:        newMockAuditRepository(),
+		Logger:                 logger,
+		Config:                 cfg,
+	})
+
+	resp, err := service.Login(ctx, &dto.LoginRequest{
+		Email:    "test@example.com",
+		Password: "password",
+		ClientIP: testClientIP,
+	})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, apperr.ErrEmailNotVerified, err)
+	mockLoginAttemptRepo.AssertNotCalled(t, "Reset", ctx, testClientIP, "test@example.com")
+}
+
+func TestAuthService_VerifyEmail(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	userID := uuid.New()
+	token := &entity.ActionToken{
+		Purpose: entity.TokenPurposeEmailVerification,
+		UserID:  userID,
+		Email:   "test@example.com",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockActionTokenRepository)
+
+		verifiedAt := time.Now()
+		user := &entity.User{ID: userID, Email: "test@example.com"}
+		verified := &entity.User{ID: userID, Email: "test@example.com", EmailVerifiedAt: &verifiedAt}
+
+		mockTokenRepo.On("Consume", ctx, entity.TokenPurposeEmailVerification, "raw").Return(token, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+		mockUserRepo.On("MarkEmailVerified", ctx, userID).Return(verified, nil)
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:        mockUserRepo,
+			ActionTokenRepository: mockTokenRepo,
+			AuditRepository:       newMockAuditRepository(),
+			Logger:                logger,
+			Config:                &config.Config{},
+		})
+
+		err := service.VerifyEmail(ctx, &dto.VerifyEmailRequest{Token: "raw"})
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockActionTokenRepository)
+
+		mockTokenRepo.On("Consume", ctx, entity.TokenPurposeEmailVerification, "raw").Return(nil, nil)
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:        mockUserRepo,
+			ActionTokenRepository: mockTokenRepo,
+			AuditRepository:       newMockAuditRepository(),
+			Logger:                logger,
+			Config:                &config.Config{},
+		})
+
+		err := service.VerifyEmail(ctx, &dto.VerifyEmailRequest{Token: "raw"})
+
+		assert.Equal(t, apperr.ErrInvalidConfirmationToken, err)
+		mockUserRepo.AssertNotCalled(t, "MarkEmailVerified", mock.Anything, mock.Anything)
+	})
+
+	t.Run("email changed since token was issued", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockActionTokenRepository)
+
+		user := &entity.User{ID: userID, Email: "new@example.com"}
+
+		mockTokenRepo.On("Consume", ctx, entity.TokenPurposeEmailVerification, "raw").Return(token, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:        mockUserRepo,
+			ActionTokenRepository: mockTokenRepo,
+			AuditRepository:       newMockAuditRepository(),
+			Logger:                logger,
+			Config:                &config.Config{},
+		})
+
+		err := service.VerifyEmail(ctx, &dto.VerifyEmailRequest{Token: "raw"})
+
+		assert.Equal(t, apperr.ErrInvalidConfirmationToken, err)
+		mockUserRepo.AssertNotCalled(t, "MarkEmailVerified", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_RequestPasswordReset(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	cfg := &config.Config{
+		Mail: config.MailConfig{
+			LinkBaseURL: "https://example.com/",
+		},
+		Account: config.AccountConfig{
+			PasswordResetTTL: time.Hour,
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockActionTokenRepository)
+		mockMailer := new(MockMailer)
+
+		user := &entity.User{ID: uuid.New(), Email: "test@example.com"}
+
+		mockUserRepo.On("GetByEmail", ctx, "test@example.com").Return(user, nil)
+		mockTokenRepo.On("Issue", ctx, entity.ActionToken{
+			Purpose: entity.TokenPurposePasswordReset,
+			UserID:  user.ID,
+			Email:   user.Email,
+		}, time.Hour).Return("reset-token", nil)
+		mockMailer.On("Send", ctx, mock.MatchedBy(func(msg mailer.Message) bool {
+			return msg.To == "test@example.com" &&
+				strings.Contains(msg.Body, "https://example.com/reset-password?token=reset-token")
+		})).Return(nil)
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:        mockUserRepo,
+			ActionTokenRepository: mockTokenRepo,
+			Mailer:                mockMailer,
+			AuditRepository:       newMockAuditRepository(),
+			Logger:                logger,
+			Config:                cfg,
+		})
+
+		err := service.RequestPasswordReset(ctx, &dto.PasswordResetRequest{Email: "test@example.com"})
+
+		assert.NoError(t, err)
+		mockTokenRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockActionTokenRepository)
+		mockMailer := new(MockMailer)
+
+		mockUserRepo.On("GetByEmail", ctx, "nobody@example.com").Return(nil, apperr.ErrUserNotFound)
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:        mockUserRepo,
+			ActionTokenRepository: mockTokenRepo,
+			Mailer:                mockMailer,
+			AuditRepository:       newMockAuditRepository(),
+			Logger:                logger,
+			Config:                cfg,
+		})
+
+		err := service.RequestPasswordReset(ctx, &dto.PasswordResetRequest{Email: "nobody@example.com"})
+
+		assert.NoError(t, err)
+		mockTokenRepo.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockActionTokenRepository)
+		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
+		mockSessionRepo := new(MockSessionRepository)
+		mockTeamRepo := new(MockTeamRepository)
+
+		user := &entity.User{ID: userID, Email: "test@example.com", PasswordHash: hashPassword("old-password")}
+
+		mockTokenRepo.On("Consume", ctx, entity.TokenPurposePasswordReset, "raw").Return(&entity.ActionToken{
+			Purpose: entity.TokenPurposePasswordReset,
+			UserID:  userID,
+			Email:   "test@example.com",
+		}, nil)
+		mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{{Name: "Test Team"}}, nil)
+		mockSessionRepo.On("RevokeAll", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil)
+		mockUserRepo.On("UpdatePassword", ctx, userID, mock.MatchedBy(func(hash string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(hash), []byte("new-password")) == nil
+		})).Return(user, nil)
+		mockLoginAttemptRepo.On("Unlock", ctx, "test@example.com").Return(nil)
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:         mockUserRepo,
+			TeamRepository:         mockTeamRepo,
+			ActionTokenRepository:  mockTokenRepo,
+			SessionRepository:      mockSessionRepo,
+			LoginAttemptRepository: mockLoginAttemptRepo,
+			AuditRepository:        newMockAuditRepository(),
+			Logger:                 logger,
+			Config:                 &config.Config{},
+		})
+
+		err := service.ResetPassword(ctx, &dto.ConfirmPasswordResetRequest{Token: "raw", NewPassword: "new-password"})
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+		mockLoginAttemptRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockActionTokenRepository)
+
+		mockTokenRepo.On("Consume", ctx, entity.TokenPurposePasswordReset, "raw").Return(nil, nil)
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:        mockUserRepo,
+			ActionTokenRepository: mockTokenRepo,
+			AuditRepository:       newMockAuditRepository(),
+			Logger:                logger,
+			Config:                &config.Config{},
+		})
+
+		err := service.ResetPassword(ctx, &dto.ConfirmPasswordResetRequest{Token: "raw", NewPassword: "new-password"})
+
+		assert.Equal(t, apperr.ErrInvalidConfirmationToken, err)
+		mockUserRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_CheckSession(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	userID := uuid.New()
+	revokedBefore := time.Now()
+
+	bannedAt := time.Now()
+
+	bannedID := uuid.New()
+	deletedID := uuid.New()
+
+	mockSessionRepo := new(MockSessionRepository)
+	mockSessionRepo.On("RevokedBefore", ctx, userID).Return(&revokedBefore, nil)
+	mockSessionRepo.On("RevokedBefore", ctx, bannedID).Return(nil, nil)
+	mockSessionRepo.On("RevokedBefore", ctx, deletedID).Return(nil, nil)
+
+	mockUserRepo := new(MockUserRepository)
+	mockUserRepo.On("GetByID", ctx, userID).Return(&entity.User{ID: userID, Role: entity.RoleManager}, nil)
+	mockUserRepo.On("GetByID", ctx, bannedID).Return(&entity.User{ID: bannedID, Role: entity.RoleManager, BannedAt: &bannedAt}, nil)
+	mockUserRepo.On("GetByID", ctx, deletedID).Return(nil, apperr.ErrUserNotFound)
+
+	service := NewAuthService(AuthServiceParams{
+		UserRepository:    mockUserRepo,
+		SessionRepository: mockSessionRepo,
+		Logger:            logger,
+		Config:            &config.Config{},
+	})
+
+	t.Run("issued after revocation", func(t *testing.T) {
+		assert.NoError(t, service.CheckSession(ctx, userID, entity.RoleManager, revokedBefore))
+	})
+
+	t.Run("issued before revocation", func(t *testing.T) {
+		err := service.CheckSession(ctx, userID, entity.RoleManager, revokedBefore.Add(-time.Second))
+
+		assert.Equal(t, apperr.ErrInvalidToken, err)
+	})
+
+	t.Run("role changed", func(t *testing.T) {
+		err := service.CheckSession(ctx, userID, entity.RoleAdmin, revokedBefore)
+
+		assert.Equal(t, apperr.ErrInvalidToken, err)
+	})
+
+	t.Run("banned", func(t *testing.T) {
+		err := service.CheckSession(ctx, bannedID, entity.RoleManager, time.Now())
+
+		assert.Equal(t, apperr.ErrAccountBanned, err)
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		err := service.CheckSession(ctx, deletedID, entity.RoleManager, time.Now())
+
+		assert.Equal(t, apperr.ErrInvalidToken, err)
+	})
+
+	t.Run("never revoked", func(t *testing.T) {
+		otherID := uuid.New()
+		mockSessionRepo.On("RevokedBefore", ctx, otherID).Return(nil, nil)
+		mockUserRepo.On("GetByID", ctx, otherID).Return(&entity.User{ID: otherID, Role: entity.RoleManager}, nil)
+
+		assert.NoError(t, service.CheckSession(ctx, otherID, entity.RoleManager, time.Time{}))
+	})
+}
+
+func TestAuthService_LoginRehashesPassword(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	jwtManager := jwt.NewManager(jwt.Config{Keys: jwt.NewHMACKeyRing("test-secret")})
+
+	cfg := &config.Config{
+		Login: config.LoginConfig{
+			MaxLoginAttempts: 5,
+		},
+		Password: config.PasswordConfig{
+			BcryptCost: bcrypt.MinCost,
+		},
+	}
+
+	mockUserRepo := new(MockUserRepository)
+	mockLoginAttemptRepo := new(MockLoginAttemptRepository)
+
+	user := &entity.User{
+		ID:           uuid.New(),
+		Email:        "test@example.com",
+		PasswordHash: hashPassword("password"),
+	}
+
+	mockLoginAttemptRepo.On("LockedFor", ctx, testClientIP, "test@example.com").Return(time.Duration(0), nil)
+	mockUserRepo.On("GetByEmail", ctx, "test@example.com").Return(user, nil)
+	mockLoginAttemptRepo.On("Reset", ctx, testClientIP, "test@example.com").Return(nil)
+	mockUserRepo.On("UpdatePassword", ctx, user.ID, mock.MatchedBy(func(hash string) bool {
+		cost, err := bcrypt.Cost([]byte(hash))
+
+		return err == nil && cost == bcrypt.MinCost &&
+			bcrypt.CompareHashAndPassword([]byte(hash), []byte("password")) == nil
+	})).Return(user, nil)
+
+	service := NewAuthService(AuthServiceParams{
+		UserRepository:         mockUserRepo,
+		LoginAttemptRepository: mockLoginAttemptRepo,
+		JWTManager:             jwtManager,
+		AuditRepository:        newMockAuditRepository(),
+		Logger:                 logger,
+		Config:                 cfg,
+	})
+
+	_, err := service.Login(ctx, &dto.LoginRequest{
+		Email:    "test@example.com",
+		Password: "password",
+		ClientIP: testClientIP,
+	})
+
+	assert.NoError(t, err)
+	mockUserRepo.AssertExpectations(t)
+}
+
+type MockIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdentityRepository) GetBySubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	args := m.Called(ctx, provider, subject)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.UserIdentity), args.Error(1)
+}
+
+func (m *MockIdentityRepository) Create(ctx context.Context, userID uuid.UUID, provider, subject string, email *string) (*entity.UserIdentity, error) {
+	args := m.Called(ctx, userID, provider, subject, email)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.UserIdentity), args.Error(1)
+}
+
+func (m *MockIdentityRepository) RecordLogin(ctx context.Context, id uuid.UUID, email *string) error {
+	args := m.Called(ctx, id, email)
+
+	return args.Error(0)
+}
+
+type MockOIDCStateRepository struct {
+	mock.Mock
+}
+
+func (m *MockOIDCStateRepository) Save(ctx context.Context, state string, login entity.OIDCLoginState, ttl time.Duration) error {
+	args := m.Called(ctx, state, login, ttl)
+
+	return args.Error(0)
+}
+
+func (m *MockOIDCStateRepository) Consume(ctx context.Context, state string) (*entity.OIDCLoginState, error) {
+	args := m.Called(ctx, state)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.OIDCLoginState), args.Error(1)
+}
+
+// signInWithOIDC runs the browser part of a login against the test provider:
+// it starts the login, lets the provider sign identity in and arranges for the
+// saved state to be consumed by the callback. It returns the callback request.
+func signInWithOIDC(
+	t *testing.T,
+	ctx context.Context,
+	service *AuthService,
+	provider *oidctest.Server,
+	stateRepo *MockOIDCStateRepository,
+	identity oidc.Identity,
+	req *dto.OIDCAuthorizeRequest,
+) *dto.OIDCCallbackRequest {
+	t.Helper()
+
+	var saved entity.OIDCLoginState
+
+	stateRepo.On("Save", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("entity.OIDCLoginState"), 10*time.Minute).
+		Run(func(args mock.Arguments) { saved = args.Get(2).(entity.OIDCLoginState) }).
+		Return(nil).Once()
+
+	resp, err := service.StartOIDCLogin(ctx, "test", req)
+	assert.NoError(t, err)
+
+	provider.SignIn(identity)
+
+	code, state, err := provider.Authorize(resp.AuthorizationURL)
+	assert.NoError(t, err)
+
+	stateRepo.On("Consume", ctx, state).Return(&saved, nil).Once()
+
+	return &dto.OIDCCallbackRequest{Code: code, State: state}
+}
+
+func TestAuthService_OIDCLogin(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	jwtManager := jwt.NewManager(jwt.Config{Keys: jwt.NewHMACKeyRing("test-secret")})
+
+	cfg := &config.Config{
+		OIDC: config.OIDCConfig{
+			StateTTL: 10 * time.Minute,
+		},
+	}
+
+	provider, err := oidctest.NewServer()
+	assert.NoError(t, err)
+	t.Cleanup(provider.Close)
+
+	providers := ports.OIDCProviders{
+		"test": oidc.New(provider.Config("https://example.com/auth/callback"), http.DefaultClient),
+	}
+
+	identity := oidc.Identity{
+		Subject:       "subject-1",
+		Email:         "test@example.com",
+		EmailVerified: true,
+		Name:          "Jane Doe",
+	}
+	email := identity.Email
+
+	t.Run("lists providers", func(t *testing.T) {
+		service := NewAuthService(AuthServiceParams{
+			OIDCProviders: ports.OIDCProviders{"b": nil, "a": nil},
+			Logger:        logger,
+			Config:        cfg,
+		})
+
+		resp, err := service.ListOIDCProviders(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, resp.Providers)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		service := NewAuthService(AuthServiceParams{
+			OIDCProviders: providers,
+			Logger:        logger,
+			Config:        cfg,
+		})
+
+		resp, err := service.StartOIDCLogin(ctx, "other", &dto.OIDCAuthorizeRequest{})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperr.ErrUnknownIdentityProvider, err)
+	})
+
+	t.Run("linked identity", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockIdentityRepo := new(MockIdentityRepository)
+		mockStateRepo := new(MockOIDCStateRepository)
+
+		user := &entity.User{ID: uuid.New(), Email: "test@example.com"}
+		linked := &entity.UserIdentity{ID: uuid.New(), UserID: user.ID, Provider: "test", Subject: "subject-1"}
+
+		mockIdentityRepo.On("GetBySubject", ctx, "test", "subject-1").Return(linked, nil)
+		mockIdentityRepo.On("RecordLogin", ctx, linked.ID, &email).Return(nil)
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:      mockUserRepo,
+			IdentityRepository:  mockIdentityRepo,
+			OIDCStateRepository: mockStateRepo,
+			OIDCProviders:       providers,
+			AuditRepository:     newMockAuditRepository(),
+			JWTManager:          jwtManager,
+			Logger:              logger,
+			Config:              cfg,
+		})
+
+		req := signInWithOIDC(t, ctx, service, provider, mockStateRepo, identity, &dto.OIDCAuthorizeRequest{})
+
+		resp, err := service.CompleteOIDCLogin(ctx, "test", req)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, resp.AccessToken)
+		assert.NotEmpty(t, resp.RefreshToken)
+		mockIdentityRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+		mockStateRepo.AssertExpectations(t)
+	})
+
+	t.Run("creates account", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockIdentityRepo := new(MockIdentityRepository)
+		mockStateRepo := new(MockOIDCStateRepository)
+
+		user := &entity.User{ID: uuid.New(), Email: "test@example.com"}
+		team := &entity.Team{ID: uuid.New(), UserID: user.ID}
+		verifiedAt := time.Now()
+		verified := &entity.User{ID: user.ID, Email: user.Email, EmailVerifiedAt: &verifiedAt}
+
+		mockIdentityRepo.On("GetBySubject", ctx, "test", "subject-1").Return(nil, apperr.ErrIdentityNotFound)
+		mockUserRepo.On("GetByEmail", ctx, "test@example.com").Return(nil, apperr.ErrUserNotFound)
+		mockUserRepo.On("Create", ctx, "test@example.com", "").Return(user, nil)
+		mockTeamRepo.On("Create", ctx, isTeamFounding(user.ID, "Jane Doe FC", "England")).Return(team, nil)
+		mockIdentityRepo.On("Create", ctx, user.ID, "test", "subject-1", &email).Return(&entity.UserIdentity{}, nil)
+		mockUserRepo.On("MarkEmailVerified", ctx, user.ID).Return(verified, nil)
+
+		mockScores, mockBoards := newMockLeaderboards()
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:             mockUserRepo,
+			TeamRepository:             mockTeamRepo,
+			LeaderboardRepository:      mockScores,
+			LeaderboardCacheRepository: mockBoards,
+			IdentityRepository:         mockIdentityRepo,
+			OIDCStateRepository:        mockStateRepo,
+			OIDCProviders:              providers,
+			AuditRepository:            newMockAuditRepository(),
+			JWTManager:                 jwtManager,
+			Logger:                     logger,
+			Config:                     cfg,
+		})
+
+		req := signInWithOIDC(t, ctx, service, provider, mockStateRepo, identity,
+			&dto.OIDCAuthorizeRequest{Country: "England"})
+
+		resp, err := service.CompleteOIDCLogin(ctx, "test", req)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, resp.AccessToken)
+		mockUserRepo.AssertExpectations(t)
+		mockTeamRepo.AssertExpectations(t)
+		mockIdentityRepo.AssertExpectations(t)
+	})
+
+	t.Run("links verified account", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockIdentityRepo := new(MockIdentityRepository)
+		mockStateRepo := new(MockOIDCStateRepository)
+
+		verifiedAt := time.Now()
+		user := &entity.User{ID: uuid.New(), Email: "test@example.com", EmailVerifiedAt: &verifiedAt}
+
+		mockIdentityRepo.On("GetBySubject", ctx, "test", "subject-1").Return(nil, apperr.ErrIdentityNotFound)
+		mockUserRepo.On("GetByEmail", ctx, "test@example.com").Return(user, nil)
+		mockIdentityRepo.On("Create", ctx, user.ID, "test", "subject-1", &email).Return(&entity.UserIdentity{}, nil)
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:      mockUserRepo,
+			IdentityRepository:  mockIdentityRepo,
+			OIDCStateRepository: mockStateRepo,
+			OIDCProviders:       providers,
+			AuditRepository:     newMockAuditRepository(),
+			JWTManager:          jwtManager,
+			Logger:              logger,
+			Config:              cfg,
+		})
+
+		req := signInWithOIDC(t, ctx, service, provider, mockStateRepo, identity, &dto.OIDCAuthorizeRequest{})
+
+		resp, err := service.CompleteOIDCLogin(ctx, "test", req)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, resp.AccessToken)
+		mockIdentityRepo.AssertExpectations(t)
+	})
+
+	t.Run("unverified email conflict", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockIdentityRepo := new(MockIdentityRepository)
+		mockStateRepo := new(MockOIDCStateRepository)
+
+		user := &entity.User{ID: uuid.New(), Email: "test@example.com"}
+
+		mockIdentityRepo.On("GetBySubject", ctx, "test", "subject-1").Return(nil, apperr.ErrIdentityNotFound)
+		mockUserRepo.On("GetByEmail", ctx, "test@example.com").Return(user, nil)
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:      mockUserRepo,
+			IdentityRepository:  mockIdentityRepo,
+			OIDCStateRepository: mockStateRepo,
+			OIDCProviders:       providers,
+			AuditRepository:     newMockAuditRepository(),
+			JWTManager:          jwtManager,
+			Logger:              logger,
+			Config:              cfg,
+		})
+
+		req := signInWithOIDC(t, ctx, service, provider, mockStateRepo, identity, &dto.OIDCAuthorizeRequest{})
+
+		resp, err := service.CompleteOIDCLogin(ctx, "test", req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperr.ErrIdentityEmailConflict, err)
+		mockIdentityRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		mockStateRepo := new(MockOIDCStateRepository)
+
+		mockStateRepo.On("Consume", ctx, "unknown").Return(nil, nil)
+
+		service := NewAuthService(AuthServiceParams{
+			OIDCStateRepository: mockStateRepo,
+			OIDCProviders:       providers,
+			Logger:              logger,
+			Config:              cfg,
+		})
+
+		resp, err := service.CompleteOIDCLogin(ctx, "test", &dto.OIDCCallbackRequest{Code: "code", State: "unknown"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperr.ErrInvalidOIDCState, err)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		mockStateRepo := new(MockOIDCStateRepository)
+
+		mockStateRepo.On("Consume", ctx, "state").Return(&entity.OIDCLoginState{Provider: "test"}, nil)
+
+		service := NewAuthService(AuthServiceParams{
+			OIDCStateRepository: mockStateRepo,
+			OIDCProviders:       providers,
+			Logger:              logger,
+			Config:              cfg,
+		})
+
+		resp, err := service.CompleteOIDCLogin(ctx, "test", &dto.OIDCCallbackRequest{State: "state", Error: "access_denied"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperr.ErrIdentityProviderFailed, err)
+	})
 }
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+	"soccer_manager_service/pkg/mailer"
+	"strings"
+	"time"
+)
+
+// sendVerificationEmail issues an email verification token for user and mails
+// the link to their current address. Earlier verification links stop working.
+func sendVerificationEmail(
+	ctx context.Context,
+	tokens ports.ActionTokenRepository,
+	mail ports.Mailer,
+	cfg *config.Config,
+	user *entity.User,
+) error {
+	ttl := cfg.Account.EmailVerificationTTL
+
+	token, err := tokens.Issue(ctx, entity.ActionToken{
+		Purpose: entity.TokenPurposeEmailVerification,
+		UserID:  user.ID,
+		Email:   user.Email,
+	}, ttl)
+	if err != nil {
+		return fmt.Errorf("issue verification token: %w", err)
+	}
+
+	if err := mail.Send(ctx, verificationEmail(cfg.Mail.LinkBaseURL, user.Email, token, ttl)); err != nil {
+		return fmt.Errorf("send verification email: %w", err)
+	}
+
+	return nil
+}
+
+func verificationEmail(baseURL, to, token string, ttl time.Duration) mailer.Message {
+	return mailer.Message{
+		To:      to,
+		Subject: "Confirm your email address",
+		Body: fmt.Sprintf(
+			"Welcome to Soccer Manager!\n\n"+
+				"Confirm your email address by opening this link:\n\n%s\n\n"+
+				"The link expires in %s. If you did not create an account, ignore this email.\n",
+			actionLink(baseURL, "/verify-email", token), ttl),
+	}
+}
+
+func passwordResetEmail(baseURL, to, token string, ttl time.Duration) mailer.Message {
+	return mailer.Message{
+		To:      to,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Someone asked to reset the password of your Soccer Manager account.\n\n"+
+				"Choose a new password by opening this link:\n\n%s\n\n"+
+				"The link expires in %s and can be used once. If it was not you, ignore this email.\n",
+			actionLink(baseURL, "/reset-password", token), ttl),
+	}
+}
+
+func actionLink(baseURL, path, token string) string {
+	return strings.TrimRight(baseURL, "/") + path + "?" + url.Values{"token": {token}}.Encode()
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+	"soccer_manager_service/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// teamEventHandlers keep team caches and leaderboards in step with domain
+// events. Cache invalidation repeats what the services do inline for
+// read-your-writes, so that a crash between commit and cleanup cannot leave
+// a stale team behind.
+type teamEventHandlers struct {
+	teamCacheRepository        ports.TeamCacheRepository
+	leaderboardRepository      ports.LeaderboardRepository
+	leaderboardCacheRepository ports.LeaderboardCacheRepository
+	logger                     *zap.Logger
+}
+
+func (h *teamEventHandlers) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, h.logger, zap.String("service", "TeamEventHandlers"))
+}
+
+func (h *teamEventHandlers) subscribe(events *EventService) {
+	events.Subscribe(entity.EventPlayerSold, "team_cache", h.onPlayerSold)
+	events.Subscribe(entity.EventTeamRenamed, "team_cache", h.onTeamRenamed)
+}
+
+func (h *teamEventHandlers) onPlayerSold(ctx context.Context, event entity.Event) error {
+	var sold entity.PlayerSold
+	if err := event.Decode(&sold); err != nil {
+		return err
+	}
+
+	log := h.log(ctx)
+
+	err := errors.Join(
+		h.teamCacheRepository.InvalidateTeam(ctx, sold.BuyerID),
+		h.teamCacheRepository.InvalidateTeam(ctx, sold.SellerID),
+	)
+	if err != nil {
+		return err
+	}
+
+	updateLeaderboards(ctx, h.leaderboardRepository, h.leaderboardCacheRepository, log, sold.BuyerID, sold.SellerID)
+
+	sale := entity.LeaderboardEntry{ID: sold.TransferID, Score: sold.Price}
+	if err := h.leaderboardCacheRepository.Set(ctx, entity.LeaderboardTransfers, sale); err != nil {
+		log.Warn("failed to update transfers leaderboard", zap.Error(err))
+	}
+
+	return nil
+}
+
+func (h *teamEventHandlers) onTeamRenamed(ctx context.Context, event entity.Event) error {
+	var renamed entity.TeamRenamed
+	if err := event.Decode(&renamed); err != nil {
+		return err
+	}
+
+	return h.teamCacheRepository.InvalidateTeam(ctx, renamed.TeamID)
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+	"soccer_manager_service/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// outboxPruneInterval is how often Run deletes published events older than
+// OUTBOX_RETENTION.
+const outboxPruneInterval = time.Hour
+
+// EventService dispatches the domain events of the outbox to the in-process
+// handlers subscribed to their type and to every sink. An event is marked
+// published once all of them took it; otherwise it is retried with
+// exponential backoff, and those that succeeded see it again.
+type EventService struct {
+	outboxRepository ports.OutboxRepository
+	sinks            []ports.EventSink
+	handlers         map[entity.EventType][]eventSubscription
+	logger           *zap.Logger
+	config           *config.Config
+}
+
+type EventServiceParams struct {
+	OutboxRepository ports.OutboxRepository
+	Sinks            []ports.EventSink
+	Logger           *zap.Logger
+	Config           *config.Config
+}
+
+type eventSubscription struct {
+	name    string
+	handler ports.EventHandler
+}
+
+func NewEventService(params EventServiceParams) *EventService {
+	return &EventService{
+		outboxRepository: params.OutboxRepository,
+		sinks:            params.Sinks,
+		handlers:         make(map[entity.EventType][]eventSubscription),
+		logger:           params.Logger.With(zap.String("service", "EventService")),
+		config:           params.Config,
+	}
+}
+
+func (s *EventService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger, zap.String("service", "EventService"))
+}
+
+// Subscribe registers handler for events of eventType under name, which
+// appears in logs and errors. Handlers must be subscribed before Run.
+func (s *EventService) Subscribe(eventType entity.EventType, name string, handler ports.EventHandler) {
+	s.handlers[eventType] = append(s.handlers[eventType], eventSubscription{name: name, handler: handler})
+}
+
+// Run dispatches events every OUTBOX_POLL_INTERVAL until ctx is done. A full
+// batch is followed by the next one right away.
+func (s *EventService) Run(ctx context.Context) error {
+	log := s.log(ctx)
+
+	ticker := time.NewTicker(s.config.Outbox.PollInterval)
+	defer ticker.Stop()
+
+	var pruned time.Time
+
+	for {
+		dispatched, err := s.Dispatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error("failed to dispatch events", zap.Error(err))
+		}
+
+		if time.Since(pruned) >= outboxPruneInterval {
+			s.prune(ctx)
+			pruned = time.Now()
+		}
+
+		if err == nil && dispatched == int(s.config.Outbox.BatchSize) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch delivers one batch of pending events and returns its size.
+func (s *EventService) Dispatch(ctx context.Context) (int, error) {
+	log := s.log(ctx)
+
+	events, err := s.outboxRepository.Claim(ctx, s.config.Outbox.BatchSize, s.config.Outbox.Lease)
+	if err != nil {
+		log.Error("failed to claim events", zap.Error(err))
+
+		return 0, err
+	}
+
+	var errs []error
+
+	for _, event := range events {
+		if err := s.deliver(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(events), errors.Join(errs...)
+}
+
+// deliver hands event to its handlers and the sinks and records the outcome.
+// Only failures to record it are returned; the event stays claimed until its
+// lease expires and is then retried.
+func (s *EventService) deliver(ctx context.Context, event entity.Event) error {
+	log := s.log(ctx).With(
+		zap.String("event_id", event.ID.String()),
+		zap.String("type", string(event.Type)),
+		zap.Int("attempt", event.Attempts))
+
+	var errs []error
+
+	for _, subscription := range s.handlers[event.Type] {
+		if err := subscription.handler(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("handler %s: %w", subscription.name, err))
+		}
+	}
+
+	for _, sink := range s.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("sink %s: %w", sink.Name(), err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		delay := s.backoff(event.Attempts)
+
+		log.Warn("event delivery failed", zap.Duration("retry_in", delay), zap.Error(err))
+
+		if err := s.outboxRepository.Retry(ctx, event.ID, delay, err.Error()); err != nil {
+			log.Error("failed to schedule event retry", zap.Error(err))
+
+			return err
+		}
+
+		return nil
+	}
+
+	if err := s.outboxRepository.MarkPublished(ctx, event.ID); err != nil {
+		log.Error("failed to mark event published", zap.Error(err))
+
+		return err
+	}
+
+	log.Debug("event published")
+
+	return nil
+}
+
+// backoff is the delay before the next attempt after attempts failures.
+func (s *EventService) backoff(attempts int) time.Duration {
+	return exponentialBackoff(s.config.Outbox.RetryBackoff, s.config.Outbox.MaxBackoff, attempts)
+}
+
+// exponentialBackoff is base after the first failure, doubling with every
+// further one up to maxDelay.
+func exponentialBackoff(base, maxDelay time.Duration, attempts int) time.Duration {
+	delay := base
+
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxDelay)
+}
+
+func (s *EventService) prune(ctx context.Context) {
+	log := s.log(ctx)
+
+	deleted, err := s.outboxRepository.Prune(ctx, time.Now().Add(-s.config.Outbox.Retention))
+	if err != nil {
+		log.Warn("failed to prune published events", zap.Error(err))
+
+		return
+	}
+
+	if deleted > 0 {
+		log.Info("pruned published events", zap.Int64("deleted", deleted))
+	}
+}
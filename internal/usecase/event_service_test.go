@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepository) Claim(ctx context.Context, limit uint, lease time.Duration) ([]entity.Event, error) {
+	args := m.Called(ctx, limit, lease)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.Event), args.Error(1)
+}
+
+func (m *MockOutboxRepository) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) Retry(ctx context.Context, id uuid.UUID, delay time.Duration, lastError string) error {
+	args := m.Called(ctx, id, delay, lastError)
+
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) Prune(ctx context.Context, publishedBefore time.Time) (int64, error) {
+	args := m.Called(ctx, publishedBefore)
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockEventSink struct {
+	mock.Mock
+}
+
+func (m *MockEventSink) Name() string {
+	return "mock"
+}
+
+func (m *MockEventSink) Publish(ctx context.Context, event entity.Event) error {
+	args := m.Called(ctx, event)
+
+	return args.Error(0)
+}
+
+func newTestOutboxConfig() *config.Config {
+	return &config.Config{Outbox: config.OutboxConfig{
+		PollInterval: time.Second,
+		BatchSize:    10,
+		Lease:        30 * time.Second,
+		RetryBackoff: time.Second,
+		MaxBackoff:   time.Minute,
+		Retention:    24 * time.Hour,
+	}}
+}
+
+func newTestEvent(t *testing.T, eventType entity.EventType, payload any) entity.Event {
+	t.Helper()
+
+	event, err := entity.NewEvent(eventType, uuid.New(), payload)
+	assert.NoError(t, err)
+
+	event.Attempts = 1
+
+	return event
+}
+
+func TestEventService_Dispatch(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+	cfg := newTestOutboxConfig()
+
+	t.Run("delivers events to their handlers and the sinks", func(t *testing.T) {
+		sold := newTestEvent(t, entity.EventPlayerSold, entity.PlayerSold{TransferID: uuid.New()})
+		renamed := newTestEvent(t, entity.EventTeamRenamed, entity.TeamRenamed{TeamID: uuid.New()})
+
+		mockOutbox := new(MockOutboxRepository)
+		mockOutbox.On("Claim", ctx, uint(10), 30*time.Second).Return([]entity.Event{sold, renamed}, nil)
+		mockOutbox.On("MarkPublished", ctx, sold.ID).Return(nil)
+		mockOutbox.On("MarkPublished", ctx, renamed.ID).Return(nil)
+
+		mockSink := new(MockEventSink)
+		mockSink.On("Publish", ctx, sold).Return(nil)
+		mockSink.On("Publish", ctx, renamed).Return(nil)
+
+		service := NewEventService(EventServiceParams{
+			OutboxRepository: mockOutbox,
+			Sinks:            []ports.EventSink{mockSink},
+			Logger:           logger,
+			Config:           cfg,
+		})
+
+		var handled []uuid.UUID
+
+		service.Subscribe(entity.EventPlayerSold, "test", func(_ context.Context, event entity.Event) error {
+			handled = append(handled, event.ID)
+
+			return nil
+		})
+
+		dispatched, err := service.Dispatch(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, dispatched)
+		assert.Equal(t, []uuid.UUID{sold.ID}, handled)
+		mockOutbox.AssertExpectations(t)
+		mockSink.AssertExpectations(t)
+	})
+
+	t.Run("retries failed events with backoff", func(t *testing.T) {
+		event := newTestEvent(t, entity.EventPlayerListed, entity.PlayerListed{TransferID: uuid.New()})
+		event.Attempts = 3
+
+		mockOutbox := new(MockOutboxRepository)
+		mockOutbox.On("Claim", ctx, uint(10), 30*time.Second).Return([]entity.Event{event}, nil)
+		mockOutbox.On("Retry", ctx, event.ID, 4*time.Second, "sink mock: broker down").Return(nil)
+
+		mockSink := new(MockEventSink)
+		mockSink.On("Publish", ctx, event).Return(errors.New("broker down"))
+
+		service := NewEventService(EventServiceParams{
+			OutboxRepository: mockOutbox,
+			Sinks:            []ports.EventSink{mockSink},
+			Logger:           logger,
+			Config:           cfg,
+		})
+
+		dispatched, err := service.Dispatch(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, dispatched)
+		mockOutbox.AssertExpectations(t)
+		mockOutbox.AssertNotCalled(t, "MarkPublished", mock.Anything, mock.Anything)
+	})
+
+	t.Run("claim error", func(t *testing.T) {
+		claimErr := errors.New("connection refused")
+
+		mockOutbox := new(MockOutboxRepository)
+		mockOutbox.On("Claim", ctx, uint(10), 30*time.Second).Return(nil, claimErr)
+
+		service := NewEventService(EventServiceParams{
+			OutboxRepository: mockOutbox,
+			Logger:           logger,
+			Config:           cfg,
+		})
+
+		dispatched, err := service.Dispatch(ctx)
+
+		assert.ErrorIs(t, err, claimErr)
+		assert.Zero(t, dispatched)
+	})
+}
+
+func TestEventService_backoff(t *testing.T) {
+	service := NewEventService(EventServiceParams{Logger: zap.NewNop(), Config: newTestOutboxConfig()})
+
+	assert.Equal(t, time.Second, service.backoff(1))
+	assert.Equal(t, 2*time.Second, service.backoff(2))
+	assert.Equal(t, 32*time.Second, service.backoff(6))
+	assert.Equal(t, time.Minute, service.backoff(7))
+	assert.Equal(t, time.Minute, service.backoff(100))
+}
+
+func TestTeamEventHandlers_onPlayerSold(t *testing.T) {
+	ctx := context.Background()
+
+	buyerID := uuid.New()
+	sellerID := uuid.New()
+	transferID := uuid.New()
+
+	event := newTestEvent(t, entity.EventPlayerSold, entity.PlayerSold{
+		TransferID: transferID,
+		SellerID:   sellerID,
+		BuyerID:    buyerID,
+		Price:      1000000,
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockCacheRepo := new(MockTeamCacheRepository)
+		mockCacheRepo.On("InvalidateTeam", ctx, buyerID).Return(nil)
+		mockCacheRepo.On("InvalidateTeam", ctx, sellerID).Return(nil)
+
+		mockScores, mockBoards := newMockLeaderboards()
+
+		handlers := &teamEventHandlers{
+			teamCacheRepository:        mockCacheRepo,
+			leaderboardRepository:      mockScores,
+			leaderboardCacheRepository: mockBoards,
+			logger:                     zap.NewNop(),
+		}
+
+		err := handlers.onPlayerSold(ctx, event)
+
+		assert.NoError(t, err)
+		mockCacheRepo.AssertExpectations(t)
+		mockScores.AssertCalled(t, "Scores", ctx, entity.LeaderboardTransferProfit,
+			entity.LeaderboardFilter{TeamIDs: []uuid.UUID{buyerID, sellerID}})
+		mockBoards.AssertCalled(t, "Set", ctx, entity.LeaderboardTransfers,
+			[]entity.LeaderboardEntry{{ID: transferID, Score: 1000000}})
+	})
+
+	t.Run("cache failure is retried", func(t *testing.T) {
+		cacheErr := errors.New("redis down")
+
+		mockCacheRepo := new(MockTeamCacheRepository)
+		mockCacheRepo.On("InvalidateTeam", ctx, buyerID).Return(cacheErr)
+		mockCacheRepo.On("InvalidateTeam", ctx, sellerID).Return(nil)
+
+		mockScores, mockBoards := newMockLeaderboards()
+
+		handlers := &teamEventHandlers{
+			teamCacheRepository:        mockCacheRepo,
+			leaderboardRepository:      mockScores,
+			leaderboardCacheRepository: mockBoards,
+			logger:                     zap.NewNop(),
+		}
+
+		err := handlers.onPlayerSold(ctx, event)
+
+		assert.ErrorIs(t, err, cacheErr)
+		mockBoards.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
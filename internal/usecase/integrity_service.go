@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+	"soccer_manager_service/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IntegrityService struct {
+	integrityRepository ports.IntegrityRepository
+	teamCacheRepository ports.TeamCacheRepository
+	auditRepository     ports.AuditRepository
+	logger              *zap.Logger
+}
+
+type IntegrityServiceParams struct {
+	IntegrityRepository ports.IntegrityRepository
+	TeamCacheRepository ports.TeamCacheRepository
+	AuditRepository     ports.AuditRepository
+	Logger              *zap.Logger
+}
+
+func NewIntegrityService(params IntegrityServiceParams) *IntegrityService {
+	return &IntegrityService{
+		integrityRepository: params.IntegrityRepository,
+		teamCacheRepository: params.TeamCacheRepository,
+		auditRepository:     params.AuditRepository,
+		logger:              params.Logger.With(zap.String("service", "IntegrityService")),
+	}
+}
+
+func (s *IntegrityService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger, zap.String("service", "IntegrityService"))
+}
+
+// Check scans the database for inconsistencies. With fix set, all of them are
+// repaired in one transaction, the affected teams are evicted from the cache
+// and every repair is written to the audit log.
+func (s *IntegrityService) Check(ctx context.Context, fix bool) (*entity.IntegrityReport, error) {
+	log := s.log(ctx)
+
+	report := &entity.IntegrityReport{CheckedAt: time.Now(), Fixed: fix}
+
+	var err error
+
+	if fix {
+		report.Issues, err = s.integrityRepository.Fix(ctx)
+	} else {
+		report.Issues, err = s.integrityRepository.Check(ctx)
+	}
+
+	if err != nil {
+		log.Error("integrity check failed", zap.Bool("fix", fix), zap.Error(err))
+
+		return nil, err
+	}
+
+	log.Info("integrity check completed", zap.Bool("fix", fix), zap.Int("issues", len(report.Issues)))
+
+	if fix && len(report.Issues) > 0 {
+		s.invalidateTeams(ctx, report.Issues)
+		s.auditRepairs(ctx, report.Issues)
+	}
+
+	return report, nil
+}
+
+func (s *IntegrityService) invalidateTeams(ctx context.Context, issues []entity.IntegrityIssue) {
+	log := s.log(ctx)
+	seen := make(map[uuid.UUID]struct{})
+
+	for _, issue := range issues {
+		if issue.TeamID == nil {
+			continue
+		}
+
+		if _, ok := seen[*issue.TeamID]; ok {
+			continue
+		}
+
+		seen[*issue.TeamID] = struct{}{}
+
+		if err := s.teamCacheRepository.InvalidateTeam(ctx, *issue.TeamID); err != nil {
+			log.Warn("failed to invalidate team cache", zap.String("team_id", issue.TeamID.String()), zap.Error(err))
+		}
+	}
+}
+
+func (s *IntegrityService) auditRepairs(ctx context.Context, issues []entity.IntegrityIssue) {
+	entries := make([]entity.AuditEntry, 0, len(issues))
+
+	for _, issue := range issues {
+		entry := newAuditEntry(ctx, nil, "integrity.repaired", issue.EntityType, issue.EntityID, nil, nil)
+		entry.Metadata = map[string]any{
+			"issue":  string(issue.Kind),
+			"detail": issue.Detail,
+			"repair": issue.Repair,
+		}
+
+		entries = append(entries, entry)
+	}
+
+	recordAudit(ctx, s.auditRepository, s.log(ctx), entries...)
+}
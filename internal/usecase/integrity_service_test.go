@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"soccer_manager_service/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type MockIntegrityRepository struct {
+	mock.Mock
+}
+
+func (m *MockIntegrityRepository) Check(ctx context.Context) ([]entity.IntegrityIssue, error) {
+	args := m.Called(ctx)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.IntegrityIssue), args.Error(1)
+}
+
+func (m *MockIntegrityRepository) Fix(ctx context.Context) ([]entity.IntegrityIssue, error) {
+	args := m.Called(ctx)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.IntegrityIssue), args.Error(1)
+}
+
+func TestIntegrityService_Check(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	teamID := uuid.New()
+	transferID := uuid.New()
+
+	issues := []entity.IntegrityIssue{
+		{Kind: entity.IssueTotalValueMismatch, EntityType: entity.AuditEntityTeam, EntityID: teamID, TeamID: &teamID},
+		{Kind: entity.IssueStaleTransfer, EntityType: entity.AuditEntityTransfer, EntityID: transferID, TeamID: &teamID},
+	}
+
+	t.Run("report only", func(t *testing.T) {
+		mockIntegrityRepo := new(MockIntegrityRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+		mockAuditRepo := new(MockAuditRepository)
+
+		mockIntegrityRepo.On("Check", ctx).Return(issues, nil)
+
+		service := NewIntegrityService(IntegrityServiceParams{
+			IntegrityRepository: mockIntegrityRepo,
+			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     mockAuditRepo,
+			Logger:              logger,
+		})
+
+		report, err := service.Check(ctx, false)
+
+		assert.NoError(t, err)
+		assert.False(t, report.Fixed)
+		assert.Equal(t, issues, report.Issues)
+		mockIntegrityRepo.AssertNotCalled(t, "Fix", mock.Anything)
+		mockCacheRepo.AssertNotCalled(t, "InvalidateTeam", mock.Anything, mock.Anything)
+		mockAuditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fix", func(t *testing.T) {
+		mockIntegrityRepo := new(MockIntegrityRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+		mockAuditRepo := new(MockAuditRepository)
+
+		mockIntegrityRepo.On("Fix", ctx).Return(issues, nil)
+		mockCacheRepo.On("InvalidateTeam", ctx, teamID).Return(nil).Once()
+		mockAuditRepo.On("Create", ctx, mock.MatchedBy(func(entries []entity.AuditEntry) bool {
+			return len(entries) == 2 &&
+				entries[0].Action == "integrity.repaired" &&
+				entries[0].ActorID == nil &&
+				*entries[0].EntityID == teamID &&
+				entries[0].Metadata["issue"] == string(entity.IssueTotalValueMismatch) &&
+				entries[1].EntityType == entity.AuditEntityTransfer
+		})).Return(nil)
+
+		service := NewIntegrityService(IntegrityServiceParams{
+			IntegrityRepository: mockIntegrityRepo,
+			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     mockAuditRepo,
+			Logger:              logger,
+		})
+
+		report, err := service.Check(ctx, true)
+
+		assert.NoError(t, err)
+		assert.True(t, report.Fixed)
+		assert.Len(t, report.Issues, 2)
+		mockIntegrityRepo.AssertExpectations(t)
+		mockCacheRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
+	})
+
+	t.Run("fix fails", func(t *testing.T) {
+		mockIntegrityRepo := new(MockIntegrityRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+
+		mockIntegrityRepo.On("Fix", ctx).Return(nil, errors.New("database error"))
+
+		service := NewIntegrityService(IntegrityServiceParams{
+			IntegrityRepository: mockIntegrityRepo,
+			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+		})
+
+		report, err := service.Check(ctx, true)
+
+		assert.Error(t, err)
+		assert.Nil(t, report)
+		mockCacheRepo.AssertNotCalled(t, "InvalidateTeam", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// LeaderboardService serves the leaderboards kept in Redis. Services that
+// change scores update them with updateLeaderboards as they go; Rebuild
+// recomputes them from Postgres to repair whatever those updates missed.
+type LeaderboardService struct {
+	leaderboardRepository      ports.LeaderboardRepository
+	leaderboardCacheRepository ports.LeaderboardCacheRepository
+	teamRepository             ports.TeamRepository
+	logger                     *zap.Logger
+	config                     *config.Config
+}
+
+type LeaderboardServiceParams struct {
+	LeaderboardRepository      ports.LeaderboardRepository
+	LeaderboardCacheRepository ports.LeaderboardCacheRepository
+	TeamRepository             ports.TeamRepository
+	Logger                     *zap.Logger
+	Config                     *config.Config
+}
+
+func NewLeaderboardService(params LeaderboardServiceParams) *LeaderboardService {
+	return &LeaderboardService{
+		leaderboardRepository:      params.LeaderboardRepository,
+		leaderboardCacheRepository: params.LeaderboardCacheRepository,
+		teamRepository:             params.TeamRepository,
+		logger:                     params.Logger.With(zap.String("service", "LeaderboardService")),
+		config:                     params.Config,
+	}
+}
+
+func (s *LeaderboardService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger, zap.String("service", "LeaderboardService"))
+}
+
+// Get returns a page of the leaderboard together with the caller's own ranks.
+func (s *LeaderboardService) Get(ctx context.Context, userID uuid.UUID, kind entity.LeaderboardKind, req *dto.LeaderboardRequest) (*dto.LeaderboardResponse, error) {
+	log := s.log(ctx)
+
+	if !slices.Contains(entity.Leaderboards, kind) {
+		return nil, apperr.ErrLeaderboardNotFound
+	}
+
+	limit := listLimit(req.Limit)
+
+	top, err := s.leaderboardCacheRepository.Top(ctx, kind, limit, req.Offset)
+	if err != nil {
+		log.Error("failed to read leaderboard", zap.Error(err))
+
+		return nil, err
+	}
+
+	total, err := s.leaderboardCacheRepository.Count(ctx, kind)
+	if err != nil {
+		log.Error("failed to count leaderboard", zap.Error(err))
+
+		return nil, err
+	}
+
+	mine, err := s.ownRanks(ctx, userID, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.leaderboardRepository.Describe(ctx, kind, slices.Concat(top, mine))
+	if err != nil {
+		log.Error("failed to describe leaderboard entries", zap.Error(err))
+
+		return nil, err
+	}
+
+	return &dto.LeaderboardResponse{
+		Kind:    kind,
+		Entries: entries[:len(top)],
+		Mine:    entries[len(top):],
+		Total:   total,
+		Limit:   limit,
+		Offset:  req.Offset,
+	}, nil
+}
+
+// ownRanks ranks the user's teams or, on the transfers leaderboard, their
+// most expensive purchase.
+func (s *LeaderboardService) ownRanks(ctx context.Context, userID uuid.UUID, kind entity.LeaderboardKind) ([]entity.LeaderboardEntry, error) {
+	log := s.log(ctx)
+
+	teams, err := s.teamRepository.ListByUserID(ctx, userID)
+	if err != nil {
+		log.Error("failed to list teams", zap.Error(err))
+
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(teams))
+	for _, team := range teams {
+		ids = append(ids, team.ID)
+	}
+
+	if kind == entity.LeaderboardTransfers && len(ids) > 0 {
+		best, err := s.leaderboardRepository.Scores(ctx, kind, entity.LeaderboardFilter{TeamIDs: ids, Limit: 1})
+		if err != nil {
+			log.Error("failed to get most expensive purchase", zap.Error(err))
+
+			return nil, err
+		}
+
+		ids = ids[:0]
+		for _, entry := range best {
+			ids = append(ids, entry.ID)
+		}
+	}
+
+	mine := make([]entity.LeaderboardEntry, 0, len(ids))
+
+	for _, id := range ids {
+		entry, err := s.leaderboardCacheRepository.Rank(ctx, kind, id)
+		if err != nil {
+			log.Error("failed to read leaderboard rank", zap.Error(err))
+
+			return nil, err
+		}
+
+		if entry != nil {
+			mine = append(mine, *entry)
+		}
+	}
+
+	slices.SortFunc(mine, func(a, b entity.LeaderboardEntry) int {
+		return cmp.Compare(a.Rank, b.Rank)
+	})
+
+	return mine, nil
+}
+
+// Rebuild recomputes every leaderboard from Postgres. The transfers
+// leaderboard keeps only the most expensive LEADERBOARD_TRANSFERS_SIZE
+// transfers.
+func (s *LeaderboardService) Rebuild(ctx context.Context) error {
+	log := s.log(ctx)
+
+	var errs []error
+
+	for _, kind := range entity.Leaderboards {
+		filter := entity.LeaderboardFilter{}
+		if kind == entity.LeaderboardTransfers {
+			filter.Limit = s.config.Leaderboard.TransfersSize
+		}
+
+		entries, err := s.leaderboardRepository.Scores(ctx, kind, filter)
+		if err != nil {
+			log.Error("failed to compute leaderboard", zap.String("kind", string(kind)), zap.Error(err))
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if err := s.leaderboardCacheRepository.Replace(ctx, kind, entries); err != nil {
+			log.Error("failed to replace leaderboard", zap.String("kind", string(kind)), zap.Error(err))
+			errs = append(errs, err)
+
+			continue
+		}
+
+		log.Debug("leaderboard rebuilt", zap.String("kind", string(kind)), zap.Int("entries", len(entries)))
+	}
+
+	return errors.Join(errs...)
+}
+
+// updateLeaderboards rescores teams on the team leaderboards. Failures are
+// only logged; the next rebuild repairs them.
+func updateLeaderboards(
+	ctx context.Context,
+	scores ports.LeaderboardRepository,
+	boards ports.LeaderboardCacheRepository,
+	log *zap.Logger,
+	teamIDs ...uuid.UUID,
+) {
+	for _, kind := range entity.TeamLeaderboards {
+		entries, err := scores.Scores(ctx, kind, entity.LeaderboardFilter{TeamIDs: teamIDs})
+		if err != nil {
+			log.Warn("failed to compute leaderboard scores", zap.String("kind", string(kind)), zap.Error(err))
+
+			continue
+		}
+
+		if err := boards.Set(ctx, kind, entries...); err != nil {
+			log.Warn("failed to update leaderboard", zap.String("kind", string(kind)), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/entity"
+	apperr "soccer_manager_service/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type MockLeaderboardRepository struct {
+	mock.Mock
+}
+
+func (m *MockLeaderboardRepository) Scores(ctx context.Context, kind entity.LeaderboardKind, filter entity.LeaderboardFilter) ([]entity.LeaderboardEntry, error) {
+	args := m.Called(ctx, kind, filter)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.LeaderboardEntry), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) Describe(ctx context.Context, kind entity.LeaderboardKind, entries []entity.LeaderboardEntry) ([]entity.LeaderboardEntry, error) {
+	args := m.Called(ctx, kind, entries)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.LeaderboardEntry), args.Error(1)
+}
+
+type MockLeaderboardCacheRepository struct {
+	mock.Mock
+}
+
+func (m *MockLeaderboardCacheRepository) Set(ctx context.Context, kind entity.LeaderboardKind, entries ...entity.LeaderboardEntry) error {
+	args := m.Called(ctx, kind, entries)
+
+	return args.Error(0)
+}
+
+func (m *MockLeaderboardCacheRepository) Remove(ctx context.Context, kind entity.LeaderboardKind, ids ...uuid.UUID) error {
+	args := m.Called(ctx, kind, ids)
+
+	return args.Error(0)
+}
+
+func (m *MockLeaderboardCacheRepository) Replace(ctx context.Context, kind entity.LeaderboardKind, entries []entity.LeaderboardEntry) error {
+	args := m.Called(ctx, kind, entries)
+
+	return args.Error(0)
+}
+
+func (m *MockLeaderboardCacheRepository) Top(ctx context.Context, kind entity.LeaderboardKind, limit, offset uint) ([]entity.LeaderboardEntry, error) {
+	args := m.Called(ctx, kind, limit, offset)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.LeaderboardEntry), args.Error(1)
+}
+
+func (m *MockLeaderboardCacheRepository) Rank(ctx context.Context, kind entity.LeaderboardKind, id uuid.UUID) (*entity.LeaderboardEntry, error) {
+	args := m.Called(ctx, kind, id)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.LeaderboardEntry), args.Error(1)
+}
+
+func (m *MockLeaderboardCacheRepository) Count(ctx context.Context, kind entity.LeaderboardKind) (int64, error) {
+	args := m.Called(ctx, kind)
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// newMockLeaderboards returns leaderboard mocks that accept any update, for
+// tests of services that only keep the leaderboards current.
+func newMockLeaderboards() (*MockLeaderboardRepository, *MockLeaderboardCacheRepository) {
+	scores := new(MockLeaderboardRepository)
+	scores.On("Scores", mock.Anything, mock.Anything, mock.Anything).Return([]entity.LeaderboardEntry{}, nil)
+
+	boards := new(MockLeaderboardCacheRepository)
+	boards.On("Set", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	boards.On("Remove", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	return scores, boards
+}
+
+func TestLeaderboardService_Get(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+	userID := uuid.New()
+	teamID := uuid.New()
+	otherTeamID := uuid.New()
+
+	t.Run("team leaderboard with own rank", func(t *testing.T) {
+		mockScores := new(MockLeaderboardRepository)
+		mockBoards := new(MockLeaderboardCacheRepository)
+		mockTeamRepo := new(MockTeamRepository)
+
+		top := []entity.LeaderboardEntry{{Rank: 1, ID: otherTeamID, Score: 30000000}}
+		mine := entity.LeaderboardEntry{Rank: 7, ID: teamID, Score: 20000000}
+
+		mockBoards.On("Top", ctx, entity.LeaderboardTotalValue, uint(1), uint(0)).Return(top, nil)
+		mockBoards.On("Count", ctx, entity.LeaderboardTotalValue).Return(int64(12), nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{{ID: teamID, UserID: userID}}, nil)
+		mockBoards.On("Rank", ctx, entity.LeaderboardTotalValue, teamID).Return(&mine, nil)
+		mockScores.On("Describe", ctx, entity.LeaderboardTotalValue, []entity.LeaderboardEntry{top[0], mine}).
+			Return([]entity.LeaderboardEntry{
+				{Rank: 1, ID: otherTeamID, Score: 30000000, TeamID: &otherTeamID, TeamName: "Leaders"},
+				{Rank: 7, ID: teamID, Score: 20000000, TeamID: &teamID, TeamName: "Mine"},
+			}, nil)
+
+		service := NewLeaderboardService(LeaderboardServiceParams{
+			LeaderboardRepository:      mockScores,
+			LeaderboardCacheRepository: mockBoards,
+			TeamRepository:             mockTeamRepo,
+			Logger:                     logger,
+		})
+
+		result, err := service.Get(ctx, userID, entity.LeaderboardTotalValue, &dto.LeaderboardRequest{Limit: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), result.Total)
+		assert.Equal(t, []entity.LeaderboardEntry{
+			{Rank: 1, ID: otherTeamID, Score: 30000000, TeamID: &otherTeamID, TeamName: "Leaders"},
+		}, result.Entries)
+		assert.Equal(t, []entity.LeaderboardEntry{
+			{Rank: 7, ID: teamID, Score: 20000000, TeamID: &teamID, TeamName: "Mine"},
+		}, result.Mine)
+		mockBoards.AssertExpectations(t)
+		mockScores.AssertExpectations(t)
+	})
+
+	t.Run("transfers leaderboard ranks most expensive purchase", func(t *testing.T) {
+		mockScores := new(MockLeaderboardRepository)
+		mockBoards := new(MockLeaderboardCacheRepository)
+		mockTeamRepo := new(MockTeamRepository)
+
+		transferID := uuid.New()
+		ranked := entity.LeaderboardEntry{Rank: 3, ID: transferID, Score: 4000000}
+
+		mockBoards.On("Top", ctx, entity.LeaderboardTransfers, uint(20), uint(0)).Return([]entity.LeaderboardEntry{}, nil)
+		mockBoards.On("Count", ctx, entity.LeaderboardTransfers).Return(int64(3), nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{{ID: teamID, UserID: userID}}, nil)
+		mockScores.On("Scores", ctx, entity.LeaderboardTransfers, entity.LeaderboardFilter{TeamIDs: []uuid.UUID{teamID}, Limit: 1}).
+			Return([]entity.LeaderboardEntry{{ID: transferID, Score: 4000000}}, nil)
+		mockBoards.On("Rank", ctx, entity.LeaderboardTransfers, transferID).Return(&ranked, nil)
+		mockScores.On("Describe", ctx, entity.LeaderboardTransfers, []entity.LeaderboardEntry{ranked}).
+			Return([]entity.LeaderboardEntry{ranked}, nil)
+
+		service := NewLeaderboardService(LeaderboardServiceParams{
+			LeaderboardRepository:      mockScores,
+			LeaderboardCacheRepository: mockBoards,
+			TeamRepository:             mockTeamRepo,
+			Logger:                     logger,
+		})
+
+		result, err := service.Get(ctx, userID, entity.LeaderboardTransfers, &dto.LeaderboardRequest{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Entries)
+		assert.Equal(t, []entity.LeaderboardEntry{ranked}, result.Mine)
+		mockScores.AssertExpectations(t)
+	})
+
+	t.Run("unknown leaderboard", func(t *testing.T) {
+		service := NewLeaderboardService(LeaderboardServiceParams{Logger: logger})
+
+		result, err := service.Get(ctx, userID, entity.LeaderboardKind("goals"), &dto.LeaderboardRequest{})
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperr.ErrLeaderboardNotFound, err)
+	})
+}
+
+func TestLeaderboardService_Rebuild(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{Leaderboard: config.LeaderboardConfig{TransfersSize: 50}}
+
+	t.Run("success", func(t *testing.T) {
+		mockScores := new(MockLeaderboardRepository)
+		mockBoards := new(MockLeaderboardCacheRepository)
+
+		for _, kind := range entity.TeamLeaderboards {
+			entries := []entity.LeaderboardEntry{{ID: uuid.New(), Score: 1}}
+			mockScores.On("Scores", ctx, kind, entity.LeaderboardFilter{}).Return(entries, nil)
+			mockBoards.On("Replace", ctx, kind, entries).Return(nil)
+		}
+
+		mockScores.On("Scores", ctx, entity.LeaderboardTransfers, entity.LeaderboardFilter{Limit: 50}).
+			Return([]entity.LeaderboardEntry{}, nil)
+		mockBoards.On("Replace", ctx, entity.LeaderboardTransfers, []entity.LeaderboardEntry{}).Return(nil)
+
+		service := NewLeaderboardService(LeaderboardServiceParams{
+			LeaderboardRepository:      mockScores,
+			LeaderboardCacheRepository: mockBoards,
+			Logger:                     zap.NewNop(),
+			Config:                     cfg,
+		})
+
+		assert.NoError(t, service.Rebuild(ctx))
+		mockScores.AssertExpectations(t)
+		mockBoards.AssertExpectations(t)
+	})
+
+	t.Run("failure does not stop other leaderboards", func(t *testing.T) {
+		mockScores := new(MockLeaderboardRepository)
+		mockBoards := new(MockLeaderboardCacheRepository)
+
+		mockScores.On("Scores", ctx, entity.LeaderboardTotalValue, mock.Anything).Return(nil, errors.New("db down"))
+		mockScores.On("Scores", ctx, mock.Anything, mock.Anything).Return([]entity.LeaderboardEntry{}, nil)
+		mockBoards.On("Replace", ctx, mock.Anything, mock.Anything).Return(nil)
+
+		service := NewLeaderboardService(LeaderboardServiceParams{
+			LeaderboardRepository:      mockScores,
+			LeaderboardCacheRepository: mockBoards,
+			Logger:                     zap.NewNop(),
+			Config:                     cfg,
+		})
+
+		assert.Error(t, service.Rebuild(ctx))
+		mockBoards.AssertNumberOfCalls(t, "Replace", len(entity.Leaderboards)-1)
+	})
+}
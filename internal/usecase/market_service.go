@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+	"soccer_manager_service/pkg/logger"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MarketService streams market events to the clients connected to this
+// instance. Run holds the one Redis subscription of the instance and hands
+// every event to the matching subscribers.
+type MarketService struct {
+	marketEventRepository ports.MarketEventRepository
+	logger                *zap.Logger
+	config                *config.Config
+
+	mu          sync.Mutex
+	subscribers map[*marketSubscriber]struct{}
+	stopped     bool
+}
+
+type MarketServiceParams struct {
+	MarketEventRepository ports.MarketEventRepository
+	Logger                *zap.Logger
+	Config                *config.Config
+}
+
+type marketSubscriber struct {
+	userID uuid.UUID
+	events chan entity.MarketEvent
+}
+
+func NewMarketService(params MarketServiceParams) *MarketService {
+	return &MarketService{
+		marketEventRepository: params.MarketEventRepository,
+		logger:                params.Logger.With(zap.String("service", "MarketService")),
+		config:                params.Config,
+		subscribers:           make(map[*marketSubscriber]struct{}),
+	}
+}
+
+func (s *MarketService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger, zap.String("service", "MarketService"))
+}
+
+// Run delivers events to subscribers until ctx is done, then closes every
+// subscription so that open streams end.
+func (s *MarketService) Run(ctx context.Context) error {
+	log := s.log(ctx)
+
+	events, err := s.marketEventRepository.Subscribe(ctx)
+	if err != nil {
+		log.Error("failed to subscribe to market events", zap.Error(err))
+
+		return err
+	}
+
+	for event := range events {
+		s.dispatch(ctx, event)
+	}
+
+	if ctx.Err() != nil {
+		s.stop()
+	}
+
+	return ctx.Err()
+}
+
+// Subscribe registers a client of userID. The returned function ends the
+// subscription; the channel is also closed when the client falls more than
+// MARKET_STREAM_BUFFER events behind or the service stops.
+func (s *MarketService) Subscribe(ctx context.Context, userID uuid.UUID) (<-chan entity.MarketEvent, func()) {
+	subscriber := &marketSubscriber{
+		userID: userID,
+		events: make(chan entity.MarketEvent, s.config.Market.Buffer),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		close(subscriber.events)
+
+		return subscriber.events, func() {}
+	}
+
+	s.subscribers[subscriber] = struct{}{}
+
+	s.log(ctx).Debug("market stream subscribed",
+		zap.String("user_id", userID.String()),
+		zap.Int("subscribers", len(s.subscribers)))
+
+	return subscriber.events, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.remove(subscriber)
+	}
+}
+
+func (s *MarketService) dispatch(ctx context.Context, event entity.MarketEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for subscriber := range s.subscribers {
+		if !event.For(subscriber.userID) {
+			continue
+		}
+
+		select {
+		case subscriber.events <- event:
+		default:
+			s.log(ctx).Warn("dropping slow market stream subscriber", zap.String("user_id", subscriber.userID.String()))
+			s.remove(subscriber)
+		}
+	}
+}
+
+func (s *MarketService) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for subscriber := range s.subscribers {
+		s.remove(subscriber)
+	}
+
+	s.stopped = true
+}
+
+// remove must be called with mu held.
+func (s *MarketService) remove(subscriber *marketSubscriber) {
+	if _, ok := s.subscribers[subscriber]; !ok {
+		return
+	}
+
+	delete(s.subscribers, subscriber)
+	close(subscriber.events)
+}
+
+// newMarketEvent describes a change of transfer. player may be nil when only
+// the transfer is known.
+func newMarketEvent(eventType entity.MarketEventType, transfer *entity.Transfer, player *entity.Player) entity.MarketEvent {
+	event := entity.MarketEvent{
+		ID:          uuid.New(),
+		Type:        eventType,
+		TransferID:  transfer.ID,
+		PlayerID:    transfer.PlayerID,
+		SellerID:    transfer.SellerID,
+		BuyerID:     transfer.BuyerID,
+		AskingPrice: transfer.AskingPrice,
+		OccurredAt:  time.Now().UTC(),
+	}
+
+	if player != nil {
+		event.PlayerName = player.FirstName + " " + player.LastName
+	}
+
+	return event
+}
+
+// userMarketEvent is event addressed to userID only.
+func userMarketEvent(event entity.MarketEvent, eventType entity.MarketEventType, userID uuid.UUID) entity.MarketEvent {
+	event.ID = uuid.New()
+	event.Type = eventType
+	event.UserID = &userID
+
+	return event
+}
+
+// publishMarketEvents sends events to the market stream. Streaming is best
+// effort, so failures are only logged.
+func publishMarketEvents(ctx context.Context, repo ports.MarketEventRepository, log *zap.Logger, events ...entity.MarketEvent) {
+	for _, event := range events {
+		if err := repo.Publish(ctx, event); err != nil {
+			log.Warn("failed to publish market event",
+				zap.String("type", string(event.Type)),
+				zap.String("transfer_id", event.TransferID.String()),
+				zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type MockMarketEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockMarketEventRepository) Publish(ctx context.Context, event entity.MarketEvent) error {
+	args := m.Called(ctx, event)
+
+	return args.Error(0)
+}
+
+func (m *MockMarketEventRepository) Subscribe(ctx context.Context) (<-chan entity.MarketEvent, error) {
+	args := m.Called(ctx)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(chan entity.MarketEvent), args.Error(1)
+}
+
+// newMockMarketEvents accepts every published event.
+func newMockMarketEvents() *MockMarketEventRepository {
+	events := new(MockMarketEventRepository)
+	events.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+	return events
+}
+
+// publishedMarketEvents returns the events passed to Publish, in order.
+func publishedMarketEvents(events *MockMarketEventRepository) []entity.MarketEvent {
+	var published []entity.MarketEvent
+
+	for _, call := range events.Calls {
+		if call.Method == "Publish" {
+			published = append(published, call.Arguments.Get(1).(entity.MarketEvent))
+		}
+	}
+
+	return published
+}
+
+func newTestMarketService(repo *MockMarketEventRepository, buffer int) *MarketService {
+	return NewMarketService(MarketServiceParams{
+		MarketEventRepository: repo,
+		Logger:                zap.NewNop(),
+		Config:                &config.Config{Market: config.MarketConfig{Buffer: buffer}},
+	})
+}
+
+// receive reads one event or fails after a second.
+func receive(t *testing.T, events <-chan entity.MarketEvent) (entity.MarketEvent, bool) {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		return event, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for market event")
+
+		return entity.MarketEvent{}, false
+	}
+}
+
+func TestMarketService_Run(t *testing.T) {
+	sellerID := uuid.New()
+	otherID := uuid.New()
+
+	t.Run("delivers public events to everyone and user events to their user", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		published := make(chan entity.MarketEvent)
+		repo := new(MockMarketEventRepository)
+		repo.On("Subscribe", ctx).Return(published, nil)
+
+		service := newTestMarketService(repo, 8)
+
+		sellerEvents, unsubscribeSeller := service.Subscribe(ctx, sellerID)
+		defer unsubscribeSeller()
+
+		otherEvents, unsubscribeOther := service.Subscribe(ctx, otherID)
+		defer unsubscribeOther()
+
+		done := make(chan error)
+		go func() { done <- service.Run(ctx) }()
+
+		sold := newMarketEvent(entity.MarketEventListingSold, &entity.Transfer{ID: uuid.New(), AskingPrice: 1000000}, nil)
+		published <- sold
+		published <- userMarketEvent(sold, entity.MarketEventPlayerSold, sellerID)
+		published <- newMarketEvent(entity.MarketEventListingCreated, &entity.Transfer{ID: uuid.New()}, nil)
+
+		event, _ := receive(t, sellerEvents)
+		assert.Equal(t, entity.MarketEventListingSold, event.Type)
+		event, _ = receive(t, sellerEvents)
+		assert.Equal(t, entity.MarketEventPlayerSold, event.Type)
+		assert.Equal(t, sold.TransferID, event.TransferID)
+		event, _ = receive(t, sellerEvents)
+		assert.Equal(t, entity.MarketEventListingCreated, event.Type)
+
+		event, _ = receive(t, otherEvents)
+		assert.Equal(t, entity.MarketEventListingSold, event.Type)
+		event, _ = receive(t, otherEvents)
+		assert.Equal(t, entity.MarketEventListingCreated, event.Type)
+
+		cancel()
+		close(published)
+
+		assert.ErrorIs(t, <-done, context.Canceled)
+
+		_, ok := receive(t, sellerEvents)
+		assert.False(t, ok)
+
+		events, _ := service.Subscribe(context.Background(), sellerID)
+		_, ok = receive(t, events)
+		assert.False(t, ok)
+	})
+
+	t.Run("drops subscribers that fall behind", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		published := make(chan entity.MarketEvent)
+		repo := new(MockMarketEventRepository)
+		repo.On("Subscribe", ctx).Return(published, nil)
+
+		service := newTestMarketService(repo, 1)
+
+		events, unsubscribe := service.Subscribe(ctx, sellerID)
+		defer unsubscribe()
+
+		done := make(chan error)
+		go func() { done <- service.Run(ctx) }()
+
+		published <- newMarketEvent(entity.MarketEventListingCreated, &entity.Transfer{ID: uuid.New()}, nil)
+		published <- newMarketEvent(entity.MarketEventListingCreated, &entity.Transfer{ID: uuid.New()}, nil)
+		close(published)
+
+		assert.NoError(t, <-done)
+
+		_, ok := receive(t, events)
+		assert.True(t, ok)
+
+		_, ok = receive(t, events)
+		assert.False(t, ok)
+	})
+
+	t.Run("subscription error", func(t *testing.T) {
+		ctx := context.Background()
+		subscribeErr := errors.New("redis down")
+
+		repo := new(MockMarketEventRepository)
+		repo.On("Subscribe", ctx).Return(nil, subscribeErr)
+
+		service := newTestMarketService(repo, 8)
+
+		assert.ErrorIs(t, service.Run(ctx), subscribeErr)
+	})
+}
+
+func TestMarketService_Subscribe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unsubscribe closes the channel once", func(t *testing.T) {
+		service := newTestMarketService(new(MockMarketEventRepository), 8)
+
+		events, unsubscribe := service.Subscribe(ctx, uuid.New())
+		unsubscribe()
+		unsubscribe()
+
+		_, ok := receive(t, events)
+		assert.False(t, ok)
+		assert.Empty(t, service.subscribers)
+	})
+}
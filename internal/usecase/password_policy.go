@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"fmt"
+	"net/mail"
+	"soccer_manager_service/internal/config"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/password"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// minPersonalTermLength keeps short email local parts and team names from
+// ruling out large parts of the password space.
+const minPersonalTermLength = 3
+
+// passwordPolicy validates new passwords and hashes them with the configured
+// bcrypt cost.
+type passwordPolicy struct {
+	config config.PasswordConfig
+}
+
+func newPasswordPolicy(cfg config.PasswordConfig) *passwordPolicy {
+	return &passwordPolicy{config: cfg}
+}
+
+// Validate checks password against the policy. personal holds values the
+// password must not contain, such as the user's email and team name.
+func (p *passwordPolicy) Validate(pw string, personal ...string) error {
+	if p.config.MinLength > 0 && utf8.RuneCountInString(pw) < p.config.MinLength {
+		return apperr.WithData(apperr.ErrPasswordTooShort, map[string]any{"Count": p.config.MinLength})
+	}
+
+	if p.config.MaxLength > 0 && len(pw) > p.config.MaxLength {
+		return apperr.WithData(apperr.ErrPasswordTooLong, map[string]any{"Count": p.config.MaxLength})
+	}
+
+	if password.CharClasses(pw) < p.config.MinCharClasses {
+		return apperr.WithData(apperr.ErrPasswordTooSimple, map[string]any{"Count": p.config.MinCharClasses})
+	}
+
+	if p.config.RejectPersonal && containsPersonal(pw, personal) {
+		return apperr.ErrPasswordContainsPersonal
+	}
+
+	if p.config.RejectCommon && password.IsCommon(pw) {
+		return apperr.ErrPasswordTooCommon
+	}
+
+	return nil
+}
+
+func (p *passwordPolicy) Hash(pw string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(pw), p.cost())
+	if err != nil {
+		return "", fmt.Errorf("hash password: %w", err)
+	}
+
+	return string(hash), nil
+}
+
+// NeedsRehash reports whether hash was made with a different cost than the
+// configured one.
+func (p *passwordPolicy) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+
+	return err == nil && cost != p.cost()
+}
+
+func (p *passwordPolicy) cost() int {
+	if p.config.BcryptCost < bcrypt.MinCost {
+		return bcrypt.DefaultCost
+	}
+
+	return p.config.BcryptCost
+}
+
+// containsPersonal reports whether pw contains one of the personal values,
+// ignoring case and whitespace. For emails only the local part is used.
+func containsPersonal(pw string, personal []string) bool {
+	pw = normalizePersonal(pw)
+
+	for _, value := range personal {
+		if addr, err := mail.ParseAddress(value); err == nil {
+			value, _, _ = strings.Cut(addr.Address, "@")
+		}
+
+		term := normalizePersonal(value)
+		if utf8.RuneCountInString(term) >= minPersonalTermLength && strings.Contains(pw, term) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizePersonal(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+
+		return unicode.ToLower(r)
+	}, s)
+}
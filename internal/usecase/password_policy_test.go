@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"soccer_manager_service/internal/config"
+	apperr "soccer_manager_service/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := newPasswordPolicy(config.PasswordConfig{
+		MinLength:      10,
+		MaxLength:      72,
+		MinCharClasses: 3,
+		RejectPersonal: true,
+		RejectCommon:   true,
+	})
+
+	tests := []struct {
+		name     string
+		password string
+		want     error
+	}{
+		{name: "valid", password: "Correct-Horse-42"},
+		{name: "too short", password: "Ab1!", want: apperr.ErrPasswordTooShort},
+		{name: "too long", password: "Aa1" + strings.Repeat("x", 80), want: apperr.ErrPasswordTooLong},
+		{name: "too few classes", password: "onlylowercaseletters", want: apperr.ErrPasswordTooSimple},
+		{name: "contains email local part", password: "John.Smith-2025", want: apperr.ErrPasswordContainsPersonal},
+		{name: "contains team name", password: "RedLions#2025", want: apperr.ErrPasswordContainsPersonal},
+		{name: "common", password: "Password123", want: apperr.ErrPasswordTooCommon},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password, "john.smith@example.com", "Red Lions")
+
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicy_NeedsRehash(t *testing.T) {
+	policy := newPasswordPolicy(config.PasswordConfig{BcryptCost: bcrypt.MinCost + 1})
+
+	oldHash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	newHash, err := policy.Hash("password")
+
+	assert.NoError(t, err)
+	assert.True(t, policy.NeedsRehash(string(oldHash)))
+	assert.False(t, policy.NeedsRehash(newHash))
+}
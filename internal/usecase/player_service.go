@@ -5,6 +5,8 @@ import (
 	"soccer_manager_service/internal/dto"
 	"soccer_manager_service/internal/entity"
 	"soccer_manager_service/internal/ports"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -14,6 +16,7 @@ type PlayerService struct {
 	playerRepository    ports.PlayerRepository
 	teamRepository      ports.TeamRepository
 	teamCacheRepository ports.TeamCacheRepository
+	auditRepository     ports.AuditRepository
 	logger              *zap.Logger
 }
 
@@ -21,6 +24,7 @@ type PlayerServiceParams struct {
 	PlayerRepository    ports.PlayerRepository
 	TeamRepository      ports.TeamRepository
 	TeamCacheRepository ports.TeamCacheRepository
+	AuditRepository     ports.AuditRepository
 	Logger              *zap.Logger
 }
 
@@ -29,30 +33,80 @@ func NewPlayerService(params PlayerServiceParams) *PlayerService {
 		playerRepository:    params.PlayerRepository,
 		teamRepository:      params.TeamRepository,
 		teamCacheRepository: params.TeamCacheRepository,
+		auditRepository:     params.AuditRepository,
 		logger:              params.Logger.With(zap.String("service", "PlayerService")),
 	}
 }
 
-func (s *PlayerService) UpdatePlayer(ctx context.Context, userID, playerID uuid.UUID, req *dto.UpdatePlayerRequest) (*entity.Player, error) {
-	s.logger.Info("updating player", zap.String("player_id", playerID.String()), zap.String("user_id", userID.String()))
+func (s *PlayerService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger, zap.String("service", "PlayerService"))
+}
+
+// GetPublicPlayer returns the profile of any player with the public profile
+// of its team.
+func (s *PlayerService) GetPublicPlayer(ctx context.Context, playerID uuid.UUID) (*dto.PublicPlayerResponse, error) {
+	log := s.log(ctx)
 
 	player, err := s.playerRepository.GetByID(ctx, playerID)
 	if err != nil {
-		s.logger.Error("failed to get player", zap.Error(err))
+		log.Warn("failed to get player", zap.String("player_id", playerID.String()), zap.Error(err))
+
+		return nil, err
+	}
+
+	team, err := s.teamRepository.GetByID(ctx, player.TeamID)
+	if err != nil {
+		log.Error("failed to get team", zap.Error(err))
 
 		return nil, err
 	}
 
+	return &dto.PublicPlayerResponse{
+		Player: publicPlayer(player),
+		Team:   publicTeam(team),
+	}, nil
+}
+
+// UpdatePlayer changes a player of the selected team; see TeamService for
+// how teamID selects it.
+func (s *PlayerService) UpdatePlayer(ctx context.Context, userID, teamID, playerID uuid.UUID, req *dto.UpdatePlayerRequest) (*entity.Player, error) {
+	log := s.log(ctx)
+
+	log.Info("updating player", zap.String("player_id", playerID.String()), zap.String("user_id", userID.String()))
+
+	player, err := s.playerRepository.GetByID(ctx, playerID)
+	if err != nil {
+		log.Error("failed to get player", zap.Error(err))
+
+		return nil, err
+	}
+
+	team, err := selectTeam(ctx, s.teamRepository, log, userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if player.TeamID != team.ID {
+		log.Warn("player does not belong to user's team",
+			zap.String("player_team_id", player.TeamID.String()),
+			zap.String("user_team_id", team.ID.String()))
+
+		return nil, apperr.ErrForbidden
+	}
+
 	updatedPlayer, err := s.playerRepository.Update(ctx, player.ID, req.FirstName, req.LastName, req.Country)
 	if err != nil {
-		s.logger.Error("failed to update player", zap.Error(err))
+		log.Error("failed to update player", zap.Error(err))
 
 		return nil, err
 	}
 
-	if err := s.teamCacheRepository.InvalidateTeam(ctx, userID); err != nil {
-		s.logger.Warn("failed to invalidate team cache", zap.Error(err))
+	if err := s.teamCacheRepository.InvalidateTeam(ctx, team.ID); err != nil {
+		log.Warn("failed to invalidate team cache", zap.Error(err))
 	}
 
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &userID, "player.updated", entity.AuditEntityPlayer, player.ID, player, updatedPlayer))
+
 	return updatedPlayer, nil
 }
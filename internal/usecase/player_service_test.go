@@ -5,11 +5,13 @@ import (
 	"errors"
 	"testing"
 
+	"soccer_manager_service/internal/dto"
 	"soccer_manager_service/internal/entity"
 	apperr "soccer_manager_service/pkg/errors"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 )
 
@@ -18,7 +20,9 @@ func TestPlayerService_UpdatePlayer(t *testing.T) {
 	ctx := context.Background()
 
 	userID := uuid.New()
+	teamID := uuid.New()
 	playerID := uuid.New()
+	team := &entity.Team{ID: teamID, UserID: userID}
 
 	t.Run("success", func(t *testing.T) {
 		mockPlayerRepo := new(MockPlayerRepository)
@@ -27,6 +31,7 @@ func TestPlayerService_UpdatePlayer(t *testing.T) {
 
 		player := &entity.Player{
 			ID:        playerID,
+			TeamID:    teamID,
 			FirstName: "John",
 			LastName:  "Doe",
 			Country:   "USA",
@@ -40,23 +45,25 @@ func TestPlayerService_UpdatePlayer(t *testing.T) {
 		}
 
 		mockPlayerRepo.On("GetByID", ctx, playerID).Return(player, nil)
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(team, nil)
 		mockPlayerRepo.On("Update", ctx, playerID, "Jane", "Smith", "UK").Return(updatedPlayer, nil)
-		mockCacheRepo.On("InvalidateTeam", ctx, userID).Return(nil)
+		mockCacheRepo.On("InvalidateTeam", ctx, teamID).Return(nil)
 
 		service := NewPlayerService(PlayerServiceParams{
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		req := &entity.UpdatePlayerRequest{
+		req := &dto.UpdatePlayerRequest{
 			FirstName: "Jane",
 			LastName:  "Smith",
 			Country:   "UK",
 		}
 
-		result, err := service.UpdatePlayer(ctx, userID, playerID, req)
+		result, err := service.UpdatePlayer(ctx, userID, teamID, playerID, req)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -78,16 +85,17 @@ func TestPlayerService_UpdatePlayer(t *testing.T) {
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		req := &entity.UpdatePlayerRequest{
+		req := &dto.UpdatePlayerRequest{
 			FirstName: "Jane",
 			LastName:  "Smith",
 			Country:   "UK",
 		}
 
-		result, err := service.UpdatePlayer(ctx, userID, playerID, req)
+		result, err := service.UpdatePlayer(ctx, userID, teamID, playerID, req)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -102,28 +110,31 @@ func TestPlayerService_UpdatePlayer(t *testing.T) {
 
 		player := &entity.Player{
 			ID:        playerID,
+			TeamID:    teamID,
 			FirstName: "John",
 			LastName:  "Doe",
 			Country:   "USA",
 		}
 
 		mockPlayerRepo.On("GetByID", ctx, playerID).Return(player, nil)
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(team, nil)
 		mockPlayerRepo.On("Update", ctx, playerID, "Jane", "Smith", "UK").Return(nil, errors.New("database error"))
 
 		service := NewPlayerService(PlayerServiceParams{
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		req := &entity.UpdatePlayerRequest{
+		req := &dto.UpdatePlayerRequest{
 			FirstName: "Jane",
 			LastName:  "Smith",
 			Country:   "UK",
 		}
 
-		result, err := service.UpdatePlayer(ctx, userID, playerID, req)
+		result, err := service.UpdatePlayer(ctx, userID, teamID, playerID, req)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -137,6 +148,7 @@ func TestPlayerService_UpdatePlayer(t *testing.T) {
 
 		player := &entity.Player{
 			ID:        playerID,
+			TeamID:    teamID,
 			FirstName: "John",
 			LastName:  "Doe",
 			Country:   "USA",
@@ -150,23 +162,25 @@ func TestPlayerService_UpdatePlayer(t *testing.T) {
 		}
 
 		mockPlayerRepo.On("GetByID", ctx, playerID).Return(player, nil)
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(team, nil)
 		mockPlayerRepo.On("Update", ctx, playerID, "Jane", "Smith", "UK").Return(updatedPlayer, nil)
-		mockCacheRepo.On("InvalidateTeam", ctx, userID).Return(errors.New("cache error"))
+		mockCacheRepo.On("InvalidateTeam", ctx, teamID).Return(errors.New("cache error"))
 
 		service := NewPlayerService(PlayerServiceParams{
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		req := &entity.UpdatePlayerRequest{
+		req := &dto.UpdatePlayerRequest{
 			FirstName: "Jane",
 			LastName:  "Smith",
 			Country:   "UK",
 		}
 
-		result, err := service.UpdatePlayer(ctx, userID, playerID, req)
+		result, err := service.UpdatePlayer(ctx, userID, teamID, playerID, req)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -182,6 +196,7 @@ func TestPlayerService_UpdatePlayer(t *testing.T) {
 
 		player := &entity.Player{
 			ID:        playerID,
+			TeamID:    teamID,
 			FirstName: "John",
 			LastName:  "Doe",
 			Country:   "USA",
@@ -195,21 +210,23 @@ func TestPlayerService_UpdatePlayer(t *testing.T) {
 		}
 
 		mockPlayerRepo.On("GetByID", ctx, playerID).Return(player, nil)
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(team, nil)
 		mockPlayerRepo.On("Update", ctx, playerID, "Jane", "", "").Return(updatedPlayer, nil)
-		mockCacheRepo.On("InvalidateTeam", ctx, userID).Return(nil)
+		mockCacheRepo.On("InvalidateTeam", ctx, teamID).Return(nil)
 
 		service := NewPlayerService(PlayerServiceParams{
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		req := &entity.UpdatePlayerRequest{
+		req := &dto.UpdatePlayerRequest{
 			FirstName: "Jane",
 		}
 
-		result, err := service.UpdatePlayer(ctx, userID, playerID, req)
+		result, err := service.UpdatePlayer(ctx, userID, teamID, playerID, req)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -217,4 +234,130 @@ func TestPlayerService_UpdatePlayer(t *testing.T) {
 		mockPlayerRepo.AssertExpectations(t)
 		mockCacheRepo.AssertExpectations(t)
 	})
+	t.Run("only team selected by default", func(t *testing.T) {
+		mockPlayerRepo := new(MockPlayerRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+
+		player := &entity.Player{ID: playerID, TeamID: teamID, FirstName: "John"}
+		updatedPlayer := &entity.Player{ID: playerID, TeamID: teamID, FirstName: "Jane"}
+
+		mockPlayerRepo.On("GetByID", ctx, playerID).Return(player, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{*team}, nil)
+		mockPlayerRepo.On("Update", ctx, playerID, "Jane", "", "").Return(updatedPlayer, nil)
+		mockCacheRepo.On("InvalidateTeam", ctx, teamID).Return(nil)
+
+		service := NewPlayerService(PlayerServiceParams{
+			PlayerRepository:    mockPlayerRepo,
+			TeamRepository:      mockTeamRepo,
+			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+		})
+
+		result, err := service.UpdatePlayer(ctx, userID, uuid.Nil, playerID, &dto.UpdatePlayerRequest{FirstName: "Jane"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Jane", result.FirstName)
+		mockTeamRepo.AssertExpectations(t)
+		mockCacheRepo.AssertExpectations(t)
+	})
+
+	t.Run("player of another team", func(t *testing.T) {
+		mockPlayerRepo := new(MockPlayerRepository)
+		mockTeamRepo := new(MockTeamRepository)
+
+		player := &entity.Player{ID: playerID, TeamID: uuid.New(), FirstName: "John"}
+
+		mockPlayerRepo.On("GetByID", ctx, playerID).Return(player, nil)
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(team, nil)
+
+		service := NewPlayerService(PlayerServiceParams{
+			PlayerRepository:    mockPlayerRepo,
+			TeamRepository:      mockTeamRepo,
+			TeamCacheRepository: new(MockTeamCacheRepository),
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+		})
+
+		result, err := service.UpdatePlayer(ctx, userID, teamID, playerID, &dto.UpdatePlayerRequest{FirstName: "Jane"})
+
+		assert.ErrorIs(t, err, apperr.ErrForbidden)
+		assert.Nil(t, result)
+		mockPlayerRepo.AssertNotCalled(t, "Update", ctx, playerID, "Jane", "", "")
+	})
+
+	t.Run("team of another user", func(t *testing.T) {
+		mockPlayerRepo := new(MockPlayerRepository)
+		mockTeamRepo := new(MockTeamRepository)
+
+		player := &entity.Player{ID: playerID, TeamID: teamID, FirstName: "John"}
+
+		mockPlayerRepo.On("GetByID", ctx, playerID).Return(player, nil)
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(&entity.Team{ID: teamID, UserID: uuid.New()}, nil)
+
+		service := NewPlayerService(PlayerServiceParams{
+			PlayerRepository:    mockPlayerRepo,
+			TeamRepository:      mockTeamRepo,
+			TeamCacheRepository: new(MockTeamCacheRepository),
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+		})
+
+		result, err := service.UpdatePlayer(ctx, userID, teamID, playerID, &dto.UpdatePlayerRequest{FirstName: "Jane"})
+
+		assert.ErrorIs(t, err, apperr.ErrTeamNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestPlayerService_GetPublicPlayer(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+	playerID := uuid.New()
+	teamID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockPlayerRepo := new(MockPlayerRepository)
+		mockTeamRepo := new(MockTeamRepository)
+
+		player := &entity.Player{ID: playerID, TeamID: teamID, FirstName: "Leo", MarketValue: 1000000}
+		team := &entity.Team{ID: teamID, UserID: uuid.New(), Name: "Public Team", Budget: 5000000, TotalValue: 20000000}
+
+		mockPlayerRepo.On("GetByID", ctx, playerID).Return(player, nil)
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(team, nil)
+
+		service := NewPlayerService(PlayerServiceParams{
+			PlayerRepository: mockPlayerRepo,
+			TeamRepository:   mockTeamRepo,
+			Logger:           logger,
+		})
+
+		result, err := service.GetPublicPlayer(ctx, playerID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &dto.PublicPlayerResponse{
+			Player: dto.PublicPlayer{ID: playerID, TeamID: teamID, FirstName: "Leo", MarketValue: 1000000},
+			Team:   dto.PublicTeam{ID: teamID, Name: "Public Team", TotalValue: 20000000},
+		}, result)
+	})
+
+	t.Run("player not found", func(t *testing.T) {
+		mockPlayerRepo := new(MockPlayerRepository)
+		mockTeamRepo := new(MockTeamRepository)
+
+		mockPlayerRepo.On("GetByID", ctx, playerID).Return(nil, apperr.ErrPlayerNotFound)
+
+		service := NewPlayerService(PlayerServiceParams{
+			PlayerRepository: mockPlayerRepo,
+			TeamRepository:   mockTeamRepo,
+			Logger:           logger,
+		})
+
+		result, err := service.GetPublicPlayer(ctx, playerID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperr.ErrPlayerNotFound, err)
+		mockTeamRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
 }
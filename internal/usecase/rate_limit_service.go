@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+	"soccer_manager_service/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type RateLimitService struct {
+	rateLimitRepository ports.RateLimitRepository
+	logger              *zap.Logger
+}
+
+type RateLimitServiceParams struct {
+	RateLimitRepository ports.RateLimitRepository
+	Logger              *zap.Logger
+}
+
+func NewRateLimitService(params RateLimitServiceParams) *RateLimitService {
+	return &RateLimitService{
+		rateLimitRepository: params.RateLimitRepository,
+		logger:              params.Logger.With(zap.String("service", "RateLimitService")),
+	}
+}
+
+func (s *RateLimitService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger, zap.String("service", "RateLimitService"))
+}
+
+// Allow counts one request of subject, a user or a client IP, against the
+// budget of scope. Route groups sharing a scope share the budget.
+func (s *RateLimitService) Allow(ctx context.Context, scope, subject string, rate config.Rate) (*entity.RateLimitResult, error) {
+	result, err := s.rateLimitRepository.Allow(ctx, scope+":"+subject, rate.Requests, rate.Window)
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.Allowed {
+		s.log(ctx).Warn("rate limit exceeded",
+			zap.String("scope", scope),
+			zap.String("subject", subject),
+			zap.Stringer("rate", rate))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type MockRateLimitRepository struct {
+	mock.Mock
+}
+
+func (m *MockRateLimitRepository) Allow(ctx context.Context, key string, limit int, window time.Duration) (*entity.RateLimitResult, error) {
+	args := m.Called(ctx, key, limit, window)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.RateLimitResult), args.Error(1)
+}
+
+func TestRateLimitService_Allow(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	rate := config.Rate{Requests: 10, Window: time.Minute}
+
+	t.Run("keys by scope and subject", func(t *testing.T) {
+		mockRepo := new(MockRateLimitRepository)
+
+		expected := &entity.RateLimitResult{Allowed: false, Limit: 10, Remaining: 0, Reset: 30 * time.Second}
+		mockRepo.On("Allow", ctx, "buy:user:42", 10, time.Minute).Return(expected, nil)
+
+		service := NewRateLimitService(RateLimitServiceParams{
+			RateLimitRepository: mockRepo,
+			Logger:              logger,
+		})
+
+		result, err := service.Allow(ctx, "buy", "user:42", rate)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(MockRateLimitRepository)
+
+		repoErr := errors.New("circuit breaker is open")
+		mockRepo.On("Allow", ctx, "api:ip:203.0.113.7", 10, time.Minute).Return(nil, repoErr)
+
+		service := NewRateLimitService(RateLimitServiceParams{
+			RateLimitRepository: mockRepo,
+			Logger:              logger,
+		})
+
+		result, err := service.Allow(ctx, "api", "ip:203.0.113.7", rate)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, repoErr)
+	})
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/ports"
 	"soccer_manager_service/internal/repository"
 	"soccer_manager_service/internal/usecase/adapters"
 	"soccer_manager_service/pkg/jwt"
@@ -11,10 +12,20 @@ import (
 )
 
 type Service struct {
-	Auth     adapters.AuthService
-	Team     adapters.TeamService
-	Player   adapters.PlayerService
-	Transfer adapters.TransferService
+	Auth        adapters.AuthService
+	Account     adapters.AccountService
+	TwoFactor   adapters.TwoFactorService
+	APIKey      adapters.APIKeyService
+	Webhook     adapters.WebhookService
+	Team        adapters.TeamService
+	Player      adapters.PlayerService
+	Transfer    adapters.TransferService
+	Leaderboard adapters.LeaderboardService
+	Market      adapters.MarketService
+	Events      adapters.EventService
+	Admin       adapters.AdminService
+	Integrity   adapters.IntegrityService
+	RateLimit   adapters.RateLimitService
 }
 
 type Params struct {
@@ -24,15 +35,31 @@ type Params struct {
 	Config     *config.Config
 	Repository *repository.Repository
 	JWTManager *jwt.Manager
+	Mailer     ports.Mailer
+	EventSinks []ports.EventSink
+
+	WebhookSender ports.WebhookSender
+
+	OIDCProviders ports.OIDCProviders
 }
 
 func NewUsecase(params Params) *Service {
 	factory := newServiceFactory(params)
 
 	return &Service{
-		Auth:     factory.CreateAuthService(),
-		Team:     factory.CreateTeamService(),
-		Player:   factory.CreatePlayerService(),
-		Transfer: factory.CreateTransferService(),
+		Auth:        factory.CreateAuthService(),
+		Account:     factory.CreateAccountService(),
+		TwoFactor:   factory.CreateTwoFactorService(),
+		APIKey:      factory.CreateAPIKeyService(),
+		Webhook:     factory.CreateWebhookService(),
+		Team:        factory.CreateTeamService(),
+		Player:      factory.CreatePlayerService(),
+		Transfer:    factory.CreateTransferService(),
+		Leaderboard: factory.CreateLeaderboardService(),
+		Market:      factory.CreateMarketService(),
+		Events:      factory.CreateEventService(),
+		Admin:       factory.CreateAdminService(),
+		Integrity:   factory.CreateIntegrityService(),
+		RateLimit:   factory.CreateRateLimitService(),
 	}
 }
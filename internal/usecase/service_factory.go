@@ -6,6 +6,10 @@ import (
 
 type serviceFactory struct {
 	params Params
+
+	// webhooks is shared by the webhook API and the event handler that
+	// queues deliveries.
+	webhooks *WebhookService
 }
 
 func newServiceFactory(params Params) *serviceFactory {
@@ -13,41 +17,213 @@ func newServiceFactory(params Params) *serviceFactory {
 }
 
 func (f *serviceFactory) CreateAuthService() adapters.AuthService {
-	return NewAuthService(AuthServiceParams{
-		UserRepository:         f.params.Repository.User,
-		TeamRepository:         f.params.Repository.Team,
-		PlayerRepository:       f.params.Repository.Player,
-		LoginAttemptRepository: f.params.Repository.LoginAttempt,
-		JWTManager:             f.params.JWTManager,
-		Logger:                 f.params.Logger,
-		Config:                 f.params.Config,
+	service := NewAuthService(AuthServiceParams{
+		UserRepository:             f.params.Repository.User,
+		TeamRepository:             f.params.Repository.Team,
+		LoginAttemptRepository:     f.params.Repository.LoginAttempt,
+		AuditRepository:            f.params.Repository.Audit,
+		ActionTokenRepository:      f.params.Repository.ActionToken,
+		SessionRepository:          f.params.Repository.Session,
+		TwoFactorRepository:        f.params.Repository.TwoFactor,
+		IdentityRepository:         f.params.Repository.Identity,
+		OIDCStateRepository:        f.params.Repository.OIDCState,
+		OIDCProviders:              f.params.OIDCProviders,
+		Mailer:                     f.params.Mailer,
+		JWTManager:                 f.params.JWTManager,
+		LeaderboardRepository:      f.params.Repository.Leaderboard,
+		LeaderboardCacheRepository: f.params.Repository.LeaderboardCache,
+		Logger:                     f.params.Logger,
+		Config:                     f.params.Config,
 	})
+
+	return &tracedAuthService{next: service}
 }
 
-func (f *serviceFactory) CreateTeamService() adapters.TeamService {
-	return NewTeamService(TeamServiceParams{
-		TeamRepository:      f.params.Repository.Team,
-		PlayerRepository:    f.params.Repository.Player,
-		TeamCacheRepository: f.params.Repository.TeamCache,
+func (f *serviceFactory) CreateAccountService() adapters.AccountService {
+	service := NewAccountService(AccountServiceParams{
+		UserRepository:             f.params.Repository.User,
+		TeamRepository:             f.params.Repository.Team,
+		TeamCacheRepository:        f.params.Repository.TeamCache,
+		SessionRepository:          f.params.Repository.Session,
+		ActionTokenRepository:      f.params.Repository.ActionToken,
+		AuditRepository:            f.params.Repository.Audit,
+		Mailer:                     f.params.Mailer,
+		JWTManager:                 f.params.JWTManager,
+		LeaderboardCacheRepository: f.params.Repository.LeaderboardCache,
+		MarketEventRepository:      f.params.Repository.MarketEvents,
+		Logger:                     f.params.Logger,
+		Config:                     f.params.Config,
+	})
+
+	return &tracedAccountService{next: service}
+}
+
+func (f *serviceFactory) CreateAPIKeyService() adapters.APIKeyService {
+	service := NewAPIKeyService(APIKeyServiceParams{
+		APIKeyRepository: f.params.Repository.APIKey,
+		UserRepository:   f.params.Repository.User,
+		AuditRepository:  f.params.Repository.Audit,
+		Logger:           f.params.Logger,
+		Config:           f.params.Config,
+	})
+
+	return &tracedAPIKeyService{next: service}
+}
+
+func (f *serviceFactory) CreateWebhookService() adapters.WebhookService {
+	return &tracedWebhookService{next: f.webhookService()}
+}
+
+func (f *serviceFactory) webhookService() *WebhookService {
+	if f.webhooks == nil {
+		f.webhooks = NewWebhookService(WebhookServiceParams{
+			WebhookRepository:  f.params.Repository.Webhook,
+			DeliveryRepository: f.params.Repository.WebhookDelivery,
+			TeamRepository:     f.params.Repository.Team,
+			AuditRepository:    f.params.Repository.Audit,
+			Sender:             f.params.WebhookSender,
+			Logger:             f.params.Logger,
+			Config:             f.params.Config,
+		})
+	}
+
+	return f.webhooks
+}
+
+func (f *serviceFactory) CreateTwoFactorService() adapters.TwoFactorService {
+	service := NewTwoFactorService(TwoFactorServiceParams{
+		UserRepository:      f.params.Repository.User,
+		TwoFactorRepository: f.params.Repository.TwoFactor,
+		AuditRepository:     f.params.Repository.Audit,
 		Logger:              f.params.Logger,
+		Config:              f.params.Config,
+	})
+
+	return &tracedTwoFactorService{next: service}
+}
+
+func (f *serviceFactory) CreateTeamService() adapters.TeamService {
+	service := NewTeamService(TeamServiceParams{
+		TeamRepository:             f.params.Repository.Team,
+		PlayerRepository:           f.params.Repository.Player,
+		TeamCacheRepository:        f.params.Repository.TeamCache,
+		AuditRepository:            f.params.Repository.Audit,
+		LedgerRepository:           f.params.Repository.Ledger,
+		LeaderboardRepository:      f.params.Repository.Leaderboard,
+		LeaderboardCacheRepository: f.params.Repository.LeaderboardCache,
+		Logger:                     f.params.Logger,
+		Config:                     f.params.Config,
 	})
+
+	return &tracedTeamService{next: service}
 }
 
 func (f *serviceFactory) CreatePlayerService() adapters.PlayerService {
-	return NewPlayerService(PlayerServiceParams{
+	service := NewPlayerService(PlayerServiceParams{
 		PlayerRepository:    f.params.Repository.Player,
 		TeamRepository:      f.params.Repository.Team,
 		TeamCacheRepository: f.params.Repository.TeamCache,
+		AuditRepository:     f.params.Repository.Audit,
 		Logger:              f.params.Logger,
 	})
+
+	return &tracedPlayerService{next: service}
 }
 
 func (f *serviceFactory) CreateTransferService() adapters.TransferService {
-	return NewTransferService(TransferServiceParams{
-		TransferRepository:  f.params.Repository.Transfer,
-		PlayerRepository:    f.params.Repository.Player,
-		TeamRepository:      f.params.Repository.Team,
+	service := NewTransferService(TransferServiceParams{
+		TransferRepository:    f.params.Repository.Transfer,
+		PlayerRepository:      f.params.Repository.Player,
+		TeamRepository:        f.params.Repository.Team,
+		TeamCacheRepository:   f.params.Repository.TeamCache,
+		AuditRepository:       f.params.Repository.Audit,
+		MarketEventRepository: f.params.Repository.MarketEvents,
+		Logger:                f.params.Logger,
+	})
+
+	return &tracedTransferService{next: service}
+}
+
+func (f *serviceFactory) CreateLeaderboardService() adapters.LeaderboardService {
+	service := NewLeaderboardService(LeaderboardServiceParams{
+		LeaderboardRepository:      f.params.Repository.Leaderboard,
+		LeaderboardCacheRepository: f.params.Repository.LeaderboardCache,
+		TeamRepository:             f.params.Repository.Team,
+		Logger:                     f.params.Logger,
+		Config:                     f.params.Config,
+	})
+
+	return &tracedLeaderboardService{next: service}
+}
+
+func (f *serviceFactory) CreateMarketService() adapters.MarketService {
+	service := NewMarketService(MarketServiceParams{
+		MarketEventRepository: f.params.Repository.MarketEvents,
+		Logger:                f.params.Logger,
+		Config:                f.params.Config,
+	})
+
+	return &tracedMarketService{next: service}
+}
+
+// CreateEventService returns the event dispatcher with the in-process
+// handlers subscribed.
+func (f *serviceFactory) CreateEventService() adapters.EventService {
+	service := NewEventService(EventServiceParams{
+		OutboxRepository: f.params.Repository.Outbox,
+		Sinks:            f.params.EventSinks,
+		Logger:           f.params.Logger,
+		Config:           f.params.Config,
+	})
+
+	teamHandlers := &teamEventHandlers{
+		teamCacheRepository:        f.params.Repository.TeamCache,
+		leaderboardRepository:      f.params.Repository.Leaderboard,
+		leaderboardCacheRepository: f.params.Repository.LeaderboardCache,
+		logger:                     f.params.Logger,
+	}
+	teamHandlers.subscribe(service)
+	f.webhookService().subscribe(service)
+
+	return &tracedEventService{next: service}
+}
+
+func (f *serviceFactory) CreateAdminService() adapters.AdminService {
+	service := NewAdminService(AdminServiceParams{
+		UserRepository:             f.params.Repository.User,
+		TeamRepository:             f.params.Repository.Team,
+		TransferRepository:         f.params.Repository.Transfer,
+		TeamCacheRepository:        f.params.Repository.TeamCache,
+		AuditRepository:            f.params.Repository.Audit,
+		LedgerRepository:           f.params.Repository.Ledger,
+		LoginAttemptRepository:     f.params.Repository.LoginAttempt,
+		TwoFactorRepository:        f.params.Repository.TwoFactor,
+		LeaderboardRepository:      f.params.Repository.Leaderboard,
+		LeaderboardCacheRepository: f.params.Repository.LeaderboardCache,
+		MarketEventRepository:      f.params.Repository.MarketEvents,
+		SessionRepository:          f.params.Repository.Session,
+		Logger:                     f.params.Logger,
+	})
+
+	return &tracedAdminService{next: service}
+}
+
+func (f *serviceFactory) CreateIntegrityService() adapters.IntegrityService {
+	service := NewIntegrityService(IntegrityServiceParams{
+		IntegrityRepository: f.params.Repository.Integrity,
 		TeamCacheRepository: f.params.Repository.TeamCache,
+		AuditRepository:     f.params.Repository.Audit,
 		Logger:              f.params.Logger,
 	})
+
+	return &tracedIntegrityService{next: service}
+}
+
+func (f *serviceFactory) CreateRateLimitService() adapters.RateLimitService {
+	service := NewRateLimitService(RateLimitServiceParams{
+		RateLimitRepository: f.params.Repository.RateLimit,
+		Logger:              f.params.Logger,
+	})
+
+	return &tracedRateLimitService{next: service}
 }
@@ -2,61 +2,112 @@ package usecase
 
 import (
 	"context"
+	"math/rand"
+	"soccer_manager_service/internal/config"
 	"soccer_manager_service/internal/dto"
 	"soccer_manager_service/internal/entity"
 	"soccer_manager_service/internal/ports"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+	"strings"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+var (
+	firstNames = []string{"Oliver", "Jack", "Harry", "George", "Noah", "Charlie", "Leo", "Oscar", "Jacob", "Liam"}
+	lastNames  = []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Martinez", "Hernandez"}
+	countries  = []string{"England", "Spain", "Germany", "France", "Italy", "Brazil", "Argentina", "Portugal", "Netherlands", "Belgium"}
+)
+
+// initialTeamBudget is the seed money every new team is credited with.
+const initialTeamBudget = 5000000
+
+// TeamService manages the teams of a user. A user can have several teams;
+// operations act on the team selected by teamID, which may be uuid.Nil when
+// the user has only one.
 type TeamService struct {
-	teamRepository      ports.TeamRepository
-	playerRepository    ports.PlayerRepository
-	teamCacheRepository ports.TeamCacheRepository
-	logger              *zap.Logger
+	teamRepository             ports.TeamRepository
+	playerRepository           ports.PlayerRepository
+	teamCacheRepository        ports.TeamCacheRepository
+	auditRepository            ports.AuditRepository
+	ledgerRepository           ports.LedgerRepository
+	leaderboardRepository      ports.LeaderboardRepository
+	leaderboardCacheRepository ports.LeaderboardCacheRepository
+	logger                     *zap.Logger
+	config                     *config.Config
 }
 
 type TeamServiceParams struct {
-	TeamRepository      ports.TeamRepository
-	PlayerRepository    ports.PlayerRepository
-	TeamCacheRepository ports.TeamCacheRepository
-	Logger              *zap.Logger
+	TeamRepository             ports.TeamRepository
+	PlayerRepository           ports.PlayerRepository
+	TeamCacheRepository        ports.TeamCacheRepository
+	AuditRepository            ports.AuditRepository
+	LedgerRepository           ports.LedgerRepository
+	LeaderboardRepository      ports.LeaderboardRepository
+	LeaderboardCacheRepository ports.LeaderboardCacheRepository
+	Logger                     *zap.Logger
+	Config                     *config.Config
 }
 
 func NewTeamService(params TeamServiceParams) *TeamService {
 	return &TeamService{
-		teamRepository:      params.TeamRepository,
-		playerRepository:    params.PlayerRepository,
-		teamCacheRepository: params.TeamCacheRepository,
-		logger:              params.Logger.With(zap.String("service", "TeamService")),
+		teamRepository:             params.TeamRepository,
+		playerRepository:           params.PlayerRepository,
+		teamCacheRepository:        params.TeamCacheRepository,
+		auditRepository:            params.AuditRepository,
+		ledgerRepository:           params.LedgerRepository,
+		leaderboardRepository:      params.LeaderboardRepository,
+		leaderboardCacheRepository: params.LeaderboardCacheRepository,
+		logger:                     params.Logger.With(zap.String("service", "TeamService")),
+		config:                     params.Config,
 	}
 }
 
-func (s *TeamService) GetMyTeam(ctx context.Context, userID uuid.UUID) (*dto.TeamWithPlayersResponse, error) {
-	s.logger.Info("getting team", zap.String("user_id", userID.String()))
+func (s *TeamService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger, zap.String("service", "TeamService"))
+}
+
+func (s *TeamService) GetMyTeam(ctx context.Context, userID, teamID uuid.UUID) (*dto.TeamWithPlayersResponse, error) {
+	log := s.log(ctx)
+
+	log.Info("getting team", zap.String("user_id", userID.String()))
+
+	// Without a selected team the user's only team is looked up first, so
+	// that its cached entry can be used.
+	var team *entity.Team
+
+	if teamID == uuid.Nil {
+		selected, err := selectTeam(ctx, s.teamRepository, log, userID, teamID)
+		if err != nil {
+			return nil, err
+		}
+
+		team, teamID = selected, selected.ID
+	}
 
-	cachedTeam, err := s.teamCacheRepository.GetTeam(ctx, userID)
+	cachedTeam, err := s.teamCacheRepository.GetTeam(ctx, teamID)
 	if err != nil {
-		s.logger.Warn("failed to get cached team", zap.Error(err))
+		log.Warn("failed to get cached team", zap.Error(err))
 	}
 
-	if cachedTeam != nil {
-		s.logger.Debug("team retrieved from cache", zap.String("user_id", userID.String()))
+	if cachedTeam != nil && cachedTeam.Team.UserID == userID {
+		log.Debug("team retrieved from cache", zap.String("team_id", teamID.String()))
 
 		return cachedTeam, nil
 	}
 
-	team, err := s.teamRepository.GetByUserID(ctx, userID)
-	if err != nil {
-		s.logger.Error("failed to get team", zap.Error(err))
-
-		return nil, err
+	if team == nil {
+		team, err = selectTeam(ctx, s.teamRepository, log, userID, teamID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	players, err := s.playerRepository.GetByTeamID(ctx, team.ID)
 	if err != nil {
-		s.logger.Error("failed to get players", zap.Error(err))
+		log.Error("failed to get players", zap.Error(err))
 
 		return nil, err
 	}
@@ -69,7 +120,7 @@ func (s *TeamService) GetMyTeam(ctx context.Context, userID uuid.UUID) (*dto.Tea
 
 	if team.TotalValue != totalValue {
 		if err := s.teamRepository.UpdateTotalValue(ctx, team.ID, totalValue); err != nil {
-			s.logger.Warn("failed to update team total value", zap.Error(err))
+			log.Warn("failed to update team total value", zap.Error(err))
 		} else {
 			team.TotalValue = totalValue
 		}
@@ -80,33 +131,338 @@ func (s *TeamService) GetMyTeam(ctx context.Context, userID uuid.UUID) (*dto.Tea
 		Players: players,
 	}
 
-	if err := s.teamCacheRepository.SetTeam(ctx, userID, teamWithPlayers); err != nil {
-		s.logger.Warn("failed to cache team", zap.Error(err))
+	if err := s.teamCacheRepository.SetTeam(ctx, team.ID, teamWithPlayers); err != nil {
+		log.Warn("failed to cache team", zap.Error(err))
 	}
 
 	return teamWithPlayers, nil
 }
 
-func (s *TeamService) UpdateTeam(ctx context.Context, userID uuid.UUID, req *dto.UpdateTeamRequest) (*entity.Team, error) {
-	s.logger.Info("updating team", zap.String("user_id", userID.String()))
+func (s *TeamService) UpdateTeam(ctx context.Context, userID, teamID uuid.UUID, req *dto.UpdateTeamRequest) (*entity.Team, error) {
+	log := s.log(ctx)
 
-	existingTeam, err := s.teamRepository.GetByUserID(ctx, userID)
-	if err != nil {
-		s.logger.Error("failed to get team", zap.Error(err))
+	log.Info("updating team", zap.String("user_id", userID.String()))
 
+	existingTeam, err := selectTeam(ctx, s.teamRepository, log, userID, teamID)
+	if err != nil {
 		return nil, err
 	}
 
 	team, err := s.teamRepository.Update(ctx, existingTeam.ID, req.Name, req.Country)
 	if err != nil {
-		s.logger.Error("failed to update team", zap.Error(err))
+		log.Error("failed to update team", zap.Error(err))
+
+		return nil, err
+	}
+
+	if err := s.teamCacheRepository.InvalidateTeam(ctx, team.ID); err != nil {
+		log.Warn("failed to invalidate team cache", zap.Error(err))
+	}
+
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &userID, "team.updated", entity.AuditEntityTeam, team.ID, existingTeam, team))
+
+	return team, nil
+}
+
+func (s *TeamService) GetFinances(ctx context.Context, userID, teamID uuid.UUID, req *dto.FinancesRequest) (*dto.TeamFinancesResponse, error) {
+	log := s.log(ctx)
+
+	team, err := selectTeam(ctx, s.teamRepository, log, userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := s.ledgerRepository.Balance(ctx, team.ID)
+	if err != nil {
+		log.Error("failed to get ledger balance", zap.Error(err))
+
+		return nil, err
+	}
+
+	if balance != team.Budget {
+		log.Error("team budget does not match ledger balance",
+			zap.String("team_id", team.ID.String()),
+			zap.Int64("budget", team.Budget),
+			zap.Int64("ledger_balance", balance))
+	}
+
+	limit := listLimit(req.Limit)
+
+	entries, err := s.ledgerRepository.Statement(ctx, team.ID, limit, req.Offset)
+	if err != nil {
+		log.Error("failed to get ledger statement", zap.Error(err))
+
+		return nil, err
+	}
+
+	return &dto.TeamFinancesResponse{
+		TeamID:        team.ID,
+		Budget:        team.Budget,
+		LedgerBalance: balance,
+		Verified:      balance == team.Budget,
+		Entries:       entries,
+		Limit:         limit,
+		Offset:        req.Offset,
+	}, nil
+}
+
+// ListTeams returns the user's teams, oldest first.
+func (s *TeamService) ListTeams(ctx context.Context, userID uuid.UUID) (*dto.TeamListResponse, error) {
+	teams, err := s.teamRepository.ListByUserID(ctx, userID)
+	if err != nil {
+		s.log(ctx).Error("failed to list teams", zap.Error(err))
+
+		return nil, err
+	}
+
+	return &dto.TeamListResponse{Teams: teams}, nil
+}
+
+// CreateTeam gives the user another team with the same seed money and squad
+// as the team created at registration.
+func (s *TeamService) CreateTeam(ctx context.Context, userID uuid.UUID, req *dto.CreateTeamRequest) (*entity.Team, error) {
+	log := s.log(ctx)
+
+	teams, err := s.teamRepository.ListByUserID(ctx, userID)
+	if err != nil {
+		log.Error("failed to list teams", zap.Error(err))
+
+		return nil, err
+	}
+
+	if len(teams) >= s.config.Team.MaxPerUser {
+		log.Warn("team limit reached", zap.String("user_id", userID.String()))
+
+		return nil, apperr.ErrTeamLimitReached
+	}
+
+	team, err := createTeam(ctx, s.teamRepository, log, userID, strings.TrimSpace(req.Name), strings.TrimSpace(req.Country))
+	if err != nil {
+		return nil, err
+	}
+
+	updateLeaderboards(ctx, s.leaderboardRepository, s.leaderboardCacheRepository, log, team.ID)
+
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &userID, "team.created", entity.AuditEntityTeam, team.ID, nil, team))
+
+	log.Info("team created", zap.String("user_id", userID.String()), zap.String("team_id", team.ID.String()))
+
+	return team, nil
+}
+
+// GetPublicTeam returns the profile of any team as other users see it,
+// without the owner and the budget.
+func (s *TeamService) GetPublicTeam(ctx context.Context, teamID uuid.UUID) (*dto.PublicTeamResponse, error) {
+	log := s.log(ctx)
+
+	cachedTeam, err := s.teamCacheRepository.GetPublicTeam(ctx, teamID)
+	if err != nil {
+		log.Warn("failed to get cached public team", zap.Error(err))
+	}
+
+	if cachedTeam != nil {
+		return cachedTeam, nil
+	}
+
+	team, err := s.teamRepository.GetByID(ctx, teamID)
+	if err != nil {
+		log.Warn("failed to get team", zap.String("team_id", teamID.String()), zap.Error(err))
+
+		return nil, err
+	}
+
+	players, err := s.playerRepository.GetByTeamID(ctx, team.ID)
+	if err != nil {
+		log.Error("failed to get players", zap.Error(err))
+
+		return nil, err
+	}
+
+	profile := &dto.PublicTeamResponse{
+		Team:    publicTeam(team),
+		Players: make([]dto.PublicPlayer, 0, len(players)),
+	}
+
+	var totalValue int64
+
+	for _, p := range players {
+		totalValue += p.MarketValue
+		profile.Players = append(profile.Players, publicPlayer(&p))
+	}
+
+	profile.Team.TotalValue = totalValue
+
+	if err := s.teamCacheRepository.SetPublicTeam(ctx, team.ID, profile); err != nil {
+		log.Warn("failed to cache public team", zap.Error(err))
+	}
+
+	return profile, nil
+}
+
+// SearchTeams returns a page of public team profiles matching the name and
+// country in req.
+func (s *TeamService) SearchTeams(ctx context.Context, req *dto.SearchTeamsRequest) (*dto.PublicTeamsResponse, error) {
+	limit := listLimit(req.Limit)
+
+	filter := entity.TeamFilter{
+		Name:    strings.TrimSpace(req.Name),
+		Country: strings.TrimSpace(req.Country),
+	}
+
+	teams, err := s.teamRepository.Search(ctx, filter, limit, req.Offset)
+	if err != nil {
+		s.log(ctx).Error("failed to search teams", zap.Error(err))
+
+		return nil, err
+	}
+
+	result := make([]dto.PublicTeam, 0, len(teams))
+
+	for _, team := range teams {
+		result = append(result, publicTeam(&team))
+	}
+
+	return &dto.PublicTeamsResponse{
+		Teams:  result,
+		Limit:  limit,
+		Offset: req.Offset,
+	}, nil
+}
+
+func publicTeam(team *entity.Team) dto.PublicTeam {
+	return dto.PublicTeam{
+		ID:         team.ID,
+		Name:       team.Name,
+		Country:    team.Country,
+		TotalValue: team.TotalValue,
+		CreatedAt:  team.CreatedAt,
+	}
+}
+
+func publicPlayer(player *entity.Player) dto.PublicPlayer {
+	return dto.PublicPlayer{
+		ID:          player.ID,
+		TeamID:      player.TeamID,
+		FirstName:   player.FirstName,
+		LastName:    player.LastName,
+		Country:     player.Country,
+		Age:         player.Age,
+		Position:    player.Position,
+		MarketValue: player.MarketValue,
+	}
+}
+
+// selectTeam returns the user's team with ID teamID or, when teamID is
+// uuid.Nil, the user's only team. Teams of other users are reported as not
+// found.
+func selectTeam(ctx context.Context, teams ports.TeamRepository, log *zap.Logger, userID, teamID uuid.UUID) (*entity.Team, error) {
+	if teamID != uuid.Nil {
+		team, err := teams.GetByID(ctx, teamID)
+		if err != nil {
+			log.Warn("failed to get team", zap.String("team_id", teamID.String()), zap.Error(err))
+
+			return nil, err
+		}
+
+		if team.UserID != userID {
+			log.Warn("team belongs to another user", zap.String("team_id", teamID.String()))
+
+			return nil, apperr.ErrTeamNotFound
+		}
+
+		return team, nil
+	}
+
+	owned, err := teams.ListByUserID(ctx, userID)
+	if err != nil {
+		log.Error("failed to list teams", zap.Error(err))
 
 		return nil, err
 	}
 
-	if err := s.teamCacheRepository.InvalidateTeam(ctx, userID); err != nil {
-		s.logger.Warn("failed to invalidate team cache", zap.Error(err))
+	switch len(owned) {
+	case 0:
+		return nil, apperr.ErrTeamNotFound
+	case 1:
+		return &owned[0], nil
+	default:
+		return nil, apperr.ErrTeamSelectionRequired
+	}
+}
+
+// createTeam creates a team with its seed money and initial squad.
+func createTeam(
+	ctx context.Context,
+	teams ports.TeamRepository,
+	log *zap.Logger,
+	userID uuid.UUID,
+	name, country string,
+) (*entity.Team, error) {
+	teamID := uuid.New()
+	players := initialPlayers(teamID)
+
+	var totalValue int64
+
+	for _, p := range players {
+		totalValue += p.MarketValue
+	}
+
+	team, err := teams.Create(ctx, entity.TeamFounding{
+		Team: entity.Team{
+			ID:         teamID,
+			UserID:     userID,
+			Name:       name,
+			Country:    country,
+			TotalValue: totalValue,
+		},
+		SeedMoney: entity.LedgerTransaction{
+			Kind:        entity.LedgerKindSeedMoney,
+			Description: "Initial team budget",
+			Postings: []entity.LedgerPosting{
+				entity.TeamPosting(teamID, initialTeamBudget),
+				entity.SystemPosting(entity.LedgerAccountSeedCapital, -initialTeamBudget),
+			},
+		},
+		Players: players,
+	})
+	if err != nil {
+		log.Error("failed to create team", zap.Error(err))
+
+		return nil, err
 	}
 
 	return team, nil
 }
+
+// initialPlayers returns a random squad for a new team.
+func initialPlayers(teamID uuid.UUID) []entity.Player {
+	positions := []struct {
+		position entity.PlayerPosition
+		count    int
+	}{
+		{entity.PositionGoalkeeper, 3},
+		{entity.PositionDefender, 6},
+		{entity.PositionMidfielder, 6},
+		{entity.PositionAttacker, 5},
+	}
+
+	var players []entity.Player
+
+	for _, p := range positions {
+		for i := 0; i < p.count; i++ {
+			players = append(players, entity.Player{
+				TeamID:      teamID,
+				FirstName:   firstNames[rand.Intn(len(firstNames))],
+				LastName:    lastNames[rand.Intn(len(lastNames))],
+				Country:     countries[rand.Intn(len(countries))],
+				Age:         18 + rand.Intn(23),
+				Position:    p.position,
+				MarketValue: 1000000,
+			})
+		}
+	}
+
+	return players
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/stretchr/testify/mock"
 	"testing"
 
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/dto"
 	"soccer_manager_service/internal/entity"
 	apperr "soccer_manager_service/pkg/errors"
 
@@ -26,7 +28,7 @@ func TestTeamService_GetMyTeam(t *testing.T) {
 		mockPlayerRepo := new(MockPlayerRepository)
 		mockCacheRepo := new(MockTeamCacheRepository)
 
-		cachedTeam := &entity.TeamWithPlayers{
+		cachedTeam := &dto.TeamWithPlayersResponse{
 			Team: entity.Team{
 				ID:     teamID,
 				UserID: userID,
@@ -37,16 +39,17 @@ func TestTeamService_GetMyTeam(t *testing.T) {
 			},
 		}
 
-		mockCacheRepo.On("GetTeam", ctx, userID).Return(cachedTeam, nil)
+		mockCacheRepo.On("GetTeam", ctx, teamID).Return(cachedTeam, nil)
 
 		service := NewTeamService(TeamServiceParams{
 			TeamRepository:      mockTeamRepo,
 			PlayerRepository:    mockPlayerRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		result, err := service.GetMyTeam(ctx, userID)
+		result, err := service.GetMyTeam(ctx, userID, teamID)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -72,11 +75,11 @@ func TestTeamService_GetMyTeam(t *testing.T) {
 			{ID: uuid.New(), TeamID: teamID, MarketValue: 2000000},
 		}
 
-		mockCacheRepo.On("GetTeam", ctx, userID).Return(nil, errors.New("cache miss"))
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(team, nil)
+		mockCacheRepo.On("GetTeam", ctx, teamID).Return(nil, errors.New("cache miss"))
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(team, nil)
 		mockPlayerRepo.On("GetByTeamID", ctx, teamID).Return(players, nil)
 		mockTeamRepo.On("UpdateTotalValue", ctx, teamID, int64(3000000)).Return(nil)
-		mockCacheRepo.On("SetTeam", ctx, userID, mock.MatchedBy(func(t *entity.TeamWithPlayers) bool {
+		mockCacheRepo.On("SetTeam", ctx, teamID, mock.MatchedBy(func(t *dto.TeamWithPlayersResponse) bool {
 			return t.Team.TotalValue == 3000000
 		})).Return(nil)
 
@@ -84,10 +87,11 @@ func TestTeamService_GetMyTeam(t *testing.T) {
 			TeamRepository:      mockTeamRepo,
 			PlayerRepository:    mockPlayerRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		result, err := service.GetMyTeam(ctx, userID)
+		result, err := service.GetMyTeam(ctx, userID, teamID)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -104,17 +108,18 @@ func TestTeamService_GetMyTeam(t *testing.T) {
 		mockPlayerRepo := new(MockPlayerRepository)
 		mockCacheRepo := new(MockTeamCacheRepository)
 
-		mockCacheRepo.On("GetTeam", ctx, userID).Return(nil, errors.New("cache miss"))
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(nil, apperr.ErrTeamNotFound)
+		mockCacheRepo.On("GetTeam", ctx, teamID).Return(nil, errors.New("cache miss"))
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(nil, apperr.ErrTeamNotFound)
 
 		service := NewTeamService(TeamServiceParams{
 			TeamRepository:      mockTeamRepo,
 			PlayerRepository:    mockPlayerRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		result, err := service.GetMyTeam(ctx, userID)
+		result, err := service.GetMyTeam(ctx, userID, teamID)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -134,18 +139,19 @@ func TestTeamService_GetMyTeam(t *testing.T) {
 			Name:   "Test Team",
 		}
 
-		mockCacheRepo.On("GetTeam", ctx, userID).Return(nil, errors.New("cache miss"))
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(team, nil)
+		mockCacheRepo.On("GetTeam", ctx, teamID).Return(nil, errors.New("cache miss"))
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(team, nil)
 		mockPlayerRepo.On("GetByTeamID", ctx, teamID).Return(nil, errors.New("database error"))
 
 		service := NewTeamService(TeamServiceParams{
 			TeamRepository:      mockTeamRepo,
 			PlayerRepository:    mockPlayerRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		result, err := service.GetMyTeam(ctx, userID)
+		result, err := service.GetMyTeam(ctx, userID, teamID)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -171,10 +177,10 @@ func TestTeamService_GetMyTeam(t *testing.T) {
 			{ID: uuid.New(), TeamID: teamID, MarketValue: 2500000},
 		}
 
-		mockCacheRepo.On("GetTeam", ctx, userID).Return(nil, errors.New("cache miss"))
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(team, nil)
+		mockCacheRepo.On("GetTeam", ctx, teamID).Return(nil, errors.New("cache miss"))
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(team, nil)
 		mockPlayerRepo.On("GetByTeamID", ctx, teamID).Return(players, nil)
-		mockCacheRepo.On("SetTeam", ctx, userID, mock.MatchedBy(func(t *entity.TeamWithPlayers) bool {
+		mockCacheRepo.On("SetTeam", ctx, teamID, mock.MatchedBy(func(t *dto.TeamWithPlayersResponse) bool {
 			return t.Team.TotalValue == 5000000
 		})).Return(nil)
 
@@ -182,10 +188,11 @@ func TestTeamService_GetMyTeam(t *testing.T) {
 			TeamRepository:      mockTeamRepo,
 			PlayerRepository:    mockPlayerRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		result, err := service.GetMyTeam(ctx, userID)
+		result, err := service.GetMyTeam(ctx, userID, teamID)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -194,6 +201,105 @@ func TestTeamService_GetMyTeam(t *testing.T) {
 		mockPlayerRepo.AssertExpectations(t)
 		mockCacheRepo.AssertExpectations(t)
 	})
+	t.Run("only team selected by default", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockPlayerRepo := new(MockPlayerRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+
+		team := entity.Team{ID: teamID, UserID: userID, Name: "Test Team"}
+
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{team}, nil)
+		mockCacheRepo.On("GetTeam", ctx, teamID).Return(nil, nil)
+		mockPlayerRepo.On("GetByTeamID", ctx, teamID).Return([]entity.Player{}, nil)
+		mockCacheRepo.On("SetTeam", ctx, teamID, mock.Anything).Return(nil)
+
+		service := NewTeamService(TeamServiceParams{
+			TeamRepository:      mockTeamRepo,
+			PlayerRepository:    mockPlayerRepo,
+			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+		})
+
+		result, err := service.GetMyTeam(ctx, userID, uuid.Nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, teamID, result.Team.ID)
+		mockTeamRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+		mockCacheRepo.AssertExpectations(t)
+	})
+
+	t.Run("only team from cache", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockPlayerRepo := new(MockPlayerRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+
+		team := entity.Team{ID: teamID, UserID: userID, Name: "Cached Team"}
+
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{team}, nil)
+		mockCacheRepo.On("GetTeam", ctx, teamID).Return(&dto.TeamWithPlayersResponse{Team: team}, nil)
+
+		service := NewTeamService(TeamServiceParams{
+			TeamRepository:      mockTeamRepo,
+			PlayerRepository:    mockPlayerRepo,
+			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+		})
+
+		result, err := service.GetMyTeam(ctx, userID, uuid.Nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Cached Team", result.Team.Name)
+		mockPlayerRepo.AssertNotCalled(t, "GetByTeamID", mock.Anything, mock.Anything)
+		mockCacheRepo.AssertNotCalled(t, "SetTeam", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("several teams require selection", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{
+			{ID: teamID, UserID: userID},
+			{ID: uuid.New(), UserID: userID},
+		}, nil)
+
+		service := NewTeamService(TeamServiceParams{
+			TeamRepository:      mockTeamRepo,
+			PlayerRepository:    new(MockPlayerRepository),
+			TeamCacheRepository: new(MockTeamCacheRepository),
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+		})
+
+		result, err := service.GetMyTeam(ctx, userID, uuid.Nil)
+
+		assert.ErrorIs(t, err, apperr.ErrTeamSelectionRequired)
+		assert.Nil(t, result)
+	})
+
+	t.Run("cached team of another user", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+
+		otherUserID := uuid.New()
+		cachedTeam := &dto.TeamWithPlayersResponse{Team: entity.Team{ID: teamID, UserID: otherUserID}}
+
+		mockCacheRepo.On("GetTeam", ctx, teamID).Return(cachedTeam, nil)
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(&entity.Team{ID: teamID, UserID: otherUserID}, nil)
+
+		service := NewTeamService(TeamServiceParams{
+			TeamRepository:      mockTeamRepo,
+			PlayerRepository:    new(MockPlayerRepository),
+			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+		})
+
+		result, err := service.GetMyTeam(ctx, userID, teamID)
+
+		assert.ErrorIs(t, err, apperr.ErrTeamNotFound)
+		assert.Nil(t, result)
+	})
 }
 
 func TestTeamService_UpdateTeam(t *testing.T) {
@@ -220,29 +326,42 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 			Name:   "New Team",
 		}
 
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(existingTeam, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{*existingTeam}, nil)
 		mockTeamRepo.On("Update", ctx, teamID, "New Team", "Spain").Return(updatedTeam, nil)
-		mockCacheRepo.On("InvalidateTeam", ctx, userID).Return(nil)
+		mockCacheRepo.On("InvalidateTeam", ctx, teamID).Return(nil)
+
+		mockAuditRepo := new(MockAuditRepository)
+		mockAuditRepo.On("Create", ctx, []entity.AuditEntry{{
+			ActorID:    &userID,
+			Action:     "team.updated",
+			EntityType: entity.AuditEntityTeam,
+			EntityID:   &teamID,
+			Changes: map[string]entity.AuditChange{
+				"name": {Before: "Old Team", After: "New Team"},
+			},
+		}}).Return(nil)
 
 		service := NewTeamService(TeamServiceParams{
 			TeamRepository:      mockTeamRepo,
 			PlayerRepository:    mockPlayerRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     mockAuditRepo,
 			Logger:              logger,
 		})
 
-		req := &entity.UpdateTeamRequest{
+		req := &dto.UpdateTeamRequest{
 			Name:    "New Team",
 			Country: "Spain",
 		}
 
-		result, err := service.UpdateTeam(ctx, userID, req)
+		result, err := service.UpdateTeam(ctx, userID, uuid.Nil, req)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, "New Team", result.Name)
 		mockTeamRepo.AssertExpectations(t)
 		mockCacheRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
 	})
 
 	t.Run("team not found", func(t *testing.T) {
@@ -250,21 +369,22 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 		mockPlayerRepo := new(MockPlayerRepository)
 		mockCacheRepo := new(MockTeamCacheRepository)
 
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(nil, apperr.ErrTeamNotFound)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{}, nil)
 
 		service := NewTeamService(TeamServiceParams{
 			TeamRepository:      mockTeamRepo,
 			PlayerRepository:    mockPlayerRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		req := &entity.UpdateTeamRequest{
+		req := &dto.UpdateTeamRequest{
 			Name:    "New Team",
 			Country: "Spain",
 		}
 
-		result, err := service.UpdateTeam(ctx, userID, req)
+		result, err := service.UpdateTeam(ctx, userID, uuid.Nil, req)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -283,22 +403,23 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 			Name:   "Old Team",
 		}
 
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(existingTeam, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{*existingTeam}, nil)
 		mockTeamRepo.On("Update", ctx, teamID, "New Team", "Spain").Return(nil, errors.New("database error"))
 
 		service := NewTeamService(TeamServiceParams{
 			TeamRepository:      mockTeamRepo,
 			PlayerRepository:    mockPlayerRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		req := &entity.UpdateTeamRequest{
+		req := &dto.UpdateTeamRequest{
 			Name:    "New Team",
 			Country: "Spain",
 		}
 
-		result, err := service.UpdateTeam(ctx, userID, req)
+		result, err := service.UpdateTeam(ctx, userID, uuid.Nil, req)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -322,23 +443,24 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 			Name:   "New Team",
 		}
 
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(existingTeam, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{*existingTeam}, nil)
 		mockTeamRepo.On("Update", ctx, teamID, "New Team", "Spain").Return(updatedTeam, nil)
-		mockCacheRepo.On("InvalidateTeam", ctx, userID).Return(errors.New("cache error"))
+		mockCacheRepo.On("InvalidateTeam", ctx, teamID).Return(errors.New("cache error"))
 
 		service := NewTeamService(TeamServiceParams{
 			TeamRepository:      mockTeamRepo,
 			PlayerRepository:    mockPlayerRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		req := &entity.UpdateTeamRequest{
+		req := &dto.UpdateTeamRequest{
 			Name:    "New Team",
 			Country: "Spain",
 		}
 
-		result, err := service.UpdateTeam(ctx, userID, req)
+		result, err := service.UpdateTeam(ctx, userID, uuid.Nil, req)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -347,3 +469,335 @@ func TestTeamService_UpdateTeam(t *testing.T) {
 		mockCacheRepo.AssertExpectations(t)
 	})
 }
+
+func TestTeamService_GetFinances(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+
+	userID := uuid.New()
+	teamID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockLedgerRepo := new(MockLedgerRepository)
+
+		team := &entity.Team{ID: teamID, UserID: userID, Budget: 4000000}
+		entries := []entity.StatementLine{
+			{LedgerEntry: entity.LedgerEntry{Kind: entity.LedgerKindTransfer, TeamID: &teamID, Amount: -1000000}, Balance: 4000000},
+			{LedgerEntry: entity.LedgerEntry{Kind: entity.LedgerKindSeedMoney, TeamID: &teamID, Amount: 5000000}, Balance: 5000000},
+		}
+
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{*team}, nil)
+		mockLedgerRepo.On("Balance", ctx, teamID).Return(int64(4000000), nil)
+		mockLedgerRepo.On("Statement", ctx, teamID, uint(20), uint(0)).Return(entries, nil)
+
+		service := NewTeamService(TeamServiceParams{
+			TeamRepository:   mockTeamRepo,
+			LedgerRepository: mockLedgerRepo,
+			Logger:           logger,
+		})
+
+		result, err := service.GetFinances(ctx, userID, uuid.Nil, &dto.FinancesRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4000000), result.Budget)
+		assert.True(t, result.Verified)
+		assert.Equal(t, entries, result.Entries)
+		mockTeamRepo.AssertExpectations(t)
+		mockLedgerRepo.AssertExpectations(t)
+	})
+
+	t.Run("budget does not match ledger", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockLedgerRepo := new(MockLedgerRepository)
+
+		team := &entity.Team{ID: teamID, UserID: userID, Budget: 4500000}
+
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{*team}, nil)
+		mockLedgerRepo.On("Balance", ctx, teamID).Return(int64(4000000), nil)
+		mockLedgerRepo.On("Statement", ctx, teamID, uint(10), uint(10)).Return([]entity.StatementLine{}, nil)
+
+		service := NewTeamService(TeamServiceParams{
+			TeamRepository:   mockTeamRepo,
+			LedgerRepository: mockLedgerRepo,
+			Logger:           logger,
+		})
+
+		result, err := service.GetFinances(ctx, userID, uuid.Nil, &dto.FinancesRequest{Limit: 10, Offset: 10})
+
+		assert.NoError(t, err)
+		assert.False(t, result.Verified)
+		assert.Equal(t, int64(4000000), result.LedgerBalance)
+		mockLedgerRepo.AssertExpectations(t)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockLedgerRepo := new(MockLedgerRepository)
+
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{}, nil)
+
+		service := NewTeamService(TeamServiceParams{
+			TeamRepository:   mockTeamRepo,
+			LedgerRepository: mockLedgerRepo,
+			Logger:           logger,
+		})
+
+		result, err := service.GetFinances(ctx, userID, uuid.Nil, &dto.FinancesRequest{})
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperr.ErrTeamNotFound, err)
+		mockLedgerRepo.AssertNotCalled(t, "Statement", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTeamService_CreateTeam(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+	userID := uuid.New()
+	teamID := uuid.New()
+	cfg := &config.Config{Team: config.TeamConfig{MaxPerUser: 2}}
+
+	t.Run("success", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockScores, mockBoards := newMockLeaderboards()
+
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{{ID: uuid.New(), UserID: userID}}, nil)
+		mockTeamRepo.On("Create", ctx, isTeamFounding(userID, "Second Team", "Spain")).Return(&entity.Team{
+			ID:         teamID,
+			UserID:     userID,
+			Budget:     5000000,
+			TotalValue: 20000000,
+		}, nil)
+
+		service := NewTeamService(TeamServiceParams{
+			TeamRepository:             mockTeamRepo,
+			AuditRepository:            newMockAuditRepository(),
+			LeaderboardRepository:      mockScores,
+			LeaderboardCacheRepository: mockBoards,
+			Config:                     cfg,
+			Logger:                     logger,
+		})
+
+		result, err := service.CreateTeam(ctx, userID, &dto.CreateTeamRequest{Name: " Second Team ", Country: "Spain"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, teamID, result.ID)
+		assert.Equal(t, int64(5000000), result.Budget)
+		assert.Equal(t, int64(20000000), result.TotalValue)
+		mockScores.AssertCalled(t, "Scores", ctx, entity.LeaderboardBudget, entity.LeaderboardFilter{TeamIDs: []uuid.UUID{teamID}})
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("create fails", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockAuditRepo := new(MockAuditRepository)
+		mockScores, mockBoards := newMockLeaderboards()
+
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{}, nil)
+		mockTeamRepo.On("Create", ctx, isTeamFounding(userID, "Second Team", "Spain")).Return(nil, errors.New("database error"))
+
+		service := NewTeamService(TeamServiceParams{
+			TeamRepository:             mockTeamRepo,
+			AuditRepository:            mockAuditRepo,
+			LeaderboardRepository:      mockScores,
+			LeaderboardCacheRepository: mockBoards,
+			Config:                     cfg,
+			Logger:                     logger,
+		})
+
+		result, err := service.CreateTeam(ctx, userID, &dto.CreateTeamRequest{Name: "Second Team", Country: "Spain"})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "database error")
+		mockScores.AssertNotCalled(t, "Scores", mock.Anything, mock.Anything, mock.Anything)
+		mockAuditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("team limit reached", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+
+		service := NewTeamService(TeamServiceParams{
+			TeamRepository: mockTeamRepo,
+			Config:         cfg,
+			Logger:         logger,
+		})
+
+		result, err := service.CreateTeam(ctx, userID, &dto.CreateTeamRequest{Name: "Third Team", Country: "Spain"})
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperr.ErrTeamLimitReached, err)
+		mockTeamRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+// isTeamFounding matches the founding of a team for userID with the seed
+// money and squad every new team gets.
+func isTeamFounding(userID uuid.UUID, name, country string) any {
+	return mock.MatchedBy(func(founding entity.TeamFounding) bool {
+		team := founding.Team
+		if team.ID == uuid.Nil || team.UserID != userID || team.Name != name || team.Country != country ||
+			team.TotalValue != 20000000 || len(founding.Players) != 20 {
+			return false
+		}
+
+		positions := make(map[entity.PlayerPosition]int)
+
+		for _, p := range founding.Players {
+			if p.TeamID != team.ID || p.MarketValue != 1000000 || p.Age < 18 || p.Age > 40 {
+				return false
+			}
+
+			positions[p.Position]++
+		}
+
+		return assert.ObjectsAreEqual(map[entity.PlayerPosition]int{
+			entity.PositionGoalkeeper: 3,
+			entity.PositionDefender:   6,
+			entity.PositionMidfielder: 6,
+			entity.PositionAttacker:   5,
+		}, positions) && assert.ObjectsAreEqual(entity.LedgerTransaction{
+			Kind:        entity.LedgerKindSeedMoney,
+			Description: "Initial team budget",
+			Postings: []entity.LedgerPosting{
+				entity.TeamPosting(team.ID, 5000000),
+				entity.SystemPosting(entity.LedgerAccountSeedCapital, -5000000),
+			},
+		}, founding.SeedMoney)
+	})
+}
+
+func TestTeamService_ListTeams(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockTeamRepo := new(MockTeamRepository)
+	teams := []entity.Team{{ID: uuid.New(), UserID: userID}, {ID: uuid.New(), UserID: userID}}
+	mockTeamRepo.On("ListByUserID", ctx, userID).Return(teams, nil)
+
+	service := NewTeamService(TeamServiceParams{
+		TeamRepository: mockTeamRepo,
+		Logger:         zap.NewNop(),
+	})
+
+	result, err := service.ListTeams(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, teams, result.Teams)
+}
+
+func TestTeamService_GetPublicTeam(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+	teamID := uuid.New()
+
+	t.Run("success from cache", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+
+		cached := &dto.PublicTeamResponse{Team: dto.PublicTeam{ID: teamID, Name: "Cached Team"}}
+		mockCacheRepo.On("GetPublicTeam", ctx, teamID).Return(cached, nil)
+
+		service := NewTeamService(TeamServiceParams{
+			TeamRepository:      mockTeamRepo,
+			TeamCacheRepository: mockCacheRepo,
+			Logger:              logger,
+		})
+
+		result, err := service.GetPublicTeam(ctx, teamID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, cached, result)
+		mockTeamRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success from database", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockPlayerRepo := new(MockPlayerRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+
+		team := &entity.Team{
+			ID:         teamID,
+			UserID:     uuid.New(),
+			Name:       "Public Team",
+			Country:    "Spain",
+			Budget:     5000000,
+			TotalValue: 1,
+		}
+		players := []entity.Player{
+			{ID: uuid.New(), TeamID: teamID, FirstName: "Leo", MarketValue: 1000000},
+			{ID: uuid.New(), TeamID: teamID, FirstName: "Noah", MarketValue: 2000000},
+		}
+
+		expected := &dto.PublicTeamResponse{
+			Team: dto.PublicTeam{ID: teamID, Name: "Public Team", Country: "Spain", TotalValue: 3000000},
+			Players: []dto.PublicPlayer{
+				{ID: players[0].ID, TeamID: teamID, FirstName: "Leo", MarketValue: 1000000},
+				{ID: players[1].ID, TeamID: teamID, FirstName: "Noah", MarketValue: 2000000},
+			},
+		}
+
+		mockCacheRepo.On("GetPublicTeam", ctx, teamID).Return(nil, nil)
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(team, nil)
+		mockPlayerRepo.On("GetByTeamID", ctx, teamID).Return(players, nil)
+		mockCacheRepo.On("SetPublicTeam", ctx, teamID, expected).Return(nil)
+
+		service := NewTeamService(TeamServiceParams{
+			TeamRepository:      mockTeamRepo,
+			PlayerRepository:    mockPlayerRepo,
+			TeamCacheRepository: mockCacheRepo,
+			Logger:              logger,
+		})
+
+		result, err := service.GetPublicTeam(ctx, teamID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		mockCacheRepo.AssertExpectations(t)
+		mockCacheRepo.AssertNotCalled(t, "SetTeam", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+
+		mockCacheRepo.On("GetPublicTeam", ctx, teamID).Return(nil, errors.New("redis down"))
+		mockTeamRepo.On("GetByID", ctx, teamID).Return(nil, apperr.ErrTeamNotFound)
+
+		service := NewTeamService(TeamServiceParams{
+			TeamRepository:      mockTeamRepo,
+			TeamCacheRepository: mockCacheRepo,
+			Logger:              logger,
+		})
+
+		result, err := service.GetPublicTeam(ctx, teamID)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperr.ErrTeamNotFound, err)
+	})
+}
+
+func TestTeamService_SearchTeams(t *testing.T) {
+	ctx := context.Background()
+
+	mockTeamRepo := new(MockTeamRepository)
+	team := entity.Team{ID: uuid.New(), UserID: uuid.New(), Name: "Arsenal", Country: "England", Budget: 5000000, TotalValue: 20000000}
+	mockTeamRepo.On("Search", ctx, entity.TeamFilter{Name: "ars", Country: "England"}, uint(20), uint(0)).
+		Return([]entity.Team{team}, nil)
+
+	service := NewTeamService(TeamServiceParams{
+		TeamRepository: mockTeamRepo,
+		Logger:         zap.NewNop(),
+	})
+
+	result, err := service.SearchTeams(ctx, &dto.SearchTeamsRequest{Name: " ars ", Country: "England"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.PublicTeamsResponse{
+		Teams:  []dto.PublicTeam{{ID: team.ID, Name: "Arsenal", Country: "England", TotalValue: 20000000}},
+		Limit:  20,
+		Offset: 0,
+	}, result)
+}
@@ -0,0 +1,563 @@
+package usecase
+
+import (
+	"context"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/usecase/adapters"
+	"soccer_manager_service/pkg/tracing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("soccer_manager_service/internal/usecase")
+
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+type tracedAuthService struct {
+	next adapters.AuthService
+}
+
+func (s *tracedAuthService) Register(ctx context.Context, req *dto.RegisterRequest) (accessToken, refreshToken string, err error) {
+	ctx, span := startSpan(ctx, "AuthService.Register")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Register(ctx, req)
+}
+
+func (s *tracedAuthService) Login(ctx context.Context, req *dto.LoginRequest) (_ *dto.LoginResponse, err error) {
+	ctx, span := startSpan(ctx, "AuthService.Login")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Login(ctx, req)
+}
+
+func (s *tracedAuthService) CompleteTwoFactorLogin(ctx context.Context, req *dto.TwoFactorLoginRequest) (accessToken, refreshToken string, err error) {
+	ctx, span := startSpan(ctx, "AuthService.CompleteTwoFactorLogin")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.CompleteTwoFactorLogin(ctx, req)
+}
+
+func (s *tracedAuthService) ListOIDCProviders(ctx context.Context) (_ *dto.OIDCProvidersResponse, err error) {
+	ctx, span := startSpan(ctx, "AuthService.ListOIDCProviders")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ListOIDCProviders(ctx)
+}
+
+func (s *tracedAuthService) StartOIDCLogin(ctx context.Context, provider string, req *dto.OIDCAuthorizeRequest) (_ *dto.OIDCAuthorizeResponse, err error) {
+	ctx, span := startSpan(ctx, "AuthService.StartOIDCLogin", attribute.String("oidc.provider", provider))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.StartOIDCLogin(ctx, provider, req)
+}
+
+func (s *tracedAuthService) CompleteOIDCLogin(ctx context.Context, provider string, req *dto.OIDCCallbackRequest) (_ *dto.LoginResponse, err error) {
+	ctx, span := startSpan(ctx, "AuthService.CompleteOIDCLogin", attribute.String("oidc.provider", provider))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.CompleteOIDCLogin(ctx, provider, req)
+}
+
+func (s *tracedAuthService) ResendVerificationEmail(ctx context.Context, userID uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "AuthService.ResendVerificationEmail", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ResendVerificationEmail(ctx, userID)
+}
+
+func (s *tracedAuthService) VerifyEmail(ctx context.Context, req *dto.VerifyEmailRequest) (err error) {
+	ctx, span := startSpan(ctx, "AuthService.VerifyEmail")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.VerifyEmail(ctx, req)
+}
+
+func (s *tracedAuthService) RequestPasswordReset(ctx context.Context, req *dto.PasswordResetRequest) (err error) {
+	ctx, span := startSpan(ctx, "AuthService.RequestPasswordReset")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.RequestPasswordReset(ctx, req)
+}
+
+func (s *tracedAuthService) ResetPassword(ctx context.Context, req *dto.ConfirmPasswordResetRequest) (err error) {
+	ctx, span := startSpan(ctx, "AuthService.ResetPassword")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ResetPassword(ctx, req)
+}
+
+func (s *tracedAuthService) CheckSession(ctx context.Context, userID uuid.UUID, role entity.UserRole, issuedAt time.Time) (err error) {
+	ctx, span := startSpan(ctx, "AuthService.CheckSession", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.CheckSession(ctx, userID, role, issuedAt)
+}
+
+type tracedAccountService struct {
+	next adapters.AccountService
+}
+
+func (s *tracedAccountService) ChangePassword(ctx context.Context, userID uuid.UUID, req *dto.ChangePasswordRequest) (accessToken, refreshToken string, err error) {
+	ctx, span := startSpan(ctx, "AccountService.ChangePassword", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ChangePassword(ctx, userID, req)
+}
+
+func (s *tracedAccountService) ChangeEmail(ctx context.Context, userID uuid.UUID, req *dto.ChangeEmailRequest) (accessToken, refreshToken string, err error) {
+	ctx, span := startSpan(ctx, "AccountService.ChangeEmail", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ChangeEmail(ctx, userID, req)
+}
+
+func (s *tracedAccountService) DeleteAccount(ctx context.Context, userID uuid.UUID, req *dto.DeleteAccountRequest) (err error) {
+	ctx, span := startSpan(ctx, "AccountService.DeleteAccount", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.DeleteAccount(ctx, userID, req)
+}
+
+func (s *tracedAccountService) CreateScopedToken(ctx context.Context, userID uuid.UUID, req *dto.CreateScopedTokenRequest) (_ *dto.ScopedTokenResponse, err error) {
+	ctx, span := startSpan(ctx, "AccountService.CreateScopedToken", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.CreateScopedToken(ctx, userID, req)
+}
+
+type tracedAPIKeyService struct {
+	next adapters.APIKeyService
+}
+
+func (s *tracedAPIKeyService) Create(ctx context.Context, userID uuid.UUID, req *dto.CreateAPIKeyRequest) (_ *dto.CreateAPIKeyResponse, err error) {
+	ctx, span := startSpan(ctx, "APIKeyService.Create", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Create(ctx, userID, req)
+}
+
+func (s *tracedAPIKeyService) List(ctx context.Context, userID uuid.UUID) (_ *dto.APIKeyListResponse, err error) {
+	ctx, span := startSpan(ctx, "APIKeyService.List", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.List(ctx, userID)
+}
+
+func (s *tracedAPIKeyService) Revoke(ctx context.Context, userID, keyID uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "APIKeyService.Revoke",
+		attribute.String("user.id", userID.String()),
+		attribute.String("api_key.id", keyID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Revoke(ctx, userID, keyID)
+}
+
+func (s *tracedAPIKeyService) Authenticate(ctx context.Context, raw string) (_ *entity.APIKey, _ *entity.User, err error) {
+	ctx, span := startSpan(ctx, "APIKeyService.Authenticate")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Authenticate(ctx, raw)
+}
+
+type tracedWebhookService struct {
+	next adapters.WebhookService
+}
+
+func (s *tracedWebhookService) Create(ctx context.Context, userID uuid.UUID, req *dto.CreateWebhookRequest) (_ *entity.Webhook, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.Create", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Create(ctx, userID, req)
+}
+
+func (s *tracedWebhookService) List(ctx context.Context, userID uuid.UUID) (_ *dto.WebhookListResponse, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.List", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.List(ctx, userID)
+}
+
+func (s *tracedWebhookService) Get(ctx context.Context, userID, webhookID uuid.UUID) (_ *entity.Webhook, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.Get",
+		attribute.String("user.id", userID.String()),
+		attribute.String("webhook.id", webhookID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Get(ctx, userID, webhookID)
+}
+
+func (s *tracedWebhookService) Update(ctx context.Context, userID, webhookID uuid.UUID, req *dto.UpdateWebhookRequest) (_ *entity.Webhook, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.Update",
+		attribute.String("user.id", userID.String()),
+		attribute.String("webhook.id", webhookID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Update(ctx, userID, webhookID, req)
+}
+
+func (s *tracedWebhookService) Delete(ctx context.Context, userID, webhookID uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "WebhookService.Delete",
+		attribute.String("user.id", userID.String()),
+		attribute.String("webhook.id", webhookID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Delete(ctx, userID, webhookID)
+}
+
+func (s *tracedWebhookService) Ping(ctx context.Context, userID, webhookID uuid.UUID) (_ *entity.WebhookDelivery, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.Ping",
+		attribute.String("user.id", userID.String()),
+		attribute.String("webhook.id", webhookID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Ping(ctx, userID, webhookID)
+}
+
+func (s *tracedWebhookService) ListDeliveries(ctx context.Context, userID, webhookID uuid.UUID, req *dto.WebhookDeliveriesRequest) (_ *dto.WebhookDeliveriesResponse, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.ListDeliveries",
+		attribute.String("user.id", userID.String()),
+		attribute.String("webhook.id", webhookID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ListDeliveries(ctx, userID, webhookID, req)
+}
+
+// Run lasts as long as the process; each Deliver it makes gets a span.
+func (s *tracedWebhookService) Run(ctx context.Context) error {
+	return s.next.Run(ctx)
+}
+
+func (s *tracedWebhookService) Deliver(ctx context.Context) (_ int, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.Deliver")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Deliver(ctx)
+}
+
+type tracedTwoFactorService struct {
+	next adapters.TwoFactorService
+}
+
+func (s *tracedTwoFactorService) Status(ctx context.Context, userID uuid.UUID) (_ *dto.TwoFactorStatusResponse, err error) {
+	ctx, span := startSpan(ctx, "TwoFactorService.Status", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Status(ctx, userID)
+}
+
+func (s *tracedTwoFactorService) Enroll(ctx context.Context, userID uuid.UUID, req *dto.TwoFactorEnrollRequest) (_ *dto.TwoFactorEnrollResponse, err error) {
+	ctx, span := startSpan(ctx, "TwoFactorService.Enroll", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Enroll(ctx, userID, req)
+}
+
+func (s *tracedTwoFactorService) Enable(ctx context.Context, userID uuid.UUID, req *dto.TwoFactorEnableRequest) (_ *dto.RecoveryCodesResponse, err error) {
+	ctx, span := startSpan(ctx, "TwoFactorService.Enable", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Enable(ctx, userID, req)
+}
+
+func (s *tracedTwoFactorService) Disable(ctx context.Context, userID uuid.UUID, req *dto.TwoFactorConfirmRequest) (err error) {
+	ctx, span := startSpan(ctx, "TwoFactorService.Disable", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Disable(ctx, userID, req)
+}
+
+func (s *tracedTwoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, req *dto.TwoFactorConfirmRequest) (_ *dto.RecoveryCodesResponse, err error) {
+	ctx, span := startSpan(ctx, "TwoFactorService.RegenerateRecoveryCodes", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.RegenerateRecoveryCodes(ctx, userID, req)
+}
+
+type tracedTeamService struct {
+	next adapters.TeamService
+}
+
+func (s *tracedTeamService) GetMyTeam(ctx context.Context, userID, teamID uuid.UUID) (_ *dto.TeamWithPlayersResponse, err error) {
+	ctx, span := startSpan(ctx, "TeamService.GetMyTeam",
+		attribute.String("user.id", userID.String()),
+		attribute.String("team.id", teamID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.GetMyTeam(ctx, userID, teamID)
+}
+
+func (s *tracedTeamService) UpdateTeam(ctx context.Context, userID, teamID uuid.UUID, req *dto.UpdateTeamRequest) (_ *entity.Team, err error) {
+	ctx, span := startSpan(ctx, "TeamService.UpdateTeam",
+		attribute.String("user.id", userID.String()),
+		attribute.String("team.id", teamID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.UpdateTeam(ctx, userID, teamID, req)
+}
+
+func (s *tracedTeamService) GetFinances(ctx context.Context, userID, teamID uuid.UUID, req *dto.FinancesRequest) (_ *dto.TeamFinancesResponse, err error) {
+	ctx, span := startSpan(ctx, "TeamService.GetFinances",
+		attribute.String("user.id", userID.String()),
+		attribute.String("team.id", teamID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.GetFinances(ctx, userID, teamID, req)
+}
+
+func (s *tracedTeamService) ListTeams(ctx context.Context, userID uuid.UUID) (_ *dto.TeamListResponse, err error) {
+	ctx, span := startSpan(ctx, "TeamService.ListTeams", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ListTeams(ctx, userID)
+}
+
+func (s *tracedTeamService) CreateTeam(ctx context.Context, userID uuid.UUID, req *dto.CreateTeamRequest) (_ *entity.Team, err error) {
+	ctx, span := startSpan(ctx, "TeamService.CreateTeam", attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.CreateTeam(ctx, userID, req)
+}
+
+func (s *tracedTeamService) GetPublicTeam(ctx context.Context, teamID uuid.UUID) (_ *dto.PublicTeamResponse, err error) {
+	ctx, span := startSpan(ctx, "TeamService.GetPublicTeam", attribute.String("team.id", teamID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.GetPublicTeam(ctx, teamID)
+}
+
+func (s *tracedTeamService) SearchTeams(ctx context.Context, req *dto.SearchTeamsRequest) (_ *dto.PublicTeamsResponse, err error) {
+	ctx, span := startSpan(ctx, "TeamService.SearchTeams")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.SearchTeams(ctx, req)
+}
+
+type tracedPlayerService struct {
+	next adapters.PlayerService
+}
+
+func (s *tracedPlayerService) GetPublicPlayer(ctx context.Context, playerID uuid.UUID) (_ *dto.PublicPlayerResponse, err error) {
+	ctx, span := startSpan(ctx, "PlayerService.GetPublicPlayer", attribute.String("player.id", playerID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.GetPublicPlayer(ctx, playerID)
+}
+
+func (s *tracedPlayerService) UpdatePlayer(ctx context.Context, userID, teamID, playerID uuid.UUID, req *dto.UpdatePlayerRequest) (_ *entity.Player, err error) {
+	ctx, span := startSpan(ctx, "PlayerService.UpdatePlayer",
+		attribute.String("user.id", userID.String()),
+		attribute.String("team.id", teamID.String()),
+		attribute.String("player.id", playerID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.UpdatePlayer(ctx, userID, teamID, playerID, req)
+}
+
+type tracedLeaderboardService struct {
+	next adapters.LeaderboardService
+}
+
+func (s *tracedLeaderboardService) Get(ctx context.Context, userID uuid.UUID, kind entity.LeaderboardKind, req *dto.LeaderboardRequest) (_ *dto.LeaderboardResponse, err error) {
+	ctx, span := startSpan(ctx, "LeaderboardService.Get",
+		attribute.String("user.id", userID.String()),
+		attribute.String("leaderboard.kind", string(kind)))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Get(ctx, userID, kind, req)
+}
+
+func (s *tracedLeaderboardService) Rebuild(ctx context.Context) (err error) {
+	ctx, span := startSpan(ctx, "LeaderboardService.Rebuild")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Rebuild(ctx)
+}
+
+type tracedEventService struct {
+	next adapters.EventService
+}
+
+// Run lasts as long as the process; each Dispatch it makes gets a span.
+func (s *tracedEventService) Run(ctx context.Context) error {
+	return s.next.Run(ctx)
+}
+
+func (s *tracedEventService) Dispatch(ctx context.Context) (_ int, err error) {
+	ctx, span := startSpan(ctx, "EventService.Dispatch")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Dispatch(ctx)
+}
+
+type tracedMarketService struct {
+	next adapters.MarketService
+}
+
+// Run lasts as long as the process, so it gets no span of its own.
+func (s *tracedMarketService) Run(ctx context.Context) error {
+	return s.next.Run(ctx)
+}
+
+func (s *tracedMarketService) Subscribe(ctx context.Context, userID uuid.UUID) (<-chan entity.MarketEvent, func()) {
+	ctx, span := startSpan(ctx, "MarketService.Subscribe", attribute.String("user.id", userID.String()))
+	defer span.End()
+
+	return s.next.Subscribe(ctx, userID)
+}
+
+type tracedTransferService struct {
+	next adapters.TransferService
+}
+
+func (s *tracedTransferService) ListPlayer(ctx context.Context, userID, teamID, playerID uuid.UUID, req *dto.ListPlayerRequest) (_ *entity.Transfer, err error) {
+	ctx, span := startSpan(ctx, "TransferService.ListPlayer",
+		attribute.String("user.id", userID.String()),
+		attribute.String("team.id", teamID.String()),
+		attribute.String("player.id", playerID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ListPlayer(ctx, userID, teamID, playerID, req)
+}
+
+func (s *tracedTransferService) GetTransferList(ctx context.Context) (_ []dto.TransferListItemResponse, err error) {
+	ctx, span := startSpan(ctx, "TransferService.GetTransferList")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.GetTransferList(ctx)
+}
+
+func (s *tracedTransferService) BuyPlayer(ctx context.Context, userID, teamID, transferID uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "TransferService.BuyPlayer",
+		attribute.String("user.id", userID.String()),
+		attribute.String("team.id", teamID.String()),
+		attribute.String("transfer.id", transferID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.BuyPlayer(ctx, userID, teamID, transferID)
+}
+
+type tracedAdminService struct {
+	next adapters.AdminService
+}
+
+func (s *tracedAdminService) ListUsers(ctx context.Context, req *dto.AdminListRequest) (_ *dto.AdminUsersResponse, err error) {
+	ctx, span := startSpan(ctx, "AdminService.ListUsers")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ListUsers(ctx, req)
+}
+
+func (s *tracedAdminService) ListTeams(ctx context.Context, req *dto.AdminListRequest) (_ *dto.AdminTeamsResponse, err error) {
+	ctx, span := startSpan(ctx, "AdminService.ListTeams")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ListTeams(ctx, req)
+}
+
+func (s *tracedAdminService) UpdateUserRole(ctx context.Context, actorID, userID uuid.UUID, req *dto.UpdateUserRoleRequest) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, "AdminService.UpdateUserRole",
+		attribute.String("actor.id", actorID.String()),
+		attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.UpdateUserRole(ctx, actorID, userID, req)
+}
+
+func (s *tracedAdminService) BanUser(ctx context.Context, actorID, userID uuid.UUID, req *dto.BanUserRequest) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, "AdminService.BanUser",
+		attribute.String("actor.id", actorID.String()),
+		attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.BanUser(ctx, actorID, userID, req)
+}
+
+func (s *tracedAdminService) UnbanUser(ctx context.Context, actorID, userID uuid.UUID) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, "AdminService.UnbanUser",
+		attribute.String("actor.id", actorID.String()),
+		attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.UnbanUser(ctx, actorID, userID)
+}
+
+func (s *tracedAdminService) UnlockUserLogin(ctx context.Context, actorID, userID uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "AdminService.UnlockUserLogin",
+		attribute.String("actor.id", actorID.String()),
+		attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.UnlockUserLogin(ctx, actorID, userID)
+}
+
+func (s *tracedAdminService) ResetTwoFactor(ctx context.Context, actorID, userID uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "AdminService.ResetTwoFactor",
+		attribute.String("actor.id", actorID.String()),
+		attribute.String("user.id", userID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ResetTwoFactor(ctx, actorID, userID)
+}
+
+func (s *tracedAdminService) UnlockIPLogin(ctx context.Context, actorID uuid.UUID, req *dto.UnlockIPRequest) (err error) {
+	ctx, span := startSpan(ctx, "AdminService.UnlockIPLogin", attribute.String("actor.id", actorID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.UnlockIPLogin(ctx, actorID, req)
+}
+
+func (s *tracedAdminService) AdjustTeamBudget(ctx context.Context, actorID, teamID uuid.UUID, req *dto.AdjustBudgetRequest) (_ *entity.Team, err error) {
+	ctx, span := startSpan(ctx, "AdminService.AdjustTeamBudget",
+		attribute.String("actor.id", actorID.String()),
+		attribute.String("team.id", teamID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.AdjustTeamBudget(ctx, actorID, teamID, req)
+}
+
+func (s *tracedAdminService) CancelTransfer(ctx context.Context, actorID, transferID uuid.UUID, req *dto.CancelTransferRequest) (err error) {
+	ctx, span := startSpan(ctx, "AdminService.CancelTransfer",
+		attribute.String("actor.id", actorID.String()),
+		attribute.String("transfer.id", transferID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.CancelTransfer(ctx, actorID, transferID, req)
+}
+
+func (s *tracedAdminService) ListAuditLog(ctx context.Context, req *dto.AuditLogRequest) (_ *dto.AuditLogResponse, err error) {
+	ctx, span := startSpan(ctx, "AdminService.ListAuditLog")
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.ListAuditLog(ctx, req)
+}
+
+type tracedIntegrityService struct {
+	next adapters.IntegrityService
+}
+
+func (s *tracedIntegrityService) Check(ctx context.Context, fix bool) (_ *entity.IntegrityReport, err error) {
+	ctx, span := startSpan(ctx, "IntegrityService.Check", attribute.Bool("integrity.fix", fix))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Check(ctx, fix)
+}
+
+type tracedRateLimitService struct {
+	next adapters.RateLimitService
+}
+
+func (s *tracedRateLimitService) Allow(ctx context.Context, scope, subject string, rate config.Rate) (_ *entity.RateLimitResult, err error) {
+	ctx, span := startSpan(ctx, "RateLimitService.Allow", attribute.String("rate_limit.scope", scope))
+	defer func() { tracing.End(span, err) }()
+
+	return s.next.Allow(ctx, scope, subject, rate)
+}
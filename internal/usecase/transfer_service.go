@@ -2,64 +2,78 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"math/rand"
 	"soccer_manager_service/internal/dto"
 	"soccer_manager_service/internal/entity"
 	"soccer_manager_service/internal/ports"
 	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 type TransferService struct {
-	transferRepository  ports.TransferRepository
-	playerRepository    ports.PlayerRepository
-	teamRepository      ports.TeamRepository
-	teamCacheRepository ports.TeamCacheRepository
-	logger              *zap.Logger
+	transferRepository    ports.TransferRepository
+	playerRepository      ports.PlayerRepository
+	teamRepository        ports.TeamRepository
+	teamCacheRepository   ports.TeamCacheRepository
+	auditRepository       ports.AuditRepository
+	marketEventRepository ports.MarketEventRepository
+	logger                *zap.Logger
 }
 
 type TransferServiceParams struct {
-	TransferRepository  ports.TransferRepository
-	PlayerRepository    ports.PlayerRepository
-	TeamRepository      ports.TeamRepository
-	TeamCacheRepository ports.TeamCacheRepository
-	Logger              *zap.Logger
+	TransferRepository    ports.TransferRepository
+	PlayerRepository      ports.PlayerRepository
+	TeamRepository        ports.TeamRepository
+	TeamCacheRepository   ports.TeamCacheRepository
+	AuditRepository       ports.AuditRepository
+	MarketEventRepository ports.MarketEventRepository
+	Logger                *zap.Logger
 }
 
 func NewTransferService(params TransferServiceParams) *TransferService {
 	return &TransferService{
-		transferRepository:  params.TransferRepository,
-		playerRepository:    params.PlayerRepository,
-		teamRepository:      params.TeamRepository,
-		teamCacheRepository: params.TeamCacheRepository,
-		logger:              params.Logger.With(zap.String("service", "TransferService")),
+		transferRepository:    params.TransferRepository,
+		playerRepository:      params.PlayerRepository,
+		teamRepository:        params.TeamRepository,
+		teamCacheRepository:   params.TeamCacheRepository,
+		auditRepository:       params.AuditRepository,
+		marketEventRepository: params.MarketEventRepository,
+		logger:                params.Logger.With(zap.String("service", "TransferService")),
 	}
 }
 
-func (s *TransferService) ListPlayer(ctx context.Context, userID, playerID uuid.UUID, req *dto.ListPlayerRequest) (*entity.Transfer, error) {
-	s.logger.Info("listing player for transfer",
+func (s *TransferService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger, zap.String("service", "TransferService"))
+}
+
+// ListPlayer puts a player of the selected team on the market; see
+// TeamService for how teamID selects it.
+func (s *TransferService) ListPlayer(ctx context.Context, userID, teamID, playerID uuid.UUID, req *dto.ListPlayerRequest) (*entity.Transfer, error) {
+	log := s.log(ctx)
+
+	log.Info("listing player for transfer",
 		zap.String("user_id", userID.String()),
 		zap.String("player_id", playerID.String()),
 		zap.Int64("asking_price", req.AskingPrice))
 
 	player, err := s.playerRepository.GetByID(ctx, playerID)
 	if err != nil {
-		s.logger.Error("failed to get player", zap.Error(err))
+		log.Error("failed to get player", zap.Error(err))
 
 		return nil, err
 	}
 
-	team, err := s.teamRepository.GetByUserID(ctx, userID)
+	team, err := selectTeam(ctx, s.teamRepository, log, userID, teamID)
 	if err != nil {
-		s.logger.Error("failed to get team", zap.Error(err))
-
 		return nil, err
 	}
 
 	if player.TeamID != team.ID {
-		s.logger.Warn("player does not belong to user's team",
+		log.Warn("player does not belong to user's team",
 			zap.String("player_team_id", player.TeamID.String()),
 			zap.String("user_team_id", team.ID.String()))
 
@@ -68,29 +82,37 @@ func (s *TransferService) ListPlayer(ctx context.Context, userID, playerID uuid.
 
 	existingTransfer, err := s.transferRepository.GetByPlayerID(ctx, playerID)
 	if err == nil && existingTransfer != nil {
-		s.logger.Warn("player already listed for transfer", zap.String("transfer_id", existingTransfer.ID.String()))
+		log.Warn("player already listed for transfer", zap.String("transfer_id", existingTransfer.ID.String()))
 
 		return nil, apperr.ErrPlayerAlreadyListed
 	}
 
 	transfer, err := s.transferRepository.Create(ctx, playerID, team.ID, req.AskingPrice)
 	if err != nil {
-		s.logger.Error("failed to create transfer", zap.Error(err))
+		log.Error("failed to create transfer", zap.Error(err))
 
 		return nil, err
 	}
 
-	s.logger.Info("player listed for transfer successfully", zap.String("transfer_id", transfer.ID.String()))
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &userID, "transfer.listed", entity.AuditEntityTransfer, transfer.ID, nil, transfer))
+
+	publishMarketEvents(ctx, s.marketEventRepository, log,
+		newMarketEvent(entity.MarketEventListingCreated, transfer, player))
+
+	log.Info("player listed for transfer successfully", zap.String("transfer_id", transfer.ID.String()))
 
 	return transfer, nil
 }
 
 func (s *TransferService) GetTransferList(ctx context.Context) ([]dto.TransferListItemResponse, error) {
-	s.logger.Info("getting transfer list")
+	log := s.log(ctx)
+
+	log.Info("getting transfer list")
 
 	transfers, err := s.transferRepository.GetActiveTransfers(ctx)
 	if err != nil {
-		s.logger.Error("failed to get active transfers", zap.Error(err))
+		log.Error("failed to get active transfers", zap.Error(err))
 
 		return nil, err
 	}
@@ -98,18 +120,18 @@ func (s *TransferService) GetTransferList(ctx context.Context) ([]dto.TransferLi
 	var items []dto.TransferListItemResponse
 
 	for _, transfer := range transfers {
-		player, err := s.playerRepository.GetByID(ctx, transfer.PlayerID)
+		player, err := s.playerRepository.GetByID(ctx, *transfer.PlayerID)
 		if err != nil {
-			s.logger.Warn("failed to get player for transfer",
+			log.Warn("failed to get player for transfer",
 				zap.String("player_id", transfer.PlayerID.String()),
 				zap.Error(err))
 
 			continue
 		}
 
-		team, err := s.teamRepository.GetByID(ctx, transfer.SellerID)
+		team, err := s.teamRepository.GetByID(ctx, *transfer.SellerID)
 		if err != nil {
-			s.logger.Warn("failed to get team for transfer",
+			log.Warn("failed to get team for transfer",
 				zap.String("team_id", transfer.SellerID.String()),
 				zap.Error(err))
 
@@ -126,55 +148,61 @@ func (s *TransferService) GetTransferList(ctx context.Context) ([]dto.TransferLi
 	return items, nil
 }
 
-func (s *TransferService) BuyPlayer(ctx context.Context, userID, transferID uuid.UUID) error {
-	s.logger.Info("buying player",
+// BuyPlayer buys a listed player for the selected team. Players of the
+// user's other teams cannot be bought, so that a user cannot trade players
+// between their own teams to drive up market values.
+func (s *TransferService) BuyPlayer(ctx context.Context, userID, teamID, transferID uuid.UUID) error {
+	log := s.log(ctx)
+
+	log.Info("buying player",
 		zap.String("user_id", userID.String()),
 		zap.String("transfer_id", transferID.String()))
 
 	transfer, err := s.transferRepository.GetByID(ctx, transferID)
 	if err != nil {
-		s.logger.Error("failed to get transfer", zap.Error(err))
+		log.Error("failed to get transfer", zap.Error(err))
 
 		return err
 	}
 
 	if transfer.Status != entity.TransferStatusActive {
-		s.logger.Warn("transfer is not active", zap.String("status", string(transfer.Status)))
+		log.Warn("transfer is not active", zap.String("status", string(transfer.Status)))
 
 		return apperr.ErrTransferNotActive
 	}
 
-	buyerTeam, err := s.teamRepository.GetByUserID(ctx, userID)
+	buyerTeam, err := selectTeam(ctx, s.teamRepository, log, userID, teamID)
+	if err != nil {
+		return err
+	}
+
+	sellerTeam, err := s.teamRepository.GetByID(ctx, *transfer.SellerID)
 	if err != nil {
-		s.logger.Error("failed to get buyer team", zap.Error(err))
+		log.Error("failed to get seller team", zap.Error(err))
 
 		return err
 	}
 
-	if transfer.SellerID == buyerTeam.ID {
-		s.logger.Warn("cannot buy own player")
+	if sellerTeam.UserID == userID {
+		log.Warn("cannot buy own player")
 
 		return apperr.ErrCannotBuyOwnPlayer
 	}
 
 	if buyerTeam.Budget < transfer.AskingPrice {
-		s.logger.Warn("insufficient funds",
+		log.Warn("insufficient funds",
 			zap.Int64("budget", buyerTeam.Budget),
 			zap.Int64("asking_price", transfer.AskingPrice))
 
-		return apperr.ErrInsufficientFunds
-	}
-
-	sellerTeam, err := s.teamRepository.GetByID(ctx, transfer.SellerID)
-	if err != nil {
-		s.logger.Error("failed to get seller team", zap.Error(err))
-
-		return err
+		return apperr.WithData(apperr.ErrInsufficientFunds, map[string]any{
+			"Needed":    transfer.AskingPrice,
+			"Available": buyerTeam.Budget,
+		})
 	}
 
-	player, err := s.playerRepository.GetByID(ctx, transfer.PlayerID)
+	player, err := s.playerRepository.GetByID(ctx, *transfer.PlayerID)
 	if err != nil {
-		s.logger.Error("failed to get player", zap.Error(err))
+		log.Error("failed to get player", zap.Error(err))
 
 		return err
 	}
@@ -183,54 +211,98 @@ func (s *TransferService) BuyPlayer(ctx context.Context, userID, transferID uuid
 
 	newMarketValue := player.MarketValue + (player.MarketValue * int64(increasePercentage) / 100)
 
-	if err := s.playerRepository.TransferPlayer(ctx, player.ID, buyerTeam.ID); err != nil {
-		s.logger.Error("failed to transfer player", zap.Error(err))
+	err = s.transferRepository.Complete(ctx, entity.TransferPurchase{
+		TransferID:     transfer.ID,
+		PlayerID:       player.ID,
+		BuyerID:        buyerTeam.ID,
+		NewMarketValue: newMarketValue,
+		Payment: entity.LedgerTransaction{
+			Kind:        entity.LedgerKindTransfer,
+			ReferenceID: &transfer.ID,
+			Description: player.FirstName + " " + player.LastName,
+			Postings: []entity.LedgerPosting{
+				entity.TeamPosting(buyerTeam.ID, -transfer.AskingPrice),
+				entity.TeamPosting(sellerTeam.ID, transfer.AskingPrice),
+			},
+		},
+	})
+	if err != nil {
+		if errors.Is(err, apperr.ErrTransferNotActive) {
+			log.Warn("transfer was completed concurrently")
+		} else {
+			log.Error("failed to complete transfer", zap.Error(err))
+		}
 
 		return err
 	}
 
-	if err := s.playerRepository.UpdateMarketValue(ctx, player.ID, newMarketValue); err != nil {
-		s.logger.Error("failed to update player market value", zap.Error(err))
+	// The PlayerSold event written by Complete invalidates the caches too and
+	// updates the leaderboards; doing it here as well lets the buyer see the
+	// new squad right away.
+	if err := s.teamCacheRepository.InvalidateTeam(ctx, buyerTeam.ID); err != nil {
+		log.Warn("failed to invalidate buyer team cache", zap.Error(err))
+	}
 
-		return err
+	if err := s.teamCacheRepository.InvalidateTeam(ctx, sellerTeam.ID); err != nil {
+		log.Warn("failed to invalidate seller team cache", zap.Error(err))
 	}
 
-	newBuyerBudget := buyerTeam.Budget - transfer.AskingPrice
+	s.auditPurchase(ctx, userID, transfer, player, buyerTeam, sellerTeam, newMarketValue)
 
-	if err := s.teamRepository.UpdateBudget(ctx, buyerTeam.ID, newBuyerBudget); err != nil {
-		s.logger.Error("failed to update buyer budget", zap.Error(err))
+	s.publishPurchase(ctx, transfer, player, buyerTeam, sellerTeam)
 
-		return err
-	}
+	log.Info("player purchased successfully",
+		zap.String("player_id", player.ID.String()),
+		zap.String("buyer_team", buyerTeam.Name),
+		zap.String("seller_team", sellerTeam.Name),
+		zap.Int64("price", transfer.AskingPrice),
+		zap.Int64("new_market_value", newMarketValue))
 
-	newSellerBudget := sellerTeam.Budget + transfer.AskingPrice
+	return nil
+}
 
-	if err := s.teamRepository.UpdateBudget(ctx, sellerTeam.ID, newSellerBudget); err != nil {
-		s.logger.Error("failed to update seller budget", zap.Error(err))
+// publishPurchase tells the market the listing is gone and tells the seller
+// and the buyer about the deal.
+func (s *TransferService) publishPurchase(ctx context.Context, transfer *entity.Transfer, player *entity.Player, buyerTeam, sellerTeam *entity.Team) {
+	completedTransfer := *transfer
+	completedTransfer.Status = entity.TransferStatusCompleted
+	completedTransfer.BuyerID = &buyerTeam.ID
 
-		return err
-	}
+	sold := newMarketEvent(entity.MarketEventListingSold, &completedTransfer, player)
 
-	if err := s.transferRepository.Complete(ctx, transfer.ID, buyerTeam.ID); err != nil {
-		s.logger.Error("failed to complete transfer", zap.Error(err))
+	publishMarketEvents(ctx, s.marketEventRepository, s.log(ctx),
+		sold,
+		userMarketEvent(sold, entity.MarketEventPlayerSold, sellerTeam.UserID),
+		userMarketEvent(sold, entity.MarketEventPlayerBought, buyerTeam.UserID))
+}
 
-		return err
-	}
+// auditPurchase records every entity a completed purchase changed: the
+// transfer, the player and both team budgets.
+func (s *TransferService) auditPurchase(ctx context.Context, userID uuid.UUID, transfer *entity.Transfer, player *entity.Player, buyerTeam, sellerTeam *entity.Team, newMarketValue int64) {
+	completedTransfer := *transfer
+	completedTransfer.Status = entity.TransferStatusCompleted
+	completedTransfer.BuyerID = &buyerTeam.ID
 
-	if err := s.teamCacheRepository.InvalidateTeam(ctx, userID); err != nil {
-		s.logger.Warn("failed to invalidate buyer team cache", zap.Error(err))
-	}
+	transferredPlayer := *player
+	transferredPlayer.TeamID = buyerTeam.ID
+	transferredPlayer.MarketValue = newMarketValue
+
+	updatedBuyer := *buyerTeam
+	updatedBuyer.Budget -= transfer.AskingPrice
 
-	if err := s.teamCacheRepository.InvalidateTeam(ctx, sellerTeam.UserID); err != nil {
-		s.logger.Warn("failed to invalidate seller team cache", zap.Error(err))
+	updatedSeller := *sellerTeam
+	updatedSeller.Budget += transfer.AskingPrice
+
+	entries := []entity.AuditEntry{
+		newAuditEntry(ctx, &userID, "transfer.completed", entity.AuditEntityTransfer, transfer.ID, transfer, completedTransfer),
+		newAuditEntry(ctx, &userID, "player.transferred", entity.AuditEntityPlayer, player.ID, player, transferredPlayer),
+		newAuditEntry(ctx, &userID, "team.budget_changed", entity.AuditEntityTeam, buyerTeam.ID, buyerTeam, updatedBuyer),
+		newAuditEntry(ctx, &userID, "team.budget_changed", entity.AuditEntityTeam, sellerTeam.ID, sellerTeam, updatedSeller),
 	}
 
-	s.logger.Info("player purchased successfully",
-		zap.String("player_id", player.ID.String()),
-		zap.String("buyer_team", buyerTeam.Name),
-		zap.String("seller_team", sellerTeam.Name),
-		zap.Int64("price", transfer.AskingPrice),
-		zap.Int64("new_market_value", newMarketValue))
+	for i := range entries {
+		entries[i].Metadata = map[string]any{"transfer_id": transfer.ID.String()}
+	}
 
-	return nil
+	recordAudit(ctx, s.auditRepository, s.log(ctx), entries...)
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"soccer_manager_service/internal/dto"
 	"soccer_manager_service/internal/entity"
 	apperr "soccer_manager_service/pkg/errors"
 
@@ -49,8 +50,8 @@ func (m *MockTransferRepository) GetActiveTransfers(ctx context.Context) ([]enti
 	return args.Get(0).([]entity.Transfer), args.Error(1)
 }
 
-func (m *MockTransferRepository) Complete(ctx context.Context, id, buyerID uuid.UUID) error {
-	args := m.Called(ctx, id, buyerID)
+func (m *MockTransferRepository) Complete(ctx context.Context, purchase entity.TransferPurchase) error {
+	args := m.Called(ctx, purchase)
 
 	return args.Error(0)
 }
@@ -75,16 +76,6 @@ type MockPlayerRepository struct {
 	mock.Mock
 }
 
-func (m *MockPlayerRepository) Create(ctx context.Context, teamID uuid.UUID, firstName, lastName, country string, age int, position entity.PlayerPosition, marketValue int64) (*entity.Player, error) {
-	args := m.Called(ctx, teamID, firstName, lastName, country, age, position, marketValue)
-
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-
-	return args.Get(0).(*entity.Player), args.Error(1)
-}
-
 func (m *MockPlayerRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Player, error) {
 	args := m.Called(ctx, id)
 
@@ -115,24 +106,12 @@ func (m *MockPlayerRepository) Update(ctx context.Context, id uuid.UUID, firstNa
 	return args.Get(0).(*entity.Player), args.Error(1)
 }
 
-func (m *MockPlayerRepository) UpdateMarketValue(ctx context.Context, id uuid.UUID, marketValue int64) error {
-	args := m.Called(ctx, id, marketValue)
-
-	return args.Error(0)
-}
-
-func (m *MockPlayerRepository) TransferPlayer(ctx context.Context, playerID, newTeamID uuid.UUID) error {
-	args := m.Called(ctx, playerID, newTeamID)
-
-	return args.Error(0)
-}
-
 type MockTeamRepository struct {
 	mock.Mock
 }
 
-func (m *MockTeamRepository) Create(ctx context.Context, userID uuid.UUID, name, country string, budget int64) (*entity.Team, error) {
-	args := m.Called(ctx, userID, name, country, budget)
+func (m *MockTeamRepository) Create(ctx context.Context, founding entity.TeamFounding) (*entity.Team, error) {
+	args := m.Called(ctx, founding)
 
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -151,14 +130,14 @@ func (m *MockTeamRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity
 	return args.Get(0).(*entity.Team), args.Error(1)
 }
 
-func (m *MockTeamRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.Team, error) {
+func (m *MockTeamRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Team, error) {
 	args := m.Called(ctx, userID)
 
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 
-	return args.Get(0).(*entity.Team), args.Error(1)
+	return args.Get(0).([]entity.Team), args.Error(1)
 }
 
 func (m *MockTeamRepository) Update(ctx context.Context, id uuid.UUID, name, country string) (*entity.Team, error) {
@@ -171,40 +150,96 @@ func (m *MockTeamRepository) Update(ctx context.Context, id uuid.UUID, name, cou
 	return args.Get(0).(*entity.Team), args.Error(1)
 }
 
-func (m *MockTeamRepository) UpdateBudget(ctx context.Context, id uuid.UUID, budget int64) error {
-	args := m.Called(ctx, id, budget)
+func (m *MockTeamRepository) UpdateTotalValue(ctx context.Context, id uuid.UUID, totalValue int64) error {
+	args := m.Called(ctx, id, totalValue)
 
 	return args.Error(0)
 }
 
-func (m *MockTeamRepository) UpdateTotalValue(ctx context.Context, id uuid.UUID, totalValue int64) error {
-	args := m.Called(ctx, id, totalValue)
+func (m *MockTeamRepository) List(ctx context.Context, search string, limit, offset uint) ([]entity.Team, error) {
+	args := m.Called(ctx, search, limit, offset)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) Search(ctx context.Context, filter entity.TeamFilter, limit, offset uint) ([]entity.Team, error) {
+	args := m.Called(ctx, filter, limit, offset)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.Team), args.Error(1)
+}
+
+type MockLedgerRepository struct {
+	mock.Mock
+}
+
+func (m *MockLedgerRepository) Post(ctx context.Context, txn entity.LedgerTransaction) error {
+	args := m.Called(ctx, txn)
 
 	return args.Error(0)
 }
 
+func (m *MockLedgerRepository) Statement(ctx context.Context, teamID uuid.UUID, limit, offset uint) ([]entity.StatementLine, error) {
+	args := m.Called(ctx, teamID, limit, offset)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.StatementLine), args.Error(1)
+}
+
+func (m *MockLedgerRepository) Balance(ctx context.Context, teamID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, teamID)
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
 type MockTeamCacheRepository struct {
 	mock.Mock
 }
 
-func (m *MockTeamCacheRepository) SetTeam(ctx context.Context, userID uuid.UUID, team *entity.TeamWithPlayers) error {
-	args := m.Called(ctx, userID, team)
+func (m *MockTeamCacheRepository) SetTeam(ctx context.Context, teamID uuid.UUID, team *dto.TeamWithPlayersResponse) error {
+	args := m.Called(ctx, teamID, team)
 
 	return args.Error(0)
 }
 
-func (m *MockTeamCacheRepository) GetTeam(ctx context.Context, userID uuid.UUID) (*entity.TeamWithPlayers, error) {
-	args := m.Called(ctx, userID)
+func (m *MockTeamCacheRepository) GetTeam(ctx context.Context, teamID uuid.UUID) (*dto.TeamWithPlayersResponse, error) {
+	args := m.Called(ctx, teamID)
 
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 
-	return args.Get(0).(*entity.TeamWithPlayers), args.Error(1)
+	return args.Get(0).(*dto.TeamWithPlayersResponse), args.Error(1)
 }
 
-func (m *MockTeamCacheRepository) InvalidateTeam(ctx context.Context, userID uuid.UUID) error {
-	args := m.Called(ctx, userID)
+func (m *MockTeamCacheRepository) SetPublicTeam(ctx context.Context, teamID uuid.UUID, team *dto.PublicTeamResponse) error {
+	args := m.Called(ctx, teamID, team)
+
+	return args.Error(0)
+}
+
+func (m *MockTeamCacheRepository) GetPublicTeam(ctx context.Context, teamID uuid.UUID) (*dto.PublicTeamResponse, error) {
+	args := m.Called(ctx, teamID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dto.PublicTeamResponse), args.Error(1)
+}
+
+func (m *MockTeamCacheRepository) InvalidateTeam(ctx context.Context, teamID uuid.UUID) error {
+	args := m.Called(ctx, teamID)
 
 	return args.Error(0)
 }
@@ -235,31 +270,41 @@ func TestTransferService_ListPlayer(t *testing.T) {
 
 		transfer := &entity.Transfer{
 			ID:          uuid.New(),
-			PlayerID:    playerID,
-			SellerID:    teamID,
+			PlayerID:    &playerID,
+			SellerID:    &teamID,
 			AskingPrice: 1000000,
 			Status:      entity.TransferStatusActive,
 		}
 
 		mockPlayerRepo.On("GetByID", ctx, playerID).Return(player, nil)
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(team, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{*team}, nil)
 		mockTransferRepo.On("GetByPlayerID", ctx, playerID).Return(nil, apperr.ErrTransferNotFound)
 		mockTransferRepo.On("Create", ctx, playerID, teamID, int64(1000000)).Return(transfer, nil)
 
+		mockMarketEvents := newMockMarketEvents()
+
 		service := NewTransferService(TransferServiceParams{
-			TransferRepository:  mockTransferRepo,
-			PlayerRepository:    mockPlayerRepo,
-			TeamRepository:      mockTeamRepo,
-			TeamCacheRepository: mockCacheRepo,
-			Logger:              logger,
+			TransferRepository:    mockTransferRepo,
+			PlayerRepository:      mockPlayerRepo,
+			TeamRepository:        mockTeamRepo,
+			TeamCacheRepository:   mockCacheRepo,
+			AuditRepository:       newMockAuditRepository(),
+			MarketEventRepository: mockMarketEvents,
+			Logger:                logger,
 		})
 
-		req := &entity.ListPlayerRequest{AskingPrice: 1000000}
-		result, err := service.ListPlayer(ctx, userID, playerID, req)
+		req := &dto.ListPlayerRequest{AskingPrice: 1000000}
+		result, err := service.ListPlayer(ctx, userID, uuid.Nil, playerID, req)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, transfer.ID, result.ID)
+		if published := publishedMarketEvents(mockMarketEvents); assert.Len(t, published, 1) {
+			assert.Equal(t, entity.MarketEventListingCreated, published[0].Type)
+			assert.Equal(t, transfer.ID, published[0].TransferID)
+			assert.Equal(t, int64(1000000), published[0].AskingPrice)
+			assert.Nil(t, published[0].UserID)
+		}
 		mockPlayerRepo.AssertExpectations(t)
 		mockTeamRepo.AssertExpectations(t)
 		mockTransferRepo.AssertExpectations(t)
@@ -278,11 +323,12 @@ func TestTransferService_ListPlayer(t *testing.T) {
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		req := &entity.ListPlayerRequest{AskingPrice: 1000000}
-		result, err := service.ListPlayer(ctx, userID, playerID, req)
+		req := &dto.ListPlayerRequest{AskingPrice: 1000000}
+		result, err := service.ListPlayer(ctx, userID, uuid.Nil, playerID, req)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -308,18 +354,19 @@ func TestTransferService_ListPlayer(t *testing.T) {
 		}
 
 		mockPlayerRepo.On("GetByID", ctx, playerID).Return(player, nil)
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(team, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{*team}, nil)
 
 		service := NewTransferService(TransferServiceParams{
 			TransferRepository:  mockTransferRepo,
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		req := &entity.ListPlayerRequest{AskingPrice: 1000000}
-		result, err := service.ListPlayer(ctx, userID, playerID, req)
+		req := &dto.ListPlayerRequest{AskingPrice: 1000000}
+		result, err := service.ListPlayer(ctx, userID, uuid.Nil, playerID, req)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -346,12 +393,12 @@ func TestTransferService_ListPlayer(t *testing.T) {
 
 		existingTransfer := &entity.Transfer{
 			ID:       uuid.New(),
-			PlayerID: playerID,
+			PlayerID: &playerID,
 			Status:   entity.TransferStatusActive,
 		}
 
 		mockPlayerRepo.On("GetByID", ctx, playerID).Return(player, nil)
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(team, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{*team}, nil)
 		mockTransferRepo.On("GetByPlayerID", ctx, playerID).Return(existingTransfer, nil)
 
 		service := NewTransferService(TransferServiceParams{
@@ -359,11 +406,12 @@ func TestTransferService_ListPlayer(t *testing.T) {
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		req := &entity.ListPlayerRequest{AskingPrice: 1000000}
-		result, err := service.ListPlayer(ctx, userID, playerID, req)
+		req := &dto.ListPlayerRequest{AskingPrice: 1000000}
+		result, err := service.ListPlayer(ctx, userID, uuid.Nil, playerID, req)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -390,8 +438,8 @@ func TestTransferService_GetTransferList(t *testing.T) {
 		transfers := []entity.Transfer{
 			{
 				ID:          uuid.New(),
-				PlayerID:    playerID,
-				SellerID:    teamID,
+				PlayerID:    &playerID,
+				SellerID:    &teamID,
 				AskingPrice: 1000000,
 				Status:      entity.TransferStatusActive,
 			},
@@ -417,6 +465,7 @@ func TestTransferService_GetTransferList(t *testing.T) {
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
@@ -444,6 +493,7 @@ func TestTransferService_GetTransferList(t *testing.T) {
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
@@ -474,8 +524,8 @@ func TestTransferService_BuyPlayer(t *testing.T) {
 
 		transfer := &entity.Transfer{
 			ID:          transferID,
-			PlayerID:    playerID,
-			SellerID:    sellerTeamID,
+			PlayerID:    &playerID,
+			SellerID:    &sellerTeamID,
 			AskingPrice: 1000000,
 			Status:      entity.TransferStatusActive,
 		}
@@ -495,32 +545,58 @@ func TestTransferService_BuyPlayer(t *testing.T) {
 		player := &entity.Player{
 			ID:          playerID,
 			TeamID:      sellerTeamID,
+			FirstName:   "Harry",
+			LastName:    "Kane",
 			MarketValue: 1000000,
 		}
 
 		mockTransferRepo.On("GetByID", ctx, transferID).Return(transfer, nil)
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(buyerTeam, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{*buyerTeam}, nil)
 		mockTeamRepo.On("GetByID", ctx, sellerTeamID).Return(sellerTeam, nil)
 		mockPlayerRepo.On("GetByID", ctx, playerID).Return(player, nil)
-		mockPlayerRepo.On("TransferPlayer", ctx, playerID, buyerTeamID).Return(nil)
-		mockPlayerRepo.On("UpdateMarketValue", ctx, playerID, mock.AnythingOfType("int64")).Return(nil)
-		mockTeamRepo.On("UpdateBudget", ctx, buyerTeamID, int64(4000000)).Return(nil)
-		mockTeamRepo.On("UpdateBudget", ctx, sellerTeamID, int64(4000000)).Return(nil)
-		mockTransferRepo.On("Complete", ctx, transferID, buyerTeamID).Return(nil)
-		mockCacheRepo.On("InvalidateTeam", ctx, userID).Return(nil)
-		mockCacheRepo.On("InvalidateTeam", ctx, sellerUserID).Return(nil)
+		mockTransferRepo.On("Complete", ctx, mock.MatchedBy(func(purchase entity.TransferPurchase) bool {
+			return purchase.TransferID == transferID &&
+				purchase.PlayerID == playerID &&
+				purchase.BuyerID == buyerTeamID &&
+				purchase.NewMarketValue >= 1100000 && purchase.NewMarketValue <= 2000000 &&
+				assert.ObjectsAreEqual(entity.LedgerTransaction{
+					Kind:        entity.LedgerKindTransfer,
+					ReferenceID: &transferID,
+					Description: "Harry Kane",
+					Postings: []entity.LedgerPosting{
+						entity.TeamPosting(buyerTeamID, -1000000),
+						entity.TeamPosting(sellerTeamID, 1000000),
+					},
+				}, purchase.Payment)
+		})).Return(nil)
+		mockCacheRepo.On("InvalidateTeam", ctx, buyerTeamID).Return(nil)
+		mockCacheRepo.On("InvalidateTeam", ctx, sellerTeamID).Return(nil)
+
+		mockMarketEvents := newMockMarketEvents()
 
 		service := NewTransferService(TransferServiceParams{
-			TransferRepository:  mockTransferRepo,
-			PlayerRepository:    mockPlayerRepo,
-			TeamRepository:      mockTeamRepo,
-			TeamCacheRepository: mockCacheRepo,
-			Logger:              logger,
+			TransferRepository:    mockTransferRepo,
+			PlayerRepository:      mockPlayerRepo,
+			TeamRepository:        mockTeamRepo,
+			TeamCacheRepository:   mockCacheRepo,
+			AuditRepository:       newMockAuditRepository(),
+			MarketEventRepository: mockMarketEvents,
+			Logger:                logger,
 		})
 
-		err := service.BuyPlayer(ctx, userID, transferID)
+		err := service.BuyPlayer(ctx, userID, uuid.Nil, transferID)
 
 		assert.NoError(t, err)
+		if published := publishedMarketEvents(mockMarketEvents); assert.Len(t, published, 3) {
+			assert.Equal(t, entity.MarketEventListingSold, published[0].Type)
+			assert.Nil(t, published[0].UserID)
+			assert.Equal(t, buyerTeamID, *published[0].BuyerID)
+			assert.Equal(t, "Harry Kane", published[0].PlayerName)
+			assert.Equal(t, entity.MarketEventPlayerSold, published[1].Type)
+			assert.Equal(t, sellerUserID, *published[1].UserID)
+			assert.Equal(t, entity.MarketEventPlayerBought, published[2].Type)
+			assert.Equal(t, userID, *published[2].UserID)
+		}
 		mockTransferRepo.AssertExpectations(t)
 		mockPlayerRepo.AssertExpectations(t)
 		mockTeamRepo.AssertExpectations(t)
@@ -540,10 +616,11 @@ func TestTransferService_BuyPlayer(t *testing.T) {
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		err := service.BuyPlayer(ctx, userID, transferID)
+		err := service.BuyPlayer(ctx, userID, uuid.Nil, transferID)
 
 		assert.Error(t, err)
 		assert.Equal(t, apperr.ErrTransferNotFound, err)
@@ -559,8 +636,8 @@ func TestTransferService_BuyPlayer(t *testing.T) {
 		completedTime := time.Now()
 		transfer := &entity.Transfer{
 			ID:          transferID,
-			PlayerID:    playerID,
-			SellerID:    sellerTeamID,
+			PlayerID:    &playerID,
+			SellerID:    &sellerTeamID,
 			AskingPrice: 1000000,
 			Status:      entity.TransferStatusCompleted,
 			CompletedAt: &completedTime,
@@ -573,10 +650,11 @@ func TestTransferService_BuyPlayer(t *testing.T) {
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		err := service.BuyPlayer(ctx, userID, transferID)
+		err := service.BuyPlayer(ctx, userID, uuid.Nil, transferID)
 
 		assert.Error(t, err)
 		assert.Equal(t, apperr.ErrTransferNotActive, err)
@@ -591,8 +669,8 @@ func TestTransferService_BuyPlayer(t *testing.T) {
 
 		transfer := &entity.Transfer{
 			ID:          transferID,
-			PlayerID:    playerID,
-			SellerID:    buyerTeamID,
+			PlayerID:    &playerID,
+			SellerID:    &buyerTeamID,
 			AskingPrice: 1000000,
 			Status:      entity.TransferStatusActive,
 		}
@@ -604,17 +682,60 @@ func TestTransferService_BuyPlayer(t *testing.T) {
 		}
 
 		mockTransferRepo.On("GetByID", ctx, transferID).Return(transfer, nil)
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(buyerTeam, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{*buyerTeam}, nil)
+		mockTeamRepo.On("GetByID", ctx, buyerTeamID).Return(buyerTeam, nil)
 
 		service := NewTransferService(TransferServiceParams{
 			TransferRepository:  mockTransferRepo,
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		err := service.BuyPlayer(ctx, userID, transferID)
+		err := service.BuyPlayer(ctx, userID, uuid.Nil, transferID)
+
+		assert.Error(t, err)
+		assert.Equal(t, apperr.ErrCannotBuyOwnPlayer, err)
+		mockTransferRepo.AssertExpectations(t)
+		mockTeamRepo.AssertExpectations(t)
+	})
+
+	t.Run("cannot buy from another own team", func(t *testing.T) {
+		mockTransferRepo := new(MockTransferRepository)
+		mockPlayerRepo := new(MockPlayerRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+
+		transfer := &entity.Transfer{
+			ID:          transferID,
+			PlayerID:    &playerID,
+			SellerID:    &sellerTeamID,
+			AskingPrice: 1000000,
+			Status:      entity.TransferStatusActive,
+		}
+
+		buyerTeam := &entity.Team{
+			ID:     buyerTeamID,
+			UserID: userID,
+			Budget: 5000000,
+		}
+
+		mockTransferRepo.On("GetByID", ctx, transferID).Return(transfer, nil)
+		mockTeamRepo.On("GetByID", ctx, buyerTeamID).Return(buyerTeam, nil)
+		mockTeamRepo.On("GetByID", ctx, sellerTeamID).Return(&entity.Team{ID: sellerTeamID, UserID: userID}, nil)
+
+		service := NewTransferService(TransferServiceParams{
+			TransferRepository:  mockTransferRepo,
+			PlayerRepository:    mockPlayerRepo,
+			TeamRepository:      mockTeamRepo,
+			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+		})
+
+		err := service.BuyPlayer(ctx, userID, buyerTeamID, transferID)
 
 		assert.Error(t, err)
 		assert.Equal(t, apperr.ErrCannotBuyOwnPlayer, err)
@@ -630,8 +751,8 @@ func TestTransferService_BuyPlayer(t *testing.T) {
 
 		transfer := &entity.Transfer{
 			ID:          transferID,
-			PlayerID:    playerID,
-			SellerID:    sellerTeamID,
+			PlayerID:    &playerID,
+			SellerID:    &sellerTeamID,
 			AskingPrice: 10000000,
 			Status:      entity.TransferStatusActive,
 		}
@@ -643,25 +764,27 @@ func TestTransferService_BuyPlayer(t *testing.T) {
 		}
 
 		mockTransferRepo.On("GetByID", ctx, transferID).Return(transfer, nil)
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(buyerTeam, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{*buyerTeam}, nil)
+		mockTeamRepo.On("GetByID", ctx, sellerTeamID).Return(&entity.Team{ID: sellerTeamID, UserID: sellerUserID}, nil)
 
 		service := NewTransferService(TransferServiceParams{
 			TransferRepository:  mockTransferRepo,
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		err := service.BuyPlayer(ctx, userID, transferID)
+		err := service.BuyPlayer(ctx, userID, uuid.Nil, transferID)
 
 		assert.Error(t, err)
-		assert.Equal(t, apperr.ErrInsufficientFunds, err)
+		assert.ErrorIs(t, err, apperr.ErrInsufficientFunds)
 		mockTransferRepo.AssertExpectations(t)
 		mockTeamRepo.AssertExpectations(t)
 	})
 
-	t.Run("player transfer fails", func(t *testing.T) {
+	t.Run("completion fails", func(t *testing.T) {
 		mockTransferRepo := new(MockTransferRepository)
 		mockPlayerRepo := new(MockPlayerRepository)
 		mockTeamRepo := new(MockTeamRepository)
@@ -669,8 +792,8 @@ func TestTransferService_BuyPlayer(t *testing.T) {
 
 		transfer := &entity.Transfer{
 			ID:          transferID,
-			PlayerID:    playerID,
-			SellerID:    sellerTeamID,
+			PlayerID:    &playerID,
+			SellerID:    &sellerTeamID,
 			AskingPrice: 1000000,
 			Status:      entity.TransferStatusActive,
 		}
@@ -694,24 +817,118 @@ func TestTransferService_BuyPlayer(t *testing.T) {
 		}
 
 		mockTransferRepo.On("GetByID", ctx, transferID).Return(transfer, nil)
-		mockTeamRepo.On("GetByUserID", ctx, userID).Return(buyerTeam, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{*buyerTeam}, nil)
 		mockTeamRepo.On("GetByID", ctx, sellerTeamID).Return(sellerTeam, nil)
 		mockPlayerRepo.On("GetByID", ctx, playerID).Return(player, nil)
-		mockPlayerRepo.On("TransferPlayer", ctx, playerID, buyerTeamID).Return(errors.New("transfer failed"))
+		mockTransferRepo.On("Complete", ctx, mock.AnythingOfType("entity.TransferPurchase")).Return(errors.New("connection reset"))
 
 		service := NewTransferService(TransferServiceParams{
 			TransferRepository:  mockTransferRepo,
 			PlayerRepository:    mockPlayerRepo,
 			TeamRepository:      mockTeamRepo,
 			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
 			Logger:              logger,
 		})
 
-		err := service.BuyPlayer(ctx, userID, transferID)
+		err := service.BuyPlayer(ctx, userID, uuid.Nil, transferID)
 
 		assert.Error(t, err)
 		mockTransferRepo.AssertExpectations(t)
 		mockPlayerRepo.AssertExpectations(t)
 		mockTeamRepo.AssertExpectations(t)
 	})
+
+	t.Run("payment rejected by ledger", func(t *testing.T) {
+		mockTransferRepo := new(MockTransferRepository)
+		mockPlayerRepo := new(MockPlayerRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+
+		transfer := &entity.Transfer{
+			ID:          transferID,
+			PlayerID:    &playerID,
+			SellerID:    &sellerTeamID,
+			AskingPrice: 1000000,
+			Status:      entity.TransferStatusActive,
+		}
+
+		buyerTeam := &entity.Team{
+			ID:     buyerTeamID,
+			UserID: userID,
+			Budget: 5000000,
+		}
+
+		sellerTeam := &entity.Team{
+			ID:     sellerTeamID,
+			UserID: sellerUserID,
+			Budget: 3000000,
+		}
+
+		player := &entity.Player{
+			ID:          playerID,
+			TeamID:      sellerTeamID,
+			MarketValue: 1000000,
+		}
+
+		mockTransferRepo.On("GetByID", ctx, transferID).Return(transfer, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{*buyerTeam}, nil)
+		mockTeamRepo.On("GetByID", ctx, sellerTeamID).Return(sellerTeam, nil)
+		mockPlayerRepo.On("GetByID", ctx, playerID).Return(player, nil)
+		mockTransferRepo.On("Complete", ctx, mock.AnythingOfType("entity.TransferPurchase")).Return(apperr.ErrInsufficientFunds)
+
+		service := NewTransferService(TransferServiceParams{
+			TransferRepository:  mockTransferRepo,
+			PlayerRepository:    mockPlayerRepo,
+			TeamRepository:      mockTeamRepo,
+			TeamCacheRepository: mockCacheRepo,
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+		})
+
+		err := service.BuyPlayer(ctx, userID, uuid.Nil, transferID)
+
+		assert.ErrorIs(t, err, apperr.ErrInsufficientFunds)
+		mockCacheRepo.AssertNotCalled(t, "InvalidateTeam", mock.Anything, mock.Anything)
+	})
+
+	t.Run("completed by another buyer first", func(t *testing.T) {
+		mockTransferRepo := new(MockTransferRepository)
+		mockPlayerRepo := new(MockPlayerRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		mockCacheRepo := new(MockTeamCacheRepository)
+		mockAuditRepo := new(MockAuditRepository)
+		mockMarketEvents := newMockMarketEvents()
+
+		transfer := &entity.Transfer{
+			ID:          transferID,
+			PlayerID:    &playerID,
+			SellerID:    &sellerTeamID,
+			AskingPrice: 1000000,
+			Status:      entity.TransferStatusActive,
+		}
+
+		mockTransferRepo.On("GetByID", ctx, transferID).Return(transfer, nil)
+		mockTeamRepo.On("ListByUserID", ctx, userID).Return([]entity.Team{{ID: buyerTeamID, UserID: userID, Budget: 5000000}}, nil)
+		mockTeamRepo.On("GetByID", ctx, sellerTeamID).Return(&entity.Team{ID: sellerTeamID, UserID: sellerUserID}, nil)
+		mockPlayerRepo.On("GetByID", ctx, playerID).Return(&entity.Player{ID: playerID, TeamID: sellerTeamID, MarketValue: 1000000}, nil)
+		mockTransferRepo.On("Complete", ctx, mock.AnythingOfType("entity.TransferPurchase")).Return(apperr.ErrTransferNotActive)
+
+		service := NewTransferService(TransferServiceParams{
+			TransferRepository:    mockTransferRepo,
+			PlayerRepository:      mockPlayerRepo,
+			TeamRepository:        mockTeamRepo,
+			TeamCacheRepository:   mockCacheRepo,
+			AuditRepository:       mockAuditRepo,
+			MarketEventRepository: mockMarketEvents,
+			Logger:                logger,
+		})
+
+		err := service.BuyPlayer(ctx, userID, uuid.Nil, transferID)
+
+		assert.ErrorIs(t, err, apperr.ErrTransferNotActive)
+		assert.Empty(t, publishedMarketEvents(mockMarketEvents))
+		mockAuditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		mockCacheRepo.AssertNotCalled(t, "InvalidateTeam", mock.Anything, mock.Anything)
+	})
 }
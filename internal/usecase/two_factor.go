@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/secretbox"
+	"soccer_manager_service/pkg/totp"
+	"strings"
+	"time"
+)
+
+const (
+	// totpSkew accepts codes from one time step before or after the current
+	// one to tolerate clock drift on the user's device.
+	totpSkew = 1
+
+	// recoveryCodeAlphabet leaves out characters that are easily confused
+	// when a code is copied by hand.
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	recoveryCodeLength   = 10
+
+	secondFactorTOTP         = "totp"
+	secondFactorRecoveryCode = "recovery_code"
+)
+
+// twoFactor checks TOTP and recovery codes and mints new recovery codes for
+// the services that deal with two-factor authentication.
+type twoFactor struct {
+	repository ports.TwoFactorRepository
+	config     config.TwoFactorConfig
+}
+
+func newTwoFactor(repository ports.TwoFactorRepository, cfg config.TwoFactorConfig) *twoFactor {
+	return &twoFactor{repository: repository, config: cfg}
+}
+
+func (t *twoFactor) box() (*secretbox.Box, error) {
+	key, err := t.config.Key()
+	if err != nil {
+		return nil, err
+	}
+
+	return secretbox.New(key)
+}
+
+func (t *twoFactor) sealSecret(secret string) (string, error) {
+	box, err := t.box()
+	if err != nil {
+		return "", err
+	}
+
+	return box.Seal(secret)
+}
+
+// checkTOTP validates code against the user's secret without consuming it
+// and returns the time step it matched.
+func (t *twoFactor) checkTOTP(user *entity.User, code string) (int64, error) {
+	if user.TOTPSecret == nil {
+		return 0, apperr.ErrTwoFactorNotEnrolled
+	}
+
+	box, err := t.box()
+	if err != nil {
+		return 0, err
+	}
+
+	secret, err := box.Open(*user.TOTPSecret)
+	if err != nil {
+		return 0, fmt.Errorf("open totp secret: %w", err)
+	}
+
+	step, ok, err := totp.Validate(secret, strings.TrimSpace(code), time.Now(), totpSkew)
+	if err != nil {
+		return 0, fmt.Errorf("validate totp code: %w", err)
+	}
+
+	if !ok {
+		return 0, apperr.ErrInvalidTwoFactorCode
+	}
+
+	return step, nil
+}
+
+// verify accepts a current TOTP code or an unused recovery code and consumes
+// it, so that neither works twice. It returns which kind of code it was.
+func (t *twoFactor) verify(ctx context.Context, user *entity.User, code string) (string, error) {
+	if !user.HasTwoFactor() {
+		return "", apperr.ErrTwoFactorNotEnabled
+	}
+
+	if isTOTPCode(code) {
+		step, err := t.checkTOTP(user, code)
+		if err != nil {
+			return "", err
+		}
+
+		ok, err := t.repository.UseStep(ctx, user.ID, step)
+		if err != nil {
+			return "", err
+		}
+
+		if !ok {
+			return "", apperr.ErrInvalidTwoFactorCode
+		}
+
+		return secondFactorTOTP, nil
+	}
+
+	ok, err := t.repository.UseRecoveryCode(ctx, user.ID, hashRecoveryCode(code))
+	if err != nil {
+		return "", err
+	}
+
+	if !ok {
+		return "", apperr.ErrInvalidTwoFactorCode
+	}
+
+	return secondFactorRecoveryCode, nil
+}
+
+// newRecoveryCodes returns the configured number of fresh recovery codes,
+// formatted for display, together with the hashes to store.
+func (t *twoFactor) newRecoveryCodes() (codes, hashes []string, err error) {
+	codes = make([]string, 0, t.config.RecoveryCodes)
+	hashes = make([]string, 0, t.config.RecoveryCodes)
+
+	for range t.config.RecoveryCodes {
+		code, err := randomRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// randomRecoveryCode returns a code like "k7mpq-2xw9c". Bytes that would skew
+// the distribution over the alphabet are discarded.
+func randomRecoveryCode() (string, error) {
+	limit := byte(256 - 256%len(recoveryCodeAlphabet))
+
+	var (
+		code strings.Builder
+		buf  [1]byte
+	)
+
+	for n := 0; n < recoveryCodeLength; {
+		if _, err := rand.Read(buf[:]); err != nil {
+			return "", fmt.Errorf("generate recovery code: %w", err)
+		}
+
+		if buf[0] >= limit {
+			continue
+		}
+
+		if n == recoveryCodeLength/2 {
+			code.WriteByte('-')
+		}
+
+		code.WriteByte(recoveryCodeAlphabet[int(buf[0])%len(recoveryCodeAlphabet)])
+		n++
+	}
+
+	return code.String(), nil
+}
+
+func isTOTPCode(code string) bool {
+	code = strings.TrimSpace(code)
+
+	if len(code) != totp.Digits {
+		return false
+	}
+
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hashRecoveryCode hashes a recovery code as typed, ignoring case, spaces and
+// the dash in the middle.
+func hashRecoveryCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+
+		return r
+	}, strings.ToLower(code))
+
+	sum := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"context"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+	"soccer_manager_service/pkg/totp"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TwoFactorService manages TOTP two-factor authentication for the signed-in
+// user. Enrollment stores a pending secret that only takes effect once a code
+// generated from it has been verified; turning 2FA off or replacing recovery
+// codes needs both the password and a current code.
+type TwoFactorService struct {
+	userRepository  ports.UserRepository
+	auditRepository ports.AuditRepository
+	twoFactor       *twoFactor
+	logger          *zap.Logger
+	config          *config.Config
+}
+
+type TwoFactorServiceParams struct {
+	UserRepository      ports.UserRepository
+	TwoFactorRepository ports.TwoFactorRepository
+	AuditRepository     ports.AuditRepository
+	Logger              *zap.Logger
+	Config              *config.Config
+}
+
+func NewTwoFactorService(params TwoFactorServiceParams) *TwoFactorService {
+	return &TwoFactorService{
+		userRepository:  params.UserRepository,
+		auditRepository: params.AuditRepository,
+		twoFactor:       newTwoFactor(params.TwoFactorRepository, params.Config.TwoFactor),
+		logger:          params.Logger.With(zap.String("service", "TwoFactorService")),
+		config:          params.Config,
+	}
+}
+
+func (s *TwoFactorService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger, zap.String("service", "TwoFactorService"))
+}
+
+func (s *TwoFactorService) Status(ctx context.Context, userID uuid.UUID) (*dto.TwoFactorStatusResponse, error) {
+	log := s.log(ctx)
+
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user", zap.Error(err))
+
+		return nil, err
+	}
+
+	if !user.HasTwoFactor() {
+		return &dto.TwoFactorStatusResponse{}, nil
+	}
+
+	remaining, err := s.twoFactor.repository.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		log.Error("failed to count recovery codes", zap.Error(err))
+
+		return nil, err
+	}
+
+	return &dto.TwoFactorStatusResponse{
+		Enabled:                true,
+		EnabledAt:              user.TOTPEnabledAt,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+// Enroll generates a new TOTP secret for the user. Enrolling again before
+// enabling replaces the previous secret.
+func (s *TwoFactorService) Enroll(ctx context.Context, userID uuid.UUID, req *dto.TwoFactorEnrollRequest) (*dto.TwoFactorEnrollResponse, error) {
+	log := s.log(ctx)
+
+	user, err := authenticate(ctx, s.userRepository, log, userID, req.CurrentPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.HasTwoFactor() {
+		return nil, apperr.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		log.Error("failed to generate totp secret", zap.Error(err))
+
+		return nil, err
+	}
+
+	sealed, err := s.twoFactor.sealSecret(secret)
+	if err != nil {
+		log.Error("failed to encrypt totp secret", zap.Error(err))
+
+		return nil, err
+	}
+
+	if err := s.twoFactor.repository.SetPendingSecret(ctx, userID, sealed); err != nil {
+		log.Error("failed to store totp secret", zap.Error(err))
+
+		return nil, err
+	}
+
+	log.Info("two-factor enrollment started", zap.String("user_id", userID.String()))
+
+	return &dto.TwoFactorEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(s.config.TwoFactor.Issuer, user.Email, secret),
+	}, nil
+}
+
+// Enable turns 2FA on once the user proves their authenticator produces
+// valid codes for the pending secret, and returns the first recovery codes.
+func (s *TwoFactorService) Enable(ctx context.Context, userID uuid.UUID, req *dto.TwoFactorEnableRequest) (*dto.RecoveryCodesResponse, error) {
+	log := s.log(ctx)
+
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user", zap.Error(err))
+
+		return nil, err
+	}
+
+	if user.HasTwoFactor() {
+		return nil, apperr.ErrTwoFactorAlreadyEnabled
+	}
+
+	step, err := s.twoFactor.checkTOTP(user, req.Code)
+	if err != nil {
+		log.Warn("two-factor enable rejected", zap.String("user_id", userID.String()), zap.Error(err))
+
+		return nil, err
+	}
+
+	codes, hashes, err := s.twoFactor.newRecoveryCodes()
+	if err != nil {
+		log.Error("failed to generate recovery codes", zap.Error(err))
+
+		return nil, err
+	}
+
+	if err := s.twoFactor.repository.Enable(ctx, userID, step, hashes); err != nil {
+		log.Error("failed to enable two-factor", zap.Error(err))
+
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &userID, "user.two_factor_enabled", entity.AuditEntityUser, userID, nil, nil))
+
+	log.Info("two-factor enabled", zap.String("user_id", userID.String()))
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *TwoFactorService) Disable(ctx context.Context, userID uuid.UUID, req *dto.TwoFactorConfirmRequest) error {
+	log := s.log(ctx)
+
+	if _, err := s.confirm(ctx, userID, req); err != nil {
+		return err
+	}
+
+	if err := s.twoFactor.repository.Disable(ctx, userID); err != nil {
+		log.Error("failed to disable two-factor", zap.Error(err))
+
+		return err
+	}
+
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &userID, "user.two_factor_disabled", entity.AuditEntityUser, userID, nil, nil))
+
+	log.Info("two-factor disabled", zap.String("user_id", userID.String()))
+
+	return nil
+}
+
+// RegenerateRecoveryCodes invalidates all remaining recovery codes and
+// returns a new set.
+func (s *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, req *dto.TwoFactorConfirmRequest) (*dto.RecoveryCodesResponse, error) {
+	log := s.log(ctx)
+
+	if _, err := s.confirm(ctx, userID, req); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := s.twoFactor.newRecoveryCodes()
+	if err != nil {
+		log.Error("failed to generate recovery codes", zap.Error(err))
+
+		return nil, err
+	}
+
+	if err := s.twoFactor.repository.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		log.Error("failed to replace recovery codes", zap.Error(err))
+
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &userID, "user.recovery_codes_regenerated", entity.AuditEntityUser, userID, nil, nil))
+
+	log.Info("recovery codes regenerated", zap.String("user_id", userID.String()))
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// confirm checks the password and consumes a TOTP or recovery code.
+func (s *TwoFactorService) confirm(ctx context.Context, userID uuid.UUID, req *dto.TwoFactorConfirmRequest) (*entity.User, error) {
+	log := s.log(ctx)
+
+	user, err := authenticate(ctx, s.userRepository, log, userID, req.CurrentPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.twoFactor.verify(ctx, user, req.Code); err != nil {
+		log.Warn("two-factor confirmation rejected", zap.String("user_id", userID.String()), zap.Error(err))
+
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,573 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/entity"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/jwt"
+	"soccer_manager_service/pkg/totp"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type MockTwoFactorRepository struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorRepository) SetPendingSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	args := m.Called(ctx, userID, secret)
+
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) Enable(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) error {
+	args := m.Called(ctx, userID, step, codeHashes)
+
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) Disable(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	args := m.Called(ctx, userID, codeHashes)
+
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	args := m.Called(ctx, userID, step)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	args := m.Called(ctx, userID, codeHash)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, userID)
+
+	return args.Int(0), args.Error(1)
+}
+
+// testTwoFactorConfig uses a fixed all-zero encryption key.
+var testTwoFactorConfig = config.TwoFactorConfig{
+	Issuer:        "Soccer Manager",
+	EncryptionKey: "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+	ChallengeTTL:  5 * time.Minute,
+	RecoveryCodes: 10,
+}
+
+// newTwoFactorUser returns a user with the given TOTP secret, encrypted the
+// way the services expect it, and with 2FA enabled if enabled is set.
+func newTwoFactorUser(t *testing.T, secret string, enabled bool) *entity.User {
+	t.Helper()
+
+	sealed, err := newTwoFactor(nil, testTwoFactorConfig).sealSecret(secret)
+	assert.NoError(t, err)
+
+	user := &entity.User{
+		ID:           uuid.New(),
+		Email:        "test@example.com",
+		PasswordHash: hashPassword("password"),
+		TOTPSecret:   &sealed,
+	}
+
+	if enabled {
+		enabledAt := time.Now()
+		user.TOTPEnabledAt = &enabledAt
+	}
+
+	return user
+}
+
+func currentCode(t *testing.T, secret string) string {
+	t.Helper()
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	assert.NoError(t, err)
+
+	return code
+}
+
+func TestTwoFactorService_Enroll(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	cfg := &config.Config{TwoFactor: testTwoFactorConfig}
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+
+		user := &entity.User{ID: uuid.New(), Email: "test@example.com", PasswordHash: hashPassword("password")}
+
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		mockTwoFactorRepo.On("SetPendingSecret", ctx, user.ID, mock.AnythingOfType("string")).Return(nil)
+
+		service := NewTwoFactorService(TwoFactorServiceParams{
+			UserRepository:      mockUserRepo,
+			TwoFactorRepository: mockTwoFactorRepo,
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+			Config:              cfg,
+		})
+
+		resp, err := service.Enroll(ctx, user.ID, &dto.TwoFactorEnrollRequest{CurrentPassword: "password"})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, resp.Secret)
+		assert.True(t, strings.HasPrefix(resp.OTPAuthURI, "otpauth://totp/"))
+		assert.Contains(t, resp.OTPAuthURI, "secret="+resp.Secret)
+
+		// The stored secret is encrypted.
+		stored := mockTwoFactorRepo.Calls[0].Arguments.String(2)
+		assert.NotEqual(t, resp.Secret, stored)
+		mockTwoFactorRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+
+		user := &entity.User{ID: uuid.New(), Email: "test@example.com", PasswordHash: hashPassword("password")}
+
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+		service := NewTwoFactorService(TwoFactorServiceParams{
+			UserRepository:      mockUserRepo,
+			TwoFactorRepository: mockTwoFactorRepo,
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+			Config:              cfg,
+		})
+
+		resp, err := service.Enroll(ctx, user.ID, &dto.TwoFactorEnrollRequest{CurrentPassword: "wrong"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperr.ErrInvalidCurrentPassword, err)
+		mockTwoFactorRepo.AssertNotCalled(t, "SetPendingSecret", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+
+		secret, err := totp.GenerateSecret()
+		assert.NoError(t, err)
+
+		user := newTwoFactorUser(t, secret, true)
+
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+		service := NewTwoFactorService(TwoFactorServiceParams{
+			UserRepository:      mockUserRepo,
+			TwoFactorRepository: mockTwoFactorRepo,
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+			Config:              cfg,
+		})
+
+		resp, err := service.Enroll(ctx, user.ID, &dto.TwoFactorEnrollRequest{CurrentPassword: "password"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperr.ErrTwoFactorAlreadyEnabled, err)
+	})
+}
+
+func TestTwoFactorService_Enable(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	cfg := &config.Config{TwoFactor: testTwoFactorConfig}
+
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		mockAuditRepo := newMockAuditRepository()
+
+		user := newTwoFactorUser(t, secret, false)
+
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		mockTwoFactorRepo.On("Enable", ctx, user.ID, mock.AnythingOfType("int64"), mock.AnythingOfType("[]string")).Return(nil)
+
+		service := NewTwoFactorService(TwoFactorServiceParams{
+			UserRepository:      mockUserRepo,
+			TwoFactorRepository: mockTwoFactorRepo,
+			AuditRepository:     mockAuditRepo,
+			Logger:              logger,
+			Config:              cfg,
+		})
+
+		resp, err := service.Enable(ctx, user.ID, &dto.TwoFactorEnableRequest{Code: currentCode(t, secret)})
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.RecoveryCodes, testTwoFactorConfig.RecoveryCodes)
+
+		hashes := mockTwoFactorRepo.Calls[0].Arguments.Get(3).([]string)
+		for i, code := range resp.RecoveryCodes {
+			assert.Regexp(t, `^[a-z2-9]{5}-[a-z2-9]{5}$`, code)
+			assert.Equal(t, hashRecoveryCode(code), hashes[i])
+		}
+
+		mockAuditRepo.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(entries []entity.AuditEntry) bool {
+			return len(entries) == 1 && entries[0].Action == "user.two_factor_enabled"
+		}))
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+
+		user := newTwoFactorUser(t, secret, false)
+
+		code := "000000"
+		if currentCode(t, secret) == code {
+			code = "111111"
+		}
+
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+		service := NewTwoFactorService(TwoFactorServiceParams{
+			UserRepository:      mockUserRepo,
+			TwoFactorRepository: mockTwoFactorRepo,
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+			Config:              cfg,
+		})
+
+		resp, err := service.Enable(ctx, user.ID, &dto.TwoFactorEnableRequest{Code: code})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperr.ErrInvalidTwoFactorCode, err)
+		mockTwoFactorRepo.AssertNotCalled(t, "Enable", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+
+		user := &entity.User{ID: uuid.New(), Email: "test@example.com"}
+
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+		service := NewTwoFactorService(TwoFactorServiceParams{
+			UserRepository:      mockUserRepo,
+			TwoFactorRepository: new(MockTwoFactorRepository),
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+			Config:              cfg,
+		})
+
+		resp, err := service.Enable(ctx, user.ID, &dto.TwoFactorEnableRequest{Code: "123456"})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, apperr.ErrTwoFactorNotEnrolled, err)
+	})
+}
+
+func TestTwoFactorService_Disable(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	cfg := &config.Config{TwoFactor: testTwoFactorConfig}
+
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+
+	t.Run("with recovery code", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+
+		user := newTwoFactorUser(t, secret, true)
+
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		mockTwoFactorRepo.On("UseRecoveryCode", ctx, user.ID, hashRecoveryCode("abcde-fghjk")).Return(true, nil)
+		mockTwoFactorRepo.On("Disable", ctx, user.ID).Return(nil)
+
+		service := NewTwoFactorService(TwoFactorServiceParams{
+			UserRepository:      mockUserRepo,
+			TwoFactorRepository: mockTwoFactorRepo,
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+			Config:              cfg,
+		})
+
+		err := service.Disable(ctx, user.ID, &dto.TwoFactorConfirmRequest{
+			CurrentPassword: "password",
+			Code:            "ABCDE FGHJK",
+		})
+
+		assert.NoError(t, err)
+		mockTwoFactorRepo.AssertExpectations(t)
+	})
+
+	t.Run("replayed totp code", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+
+		user := newTwoFactorUser(t, secret, true)
+
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		mockTwoFactorRepo.On("UseStep", ctx, user.ID, mock.AnythingOfType("int64")).Return(false, nil)
+
+		service := NewTwoFactorService(TwoFactorServiceParams{
+			UserRepository:      mockUserRepo,
+			TwoFactorRepository: mockTwoFactorRepo,
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+			Config:              cfg,
+		})
+
+		err := service.Disable(ctx, user.ID, &dto.TwoFactorConfirmRequest{
+			CurrentPassword: "password",
+			Code:            currentCode(t, secret),
+		})
+
+		assert.Equal(t, apperr.ErrInvalidTwoFactorCode, err)
+		mockTwoFactorRepo.AssertNotCalled(t, "Disable", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+
+		user := &entity.User{ID: uuid.New(), Email: "test@example.com", PasswordHash: hashPassword("password")}
+
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+		service := NewTwoFactorService(TwoFactorServiceParams{
+			UserRepository:      mockUserRepo,
+			TwoFactorRepository: new(MockTwoFactorRepository),
+			AuditRepository:     newMockAuditRepository(),
+			Logger:              logger,
+			Config:              cfg,
+		})
+
+		err := service.Disable(ctx, user.ID, &dto.TwoFactorConfirmRequest{CurrentPassword: "password", Code: "123456"})
+
+		assert.Equal(t, apperr.ErrTwoFactorNotEnabled, err)
+	})
+}
+
+func TestTwoFactor_Verify(t *testing.T) {
+	ctx := context.Background()
+
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+
+	t.Run("old codes cannot be replayed", func(t *testing.T) {
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		user := newTwoFactorUser(t, secret, true)
+		step := totp.Step(time.Now())
+
+		codeAt := func(step int64) string {
+			code, err := totp.Code(secret, step)
+			assert.NoError(t, err)
+
+			return code
+		}
+
+		// The repository accepts a step once and nothing at or before the
+		// last accepted one, so each code has to be checked by its own step.
+		mockTwoFactorRepo.On("UseStep", ctx, user.ID, step).Return(true, nil).Once()
+		mockTwoFactorRepo.On("UseStep", ctx, user.ID, step).Return(false, nil)
+		mockTwoFactorRepo.On("UseStep", ctx, user.ID, step-1).Return(false, nil)
+
+		twoFactor := newTwoFactor(mockTwoFactorRepo, testTwoFactorConfig)
+
+		kind, err := twoFactor.verify(ctx, user, codeAt(step))
+		assert.NoError(t, err)
+		assert.Equal(t, secondFactorTOTP, kind)
+
+		_, err = twoFactor.verify(ctx, user, codeAt(step))
+		assert.Equal(t, apperr.ErrInvalidTwoFactorCode, err)
+
+		_, err = twoFactor.verify(ctx, user, codeAt(step-1))
+		assert.Equal(t, apperr.ErrInvalidTwoFactorCode, err)
+
+		mockTwoFactorRepo.AssertNumberOfCalls(t, "UseStep", 3)
+	})
+
+	t.Run("codes outside the window", func(t *testing.T) {
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		user := newTwoFactorUser(t, secret, true)
+
+		code, err := totp.Code(secret, totp.Step(time.Now())-3)
+		assert.NoError(t, err)
+
+		_, err = newTwoFactor(mockTwoFactorRepo, testTwoFactorConfig).verify(ctx, user, code)
+
+		assert.Equal(t, apperr.ErrInvalidTwoFactorCode, err)
+		mockTwoFactorRepo.AssertNotCalled(t, "UseStep", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_TwoFactorLogin(t *testing.T) {
+	logger := zap.NewNop()
+	ctx := context.Background()
+	jwtManager := jwt.NewManager(jwt.Config{
+		Keys:            jwt.NewHMACKeyRing("test-secret"),
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+	})
+	cfg := &config.Config{TwoFactor: testTwoFactorConfig}
+
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+
+	t.Run("login returns challenge", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
+		mockActionTokenRepo := new(MockActionTokenRepository)
+
+		user := newTwoFactorUser(t, secret, true)
+
+		mockLoginAttemptRepo.On("LockedFor", ctx, testClientIP, user.Email).Return(time.Duration(0), nil)
+		mockUserRepo.On("GetByEmail", ctx, user.Email).Return(user, nil)
+		mockActionTokenRepo.On("Issue", ctx, entity.ActionToken{
+			Purpose: entity.TokenPurposeTwoFactorLogin,
+			UserID:  user.ID,
+			Email:   user.Email,
+		}, testTwoFactorConfig.ChallengeTTL).Return("challenge", nil)
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:         mockUserRepo,
+			LoginAttemptRepository: mockLoginAttemptRepo,
+			ActionTokenRepository:  mockActionTokenRepo,
+			TwoFactorRepository:    new(MockTwoFactorRepository),
+			AuditRepository:        newMockAuditRepository(),
+			JWTManager:             jwtManager,
+			Logger:                 logger,
+			Config:                 cfg,
+		})
+
+		resp, err := service.Login(ctx, &dto.LoginRequest{
+			Email:    user.Email,
+			Password: "password",
+			ClientIP: testClientIP,
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, resp.TwoFactorRequired)
+		assert.Equal(t, "challenge", resp.ChallengeToken)
+		assert.Equal(t, 300, resp.ChallengeExpiresIn)
+		assert.Empty(t, resp.AccessToken)
+		assert.Empty(t, resp.RefreshToken)
+		mockLoginAttemptRepo.AssertNotCalled(t, "Reset", ctx, testClientIP, user.Email)
+	})
+
+	t.Run("complete with totp code", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
+		mockActionTokenRepo := new(MockActionTokenRepository)
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+
+		user := newTwoFactorUser(t, secret, true)
+
+		mockActionTokenRepo.On("Consume", ctx, entity.TokenPurposeTwoFactorLogin, "challenge").
+			Return(&entity.ActionToken{UserID: user.ID, Email: user.Email}, nil)
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		mockLoginAttemptRepo.On("LockedFor", ctx, testClientIP, user.Email).Return(time.Duration(0), nil)
+		mockTwoFactorRepo.On("UseStep", ctx, user.ID, mock.AnythingOfType("int64")).Return(true, nil)
+		mockLoginAttemptRepo.On("Reset", ctx, testClientIP, user.Email).Return(nil)
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:         mockUserRepo,
+			LoginAttemptRepository: mockLoginAttemptRepo,
+			ActionTokenRepository:  mockActionTokenRepo,
+			TwoFactorRepository:    mockTwoFactorRepo,
+			AuditRepository:        newMockAuditRepository(),
+			JWTManager:             jwtManager,
+			Logger:                 logger,
+			Config:                 cfg,
+		})
+
+		accessToken, refreshToken, err := service.CompleteTwoFactorLogin(ctx, &dto.TwoFactorLoginRequest{
+			ChallengeToken: "challenge",
+			Code:           currentCode(t, secret),
+			ClientIP:       testClientIP,
+		})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, accessToken)
+		assert.NotEmpty(t, refreshToken)
+		mockTwoFactorRepo.AssertExpectations(t)
+		mockLoginAttemptRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong code counts as failed login", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockLoginAttemptRepo := new(MockLoginAttemptRepository)
+		mockActionTokenRepo := new(MockActionTokenRepository)
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+
+		user := newTwoFactorUser(t, secret, true)
+
+		mockActionTokenRepo.On("Consume", ctx, entity.TokenPurposeTwoFactorLogin, "challenge").
+			Return(&entity.ActionToken{UserID: user.ID, Email: user.Email}, nil)
+		mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		mockLoginAttemptRepo.On("LockedFor", ctx, testClientIP, user.Email).Return(time.Duration(0), nil)
+		mockTwoFactorRepo.On("UseRecoveryCode", ctx, user.ID, hashRecoveryCode("wrong-code")).Return(false, nil)
+		mockLoginAttemptRepo.On("RecordFailure", ctx, testClientIP, user.Email).Return(time.Duration(0), nil)
+
+		service := NewAuthService(AuthServiceParams{
+			UserRepository:         mockUserRepo,
+			LoginAttemptRepository: mockLoginAttemptRepo,
+			ActionTokenRepository:  mockActionTokenRepo,
+			TwoFactorRepository:    mockTwoFactorRepo,
+			AuditRepository:        newMockAuditRepository(),
+			JWTManager:             jwtManager,
+			Logger:                 logger,
+			Config:                 cfg,
+		})
+
+		accessToken, refreshToken, err := service.CompleteTwoFactorLogin(ctx, &dto.TwoFactorLoginRequest{
+			ChallengeToken: "challenge",
+			Code:           "wrong-code",
+			ClientIP:       testClientIP,
+		})
+
+		assert.Empty(t, accessToken)
+		assert.Empty(t, refreshToken)
+		assert.Equal(t, apperr.ErrInvalidTwoFactorCode, err)
+		mockLoginAttemptRepo.AssertExpectations(t)
+		mockLoginAttemptRepo.AssertNotCalled(t, "Reset", ctx, testClientIP, user.Email)
+	})
+
+	t.Run("invalid challenge", func(t *testing.T) {
+		mockActionTokenRepo := new(MockActionTokenRepository)
+
+		mockActionTokenRepo.On("Consume", ctx, entity.TokenPurposeTwoFactorLogin, "expired").Return(nil, nil)
+
+		service := NewAuthService(AuthServiceParams{
+			ActionTokenRepository: mockActionTokenRepo,
+			TwoFactorRepository:   new(MockTwoFactorRepository),
+			AuditRepository:       newMockAuditRepository(),
+			JWTManager:            jwtManager,
+			Logger:                logger,
+			Config:                cfg,
+		})
+
+		_, _, err := service.CompleteTwoFactorLogin(ctx, &dto.TwoFactorLoginRequest{
+			ChallengeToken: "expired",
+			Code:           "123456",
+			ClientIP:       testClientIP,
+		})
+
+		assert.Equal(t, apperr.ErrInvalidTwoFactorChallenge, err)
+	})
+}
@@ -0,0 +1,675 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/entity"
+	"soccer_manager_service/internal/ports"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/logger"
+	"soccer_manager_service/pkg/secretbox"
+	"soccer_manager_service/pkg/webhook"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// webhookPruneInterval is how often Run deletes deliveries completed
+	// more than WEBHOOK_RETENTION ago.
+	webhookPruneInterval = time.Hour
+
+	// maxWebhookErrorLength bounds the error text kept in the delivery log.
+	maxWebhookErrorLength = 500
+)
+
+// WebhookService manages the webhook endpoints of users and delivers the
+// domain events about their teams to them. Events are queued as deliveries
+// by an event handler and sent by Run, which retries failures with
+// exponential backoff and disables endpoints that keep failing.
+type WebhookService struct {
+	webhookRepository  ports.WebhookRepository
+	deliveryRepository ports.WebhookDeliveryRepository
+	teamRepository     ports.TeamRepository
+	auditRepository    ports.AuditRepository
+	sender             ports.WebhookSender
+	logger             *zap.Logger
+	config             *config.Config
+}
+
+type WebhookServiceParams struct {
+	WebhookRepository  ports.WebhookRepository
+	DeliveryRepository ports.WebhookDeliveryRepository
+	TeamRepository     ports.TeamRepository
+	AuditRepository    ports.AuditRepository
+	Sender             ports.WebhookSender
+	Logger             *zap.Logger
+	Config             *config.Config
+}
+
+func NewWebhookService(params WebhookServiceParams) *WebhookService {
+	return &WebhookService{
+		webhookRepository:  params.WebhookRepository,
+		deliveryRepository: params.DeliveryRepository,
+		teamRepository:     params.TeamRepository,
+		auditRepository:    params.AuditRepository,
+		sender:             params.Sender,
+		logger:             params.Logger.With(zap.String("service", "WebhookService")),
+		config:             params.Config,
+	}
+}
+
+func (s *WebhookService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.logger, zap.String("service", "WebhookService"))
+}
+
+func (s *WebhookService) Create(ctx context.Context, userID uuid.UUID, req *dto.CreateWebhookRequest) (*entity.Webhook, error) {
+	log := s.log(ctx)
+
+	endpoint, err := webhookURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes, err := normalizeWebhookEvents(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.webhookRepository.ListByUser(ctx, userID)
+	if err != nil {
+		log.Error("failed to list webhooks", zap.Error(err))
+
+		return nil, err
+	}
+
+	if len(existing) >= s.config.Webhook.MaxPerUser {
+		log.Warn("webhook limit reached", zap.String("user_id", userID.String()))
+
+		return nil, apperr.ErrWebhookLimitReached
+	}
+
+	secret, err := s.sealSecret(req.Secret)
+	if err != nil {
+		log.Error("failed to encrypt webhook secret", zap.Error(err))
+
+		return nil, err
+	}
+
+	webhook, err := s.webhookRepository.Create(ctx, entity.Webhook{
+		UserID:     userID,
+		URL:        endpoint,
+		Secret:     secret,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		log.Error("failed to create webhook", zap.Error(err))
+
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &userID, "webhook.created", entity.AuditEntityWebhook, webhook.ID, nil, webhook))
+
+	log.Info("webhook created", zap.String("user_id", userID.String()), zap.String("webhook_id", webhook.ID.String()))
+
+	return webhook, nil
+}
+
+func (s *WebhookService) List(ctx context.Context, userID uuid.UUID) (*dto.WebhookListResponse, error) {
+	webhooks, err := s.webhookRepository.ListByUser(ctx, userID)
+	if err != nil {
+		s.log(ctx).Error("failed to list webhooks", zap.Error(err))
+
+		return nil, err
+	}
+
+	return &dto.WebhookListResponse{Webhooks: webhooks}, nil
+}
+
+func (s *WebhookService) Get(ctx context.Context, userID, webhookID uuid.UUID) (*entity.Webhook, error) {
+	return s.get(ctx, userID, webhookID)
+}
+
+func (s *WebhookService) Update(ctx context.Context, userID, webhookID uuid.UUID, req *dto.UpdateWebhookRequest) (*entity.Webhook, error) {
+	log := s.log(ctx)
+
+	webhook, err := s.get(ctx, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *webhook
+
+	if req.URL != "" {
+		if webhook.URL, err = webhookURL(req.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.EventTypes != nil {
+		if webhook.EventTypes, err = normalizeWebhookEvents(req.EventTypes); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Secret != "" {
+		if webhook.Secret, err = s.sealSecret(req.Secret); err != nil {
+			log.Error("failed to encrypt webhook secret", zap.Error(err))
+
+			return nil, err
+		}
+	}
+
+	if req.Enabled != nil {
+		switch {
+		case *req.Enabled:
+			webhook.DisabledAt = nil
+			webhook.Failures = 0
+		case webhook.Enabled():
+			now := time.Now()
+			webhook.DisabledAt = &now
+		}
+	}
+
+	updated, err := s.webhookRepository.Update(ctx, *webhook)
+	if err != nil {
+		log.Error("failed to update webhook", zap.Error(err))
+
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &userID, "webhook.updated", entity.AuditEntityWebhook, updated.ID, before, updated))
+
+	log.Info("webhook updated", zap.String("user_id", userID.String()), zap.String("webhook_id", updated.ID.String()))
+
+	return updated, nil
+}
+
+func (s *WebhookService) Delete(ctx context.Context, userID, webhookID uuid.UUID) error {
+	log := s.log(ctx)
+
+	webhook, err := s.get(ctx, userID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.webhookRepository.Delete(ctx, webhook.ID); err != nil {
+		if !errors.Is(err, apperr.ErrWebhookNotFound) {
+			log.Error("failed to delete webhook", zap.Error(err))
+		}
+
+		return err
+	}
+
+	recordAudit(ctx, s.auditRepository, log,
+		newAuditEntry(ctx, &userID, "webhook.deleted", entity.AuditEntityWebhook, webhook.ID, webhook, nil))
+
+	log.Info("webhook deleted", zap.String("user_id", userID.String()), zap.String("webhook_id", webhook.ID.String()))
+
+	return nil
+}
+
+// Ping sends a ping event to the endpoint right away, disabled or not, and
+// returns the delivery. It is recorded in the delivery log but neither
+// retried nor counted towards disabling the endpoint.
+func (s *WebhookService) Ping(ctx context.Context, userID, webhookID uuid.UUID) (*entity.WebhookDelivery, error) {
+	log := s.log(ctx)
+
+	webhook, err := s.get(ctx, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := entity.NewEvent(entity.WebhookEventPing, webhook.ID, entity.WebhookPing{WebhookID: webhook.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	event.CreatedAt = time.Now().UTC()
+
+	delivery, err := newWebhookDelivery(webhook, event)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Attempts = 1
+
+	status, sendErr := s.send(ctx, webhook, delivery)
+
+	now := time.Now().UTC()
+	delivery.CreatedAt = now
+	delivery.CompletedAt = &now
+	delivery.ResponseStatus = responseStatus(status)
+	delivery.Status = entity.WebhookDeliverySucceeded
+
+	if sendErr != nil {
+		lastError := webhookError(sendErr)
+		delivery.Status = entity.WebhookDeliveryFailed
+		delivery.LastError = &lastError
+	}
+
+	if err := s.deliveryRepository.Create(ctx, *delivery); err != nil {
+		log.Error("failed to record webhook ping", zap.Error(err))
+
+		return nil, err
+	}
+
+	log.Info("webhook pinged",
+		zap.String("webhook_id", webhook.ID.String()),
+		zap.String("status", string(delivery.Status)))
+
+	return delivery, nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, userID, webhookID uuid.UUID, req *dto.WebhookDeliveriesRequest) (*dto.WebhookDeliveriesResponse, error) {
+	webhook, err := s.get(ctx, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := listLimit(req.Limit)
+
+	deliveries, err := s.deliveryRepository.ListByWebhook(ctx, webhook.ID, limit, req.Offset)
+	if err != nil {
+		s.log(ctx).Error("failed to list webhook deliveries", zap.Error(err))
+
+		return nil, err
+	}
+
+	return &dto.WebhookDeliveriesResponse{
+		Deliveries: deliveries,
+		Limit:      limit,
+		Offset:     req.Offset,
+	}, nil
+}
+
+// Run sends pending deliveries every WEBHOOK_POLL_INTERVAL until ctx is done.
+// A full batch is followed by the next one right away.
+func (s *WebhookService) Run(ctx context.Context) error {
+	log := s.log(ctx)
+
+	ticker := time.NewTicker(s.config.Webhook.PollInterval)
+	defer ticker.Stop()
+
+	var pruned time.Time
+
+	for {
+		delivered, err := s.Deliver(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error("failed to deliver webhooks", zap.Error(err))
+		}
+
+		if time.Since(pruned) >= webhookPruneInterval {
+			s.prune(ctx)
+			pruned = time.Now()
+		}
+
+		if err == nil && delivered == int(s.config.Webhook.BatchSize) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Deliver sends one batch of pending deliveries concurrently and returns its
+// size.
+func (s *WebhookService) Deliver(ctx context.Context) (int, error) {
+	deliveries, err := s.deliveryRepository.Claim(ctx, s.config.Webhook.BatchSize, s.config.Webhook.Lease)
+	if err != nil {
+		s.log(ctx).Error("failed to claim webhook deliveries", zap.Error(err))
+
+		return 0, err
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for _, delivery := range deliveries {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if err := s.deliver(ctx, &delivery); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return len(deliveries), errors.Join(errs...)
+}
+
+// deliver sends delivery and records the outcome. Only failures to record it
+// are returned; the delivery stays claimed until its lease expires and is
+// then retried.
+func (s *WebhookService) deliver(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	log := s.log(ctx).With(
+		zap.String("delivery_id", delivery.ID.String()),
+		zap.String("webhook_id", delivery.WebhookID.String()),
+		zap.String("event_type", string(delivery.EventType)),
+		zap.Int("attempt", delivery.Attempts))
+
+	webhook, err := s.webhookRepository.GetByID(ctx, delivery.WebhookID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrWebhookNotFound) {
+			// Deleted since the claim, along with the delivery.
+			return nil
+		}
+
+		log.Error("failed to get webhook", zap.Error(err))
+
+		return err
+	}
+
+	status, sendErr := s.send(ctx, webhook, delivery)
+
+	if sendErr != nil && ctx.Err() != nil {
+		// Shutting down; not the endpoint's fault.
+		return ctx.Err()
+	}
+
+	if sendErr == nil {
+		if err := s.webhookRepository.RecordSuccess(ctx, webhook.ID); err != nil {
+			log.Error("failed to reset webhook failures", zap.Error(err))
+		}
+
+		if err := s.deliveryRepository.Complete(ctx, delivery.ID, entity.WebhookDeliverySucceeded, responseStatus(status), nil); err != nil {
+			log.Error("failed to record webhook delivery", zap.Error(err))
+
+			return err
+		}
+
+		log.Debug("webhook delivered")
+
+		return nil
+	}
+
+	lastError := webhookError(sendErr)
+
+	disabled, err := s.webhookRepository.RecordFailure(ctx, webhook.ID, s.config.Webhook.DisableAfter)
+	if err != nil {
+		log.Error("failed to count webhook failure", zap.Error(err))
+	}
+
+	if disabled {
+		log.Warn("webhook disabled after repeated failures", zap.Int("failures", s.config.Webhook.DisableAfter))
+
+		recordAudit(ctx, s.auditRepository, log,
+			newAuditEntry(ctx, nil, "webhook.disabled", entity.AuditEntityWebhook, webhook.ID, nil, nil))
+	}
+
+	if delivery.Attempts >= s.config.Webhook.MaxAttempts {
+		log.Warn("webhook delivery failed", zap.Error(sendErr))
+
+		if err := s.deliveryRepository.Complete(ctx, delivery.ID, entity.WebhookDeliveryFailed, responseStatus(status), &lastError); err != nil {
+			log.Error("failed to record webhook delivery", zap.Error(err))
+
+			return err
+		}
+
+		return nil
+	}
+
+	delay := exponentialBackoff(s.config.Webhook.RetryBackoff, s.config.Webhook.MaxBackoff, delivery.Attempts)
+
+	log.Warn("webhook delivery failed", zap.Duration("retry_in", delay), zap.Error(sendErr))
+
+	if err := s.deliveryRepository.Retry(ctx, delivery.ID, delay, responseStatus(status), lastError); err != nil {
+		log.Error("failed to schedule webhook retry", zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *WebhookService) send(ctx context.Context, hook *entity.Webhook, delivery *entity.WebhookDelivery) (int, error) {
+	box, err := s.box()
+	if err != nil {
+		return 0, err
+	}
+
+	secret, err := box.Open(hook.Secret)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.sender.Send(ctx, webhook.Request{
+		URL:    hook.URL,
+		Secret: secret,
+		ID:     delivery.ID.String(),
+		Event:  string(delivery.EventType),
+		Body:   delivery.Payload,
+	})
+}
+
+func (s *WebhookService) prune(ctx context.Context) {
+	log := s.log(ctx)
+
+	deleted, err := s.deliveryRepository.Prune(ctx, time.Now().Add(-s.config.Webhook.Retention))
+	if err != nil {
+		log.Warn("failed to prune webhook deliveries", zap.Error(err))
+
+		return
+	}
+
+	if deleted > 0 {
+		log.Info("pruned webhook deliveries", zap.Int64("deleted", deleted))
+	}
+}
+
+func (s *WebhookService) subscribe(events *EventService) {
+	for _, eventType := range entity.WebhookEventTypes {
+		events.Subscribe(eventType, "webhooks", s.onEvent)
+	}
+}
+
+// onEvent queues a delivery of event to the subscribed endpoints of the users
+// it concerns. Deliveries are unique per endpoint and event, so an event
+// handed over again is not sent twice.
+func (s *WebhookService) onEvent(ctx context.Context, event entity.Event) error {
+	userIDs, err := s.recipients(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := s.webhookRepository.ListSubscribed(ctx, userIDs, event.Type)
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]entity.WebhookDelivery, 0, len(webhooks))
+
+	for i := range webhooks {
+		delivery, err := newWebhookDelivery(&webhooks[i], event)
+		if err != nil {
+			return err
+		}
+
+		deliveries = append(deliveries, *delivery)
+	}
+
+	return s.deliveryRepository.Create(ctx, deliveries...)
+}
+
+// recipients returns the users whose teams event is about.
+func (s *WebhookService) recipients(ctx context.Context, event entity.Event) ([]uuid.UUID, error) {
+	switch event.Type {
+	case entity.EventPlayerListed:
+		var listed entity.PlayerListed
+		if err := event.Decode(&listed); err != nil {
+			return nil, err
+		}
+
+		return s.teamOwners(ctx, listed.SellerID)
+	case entity.EventPlayerSold:
+		var sold entity.PlayerSold
+		if err := event.Decode(&sold); err != nil {
+			return nil, err
+		}
+
+		return s.teamOwners(ctx, sold.SellerID, sold.BuyerID)
+	case entity.EventTeamUpdated:
+		var updated entity.TeamUpdated
+		if err := event.Decode(&updated); err != nil {
+			return nil, err
+		}
+
+		return []uuid.UUID{updated.UserID}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// teamOwners returns the distinct owners of teamIDs, skipping teams that no
+// longer exist.
+func (s *WebhookService) teamOwners(ctx context.Context, teamIDs ...uuid.UUID) ([]uuid.UUID, error) {
+	owners := make([]uuid.UUID, 0, len(teamIDs))
+
+	for _, teamID := range teamIDs {
+		if teamID == uuid.Nil {
+			continue
+		}
+
+		team, err := s.teamRepository.GetByID(ctx, teamID)
+		if err != nil {
+			if errors.Is(err, apperr.ErrTeamNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		if !slices.Contains(owners, team.UserID) {
+			owners = append(owners, team.UserID)
+		}
+	}
+
+	return owners, nil
+}
+
+// get returns the user's webhook; other users' webhooks are not found.
+func (s *WebhookService) get(ctx context.Context, userID, webhookID uuid.UUID) (*entity.Webhook, error) {
+	webhook, err := s.webhookRepository.GetByID(ctx, webhookID)
+	if err != nil {
+		if !errors.Is(err, apperr.ErrWebhookNotFound) {
+			s.log(ctx).Error("failed to get webhook", zap.Error(err))
+		}
+
+		return nil, err
+	}
+
+	if webhook.UserID != userID {
+		return nil, apperr.ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) box() (*secretbox.Box, error) {
+	key, err := s.config.Webhook.Key()
+	if err != nil {
+		return nil, err
+	}
+
+	return secretbox.New(key)
+}
+
+func (s *WebhookService) sealSecret(secret string) (string, error) {
+	box, err := s.box()
+	if err != nil {
+		return "", err
+	}
+
+	return box.Seal(secret)
+}
+
+// newWebhookDelivery returns a pending delivery of event to hook.
+func newWebhookDelivery(hook *entity.Webhook, event entity.Event) (*entity.WebhookDelivery, error) {
+	payload, err := json.Marshal(entity.WebhookPayload{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt,
+		Data:      event.Payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.WebhookDelivery{
+		ID:        uuid.New(),
+		WebhookID: hook.ID,
+		EventID:   event.ID,
+		EventType: event.Type,
+		Payload:   payload,
+		Status:    entity.WebhookDeliveryPending,
+	}, nil
+}
+
+// webhookURL checks that raw is an absolute http or https URL.
+func webhookURL(raw string) (string, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", apperr.ErrInvalidWebhookURL
+	}
+
+	return parsed.String(), nil
+}
+
+// normalizeWebhookEvents rejects event types webhooks cannot subscribe to and
+// drops duplicates.
+func normalizeWebhookEvents(requested []entity.EventType) ([]entity.EventType, error) {
+	eventTypes := make([]entity.EventType, 0, len(requested))
+
+	for _, eventType := range requested {
+		if !slices.Contains(entity.WebhookEventTypes, eventType) {
+			return nil, apperr.ErrInvalidWebhookEvent
+		}
+
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	return eventTypes, nil
+}
+
+func responseStatus(status int) *int {
+	if status == 0 {
+		return nil
+	}
+
+	return &status
+}
+
+func webhookError(err error) string {
+	message := err.Error()
+
+	if len(message) > maxWebhookErrorLength {
+		return strings.ToValidUTF8(message[:maxWebhookErrorLength], "")
+	}
+
+	return message
+}
@@ -0,0 +1,611 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"soccer_manager_service/internal/config"
+	"soccer_manager_service/internal/dto"
+	"soccer_manager_service/internal/entity"
+	apperr "soccer_manager_service/pkg/errors"
+	"soccer_manager_service/pkg/secretbox"
+	"soccer_manager_service/pkg/webhook"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) Create(ctx context.Context, webhook entity.Webhook) (*entity.Webhook, error) {
+	args := m.Called(ctx, webhook)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Webhook, error) {
+	args := m.Called(ctx, id)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]entity.Webhook, error) {
+	args := m.Called(ctx, userID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ListSubscribed(ctx context.Context, userIDs []uuid.UUID, eventType entity.EventType) ([]entity.Webhook, error) {
+	args := m.Called(ctx, userIDs, eventType)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Update(ctx context.Context, webhook entity.Webhook) (*entity.Webhook, error) {
+	args := m.Called(ctx, webhook)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) RecordSuccess(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) RecordFailure(ctx context.Context, id uuid.UUID, disableAfter int) (bool, error) {
+	args := m.Called(ctx, id, disableAfter)
+
+	return args.Bool(0), args.Error(1)
+}
+
+type MockWebhookDeliveryRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookDeliveryRepository) Create(ctx context.Context, deliveries ...entity.WebhookDelivery) error {
+	args := m.Called(ctx, deliveries)
+
+	return args.Error(0)
+}
+
+func (m *MockWebhookDeliveryRepository) Claim(ctx context.Context, limit uint, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	args := m.Called(ctx, limit, lease)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) Complete(ctx context.Context, id uuid.UUID, status entity.WebhookDeliveryStatus, responseStatus *int, lastError *string) error {
+	args := m.Called(ctx, id, status, responseStatus, lastError)
+
+	return args.Error(0)
+}
+
+func (m *MockWebhookDeliveryRepository) Retry(ctx context.Context, id uuid.UUID, delay time.Duration, responseStatus *int, lastError string) error {
+	args := m.Called(ctx, id, delay, responseStatus, lastError)
+
+	return args.Error(0)
+}
+
+func (m *MockWebhookDeliveryRepository) ListByWebhook(ctx context.Context, webhookID uuid.UUID, limit, offset uint) ([]entity.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID, limit, offset)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) Prune(ctx context.Context, completedBefore time.Time) (int64, error) {
+	args := m.Called(ctx, completedBefore)
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func (m *MockWebhookSender) Send(ctx context.Context, r webhook.Request) (int, error) {
+	args := m.Called(ctx, r)
+
+	return args.Int(0), args.Error(1)
+}
+
+var testWebhookKey = make([]byte, 32)
+
+func newTestWebhookConfig() *config.Config {
+	return &config.Config{Webhook: config.WebhookConfig{
+		EncryptionKey: base64.StdEncoding.EncodeToString(testWebhookKey),
+		MaxPerUser:    2,
+		Timeout:       time.Second,
+		PollInterval:  time.Second,
+		BatchSize:     10,
+		Lease:         time.Minute,
+		MaxAttempts:   3,
+		RetryBackoff:  time.Second,
+		MaxBackoff:    time.Minute,
+		DisableAfter:  5,
+		Retention:     24 * time.Hour,
+	}}
+}
+
+// newTestWebhook returns an endpoint of userID with its secret sealed under
+// testWebhookKey.
+func newTestWebhook(t *testing.T, userID uuid.UUID, secret string) *entity.Webhook {
+	t.Helper()
+
+	box, err := secretbox.New(testWebhookKey)
+	assert.NoError(t, err)
+
+	sealed, err := box.Seal(secret)
+	assert.NoError(t, err)
+
+	return &entity.Webhook{
+		ID:         uuid.New(),
+		UserID:     userID,
+		URL:        "https://example.com/hooks",
+		Secret:     sealed,
+		EventTypes: []entity.EventType{entity.EventPlayerSold},
+	}
+}
+
+func TestWebhookService_Create(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+	cfg := newTestWebhookConfig()
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockWebhookRepo := new(MockWebhookRepository)
+		mockWebhookRepo.On("ListByUser", ctx, userID).Return([]entity.Webhook{}, nil)
+		mockWebhookRepo.On("Create", ctx, mock.MatchedBy(func(w entity.Webhook) bool {
+			box, _ := secretbox.New(testWebhookKey)
+			secret, err := box.Open(w.Secret)
+
+			return err == nil && secret == "a-very-long-secret" &&
+				w.UserID == userID &&
+				w.URL == "https://example.com/hooks" &&
+				assert.ObjectsAreEqual([]entity.EventType{entity.EventPlayerSold, entity.EventTeamUpdated}, w.EventTypes)
+		})).Return(&entity.Webhook{ID: uuid.New(), UserID: userID}, nil)
+
+		service := NewWebhookService(WebhookServiceParams{
+			WebhookRepository: mockWebhookRepo,
+			AuditRepository:   newMockAuditRepository(),
+			Logger:            logger,
+			Config:            cfg,
+		})
+
+		webhook, err := service.Create(ctx, userID, &dto.CreateWebhookRequest{
+			URL:    "https://example.com/hooks",
+			Secret: "a-very-long-secret",
+			EventTypes: []entity.EventType{
+				entity.EventPlayerSold, entity.EventTeamUpdated, entity.EventPlayerSold,
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, webhook)
+		mockWebhookRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid URL", func(t *testing.T) {
+		service := NewWebhookService(WebhookServiceParams{Logger: logger, Config: cfg})
+
+		_, err := service.Create(ctx, userID, &dto.CreateWebhookRequest{
+			URL:        "ftp://example.com/hooks",
+			Secret:     "a-very-long-secret",
+			EventTypes: []entity.EventType{entity.EventPlayerSold},
+		})
+
+		assert.ErrorIs(t, err, apperr.ErrInvalidWebhookURL)
+	})
+
+	t.Run("unknown event type", func(t *testing.T) {
+		service := NewWebhookService(WebhookServiceParams{Logger: logger, Config: cfg})
+
+		_, err := service.Create(ctx, userID, &dto.CreateWebhookRequest{
+			URL:        "https://example.com/hooks",
+			Secret:     "a-very-long-secret",
+			EventTypes: []entity.EventType{entity.EventTeamRenamed},
+		})
+
+		assert.ErrorIs(t, err, apperr.ErrInvalidWebhookEvent)
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		mockWebhookRepo := new(MockWebhookRepository)
+		mockWebhookRepo.On("ListByUser", ctx, userID).Return([]entity.Webhook{{}, {}}, nil)
+
+		service := NewWebhookService(WebhookServiceParams{
+			WebhookRepository: mockWebhookRepo,
+			Logger:            logger,
+			Config:            cfg,
+		})
+
+		_, err := service.Create(ctx, userID, &dto.CreateWebhookRequest{
+			URL:        "https://example.com/hooks",
+			Secret:     "a-very-long-secret",
+			EventTypes: []entity.EventType{entity.EventPlayerSold},
+		})
+
+		assert.ErrorIs(t, err, apperr.ErrWebhookLimitReached)
+		mockWebhookRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookService_Get(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("another user's webhook is not found", func(t *testing.T) {
+		hook := newTestWebhook(t, uuid.New(), "a-very-long-secret")
+
+		mockWebhookRepo := new(MockWebhookRepository)
+		mockWebhookRepo.On("GetByID", ctx, hook.ID).Return(hook, nil)
+
+		service := NewWebhookService(WebhookServiceParams{
+			WebhookRepository: mockWebhookRepo,
+			Logger:            zap.NewNop(),
+			Config:            newTestWebhookConfig(),
+		})
+
+		_, err := service.Get(ctx, uuid.New(), hook.ID)
+
+		assert.ErrorIs(t, err, apperr.ErrWebhookNotFound)
+	})
+}
+
+func TestWebhookService_Update(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("enabling resets failures", func(t *testing.T) {
+		disabledAt := time.Now()
+
+		hook := newTestWebhook(t, userID, "a-very-long-secret")
+		hook.Failures = 5
+		hook.DisabledAt = &disabledAt
+
+		mockWebhookRepo := new(MockWebhookRepository)
+		mockWebhookRepo.On("GetByID", ctx, hook.ID).Return(hook, nil)
+		mockWebhookRepo.On("Update", ctx, mock.MatchedBy(func(w entity.Webhook) bool {
+			return w.Enabled() && w.Failures == 0
+		})).Return(hook, nil)
+
+		service := NewWebhookService(WebhookServiceParams{
+			WebhookRepository: mockWebhookRepo,
+			AuditRepository:   newMockAuditRepository(),
+			Logger:            zap.NewNop(),
+			Config:            newTestWebhookConfig(),
+		})
+
+		enabled := true
+
+		_, err := service.Update(ctx, userID, hook.ID, &dto.UpdateWebhookRequest{Enabled: &enabled})
+
+		assert.NoError(t, err)
+		mockWebhookRepo.AssertExpectations(t)
+	})
+}
+
+func TestWebhookService_onEvent(t *testing.T) {
+	ctx := context.Background()
+
+	sellerTeamID := uuid.New()
+	buyerTeamID := uuid.New()
+	sellerID := uuid.New()
+	buyerID := uuid.New()
+
+	event := newTestEvent(t, entity.EventPlayerSold, entity.PlayerSold{
+		TransferID: uuid.New(),
+		SellerID:   sellerTeamID,
+		BuyerID:    buyerTeamID,
+		Price:      1000000,
+	})
+
+	t.Run("queues deliveries for the owners of both teams", func(t *testing.T) {
+		sellerHook := newTestWebhook(t, sellerID, "a-very-long-secret")
+		buyerHook := newTestWebhook(t, buyerID, "a-very-long-secret")
+
+		mockTeamRepo := new(MockTeamRepository)
+		mockTeamRepo.On("GetByID", ctx, sellerTeamID).Return(&entity.Team{ID: sellerTeamID, UserID: sellerID}, nil)
+		mockTeamRepo.On("GetByID", ctx, buyerTeamID).Return(&entity.Team{ID: buyerTeamID, UserID: buyerID}, nil)
+
+		mockWebhookRepo := new(MockWebhookRepository)
+		mockWebhookRepo.On("ListSubscribed", ctx, []uuid.UUID{sellerID, buyerID}, entity.EventPlayerSold).
+			Return([]entity.Webhook{*sellerHook, *buyerHook}, nil)
+
+		mockDeliveryRepo := new(MockWebhookDeliveryRepository)
+		mockDeliveryRepo.On("Create", ctx, mock.MatchedBy(func(deliveries []entity.WebhookDelivery) bool {
+			if len(deliveries) != 2 {
+				return false
+			}
+
+			var payload entity.WebhookPayload
+			if err := json.Unmarshal(deliveries[0].Payload, &payload); err != nil {
+				return false
+			}
+
+			return deliveries[0].WebhookID == sellerHook.ID &&
+				deliveries[1].WebhookID == buyerHook.ID &&
+				deliveries[0].EventID == event.ID &&
+				deliveries[0].Status == entity.WebhookDeliveryPending &&
+				payload.ID == event.ID &&
+				payload.Type == entity.EventPlayerSold
+		})).Return(nil)
+
+		service := NewWebhookService(WebhookServiceParams{
+			WebhookRepository:  mockWebhookRepo,
+			DeliveryRepository: mockDeliveryRepo,
+			TeamRepository:     mockTeamRepo,
+			Logger:             zap.NewNop(),
+			Config:             newTestWebhookConfig(),
+		})
+
+		err := service.onEvent(ctx, event)
+
+		assert.NoError(t, err)
+		mockDeliveryRepo.AssertExpectations(t)
+	})
+
+	t.Run("skips deleted teams", func(t *testing.T) {
+		mockTeamRepo := new(MockTeamRepository)
+		mockTeamRepo.On("GetByID", ctx, sellerTeamID).Return(nil, apperr.ErrTeamNotFound)
+		mockTeamRepo.On("GetByID", ctx, buyerTeamID).Return(&entity.Team{ID: buyerTeamID, UserID: buyerID}, nil)
+
+		mockWebhookRepo := new(MockWebhookRepository)
+		mockWebhookRepo.On("ListSubscribed", ctx, []uuid.UUID{buyerID}, entity.EventPlayerSold).
+			Return([]entity.Webhook{}, nil)
+
+		mockDeliveryRepo := new(MockWebhookDeliveryRepository)
+		mockDeliveryRepo.On("Create", ctx, []entity.WebhookDelivery{}).Return(nil)
+
+		service := NewWebhookService(WebhookServiceParams{
+			WebhookRepository:  mockWebhookRepo,
+			DeliveryRepository: mockDeliveryRepo,
+			TeamRepository:     mockTeamRepo,
+			Logger:             zap.NewNop(),
+			Config:             newTestWebhookConfig(),
+		})
+
+		err := service.onEvent(ctx, event)
+
+		assert.NoError(t, err)
+		mockWebhookRepo.AssertExpectations(t)
+	})
+}
+
+func TestWebhookService_deliver(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+	cfg := newTestWebhookConfig()
+	userID := uuid.New()
+
+	newDelivery := func(hook *entity.Webhook, attempts int) *entity.WebhookDelivery {
+		return &entity.WebhookDelivery{
+			ID:        uuid.New(),
+			WebhookID: hook.ID,
+			EventID:   uuid.New(),
+			EventType: entity.EventPlayerSold,
+			Payload:   json.RawMessage(`{"type":"player.sold"}`),
+			Status:    entity.WebhookDeliveryPending,
+			Attempts:  attempts,
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		hook := newTestWebhook(t, userID, "a-very-long-secret")
+		delivery := newDelivery(hook, 1)
+		status := 200
+
+		mockWebhookRepo := new(MockWebhookRepository)
+		mockWebhookRepo.On("GetByID", ctx, hook.ID).Return(hook, nil)
+		mockWebhookRepo.On("RecordSuccess", ctx, hook.ID).Return(nil)
+
+		mockDeliveryRepo := new(MockWebhookDeliveryRepository)
+		mockDeliveryRepo.On("Complete", ctx, delivery.ID, entity.WebhookDeliverySucceeded, &status, (*string)(nil)).Return(nil)
+
+		mockSender := new(MockWebhookSender)
+		mockSender.On("Send", ctx, webhook.Request{
+			URL:    hook.URL,
+			Secret: "a-very-long-secret",
+			ID:     delivery.ID.String(),
+			Event:  string(entity.EventPlayerSold),
+			Body:   delivery.Payload,
+		}).Return(200, nil)
+
+		service := NewWebhookService(WebhookServiceParams{
+			WebhookRepository:  mockWebhookRepo,
+			DeliveryRepository: mockDeliveryRepo,
+			Sender:             mockSender,
+			Logger:             logger,
+			Config:             cfg,
+		})
+
+		err := service.deliver(ctx, delivery)
+
+		assert.NoError(t, err)
+		mockWebhookRepo.AssertExpectations(t)
+		mockDeliveryRepo.AssertExpectations(t)
+		mockSender.AssertExpectations(t)
+	})
+
+	t.Run("retries failures with backoff", func(t *testing.T) {
+		hook := newTestWebhook(t, userID, "a-very-long-secret")
+		delivery := newDelivery(hook, 2)
+		status := 503
+
+		mockWebhookRepo := new(MockWebhookRepository)
+		mockWebhookRepo.On("GetByID", ctx, hook.ID).Return(hook, nil)
+		mockWebhookRepo.On("RecordFailure", ctx, hook.ID, 5).Return(false, nil)
+
+		mockDeliveryRepo := new(MockWebhookDeliveryRepository)
+		mockDeliveryRepo.On("Retry", ctx, delivery.ID, 2*time.Second, &status, "unexpected status 503").Return(nil)
+
+		mockSender := new(MockWebhookSender)
+		mockSender.On("Send", ctx, mock.Anything).Return(503, errors.New("unexpected status 503"))
+
+		service := NewWebhookService(WebhookServiceParams{
+			WebhookRepository:  mockWebhookRepo,
+			DeliveryRepository: mockDeliveryRepo,
+			Sender:             mockSender,
+			Logger:             logger,
+			Config:             cfg,
+		})
+
+		err := service.deliver(ctx, delivery)
+
+		assert.NoError(t, err)
+		mockDeliveryRepo.AssertExpectations(t)
+		mockDeliveryRepo.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails after the last attempt", func(t *testing.T) {
+		hook := newTestWebhook(t, userID, "a-very-long-secret")
+		delivery := newDelivery(hook, 3)
+		lastError := "connection refused"
+
+		mockWebhookRepo := new(MockWebhookRepository)
+		mockWebhookRepo.On("GetByID", ctx, hook.ID).Return(hook, nil)
+		mockWebhookRepo.On("RecordFailure", ctx, hook.ID, 5).Return(false, nil)
+
+		mockDeliveryRepo := new(MockWebhookDeliveryRepository)
+		mockDeliveryRepo.On("Complete", ctx, delivery.ID, entity.WebhookDeliveryFailed, (*int)(nil), &lastError).Return(nil)
+
+		mockSender := new(MockWebhookSender)
+		mockSender.On("Send", ctx, mock.Anything).Return(0, errors.New(lastError))
+
+		service := NewWebhookService(WebhookServiceParams{
+			WebhookRepository:  mockWebhookRepo,
+			DeliveryRepository: mockDeliveryRepo,
+			Sender:             mockSender,
+			Logger:             logger,
+			Config:             cfg,
+		})
+
+		err := service.deliver(ctx, delivery)
+
+		assert.NoError(t, err)
+		mockDeliveryRepo.AssertExpectations(t)
+		mockDeliveryRepo.AssertNotCalled(t, "Retry", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("audits disabling the endpoint", func(t *testing.T) {
+		hook := newTestWebhook(t, userID, "a-very-long-secret")
+		delivery := newDelivery(hook, 1)
+
+		mockWebhookRepo := new(MockWebhookRepository)
+		mockWebhookRepo.On("GetByID", ctx, hook.ID).Return(hook, nil)
+		mockWebhookRepo.On("RecordFailure", ctx, hook.ID, 5).Return(true, nil)
+
+		mockDeliveryRepo := new(MockWebhookDeliveryRepository)
+		mockDeliveryRepo.On("Retry", ctx, delivery.ID, time.Second, (*int)(nil), "timeout").Return(nil)
+
+		mockSender := new(MockWebhookSender)
+		mockSender.On("Send", ctx, mock.Anything).Return(0, errors.New("timeout"))
+
+		mockAuditRepo := new(MockAuditRepository)
+		mockAuditRepo.On("Create", ctx, mock.MatchedBy(func(entries []entity.AuditEntry) bool {
+			return len(entries) == 1 &&
+				entries[0].Action == "webhook.disabled" &&
+				entries[0].ActorID == nil &&
+				*entries[0].EntityID == hook.ID
+		})).Return(nil)
+
+		service := NewWebhookService(WebhookServiceParams{
+			WebhookRepository:  mockWebhookRepo,
+			DeliveryRepository: mockDeliveryRepo,
+			AuditRepository:    mockAuditRepo,
+			Sender:             mockSender,
+			Logger:             logger,
+			Config:             cfg,
+		})
+
+		err := service.deliver(ctx, delivery)
+
+		assert.NoError(t, err)
+		mockAuditRepo.AssertExpectations(t)
+		mockDeliveryRepo.AssertExpectations(t)
+	})
+}
+
+func TestWebhookService_Ping(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("records a failed ping without counting it", func(t *testing.T) {
+		hook := newTestWebhook(t, userID, "a-very-long-secret")
+
+		mockWebhookRepo := new(MockWebhookRepository)
+		mockWebhookRepo.On("GetByID", ctx, hook.ID).Return(hook, nil)
+
+		mockDeliveryRepo := new(MockWebhookDeliveryRepository)
+		mockDeliveryRepo.On("Create", ctx, mock.MatchedBy(func(deliveries []entity.WebhookDelivery) bool {
+			return len(deliveries) == 1 &&
+				deliveries[0].EventType == entity.WebhookEventPing &&
+				deliveries[0].Status == entity.WebhookDeliveryFailed &&
+				deliveries[0].CompletedAt != nil
+		})).Return(nil)
+
+		mockSender := new(MockWebhookSender)
+		mockSender.On("Send", ctx, mock.MatchedBy(func(r webhook.Request) bool {
+			return r.Event == string(entity.WebhookEventPing) && r.Secret == "a-very-long-secret"
+		})).Return(404, errors.New("unexpected status 404"))
+
+		service := NewWebhookService(WebhookServiceParams{
+			WebhookRepository:  mockWebhookRepo,
+			DeliveryRepository: mockDeliveryRepo,
+			Sender:             mockSender,
+			Logger:             zap.NewNop(),
+			Config:             newTestWebhookConfig(),
+		})
+
+		delivery, err := service.Ping(ctx, userID, hook.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.WebhookDeliveryFailed, delivery.Status)
+		assert.Equal(t, 404, *delivery.ResponseStatus)
+		assert.Equal(t, "unexpected status 404", *delivery.LastError)
+		mockDeliveryRepo.AssertExpectations(t)
+		mockWebhookRepo.AssertNotCalled(t, "RecordFailure", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'manager' CHECK (role IN ('manager', 'moderator', 'admin')),
+    ADD COLUMN banned_at TIMESTAMP,
+    ADD COLUMN ban_reason VARCHAR(255);
+
+CREATE INDEX idx_users_role ON users(role);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_users_role;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS ban_reason,
+    DROP COLUMN IF EXISTS banned_at,
+    DROP COLUMN IF EXISTS role;
@@ -0,0 +1,54 @@
+-- +goose Up
+CREATE TABLE ledger_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaction_id UUID NOT NULL,
+    kind VARCHAR(50) NOT NULL,
+    account VARCHAR(100) NOT NULL,
+    team_id UUID,
+    amount BIGINT NOT NULL CHECK (amount <> 0),
+    reference_id UUID,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK ((account = 'team') = (team_id IS NOT NULL))
+);
+
+CREATE INDEX idx_ledger_entries_team_id ON ledger_entries(team_id, created_at, id);
+CREATE INDEX idx_ledger_entries_transaction_id ON ledger_entries(transaction_id);
+
+-- +goose StatementBegin
+CREATE FUNCTION ledger_transaction_balanced() RETURNS trigger AS $$
+BEGIN
+    IF (SELECT SUM(amount) FROM ledger_entries WHERE transaction_id = NEW.transaction_id) <> 0 THEN
+        RAISE EXCEPTION 'ledger transaction % is not balanced', NEW.transaction_id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE CONSTRAINT TRIGGER ledger_transaction_balanced
+    AFTER INSERT ON ledger_entries
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION ledger_transaction_balanced();
+
+-- Existing budgets become opening balances so that every team's budget equals
+-- the sum of its ledger entries from the start.
+WITH opening AS (
+    SELECT id AS team_id, budget, gen_random_uuid() AS transaction_id
+    FROM teams
+    WHERE budget <> 0
+)
+INSERT INTO ledger_entries (transaction_id, kind, account, team_id, amount, description)
+SELECT transaction_id, 'opening_balance', 'team', team_id, budget, 'Opening balance' FROM opening
+UNION ALL
+SELECT transaction_id, 'opening_balance', 'system:opening_balance', NULL, -budget, 'Opening balance' FROM opening;
+
+ALTER TABLE teams ALTER COLUMN budget SET DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE teams ALTER COLUMN budget SET DEFAULT 5000000;
+
+DROP TRIGGER IF EXISTS ledger_transaction_balanced ON ledger_entries;
+DROP FUNCTION IF EXISTS ledger_transaction_balanced();
+DROP TABLE IF EXISTS ledger_entries;
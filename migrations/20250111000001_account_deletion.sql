@@ -0,0 +1,24 @@
+-- +goose Up
+-- Completed and cancelled transfers outlive the player and the selling team,
+-- so that deleting an account does not erase the counterparty's history.
+-- Active transfers must be cancelled before their team or player is deleted.
+ALTER TABLE transfers
+    ALTER COLUMN player_id DROP NOT NULL,
+    ALTER COLUMN seller_id DROP NOT NULL,
+    DROP CONSTRAINT transfers_player_id_fkey,
+    DROP CONSTRAINT transfers_seller_id_fkey,
+    ADD CONSTRAINT transfers_player_id_fkey FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE SET NULL,
+    ADD CONSTRAINT transfers_seller_id_fkey FOREIGN KEY (seller_id) REFERENCES teams(id) ON DELETE SET NULL,
+    ADD CONSTRAINT transfers_active_references CHECK (status <> 'active' OR (player_id IS NOT NULL AND seller_id IS NOT NULL));
+
+-- +goose Down
+DELETE FROM transfers WHERE player_id IS NULL OR seller_id IS NULL;
+
+ALTER TABLE transfers
+    DROP CONSTRAINT transfers_active_references,
+    DROP CONSTRAINT transfers_player_id_fkey,
+    DROP CONSTRAINT transfers_seller_id_fkey,
+    ADD CONSTRAINT transfers_player_id_fkey FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE,
+    ADD CONSTRAINT transfers_seller_id_fkey FOREIGN KEY (seller_id) REFERENCES teams(id) ON DELETE CASCADE,
+    ALTER COLUMN player_id SET NOT NULL,
+    ALTER COLUMN seller_id SET NOT NULL;
@@ -0,0 +1,31 @@
+-- +goose Up
+-- Scopes are now shared by API keys and scoped access tokens and named
+-- <resource>:<action>.
+UPDATE api_keys
+SET scopes = (
+    SELECT COALESCE(jsonb_agg(
+        CASE scope
+            WHEN 'read:team' THEN 'team:read'
+            WHEN 'write:team' THEN 'team:write'
+            WHEN 'read:market' THEN 'market:read'
+            WHEN 'write:transfers' THEN 'market:trade'
+            ELSE scope
+        END
+    ), '[]'::jsonb)
+    FROM jsonb_array_elements_text(scopes) AS scope
+);
+
+-- +goose Down
+UPDATE api_keys
+SET scopes = (
+    SELECT COALESCE(jsonb_agg(
+        CASE scope
+            WHEN 'team:read' THEN 'read:team'
+            WHEN 'team:write' THEN 'write:team'
+            WHEN 'market:read' THEN 'read:market'
+            WHEN 'market:trade' THEN 'write:transfers'
+            ELSE scope
+        END
+    ), '[]'::jsonb)
+    FROM jsonb_array_elements_text(scopes) AS scope
+);
@@ -0,0 +1,25 @@
+-- +goose Up
+-- Users can manage several teams; idx_teams_user_id still serves lookups.
+ALTER TABLE teams DROP CONSTRAINT teams_user_id_key;
+
+-- +goose Down
+-- Once a user has created a second team this migration cannot be rolled back:
+-- the extra teams own players, ledger entries and transfers that deleting them
+-- would take along. Stop with an explicit error instead of failing on the
+-- constraint, so that the extra teams can be dealt with by hand first.
+-- +goose StatementBegin
+DO $$
+DECLARE
+    users_with_teams integer;
+BEGIN
+    SELECT COUNT(*) INTO users_with_teams
+    FROM (SELECT user_id FROM teams GROUP BY user_id HAVING COUNT(*) > 1) AS owners;
+
+    IF users_with_teams > 0 THEN
+        RAISE EXCEPTION '% users have more than one team; delete the extra teams before rolling back', users_with_teams;
+    END IF;
+END;
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE teams ADD CONSTRAINT teams_user_id_key UNIQUE (user_id);
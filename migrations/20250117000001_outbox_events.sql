@@ -0,0 +1,22 @@
+-- +goose Up
+-- outbox_events holds domain events written in the transaction of the change
+-- they describe. The dispatcher claims pending events by pushing available_at
+-- past a lease, so a crashed instance's claims expire and are picked up
+-- again; published events are kept for a while and then pruned.
+CREATE TABLE outbox_events (
+    id UUID PRIMARY KEY,
+    type VARCHAR(50) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    available_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events(available_at) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_events_published_at ON outbox_events(published_at) WHERE published_at IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox_events;
@@ -3,63 +3,181 @@ package apperr
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 )
 
+// Error is an application error with everything needed to render it to API
+// clients: a stable machine-readable Code, the HTTP Status and the i18n
+// MessageID used for the localized title.
+type Error struct {
+	Code      string
+	Status    int
+	MessageID string
+	message   string
+}
+
+func New(code string, status int, message string) *Error {
+	return &Error{
+		Code:      code,
+		Status:    status,
+		MessageID: "errors." + code,
+		message:   message,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
 var (
-	ErrUserAlreadyExists     = errors.New("user already exists")
-	ErrUserNotFound          = errors.New("user not found")
-	ErrTeamAlreadyExists     = errors.New("team already exists")
-	ErrTeamNotFound          = errors.New("team not found")
-	ErrPlayerNotFound        = errors.New("player not found")
-	ErrTransferNotFound      = errors.New("transfer not found")
-	ErrInvalidCredentials    = errors.New("invalid credentials")
-	ErrTooManyAttempts       = errors.New("too many login attempts")
-	ErrInsufficientFunds     = errors.New("insufficient funds")
-	ErrPlayerAlreadyListed   = errors.New("player already listed for transfer")
-	ErrCannotBuyOwnPlayer    = errors.New("cannot buy your own player")
-	ErrTransferNotActive     = errors.New("transfer is not active")
-	ErrUnauthorized          = errors.New("unauthorized")
-	ErrForbidden             = errors.New("forbidden")
-	ErrInvalidInput          = errors.New("invalid input")
-	ErrInternal              = errors.New("internal server error")
+	ErrUserAlreadyExists          = New("user_already_exists", http.StatusConflict, "user already exists")
+	ErrUserNotFound               = New("user_not_found", http.StatusNotFound, "user not found")
+	ErrTeamAlreadyExists          = New("team_already_exists", http.StatusConflict, "team already exists")
+	ErrTeamNotFound               = New("team_not_found", http.StatusNotFound, "team not found")
+	ErrPlayerNotFound             = New("player_not_found", http.StatusNotFound, "player not found")
+	ErrTransferNotFound           = New("transfer_not_found", http.StatusNotFound, "transfer not found")
+	ErrInvalidCredentials         = New("invalid_credentials", http.StatusUnauthorized, "invalid credentials")
+	ErrTooManyAttempts            = New("too_many_attempts", http.StatusTooManyRequests, "too many login attempts")
+	ErrInsufficientFunds          = New("insufficient_funds", http.StatusBadRequest, "insufficient funds")
+	ErrPlayerAlreadyListed        = New("player_already_listed", http.StatusConflict, "player already listed for transfer")
+	ErrCannotBuyOwnPlayer         = New("cannot_buy_own_player", http.StatusBadRequest, "cannot buy your own player")
+	ErrTransferNotActive          = New("transfer_not_active", http.StatusBadRequest, "transfer is not active")
+	ErrUnauthorized               = New("unauthorized", http.StatusUnauthorized, "unauthorized")
+	ErrForbidden                  = New("forbidden", http.StatusForbidden, "forbidden")
+	ErrInvalidInput               = New("invalid_input", http.StatusBadRequest, "invalid input")
+	ErrInvalidRequest             = New("invalid_request", http.StatusBadRequest, "invalid request")
+	ErrValidationFailed           = New("validation_failed", http.StatusBadRequest, "validation failed")
+	ErrInvalidPlayerID            = New("invalid_player_id", http.StatusBadRequest, "invalid player id")
+	ErrInvalidTransferID          = New("invalid_transfer_id", http.StatusBadRequest, "invalid transfer id")
+	ErrMissingAuthorization       = New("missing_authorization", http.StatusUnauthorized, "missing authorization header")
+	ErrInvalidAuthorizationFormat = New("invalid_authorization_format", http.StatusUnauthorized, "invalid authorization header format")
+	ErrInvalidToken               = New("invalid_token", http.StatusUnauthorized, "invalid or expired token")
+	ErrAccountBanned              = New("account_banned", http.StatusForbidden, "account is banned")
+	ErrCannotModifyOwnAccount     = New("cannot_modify_own_account", http.StatusBadRequest, "cannot perform this action on your own account")
+	ErrInvalidUserID              = New("invalid_user_id", http.StatusBadRequest, "invalid user id")
+	ErrInvalidTeamID              = New("invalid_team_id", http.StatusBadRequest, "invalid team id")
+	ErrInvalidConfirmationToken   = New("invalid_confirmation_token", http.StatusBadRequest, "confirmation token is invalid or has expired")
+	ErrEmailNotVerified           = New("email_not_verified", http.StatusForbidden, "email address is not verified")
+	ErrEmailAlreadyVerified       = New("email_already_verified", http.StatusConflict, "email address is already verified")
+	ErrInvalidCurrentPassword     = New("invalid_current_password", http.StatusBadRequest, "current password is incorrect")
+	ErrEmailUnchanged             = New("email_unchanged", http.StatusBadRequest, "new email is the same as the current one")
+	ErrPasswordTooShort           = New("password_too_short", http.StatusBadRequest, "password is too short")
+	ErrPasswordTooLong            = New("password_too_long", http.StatusBadRequest, "password is too long")
+	ErrPasswordTooSimple          = New("password_too_simple", http.StatusBadRequest, "password uses too few character classes")
+	ErrPasswordContainsPersonal   = New("password_contains_personal_info", http.StatusBadRequest, "password contains the email or team name")
+	ErrPasswordTooCommon          = New("password_too_common", http.StatusBadRequest, "password is too common")
+	ErrInvalidTwoFactorCode       = New("invalid_two_factor_code", http.StatusBadRequest, "two-factor code is invalid")
+	ErrInvalidTwoFactorChallenge  = New("invalid_two_factor_challenge", http.StatusUnauthorized, "two-factor challenge is invalid or has expired")
+	ErrTwoFactorAlreadyEnabled    = New("two_factor_already_enabled", http.StatusConflict, "two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled        = New("two_factor_not_enabled", http.StatusConflict, "two-factor authentication is not enabled")
+	ErrTwoFactorNotEnrolled       = New("two_factor_not_enrolled", http.StatusConflict, "two-factor enrollment has not been started")
+	ErrUnknownIdentityProvider    = New("unknown_identity_provider", http.StatusNotFound, "identity provider is not configured")
+	ErrInvalidOIDCState           = New("invalid_oidc_state", http.StatusBadRequest, "sign-in session is invalid or has expired")
+	ErrIdentityProviderFailed     = New("identity_provider_failed", http.StatusUnauthorized, "sign-in with the identity provider failed")
+	ErrIdentityEmailRequired      = New("identity_email_required", http.StatusBadRequest, "identity provider did not share an email address")
+	ErrIdentityEmailConflict      = New("identity_email_conflict", http.StatusConflict, "an account with this email already exists")
+	ErrIdentityNotFound           = New("identity_not_found", http.StatusNotFound, "identity not found")
+	ErrIdentityAlreadyLinked      = New("identity_already_linked", http.StatusConflict, "identity is already linked to an account")
+	ErrAPIKeyNotFound             = New("api_key_not_found", http.StatusNotFound, "api key not found")
+	ErrInvalidAPIKeyID            = New("invalid_api_key_id", http.StatusBadRequest, "invalid api key id")
+	ErrInvalidAPIKey              = New("invalid_api_key", http.StatusUnauthorized, "api key is invalid or has been revoked")
+	ErrInvalidScope               = New("invalid_scope", http.StatusBadRequest, "unknown scope")
+	ErrAPIKeyLimitReached         = New("api_key_limit_reached", http.StatusConflict, "too many api keys")
+	ErrInsufficientScope          = New("insufficient_scope", http.StatusForbidden, "credentials lack the scope for this operation")
+	ErrTeamSelectionRequired      = New("team_selection_required", http.StatusBadRequest, "user has several teams, select one with the X-Team-ID header")
+	ErrTeamLimitReached           = New("team_limit_reached", http.StatusConflict, "too many teams")
+	ErrLeaderboardNotFound        = New("leaderboard_not_found", http.StatusNotFound, "unknown leaderboard")
+	ErrWebhookNotFound            = New("webhook_not_found", http.StatusNotFound, "webhook not found")
+	ErrInvalidWebhookID           = New("invalid_webhook_id", http.StatusBadRequest, "invalid webhook id")
+	ErrInvalidWebhookURL          = New("invalid_webhook_url", http.StatusBadRequest, "webhook url must be an absolute http or https url")
+	ErrInvalidWebhookEvent        = New("invalid_webhook_event", http.StatusBadRequest, "unknown webhook event type")
+	ErrWebhookLimitReached        = New("webhook_limit_reached", http.StatusConflict, "too many webhooks")
+	ErrRateLimited                = New("rate_limited", http.StatusTooManyRequests, "too many requests")
+	ErrInternal                   = New("internal_error", http.StatusInternalServerError, "internal server error")
 )
 
-var errorToMessageID = map[error]string{
-	ErrUserAlreadyExists:   "errors.user_already_exists",
-	ErrUserNotFound:        "errors.user_not_found",
-	ErrTeamAlreadyExists:   "errors.team_already_exists",
-	ErrTeamNotFound:        "errors.team_not_found",
-	ErrPlayerNotFound:      "errors.player_not_found",
-	ErrTransferNotFound:    "errors.transfer_not_found",
-	ErrInvalidCredentials:  "errors.invalid_credentials",
-	ErrTooManyAttempts:     "errors.too_many_attempts",
-	ErrInsufficientFunds:   "errors.insufficient_funds",
-	ErrPlayerAlreadyListed: "errors.player_already_listed",
-	ErrCannotBuyOwnPlayer:  "errors.cannot_buy_own_player",
-	ErrTransferNotActive:   "errors.transfer_not_active",
-	ErrUnauthorized:        "errors.unauthorized",
-	ErrForbidden:           "errors.forbidden",
-	ErrInvalidInput:        "errors.invalid_input",
-	ErrInternal:            "errors.internal_error",
+// As returns the *Error wrapped in err, falling back to ErrInternal so that
+// unexpected errors never leak their text to clients.
+func As(err error) *Error {
+	var appErr *Error
+
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	return ErrInternal
+}
+
+type dataError struct {
+	err  error
+	data map[string]any
+}
+
+func (e *dataError) Error() string {
+	return e.err.Error()
+}
+
+func (e *dataError) Unwrap() error {
+	return e.err
+}
+
+// WithData attaches template data used when localizing err, e.g.
+// {"Needed": 100} for "Insufficient funds: need {{.Needed}}". A "Count" entry
+// also selects the plural form of the message.
+func WithData(err error, data map[string]any) error {
+	return &dataError{err: err, data: data}
+}
+
+type retryAfterError struct {
+	err   error
+	after time.Duration
+}
+
+func (e *retryAfterError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryAfterError) Unwrap() error {
+	return e.err
+}
+
+// WithRetryAfter records how long the client should wait before retrying,
+// for handlers that expose it as a Retry-After header.
+func WithRetryAfter(err error, after time.Duration) error {
+	return &retryAfterError{err: err, after: after}
+}
+
+// RetryAfter returns the wait attached to err with WithRetryAfter.
+func RetryAfter(err error) (time.Duration, bool) {
+	var withRetry *retryAfterError
+	if errors.As(err, &withRetry) {
+		return withRetry.after, true
+	}
+
+	return 0, false
 }
 
 func LocalizeError(err error, localizer *i18n.Localizer) string {
-	for appErr, messageID := range errorToMessageID {
-		if errors.Is(err, appErr) {
-			msg, locErr := localizer.Localize(&i18n.LocalizeConfig{
-				MessageID: messageID,
-			})
-			if locErr != nil {
-				return err.Error()
-			}
-
-			return msg
-		}
+	appErr := As(err)
+
+	config := &i18n.LocalizeConfig{MessageID: appErr.MessageID}
+
+	var withData *dataError
+	if errors.As(err, &withData) {
+		config.TemplateData = withData.data
+		config.PluralCount = withData.data["Count"]
+	}
+
+	msg, locErr := localizer.Localize(config)
+	if locErr != nil {
+		return appErr.Error()
 	}
 
-	return err.Error()
+	return msg
 }
 
 func SQLError(op string, err error) error {
@@ -74,11 +192,34 @@ func SQLExecError(op string, err error) error {
 	return fmt.Errorf("%s: sql exec error: %w", op, err)
 }
 
+// ValidationError describes a single invalid field. Rule and Param mirror the
+// failed validation tag (e.g. "min" and "8"); MessageID, when set, is the i18n
+// message rendered for clients with Field and Param as template data.
 type ValidationError struct {
-	Field   string
-	Message string
+	Field     string
+	Rule      string
+	Param     string
+	MessageID string
+	Message   string
 }
 
 func (e *ValidationError) Error() string {
 	return fmt.Sprintf("validation error: field '%s': %s", e.Field, e.Message)
 }
+
+// ValidationErrors collects every invalid field of a request. It unwraps to
+// ErrValidationFailed so it is rendered like any other application error.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, v := range e {
+		msgs = append(msgs, v.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (e ValidationErrors) Unwrap() error {
+	return ErrValidationFailed
+}
@@ -3,6 +3,11 @@ package i18n
 import (
 	"embed"
 	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
 	"strings"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
@@ -12,23 +17,114 @@ import (
 //go:embed locales/*.json
 var localesFS embed.FS
 
+const localesDir = "locales"
+
+var defaultLanguage = language.English
+
 type Manager struct {
-	bundle *i18n.Bundle
+	bundle  *i18n.Bundle
+	matcher language.Matcher
 }
 
-func NewManager() (*Manager, error) {
-	bundle := i18n.NewBundle(language.English)
+// NewManager loads every locale file bundled under locales/ and, when
+// externalDir is set, every *.json file found there. External files may add
+// new languages or override bundled messages. All languages must end up with
+// the same set of message IDs as the default language.
+func NewManager(externalDir string) (*Manager, error) {
+	bundle := i18n.NewBundle(defaultLanguage)
 	bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
 
-	if _, err := bundle.LoadMessageFileFS(localesFS, "locales/en.json"); err != nil {
+	messageIDs := make(map[language.Tag]map[string]struct{})
+
+	if err := loadMessageFiles(bundle, localesFS, localesDir, messageIDs); err != nil {
 		return nil, err
 	}
 
-	if _, err := bundle.LoadMessageFileFS(localesFS, "locales/ka.json"); err != nil {
+	if externalDir != "" {
+		if err := loadMessageFiles(bundle, os.DirFS(externalDir), ".", messageIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validateMessageIDs(messageIDs); err != nil {
 		return nil, err
 	}
 
-	return &Manager{bundle: bundle}, nil
+	return &Manager{
+		bundle:  bundle,
+		matcher: language.NewMatcher(bundle.LanguageTags()),
+	}, nil
+}
+
+func loadMessageFiles(bundle *i18n.Bundle, fsys fs.FS, dir string, messageIDs map[language.Tag]map[string]struct{}) error {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("list locale files in %s: %w", dir, err)
+	}
+
+	for _, file := range files {
+		messageFile, err := bundle.LoadMessageFileFS(fsys, file)
+		if err != nil {
+			return fmt.Errorf("load locale file %s: %w", file, err)
+		}
+
+		ids, ok := messageIDs[messageFile.Tag]
+		if !ok {
+			ids = make(map[string]struct{})
+			messageIDs[messageFile.Tag] = ids
+		}
+
+		for _, message := range messageFile.Messages {
+			ids[message.ID] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+func validateMessageIDs(messageIDs map[language.Tag]map[string]struct{}) error {
+	reference, ok := messageIDs[defaultLanguage]
+	if !ok {
+		return fmt.Errorf("no messages for default language %s", defaultLanguage)
+	}
+
+	var problems []string
+
+	for tag, ids := range messageIDs {
+		if tag == defaultLanguage {
+			continue
+		}
+
+		if missing := difference(reference, ids); len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("%s is missing %s", tag, strings.Join(missing, ", ")))
+		}
+
+		if unknown := difference(ids, reference); len(unknown) > 0 {
+			problems = append(problems, fmt.Sprintf("%s has unknown %s", tag, strings.Join(unknown, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+
+		return fmt.Errorf("locales are inconsistent: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func difference(a, b map[string]struct{}) []string {
+	var result []string
+
+	for id := range a {
+		if _, ok := b[id]; !ok {
+			result = append(result, id)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
 }
 
 func (m *Manager) Localize(lang, messageID string) string {
@@ -44,28 +140,30 @@ func (m *Manager) Localize(lang, messageID string) string {
 	return msg
 }
 
-func (m *Manager) GetLocalizer(acceptLanguage string) *i18n.Localizer {
-	langs := parseAcceptLanguage(acceptLanguage)
+// GetLocalizer returns a localizer for the best supported match of an
+// Accept-Language header together with the matched language tag.
+func (m *Manager) GetLocalizer(acceptLanguage string) (*i18n.Localizer, language.Tag) {
+	tag := m.Match(acceptLanguage)
 
-	return i18n.NewLocalizer(m.bundle, langs...)
+	return i18n.NewLocalizer(m.bundle, tag.String()), tag
 }
 
-func parseAcceptLanguage(header string) []string {
-	if header == "" {
-		return []string{"en"}
-	}
-
-	parts := strings.Split(header, ",")
-	langs := make([]string, 0, len(parts))
+// Match returns the supported language that best matches an Accept-Language
+// header, falling back to the default language.
+func (m *Manager) Match(acceptLanguage string) language.Tag {
+	tags := m.bundle.LanguageTags()
 
-	for _, part := range parts {
-		lang := strings.TrimSpace(strings.Split(part, ";")[0])
-		langs = append(langs, lang)
+	requested, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(requested) == 0 {
+		return tags[0]
 	}
 
-	if len(langs) == 0 {
-		return []string{"en"}
-	}
+	_, index, _ := m.matcher.Match(requested...)
+
+	return tags[index]
+}
 
-	return langs
+// LanguageTags lists the supported languages, default language first.
+func (m *Manager) LanguageTags() []language.Tag {
+	return m.bundle.LanguageTags()
 }
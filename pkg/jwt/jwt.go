@@ -2,6 +2,7 @@ package jwt
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -13,64 +14,107 @@ var (
 	ErrExpiredToken = errors.New("token expired")
 )
 
+// Claims are the claims of issued tokens. Scope is the space-separated list
+// of scopes (RFC 8693) a limited token is restricted to; tokens from a login
+// have none and are not limited.
 type Claims struct {
 	UserID uuid.UUID `json:"user_id"`
 	Email  string    `json:"email"`
+	Role   string    `json:"role"`
+	Scope  string    `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// Scopes returns the scopes of a limited token, nil for a full token.
+func (c *Claims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// Config configures a Manager. Issuer and Audience are set on issued tokens
+// and required on validated ones; either check is skipped when empty.
+type Config struct {
+	Keys            *KeyRing
+	Issuer          string
+	Audience        string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
 type Manager struct {
-	secret          string
+	keys            *KeyRing
+	issuer          string
+	audience        string
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
 }
 
-func NewManager(secret string, accessTTL, refreshTTL time.Duration) *Manager {
+func NewManager(config Config) *Manager {
 	return &Manager{
-		secret:          secret,
-		accessTokenTTL:  accessTTL,
-		refreshTokenTTL: refreshTTL,
+		keys:            config.Keys,
+		issuer:          config.Issuer,
+		audience:        config.Audience,
+		accessTokenTTL:  config.AccessTokenTTL,
+		refreshTokenTTL: config.RefreshTokenTTL,
 	}
 }
 
-func (m *Manager) GenerateAccessToken(userID uuid.UUID, email string) (string, error) {
-	claims := Claims{
-		UserID: userID,
-		Email:  email,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.accessTokenTTL)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+func (m *Manager) GenerateAccessToken(userID uuid.UUID, email, role string) (string, error) {
+	return m.keys.sign(m.claims(userID, email, role, m.accessTokenTTL))
+}
+
+func (m *Manager) GenerateRefreshToken(userID uuid.UUID, email, role string) (string, error) {
+	return m.keys.sign(m.claims(userID, email, role, m.refreshTokenTTL))
+}
+
+// GenerateScopedAccessToken issues an access token that is limited to scopes
+// and expires after ttl.
+func (m *Manager) GenerateScopedAccessToken(userID uuid.UUID, email, role string, scopes []string, ttl time.Duration) (string, error) {
+	if len(scopes) == 0 {
+		return "", errors.New("scoped token requires at least one scope")
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	claims := m.claims(userID, email, role, ttl)
+	claims.Scope = strings.Join(scopes, " ")
 
-	return token.SignedString([]byte(m.secret))
+	return m.keys.sign(claims)
 }
 
-func (m *Manager) GenerateRefreshToken(userID uuid.UUID, email string) (string, error) {
+func (m *Manager) claims(userID uuid.UUID, email, role string, ttl time.Duration) Claims {
+	now := time.Now()
+
 	claims := Claims{
 		UserID: userID,
 		Email:  email,
+		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.refreshTokenTTL)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    m.issuer,
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if m.audience != "" {
+		claims.Audience = jwt.ClaimStrings{m.audience}
+	}
 
-	return token.SignedString([]byte(m.secret))
+	return claims
 }
 
 func (m *Manager) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidToken
-		}
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(m.keys.methods),
+		jwt.WithExpirationRequired(),
+	}
 
-		return []byte(m.secret), nil
-	})
+	if m.issuer != "" {
+		options = append(options, jwt.WithIssuer(m.issuer))
+	}
+
+	if m.audience != "" {
+		options = append(options, jwt.WithAudience(m.audience))
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, m.keys.verificationKey, options...)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -87,3 +131,8 @@ func (m *Manager) ValidateToken(tokenString string) (*Claims, error) {
 
 	return claims, nil
 }
+
+// JWKS returns the public keys tokens can be verified with.
+func (m *Manager) JWKS() JSONWebKeySet {
+	return m.keys.JWKS()
+}
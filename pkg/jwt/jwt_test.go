@@ -0,0 +1,299 @@
+package jwt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newKeyRing(t *testing.T, signing *Key, verifying ...*Key) *KeyRing {
+	t.Helper()
+
+	ring, err := NewKeyRing(signing, verifying...)
+	assert.NoError(t, err)
+
+	return ring
+}
+
+func newTestManager(keys *KeyRing) *Manager {
+	return NewManager(Config{
+		Keys:            keys,
+		Issuer:          "soccer-manager",
+		Audience:        "soccer-manager-api",
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+	})
+}
+
+func TestManager_ValidateToken(t *testing.T) {
+	userID := uuid.New()
+	rsaKey := parseKey(t, privatePEM(t, rsaKeys[0]))
+	edKey := parseKey(t, privatePEM(t, ed25519Keys[0]))
+	manager := newTestManager(newKeyRing(t, rsaKey))
+
+	// forge signs claims for userID with method and key, setting kid when
+	// it is not empty.
+	forge := func(t *testing.T, method jwt.SigningMethod, kid string, key any) string {
+		t.Helper()
+
+		token := jwt.NewWithClaims(method, manager.claims(userID, "user@example.com", "user", time.Minute))
+
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+
+		signed, err := token.SignedString(key)
+		assert.NoError(t, err)
+
+		return signed
+	}
+
+	t.Run("rsa", func(t *testing.T) {
+		token, err := manager.GenerateAccessToken(userID, "user@example.com", "user")
+		assert.NoError(t, err)
+
+		claims, err := manager.ValidateToken(token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, userID, claims.UserID)
+		assert.Equal(t, "soccer-manager", claims.Issuer)
+		assert.Equal(t, jwt.ClaimStrings{"soccer-manager-api"}, claims.Audience)
+	})
+
+	t.Run("ed25519", func(t *testing.T) {
+		edManager := newTestManager(newKeyRing(t, edKey))
+
+		token, err := edManager.GenerateAccessToken(userID, "user@example.com", "user")
+		assert.NoError(t, err)
+
+		claims, err := edManager.ValidateToken(token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, userID, claims.UserID)
+	})
+
+	t.Run("shared secret", func(t *testing.T) {
+		hmacManager := newTestManager(NewHMACKeyRing("test-secret"))
+
+		token, err := hmacManager.GenerateAccessToken(userID, "user@example.com", "user")
+		assert.NoError(t, err)
+
+		_, err = hmacManager.ValidateToken(token)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown kid", func(t *testing.T) {
+		other := parseKey(t, privatePEM(t, rsaKeys[1]))
+		token := forge(t, jwt.SigningMethodRS256, other.ID(), rsaKeys[1])
+
+		_, err := manager.ValidateToken(token)
+
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("kid of another key", func(t *testing.T) {
+		token := forge(t, jwt.SigningMethodRS256, rsaKey.ID(), rsaKeys[1])
+
+		_, err := manager.ValidateToken(token)
+
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("missing kid", func(t *testing.T) {
+		token := forge(t, jwt.SigningMethodRS256, "", rsaKeys[0])
+
+		_, err := manager.ValidateToken(token)
+
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("hs256 signed with the public key", func(t *testing.T) {
+		// HS256 is accepted by the second ring, but only with the secret.
+		withSecret := newTestManager(newKeyRing(t, rsaKey, NewHMACKey("test-secret")))
+
+		for _, secret := range [][]byte{publicPEM(t, rsaKeys[0].Public()), []byte(rsaKey.JWK().N)} {
+			token := forge(t, jwt.SigningMethodHS256, rsaKey.ID(), secret)
+
+			_, err := manager.ValidateToken(token)
+			assert.Equal(t, ErrInvalidToken, err)
+
+			_, err = withSecret.ValidateToken(token)
+			assert.Equal(t, ErrInvalidToken, err)
+		}
+	})
+
+	t.Run("alg none", func(t *testing.T) {
+		for _, kid := range []string{rsaKey.ID(), ""} {
+			token := forge(t, jwt.SigningMethodNone, kid, jwt.UnsafeAllowNoneSignatureType)
+
+			_, err := manager.ValidateToken(token)
+
+			assert.Equal(t, ErrInvalidToken, err)
+		}
+	})
+
+	t.Run("alg of another key in the ring", func(t *testing.T) {
+		// Both algorithms are accepted by the ring, but each only with its
+		// own key.
+		mixed := newTestManager(newKeyRing(t, rsaKey, edKey))
+		token := forge(t, jwt.SigningMethodEdDSA, rsaKey.ID(), ed25519Keys[0])
+
+		_, err := mixed.ValidateToken(token)
+
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		token, err := manager.GenerateAccessToken(userID, "user@example.com", "user")
+		assert.NoError(t, err)
+
+		other, err := manager.GenerateAccessToken(userID, "user@example.com", "admin")
+		assert.NoError(t, err)
+
+		parts, otherParts := strings.Split(token, "."), strings.Split(other, ".")
+		parts[1] = otherParts[1]
+
+		_, err = manager.ValidateToken(strings.Join(parts, "."))
+
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("wrong issuer", func(t *testing.T) {
+		token, err := NewManager(Config{
+			Keys:           newKeyRing(t, rsaKey),
+			Issuer:         "someone-else",
+			Audience:       "soccer-manager-api",
+			AccessTokenTTL: time.Minute,
+		}).GenerateAccessToken(userID, "user@example.com", "user")
+		assert.NoError(t, err)
+
+		_, err = manager.ValidateToken(token)
+
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		token, err := NewManager(Config{
+			Keys:           newKeyRing(t, rsaKey),
+			Issuer:         "soccer-manager",
+			Audience:       "another-api",
+			AccessTokenTTL: time.Minute,
+		}).GenerateAccessToken(userID, "user@example.com", "user")
+		assert.NoError(t, err)
+
+		_, err = manager.ValidateToken(token)
+
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("missing issuer and audience", func(t *testing.T) {
+		token, err := NewManager(Config{
+			Keys:           newKeyRing(t, rsaKey),
+			AccessTokenTTL: time.Minute,
+		}).GenerateAccessToken(userID, "user@example.com", "user")
+		assert.NoError(t, err)
+
+		_, err = manager.ValidateToken(token)
+
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		token, err := NewManager(Config{
+			Keys:           newKeyRing(t, rsaKey),
+			Issuer:         "soccer-manager",
+			Audience:       "soccer-manager-api",
+			AccessTokenTTL: -time.Minute,
+		}).GenerateAccessToken(userID, "user@example.com", "user")
+		assert.NoError(t, err)
+
+		_, err = manager.ValidateToken(token)
+
+		assert.Equal(t, ErrExpiredToken, err)
+	})
+}
+
+func TestManager_ValidateToken_Rotation(t *testing.T) {
+	userID := uuid.New()
+	oldKey := parseKey(t, privatePEM(t, rsaKeys[0]))
+	newKey := parseKey(t, privatePEM(t, ed25519Keys[0]))
+	oldPublic := parseKey(t, publicPEM(t, rsaKeys[0].Public()))
+
+	oldToken, err := newTestManager(newKeyRing(t, oldKey)).GenerateRefreshToken(userID, "user@example.com", "user")
+	assert.NoError(t, err)
+
+	t.Run("retired key verifies while it is in the ring", func(t *testing.T) {
+		rotated := newTestManager(newKeyRing(t, newKey, oldPublic))
+
+		claims, err := rotated.ValidateToken(oldToken)
+
+		assert.NoError(t, err)
+		assert.Equal(t, userID, claims.UserID)
+	})
+
+	t.Run("new tokens are signed with the new key", func(t *testing.T) {
+		rotated := newTestManager(newKeyRing(t, newKey, oldPublic))
+
+		token, err := rotated.GenerateAccessToken(userID, "user@example.com", "user")
+		assert.NoError(t, err)
+
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+		assert.NoError(t, err)
+		assert.Equal(t, newKey.ID(), parsed.Header["kid"])
+		assert.Equal(t, "EdDSA", parsed.Header["alg"])
+
+		_, err = newTestManager(newKeyRing(t, oldKey)).ValidateToken(token)
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("retired key is rejected once removed", func(t *testing.T) {
+		_, err := newTestManager(newKeyRing(t, newKey)).ValidateToken(oldToken)
+
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("expired token of a retired key", func(t *testing.T) {
+		expired, err := NewManager(Config{
+			Keys:            newKeyRing(t, oldKey),
+			Issuer:          "soccer-manager",
+			Audience:        "soccer-manager-api",
+			RefreshTokenTTL: -time.Minute,
+		}).GenerateRefreshToken(userID, "user@example.com", "user")
+		assert.NoError(t, err)
+
+		_, err = newTestManager(newKeyRing(t, newKey, oldPublic)).ValidateToken(expired)
+
+		assert.Equal(t, ErrExpiredToken, err)
+	})
+
+	t.Run("shared secret tokens verify after switching to a key", func(t *testing.T) {
+		secretToken, err := newTestManager(NewHMACKeyRing("test-secret")).GenerateAccessToken(userID, "user@example.com", "user")
+		assert.NoError(t, err)
+
+		_, err = newTestManager(newKeyRing(t, newKey, NewHMACKey("test-secret"))).ValidateToken(secretToken)
+		assert.NoError(t, err)
+
+		_, err = newTestManager(newKeyRing(t, newKey)).ValidateToken(secretToken)
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+}
+
+func TestManager_GenerateScopedAccessToken(t *testing.T) {
+	manager := newTestManager(NewHMACKeyRing("test-secret"))
+
+	token, err := manager.GenerateScopedAccessToken(uuid.New(), "user@example.com", "user", []string{"teams:read", "market:read"}, time.Minute)
+	assert.NoError(t, err)
+
+	claims, err := manager.ValidateToken(token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"teams:read", "market:read"}, claims.Scopes())
+
+	_, err = manager.GenerateScopedAccessToken(uuid.New(), "user@example.com", "user", nil, time.Minute)
+	assert.Error(t, err)
+}
@@ -0,0 +1,248 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted for signing keys.
+const minRSAKeyBits = 2048
+
+// Key is a token signing or verification key. Asymmetric keys are identified
+// by their RFC 7638 thumbprint, which is sent as the kid header; the shared
+// HS256 secret has no ID.
+type Key struct {
+	id         string
+	method     jwt.SigningMethod
+	signingKey any
+	publicKey  any
+}
+
+// NewHMACKey returns an HS256 key for a shared secret.
+func NewHMACKey(secret string) *Key {
+	return &Key{
+		method:     jwt.SigningMethodHS256,
+		signingKey: []byte(secret),
+		publicKey:  []byte(secret),
+	}
+}
+
+// ParseKey reads a PEM encoded RSA or Ed25519 key. Private keys (PKCS #8, or
+// PKCS #1 for RSA) can sign, public keys (PKIX) can only verify. RSA keys are
+// used with RS256, Ed25519 keys with EdDSA.
+func ParseKey(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var (
+		parsed any
+		err    error
+	)
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", block.Type, err)
+	}
+
+	return newAsymmetricKey(parsed)
+}
+
+func newAsymmetricKey(parsed any) (*Key, error) {
+	key := &Key{}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.signingKey, key.publicKey = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.method, key.publicKey = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.method, key.signingKey, key.publicKey = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.method, key.publicKey = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+
+	if pub, ok := key.publicKey.(*rsa.PublicKey); ok && pub.N.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("RSA key must be at least %d bits", minRSAKeyBits)
+	}
+
+	thumbprint, err := json.Marshal(key.JWK().thumbprintMembers())
+	if err != nil {
+		return nil, fmt.Errorf("compute key id: %w", err)
+	}
+
+	sum := sha256.Sum256(thumbprint)
+	key.id = base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return key, nil
+}
+
+// ID returns the key ID, empty for the shared secret.
+func (k *Key) ID() string {
+	return k.id
+}
+
+// Algorithm returns the JWS algorithm the key is used with.
+func (k *Key) Algorithm() string {
+	return k.method.Alg()
+}
+
+// CanSign reports whether the key has a private part.
+func (k *Key) CanSign() bool {
+	return k.signingKey != nil
+}
+
+// JSONWebKey is the public part of a key as published in a key set (RFC 7517).
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// JSONWebKeySet is the document served at the JWKS endpoint.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWK returns the public part of an asymmetric key. It must not be called
+// for the shared secret.
+func (k *Key) JWK() JSONWebKey {
+	jwk := JSONWebKey{KeyID: k.id, Use: "sig", Algorithm: k.Algorithm()}
+
+	switch pub := k.publicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+
+	return jwk
+}
+
+// thumbprintMembers returns the required members of the key in the
+// lexicographic order RFC 7638 hashes them in; encoding/json sorts map keys.
+func (k JSONWebKey) thumbprintMembers() map[string]string {
+	if k.KeyType == "RSA" {
+		return map[string]string{"e": k.E, "kty": k.KeyType, "n": k.N}
+	}
+
+	return map[string]string{"crv": k.Curve, "kty": k.KeyType, "x": k.X}
+}
+
+// KeyRing holds the key new tokens are signed with and the keys tokens are
+// still accepted from. Keeping a retired key in the ring until the tokens it
+// signed have expired allows rotating keys without logging anybody out.
+type KeyRing struct {
+	signing *Key
+	keys    map[string]*Key
+	ordered []*Key
+	methods []string
+}
+
+// NewKeyRing returns a ring that signs with signing and additionally accepts
+// tokens signed by any of verifying.
+func NewKeyRing(signing *Key, verifying ...*Key) (*KeyRing, error) {
+	if signing == nil || !signing.CanSign() {
+		return nil, errors.New("signing key must be a private key")
+	}
+
+	ring := &KeyRing{signing: signing, keys: make(map[string]*Key, len(verifying)+1)}
+
+	for _, key := range append([]*Key{signing}, verifying...) {
+		if _, ok := ring.keys[key.id]; ok {
+			if key.id == "" {
+				return nil, errors.New("only one shared secret can be used")
+			}
+
+			return nil, fmt.Errorf("duplicate key %s", key.id)
+		}
+
+		ring.keys[key.id] = key
+		ring.ordered = append(ring.ordered, key)
+
+		if !slices.Contains(ring.methods, key.Algorithm()) {
+			ring.methods = append(ring.methods, key.Algorithm())
+		}
+	}
+
+	return ring, nil
+}
+
+// NewHMACKeyRing returns a ring that signs and verifies with a shared secret.
+func NewHMACKeyRing(secret string) *KeyRing {
+	// A single secret key always makes a valid ring.
+	ring, _ := NewKeyRing(NewHMACKey(secret))
+
+	return ring
+}
+
+// JWKS returns the public keys of the ring, the signing key first. The shared
+// secret is never published.
+func (r *KeyRing) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+
+	for _, key := range r.ordered {
+		if key.id != "" {
+			set.Keys = append(set.Keys, key.JWK())
+		}
+	}
+
+	return set
+}
+
+func (r *KeyRing) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(r.signing.method, claims)
+
+	if r.signing.id != "" {
+		token.Header["kid"] = r.signing.id
+	}
+
+	return token.SignedString(r.signing.signingKey)
+}
+
+// verificationKey looks up the key by the token's kid header and makes sure
+// the token uses that key's algorithm, so that e.g. a public RSA key is never
+// used as an HMAC secret.
+func (r *KeyRing) verificationKey(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := r.keys[kid]
+	if !ok || token.Method.Alg() != key.Algorithm() {
+		return nil, ErrInvalidToken
+	}
+
+	return key.publicKey, nil
+}
@@ -0,0 +1,235 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rsaKeys and ed25519Keys are generated once, RSA key generation is slow.
+var (
+	rsaKeys     = newRSAKeys(2)
+	ed25519Keys = newEd25519Keys(2)
+)
+
+func newRSAKeys(n int) []*rsa.PrivateKey {
+	keys := make([]*rsa.PrivateKey, n)
+
+	for i := range keys {
+		key, err := rsa.GenerateKey(rand.Reader, minRSAKeyBits)
+		if err != nil {
+			panic(err)
+		}
+
+		keys[i] = key
+	}
+
+	return keys
+}
+
+func newEd25519Keys(n int) []ed25519.PrivateKey {
+	keys := make([]ed25519.PrivateKey, n)
+
+	for i := range keys {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			panic(err)
+		}
+
+		keys[i] = key
+	}
+
+	return keys
+}
+
+func privatePEM(t *testing.T, key any) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func publicPEM(t *testing.T, key any) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	assert.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func parseKey(t *testing.T, data []byte) *Key {
+	t.Helper()
+
+	key, err := ParseKey(data)
+	assert.NoError(t, err)
+
+	return key
+}
+
+func TestParseKey(t *testing.T) {
+	t.Run("rsa private key", func(t *testing.T) {
+		key := parseKey(t, privatePEM(t, rsaKeys[0]))
+
+		assert.Equal(t, "RS256", key.Algorithm())
+		assert.True(t, key.CanSign())
+		assert.NotEmpty(t, key.ID())
+	})
+
+	t.Run("pkcs1 rsa private key", func(t *testing.T) {
+		data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKeys[0])})
+		key := parseKey(t, data)
+
+		assert.Equal(t, parseKey(t, privatePEM(t, rsaKeys[0])).ID(), key.ID())
+	})
+
+	t.Run("ed25519 public key", func(t *testing.T) {
+		key := parseKey(t, publicPEM(t, ed25519Keys[0].Public()))
+
+		assert.Equal(t, "EdDSA", key.Algorithm())
+		assert.False(t, key.CanSign())
+		assert.Equal(t, parseKey(t, privatePEM(t, ed25519Keys[0])).ID(), key.ID())
+	})
+
+	t.Run("short rsa key", func(t *testing.T) {
+		short, err := rsa.GenerateKey(rand.Reader, 1024)
+		assert.NoError(t, err)
+
+		_, err = ParseKey(privatePEM(t, short))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("not pem", func(t *testing.T) {
+		_, err := ParseKey([]byte("secret"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestKey_ID(t *testing.T) {
+	// The example keys and thumbprints of RFC 7638 section 3.1 and RFC 8037
+	// appendix A.3.
+	t.Run("rsa thumbprint", func(t *testing.T) {
+		n, err := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+		assert.NoError(t, err)
+
+		key := parseKey(t, publicPEM(t, &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537}))
+
+		assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", key.ID())
+	})
+
+	t.Run("ed25519 thumbprint", func(t *testing.T) {
+		x, err := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+		assert.NoError(t, err)
+
+		key := parseKey(t, publicPEM(t, ed25519.PublicKey(x)))
+
+		assert.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", key.ID())
+	})
+}
+
+func TestNewKeyRing(t *testing.T) {
+	t.Run("public signing key", func(t *testing.T) {
+		_, err := NewKeyRing(parseKey(t, publicPEM(t, rsaKeys[0].Public())))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicate key", func(t *testing.T) {
+		_, err := NewKeyRing(
+			parseKey(t, privatePEM(t, rsaKeys[0])),
+			parseKey(t, publicPEM(t, rsaKeys[0].Public())),
+		)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("two shared secrets", func(t *testing.T) {
+		_, err := NewKeyRing(parseKey(t, privatePEM(t, rsaKeys[0])), NewHMACKey("a"), NewHMACKey("b"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestKeyRing_JWKS(t *testing.T) {
+	signing := parseKey(t, privatePEM(t, rsaKeys[0]))
+	retired := parseKey(t, privatePEM(t, ed25519Keys[0]))
+
+	ring, err := NewKeyRing(signing, retired, NewHMACKey("test-secret"))
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(ring.JWKS())
+	assert.NoError(t, err)
+
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+
+	assert.NoError(t, json.Unmarshal(data, &set))
+
+	if !assert.Len(t, set.Keys, 2) {
+		return
+	}
+
+	t.Run("signing key first with its thumbprint", func(t *testing.T) {
+		jwk := set.Keys[0]
+
+		assert.Equal(t, map[string]string{
+			"kty": "RSA",
+			"kid": signing.ID(),
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(rsaKeys[0].N.Bytes()),
+			"e":   "AQAB",
+		}, jwk)
+		assert.Equal(t, thumbprint(t, `{"e":"AQAB","kty":"RSA","n":"`+jwk["n"]+`"}`), jwk["kid"])
+	})
+
+	t.Run("retired key with its thumbprint", func(t *testing.T) {
+		jwk := set.Keys[1]
+
+		assert.Equal(t, map[string]string{
+			"kty": "OKP",
+			"kid": retired.ID(),
+			"use": "sig",
+			"alg": "EdDSA",
+			"crv": "Ed25519",
+			"x":   base64.RawURLEncoding.EncodeToString(ed25519Keys[0].Public().(ed25519.PublicKey)),
+		}, jwk)
+		assert.Equal(t, thumbprint(t, `{"crv":"Ed25519","kty":"OKP","x":"`+jwk["x"]+`"}`), jwk["kid"])
+	})
+
+	t.Run("no private material or shared secret", func(t *testing.T) {
+		for _, jwk := range set.Keys {
+			for _, member := range []string{"d", "p", "q", "dp", "dq", "qi", "k"} {
+				assert.NotContains(t, jwk, member)
+			}
+		}
+
+		assert.NotContains(t, string(data), base64.RawURLEncoding.EncodeToString([]byte("test-secret")))
+	})
+
+	t.Run("shared secret only", func(t *testing.T) {
+		assert.Empty(t, NewHMACKeyRing("test-secret").JWKS().Keys)
+	})
+}
+
+func thumbprint(t *testing.T, members string) string {
+	t.Helper()
+
+	sum := sha256.Sum256([]byte(members))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,26 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type ctxKey struct{}
+
+// WithContext returns a copy of ctx carrying the request-scoped logger l.
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext returns the request-scoped logger carried by ctx extended with
+// fields. When ctx has no logger, fallback is returned as is, so callers pass
+// a fallback that already carries those fields.
+func FromContext(ctx context.Context, fallback *zap.Logger, fields ...zap.Field) *zap.Logger {
+	l, ok := ctx.Value(ctxKey{}).(*zap.Logger)
+	if !ok || l == nil {
+		return fallback
+	}
+
+	return l.With(fields...)
+}
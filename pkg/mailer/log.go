@@ -0,0 +1,27 @@
+package mailer
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Log writes mail to the application log instead of sending it. It is meant
+// for local development, where the links in the body can be copied from the
+// log.
+type Log struct {
+	logger *zap.Logger
+}
+
+func NewLog(logger *zap.Logger) *Log {
+	return &Log{logger: logger.With(zap.String("mailer", "log"))}
+}
+
+func (m *Log) Send(_ context.Context, msg Message) error {
+	m.logger.Info("mail",
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject),
+		zap.String("body", msg.Body))
+
+	return nil
+}
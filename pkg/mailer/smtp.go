@@ -0,0 +1,64 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTP sends mail through an SMTP server, upgrading to TLS with STARTTLS when
+// the server supports it.
+type SMTP struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTP(host string, port int, username, password, from string) *SMTP {
+	var auth smtp.Auth
+
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTP{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		from: from,
+		auth: auth,
+	}
+}
+
+func (m *SMTP) Send(ctx context.Context, msg Message) error {
+	data, err := render(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	sender, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("invalid sender %q: %w", m.from, err)
+	}
+
+	recipient, _ := mail.ParseAddress(msg.To)
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- smtp.SendMail(m.addr, m.auth, sender.Address, []string{recipient.Address}, data)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("send mail: %w", err)
+		}
+
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("send mail: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,12 @@
+package oidc
+
+import "time"
+
+// ExpireKeys makes the cached keys old enough for an unknown key ID to
+// trigger a new fetch.
+func ExpireKeys(p *Provider) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.keysFetched = p.keysFetched.Add(-keysRefreshInterval - time.Second)
+}
@@ -0,0 +1,240 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"soccer_manager_service/pkg/oidc"
+	"soccer_manager_service/pkg/oidc/oidctest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const redirectURL = "http://localhost/callback"
+
+var identity = oidc.Identity{
+	Subject:       "subject-1",
+	Email:         "user@example.com",
+	EmailVerified: true,
+	Name:          "Test User",
+}
+
+func newServer(t *testing.T) *oidctest.Server {
+	t.Helper()
+
+	server, err := oidctest.NewServer()
+	assert.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	server.SignIn(identity)
+
+	return server
+}
+
+// signIn runs the authorization code flow against provider, authorizing with
+// the challenge of verifier and the expected nonce, then redeeming the code
+// with exchangeVerifier and checking exchangeNonce.
+func signIn(t *testing.T, server *oidctest.Server, provider *oidc.Provider,
+	verifier, exchangeVerifier, nonce, exchangeNonce string,
+) (*oidc.Identity, error) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	authURL, err := provider.AuthCodeURL(ctx, "state", nonce, oidc.CodeChallenge(verifier))
+	assert.NoError(t, err)
+
+	code, state, err := server.Authorize(authURL)
+	assert.NoError(t, err)
+	assert.Equal(t, "state", state)
+
+	return provider.Exchange(ctx, code, exchangeVerifier, exchangeNonce)
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	server := newServer(t)
+	provider := oidc.New(server.Config(redirectURL), http.DefaultClient)
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", "nonce", oidc.CodeChallenge("verifier"))
+	assert.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	assert.NoError(t, err)
+
+	assert.Equal(t, server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	assert.Equal(t, url.Values{
+		"response_type":         {"code"},
+		"client_id":             {oidctest.ClientID},
+		"redirect_uri":          {redirectURL},
+		"scope":                 {"openid email profile"},
+		"state":                 {"state"},
+		"nonce":                 {"nonce"},
+		"code_challenge":        {oidc.CodeChallenge("verifier")},
+		"code_challenge_method": {"S256"},
+	}, parsed.Query())
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// The example of RFC 7636 appendix B.
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		oidc.CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		server := newServer(t)
+		provider := oidc.New(server.Config(redirectURL), http.DefaultClient)
+
+		got, err := signIn(t, server, provider, "verifier", "verifier", "nonce", "nonce")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &identity, got)
+	})
+
+	t.Run("wrong pkce verifier", func(t *testing.T) {
+		server := newServer(t)
+		provider := oidc.New(server.Config(redirectURL), http.DefaultClient)
+
+		for _, verifier := range []string{"another-verifier", ""} {
+			got, err := signIn(t, server, provider, "verifier", verifier, "nonce", "nonce")
+
+			assert.Nil(t, got)
+			assert.ErrorContains(t, err, "invalid_grant", verifier)
+		}
+	})
+
+	t.Run("code used twice", func(t *testing.T) {
+		server := newServer(t)
+		provider := oidc.New(server.Config(redirectURL), http.DefaultClient)
+		ctx := context.Background()
+
+		authURL, err := provider.AuthCodeURL(ctx, "state", "nonce", oidc.CodeChallenge("verifier"))
+		assert.NoError(t, err)
+
+		code, _, err := server.Authorize(authURL)
+		assert.NoError(t, err)
+
+		_, err = provider.Exchange(ctx, code, "verifier", "nonce")
+		assert.NoError(t, err)
+
+		_, err = provider.Exchange(ctx, code, "verifier", "nonce")
+		assert.ErrorContains(t, err, "invalid_grant")
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		server := newServer(t)
+		provider := oidc.New(server.Config(redirectURL), http.DefaultClient)
+
+		for _, nonce := range []string{"another-nonce", ""} {
+			got, err := signIn(t, server, provider, "verifier", "verifier", "nonce", nonce)
+
+			assert.Nil(t, got)
+			assert.ErrorIs(t, err, oidc.ErrInvalidIDToken, nonce)
+		}
+	})
+
+	t.Run("discovery issuer mismatch", func(t *testing.T) {
+		server := newServer(t)
+		config := server.Config(redirectURL)
+		config.Issuer += "/"
+
+		_, err := oidc.New(config, http.DefaultClient).AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+
+		assert.ErrorContains(t, err, "does not match")
+	})
+}
+
+func TestProvider_Exchange_InvalidClaims(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		claims map[string]any
+	}{
+		{name: "wrong issuer", claims: map[string]any{"iss": "https://issuer.example.com"}},
+		{name: "missing issuer", claims: map[string]any{"iss": nil}},
+		{name: "wrong audience", claims: map[string]any{"aud": "another-client"}},
+		{name: "missing audience", claims: map[string]any{"aud": nil}},
+		{name: "several audiences without azp", claims: map[string]any{"aud": []string{oidctest.ClientID, "another-client"}}},
+		{name: "several audiences for another azp", claims: map[string]any{
+			"aud": []string{oidctest.ClientID, "another-client"},
+			"azp": "another-client",
+		}},
+		{name: "expired", claims: map[string]any{
+			"iat": now.Add(-2 * time.Hour).Unix(),
+			"exp": now.Add(-time.Hour).Unix(),
+		}},
+		{name: "expired beyond clock skew", claims: map[string]any{"exp": now.Add(-2 * time.Minute).Unix()}},
+		{name: "missing expiry", claims: map[string]any{"exp": nil}},
+		{name: "issued in the future", claims: map[string]any{"iat": now.Add(time.Hour).Unix()}},
+		{name: "missing subject", claims: map[string]any{"sub": nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newServer(t)
+			provider := oidc.New(server.Config(redirectURL), http.DefaultClient)
+			server.SetClaims(tt.claims)
+
+			got, err := signIn(t, server, provider, "verifier", "verifier", "nonce", "nonce")
+
+			assert.Nil(t, got)
+			assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+		})
+	}
+
+	t.Run("accepted", func(t *testing.T) {
+		for name, claims := range map[string]map[string]any{
+			"several audiences with azp": {"aud": []string{oidctest.ClientID, "another-client"}, "azp": oidctest.ClientID},
+			"expired within clock skew":  {"exp": now.Add(-30 * time.Second).Unix()},
+		} {
+			server := newServer(t)
+			provider := oidc.New(server.Config(redirectURL), http.DefaultClient)
+			server.SetClaims(claims)
+
+			got, err := signIn(t, server, provider, "verifier", "verifier", "nonce", "nonce")
+
+			assert.NoError(t, err, name)
+			assert.Equal(t, &identity, got, name)
+		}
+	})
+}
+
+func TestProvider_Exchange_KeyRotation(t *testing.T) {
+	server := newServer(t)
+	provider := oidc.New(server.Config(redirectURL), http.DefaultClient)
+
+	_, err := signIn(t, server, provider, "verifier", "verifier", "nonce", "nonce")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, server.KeySetRequests())
+
+	t.Run("known key is cached", func(t *testing.T) {
+		_, err := signIn(t, server, provider, "verifier", "verifier", "nonce", "nonce")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, server.KeySetRequests())
+	})
+
+	assert.NoError(t, server.RotateKey())
+
+	t.Run("unknown key within the refresh interval", func(t *testing.T) {
+		got, err := signIn(t, server, provider, "verifier", "verifier", "nonce", "nonce")
+
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+		assert.Equal(t, 1, server.KeySetRequests())
+	})
+
+	t.Run("unknown key refetches the key set", func(t *testing.T) {
+		oidc.ExpireKeys(provider)
+
+		got, err := signIn(t, server, provider, "verifier", "verifier", "nonce", "nonce")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &identity, got)
+		assert.Equal(t, 2, server.KeySetRequests())
+	})
+}
@@ -0,0 +1,64 @@
+// Package password holds policy building blocks that do not depend on
+// configuration: a bundled list of common passwords and character class
+// counting.
+package password
+
+import (
+	_ "embed"
+	"strings"
+	"unicode"
+)
+
+// common.txt lists a few hundred of the passwords that appear most often in
+// public breach corpora, one per line, in lowercase.
+//
+//go:embed common.txt
+var commonList string
+
+var common = func() map[string]struct{} {
+	set := make(map[string]struct{})
+
+	for _, line := range strings.Split(commonList, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			set[line] = struct{}{}
+		}
+	}
+
+	return set
+}()
+
+// IsCommon reports whether password, ignoring case, is in the bundled list.
+func IsCommon(password string) bool {
+	_, ok := common[strings.ToLower(password)]
+
+	return ok
+}
+
+// CharClasses counts how many of lowercase letters, uppercase letters, digits
+// and other characters password contains.
+func CharClasses(password string) int {
+	var lower, upper, digit, other bool
+
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	count := 0
+
+	for _, present := range []bool{lower, upper, digit, other} {
+		if present {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,55 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValid(t *testing.T) {
+	valid := []string{
+		"c0ffee",
+		"3f2504e0-4f89-41d3-9a0c-0305e82c3301",
+		"req_01HZY:edge/42",
+		strings.Repeat("a", maxLength),
+	}
+
+	for _, id := range valid {
+		assert.True(t, IsValid(id), id)
+	}
+
+	invalid := []string{
+		"",
+		strings.Repeat("a", maxLength+1),
+		"with space",
+		"tab\there",
+		"injected\r\nX-Admin: true",
+		"del\x7f",
+		"nul\x00",
+		"ünïcode",
+	}
+
+	for _, id := range invalid {
+		assert.False(t, IsValid(id), "%q", id)
+	}
+}
+
+func TestNew(t *testing.T) {
+	id := New()
+
+	_, err := uuid.Parse(id)
+
+	assert.NoError(t, err)
+	assert.True(t, IsValid(id))
+	assert.NotEqual(t, id, New())
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Empty(t, FromContext(ctx))
+	assert.Equal(t, "c0ffee", FromContext(WithContext(ctx, "c0ffee")))
+}
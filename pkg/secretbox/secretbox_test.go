@@ -0,0 +1,93 @@
+package secretbox
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newBox(t *testing.T, fill byte) *Box {
+	t.Helper()
+
+	box, err := New(bytes.Repeat([]byte{fill}, KeySize))
+	assert.NoError(t, err)
+
+	return box
+}
+
+func TestNew(t *testing.T) {
+	for _, size := range []int{0, 16, 24, KeySize + 1} {
+		_, err := New(make([]byte, size))
+
+		assert.Error(t, err, "size %d", size)
+	}
+}
+
+func TestBox_Open(t *testing.T) {
+	box := newBox(t, 1)
+
+	sealed, err := box.Seal("JBSWY3DPEHPK3PXP")
+	assert.NoError(t, err)
+
+	raw, err := base64.StdEncoding.DecodeString(sealed)
+	assert.NoError(t, err)
+
+	t.Run("round trip", func(t *testing.T) {
+		plaintext, err := box.Open(sealed)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "JBSWY3DPEHPK3PXP", plaintext)
+	})
+
+	t.Run("empty plaintext", func(t *testing.T) {
+		empty, err := box.Seal("")
+		assert.NoError(t, err)
+
+		plaintext, err := box.Open(empty)
+
+		assert.NoError(t, err)
+		assert.Empty(t, plaintext)
+	})
+
+	t.Run("random nonce", func(t *testing.T) {
+		again, err := box.Seal("JBSWY3DPEHPK3PXP")
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, sealed, again)
+		assert.NotContains(t, string(raw), "JBSWY3DPEHPK3PXP")
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		// Flip a bit in the nonce, the ciphertext and the tag.
+		for _, i := range []int{0, box.aead.NonceSize(), len(raw) - 1} {
+			tampered := bytes.Clone(raw)
+			tampered[i] ^= 0x01
+
+			_, err := box.Open(base64.StdEncoding.EncodeToString(tampered))
+
+			assert.Equal(t, ErrDecrypt, err, "byte %d", i)
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		for _, n := range []int{0, box.aead.NonceSize() - 1, box.aead.NonceSize(), len(raw) - 1} {
+			_, err := box.Open(base64.StdEncoding.EncodeToString(raw[:n]))
+
+			assert.Equal(t, ErrDecrypt, err, "length %d", n)
+		}
+	})
+
+	t.Run("not base64", func(t *testing.T) {
+		_, err := box.Open("not base64!")
+
+		assert.Equal(t, ErrDecrypt, err)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		_, err := newBox(t, 2).Open(sealed)
+
+		assert.Equal(t, ErrDecrypt, err)
+	})
+}
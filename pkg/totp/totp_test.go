@@ -0,0 +1,170 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is the SHA-1 seed of the RFC 6238 test vectors, "12345678901234567890".
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func code(t *testing.T, step int64) string {
+	t.Helper()
+
+	c, err := Code(rfcSecret, step)
+	assert.NoError(t, err)
+
+	return c
+}
+
+func TestCode(t *testing.T) {
+	// RFC 6238 appendix B, SHA-1. The RFC uses eight digits, six digit codes
+	// are the last six of them.
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, v := range vectors {
+		got, err := Code(rfcSecret, Step(time.Unix(v.unix, 0)))
+
+		assert.NoError(t, err)
+		assert.Equal(t, v.code[2:], got, "time %d", v.unix)
+	}
+
+	t.Run("lower case secret", func(t *testing.T) {
+		got, err := Code("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "287082", got)
+	})
+
+	t.Run("invalid secret", func(t *testing.T) {
+		_, err := Code("not base32!", 1)
+
+		assert.Equal(t, ErrInvalidSecret, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	// 60 is the first and 89 the last second of step 2.
+	start, end := time.Unix(60, 0), time.Unix(89, 0)
+
+	t.Run("skew window", func(t *testing.T) {
+		for _, now := range []time.Time{start, end} {
+			for step, want := range map[int64]bool{0: false, 1: true, 2: true, 3: true, 4: false} {
+				got, ok, err := Validate(rfcSecret, code(t, step), now, 1)
+
+				assert.NoError(t, err)
+				assert.Equal(t, want, ok, "step %d at %d", step, now.Unix())
+
+				if want {
+					assert.Equal(t, step, got)
+				}
+			}
+		}
+	})
+
+	t.Run("step boundary", func(t *testing.T) {
+		// The window moves on exactly when the step changes.
+		before := start.Add(-time.Second)
+
+		for _, c := range []struct {
+			now  time.Time
+			step int64
+			want bool
+		}{
+			{before, 0, true},
+			{before, 3, false},
+			{start, 0, false},
+			{start, 3, true},
+		} {
+			_, ok, err := Validate(rfcSecret, code(t, c.step), c.now, 1)
+
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, ok, "step %d at %d", c.step, c.now.Unix())
+		}
+	})
+
+	t.Run("no skew", func(t *testing.T) {
+		_, ok, err := Validate(rfcSecret, code(t, 2), end, 0)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		_, ok, err = Validate(rfcSecret, code(t, 1), start, 0)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		// Callers reject steps at or before the last one they accepted, so
+		// Validate must return the step of the code, not the current one.
+		last, ok, err := Validate(rfcSecret, code(t, 2), start, 1)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		replayed, ok, err := Validate(rfcSecret, code(t, 2), end, 1)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.LessOrEqual(t, replayed, last)
+
+		older, ok, err := Validate(rfcSecret, code(t, 1), end, 1)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Less(t, older, last)
+	})
+
+	t.Run("wrong length", func(t *testing.T) {
+		_, ok, err := Validate(rfcSecret, "94287082", time.Unix(59, 0), 1)
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid secret", func(t *testing.T) {
+		_, _, err := Validate("not base32!", "123456", start, 1)
+
+		assert.Equal(t, ErrInvalidSecret, err)
+	})
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.NoError(t, err)
+
+	other, err := GenerateSecret()
+	assert.NoError(t, err)
+
+	assert.Len(t, secret, 32)
+	assert.NotEqual(t, secret, other)
+
+	_, err = Code(secret, 1)
+	assert.NoError(t, err)
+}
+
+func TestURI(t *testing.T) {
+	u, err := url.Parse(URI("Soccer Manager", "user@example.com", rfcSecret))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Soccer Manager:user@example.com", u.Path)
+	assert.Equal(t, url.Values{
+		"secret":    {rfcSecret},
+		"issuer":    {"Soccer Manager"},
+		"algorithm": {"SHA1"},
+		"digits":    {"6"},
+		"period":    {"30"},
+	}, u.Query())
+}
@@ -0,0 +1,175 @@
+// Package webhook signs and sends webhook requests.
+//
+// Every request carries the delivery ID, the event type, a Unix timestamp and
+// a signature: the hex HMAC-SHA256, keyed with the endpoint secret, of the
+// timestamp, a dot and the body. Receivers recompute it to check that the
+// request is ours and reject old timestamps to stop replays.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	HeaderID        = "X-Webhook-ID"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	signaturePrefix = "sha256="
+	userAgent       = "SoccerManager-Webhooks/1.0"
+
+	// maxResponseBody is how much of a response is read before the
+	// connection is reused; the body itself is ignored.
+	maxResponseBody = 64 << 10
+
+	// DefaultTolerance is how far a timestamp may be from the receiver's
+	// clock before Verify rejects the request as a replay.
+	DefaultTolerance = 5 * time.Minute
+)
+
+// ErrBlockedAddress is returned for endpoints that resolve to loopback,
+// private and other non-public addresses, unless they are allowed.
+var ErrBlockedAddress = errors.New("webhook: endpoint address is not public")
+
+// Request is one webhook call.
+type Request struct {
+	URL    string
+	Secret string
+	ID     string
+	Event  string
+	Body   []byte
+}
+
+// StatusError is returned for responses outside 2xx.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("webhook: unexpected response status %d", e.StatusCode)
+}
+
+// Sign returns the value of the signature header for body sent at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of body sent at
+// timestamp, and timestamp is within tolerance of the current time.
+func Verify(secret string, timestamp int64, body []byte, signature string, tolerance time.Duration) bool {
+	age := time.Since(time.Unix(timestamp, 0))
+	if age > tolerance || age < -tolerance {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+type Client struct {
+	http *http.Client
+}
+
+// NewClient returns a client that gives up on a request after timeout. It
+// does not follow redirects or use proxies, and refuses non-public addresses
+// unless allowPrivate is set.
+func NewClient(timeout time.Duration, allowPrivate bool) *Client {
+	return newClient(&net.Dialer{Timeout: timeout}, timeout, allowPrivate)
+}
+
+func newClient(dialer *net.Dialer, timeout time.Duration, allowPrivate bool) *Client {
+	if !allowPrivate {
+		dialer.Control = refusePrivate
+	}
+
+	return &Client{
+		http: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+				MaxIdleConnsPerHost: 2,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send posts r and returns the response status, or 0 if there was no
+// response. Statuses outside 2xx fail with a *StatusError.
+func (c *Client) Send(ctx context.Context, r Request) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(r.Body))
+	if err != nil {
+		return 0, fmt.Errorf("webhook: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderID, r.ID)
+	req.Header.Set(HeaderEvent, r.Event)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(r.Secret, timestamp, r.Body))
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	return resp.StatusCode, nil
+}
+
+// refusePrivate runs after name resolution, so it also catches public names
+// that resolve to internal addresses.
+func refusePrivate(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+
+	ip = ip.Unmap()
+
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() || sharedAddressSpace.Contains(ip) {
+		return ErrBlockedAddress
+	}
+
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, which IsPrivate does not
+// cover.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
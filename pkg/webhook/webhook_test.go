@@ -0,0 +1,284 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"type":"ping"}`)
+	now := time.Now().Unix()
+	signature := Sign("a-very-long-secret", now, body)
+
+	t.Run("valid", func(t *testing.T) {
+		assert.True(t, Verify("a-very-long-secret", now, body, signature, DefaultTolerance))
+	})
+
+	t.Run("bad signature", func(t *testing.T) {
+		assert.False(t, Verify("another-secret", now, body, signature, DefaultTolerance))
+		assert.False(t, Verify("a-very-long-secret", now, []byte(`{"type":"pong"}`), signature, DefaultTolerance))
+		assert.False(t, Verify("a-very-long-secret", now+1, body, signature, DefaultTolerance))
+		assert.False(t, Verify("a-very-long-secret", now, body, signature[len(signaturePrefix):], DefaultTolerance))
+		assert.False(t, Verify("a-very-long-secret", now, body, "", DefaultTolerance))
+	})
+
+	t.Run("stale timestamp", func(t *testing.T) {
+		for _, timestamp := range []int64{now - 301, now + 301, 1700000000} {
+			signed := Sign("a-very-long-secret", timestamp, body)
+
+			assert.False(t, Verify("a-very-long-secret", timestamp, body, signed, DefaultTolerance), "timestamp %d", timestamp)
+		}
+	})
+
+	t.Run("within tolerance", func(t *testing.T) {
+		for _, timestamp := range []int64{now - 290, now + 290} {
+			signed := Sign("a-very-long-secret", timestamp, body)
+
+			assert.True(t, Verify("a-very-long-secret", timestamp, body, signed, DefaultTolerance), "timestamp %d", timestamp)
+		}
+	})
+}
+
+func TestRefusePrivate(t *testing.T) {
+	blocked := []string{
+		"127.0.0.1",       // loopback
+		"10.0.0.1",        // RFC 1918
+		"172.16.5.4",      // RFC 1918
+		"192.168.1.1",     // RFC 1918
+		"169.254.169.254", // link-local, cloud metadata
+		"100.64.0.1",      // shared address space
+		"0.0.0.0",
+		"224.0.0.1",
+		"255.255.255.255",
+		"::",
+		"::1",
+		"fc00::1", // unique local
+		"fd12:3456::1",
+		"fe80::1", // link-local
+		"ff02::1",
+		"::ffff:127.0.0.1",
+		"::ffff:10.0.0.1",
+		"::ffff:169.254.169.254",
+	}
+
+	for _, ip := range blocked {
+		err := refusePrivate("tcp", net.JoinHostPort(ip, "443"), nil)
+
+		assert.ErrorIs(t, err, ErrBlockedAddress, ip)
+	}
+
+	for _, ip := range []string{"93.184.216.34", "8.8.8.8", "2606:4700:4700::1111", "::ffff:8.8.8.8"} {
+		assert.NoError(t, refusePrivate("tcp", net.JoinHostPort(ip, "443"), nil), ip)
+	}
+}
+
+func TestClient_Send(t *testing.T) {
+	ctx := context.Background()
+	body := []byte(`{"type":"ping"}`)
+
+	send := func(client *Client, url string) (int, error) {
+		return client.Send(ctx, Request{URL: url, Secret: "a-very-long-secret", ID: "delivery", Event: "ping", Body: body})
+	}
+
+	t.Run("signed request", func(t *testing.T) {
+		var got *http.Request
+
+		var gotBody []byte
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r
+			gotBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		status, err := send(NewClient(time.Second, true), server.URL)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+		assert.Equal(t, http.MethodPost, got.Method)
+		assert.Equal(t, "delivery", got.Header.Get(HeaderID))
+		assert.Equal(t, "ping", got.Header.Get(HeaderEvent))
+		assert.Equal(t, body, gotBody)
+
+		timestamp, err := strconv.ParseInt(got.Header.Get(HeaderTimestamp), 10, 64)
+		assert.NoError(t, err)
+		assert.True(t, Verify("a-very-long-secret", timestamp, gotBody, got.Header.Get(HeaderSignature), DefaultTolerance))
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		status, err := send(NewClient(time.Second, true), server.URL)
+
+		var statusErr *StatusError
+
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+	})
+
+	t.Run("redirects are not followed", func(t *testing.T) {
+		followed := false
+
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			followed = true
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer target.Close()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+		}))
+		defer server.Close()
+
+		status, err := send(NewClient(time.Second, true), server.URL)
+
+		var statusErr *StatusError
+
+		assert.Equal(t, http.StatusTemporaryRedirect, status)
+		assert.ErrorAs(t, err, &statusErr)
+		assert.False(t, followed)
+	})
+
+	t.Run("private addresses are refused", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := NewClient(time.Second, false)
+		port := strconv.Itoa(server.Listener.Addr().(*net.TCPAddr).Port)
+
+		for _, url := range []string{
+			server.URL,
+			"http://localhost:" + port + "/",
+			"http://10.0.0.1/",
+			"http://172.16.0.1/",
+			"http://192.168.0.1/",
+			"http://169.254.169.254/latest/meta-data/",
+		} {
+			status, err := send(client, url)
+
+			assert.Zero(t, status, url)
+			assert.ErrorIs(t, err, ErrBlockedAddress, url)
+		}
+	})
+
+	t.Run("public names resolving to private addresses are refused", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		port := strconv.Itoa(server.Listener.Addr().(*net.TCPAddr).Port)
+		resolver := newTestResolver(t, map[string]string{
+			"hooks.example.com.":    "127.0.0.1",
+			"internal.example.com.": "10.0.0.1",
+			"metadata.example.com.": "169.254.169.254",
+			"ula.example.com.":      "fd00::1",
+		})
+
+		for _, host := range []string{"hooks.example.com", "internal.example.com", "metadata.example.com", "ula.example.com"} {
+			client := newClient(&net.Dialer{Timeout: time.Second, Resolver: resolver}, time.Second, false)
+
+			status, err := send(client, "http://"+host+":"+port+"/")
+
+			assert.Zero(t, status, host)
+			assert.ErrorIs(t, err, ErrBlockedAddress, host)
+		}
+
+		// The same name is reachable when private addresses are allowed, so
+		// the refusal above is not a resolution failure.
+		client := newClient(&net.Dialer{Timeout: time.Second, Resolver: resolver}, time.Second, true)
+
+		status, err := send(client, "http://hooks.example.com:"+port+"/")
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+	})
+}
+
+// newTestResolver returns a resolver that answers from hosts, which maps
+// fully qualified names to a single address, through a DNS server on the
+// loopback interface.
+func newTestResolver(t *testing.T, hosts map[string]string) *net.Resolver {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			if resp, err := answer(buf[:n], hosts); err == nil {
+				_, _ = conn.WriteTo(resp, addr)
+			}
+		}
+	}()
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+
+			return d.DialContext(ctx, "udp", conn.LocalAddr().String())
+		},
+	}
+}
+
+func answer(query []byte, hosts map[string]string) ([]byte, error) {
+	var msg dnsmessage.Message
+
+	if err := msg.Unpack(query); err != nil {
+		return nil, err
+	}
+
+	if len(msg.Questions) != 1 {
+		return nil, errors.New("expected one question")
+	}
+
+	question := msg.Questions[0]
+	msg.Header.Response = true
+	msg.Header.Authoritative = true
+
+	ip, ok := hosts[question.Name.String()]
+	if !ok {
+		msg.Header.RCode = dnsmessage.RCodeNameError
+
+		return msg.Pack()
+	}
+
+	addr := netip.MustParseAddr(ip)
+	header := dnsmessage.ResourceHeader{Name: question.Name, Type: question.Type, Class: dnsmessage.ClassINET, TTL: 60}
+
+	switch {
+	case question.Type == dnsmessage.TypeA && addr.Is4():
+		msg.Answers = append(msg.Answers, dnsmessage.Resource{Header: header, Body: &dnsmessage.AResource{A: addr.As4()}})
+	case question.Type == dnsmessage.TypeAAAA && addr.Is6():
+		msg.Answers = append(msg.Answers, dnsmessage.Resource{Header: header, Body: &dnsmessage.AAAAResource{AAAA: addr.As16()}})
+	}
+
+	return msg.Pack()
+}